@@ -4,95 +4,607 @@
 package main
 
 import (
+	"bufio"
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/Rican7/define/internal/action"
+	"github.com/Rican7/define/internal/annotate"
+	"github.com/Rican7/define/internal/bloom"
 	"github.com/Rican7/define/internal/config"
+	"github.com/Rican7/define/internal/confusables"
+	"github.com/Rican7/define/internal/datapack"
+	"github.com/Rican7/define/internal/dryrun"
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/internal/fuzzy"
+	"github.com/Rican7/define/internal/health"
+	"github.com/Rican7/define/internal/httpcache"
+	"github.com/Rican7/define/internal/httpclient"
+	"github.com/Rican7/define/internal/hyphenation"
 	defineio "github.com/Rican7/define/internal/io"
 	"github.com/Rican7/define/internal/io/printer"
+	"github.com/Rican7/define/internal/level"
+	"github.com/Rican7/define/internal/morphology"
+	"github.com/Rican7/define/internal/postag"
+	"github.com/Rican7/define/internal/postprocess"
+	"github.com/Rican7/define/internal/profanity"
+	"github.com/Rican7/define/internal/pronunciation"
+	"github.com/Rican7/define/internal/quiz"
+	"github.com/Rican7/define/internal/quota"
+	"github.com/Rican7/define/internal/review"
+	"github.com/Rican7/define/internal/routing"
+	"github.com/Rican7/define/internal/scrabble"
+	"github.com/Rican7/define/internal/sentence"
+	"github.com/Rican7/define/internal/speech"
+	"github.com/Rican7/define/internal/spellcheck"
+	"github.com/Rican7/define/internal/translate"
+	"github.com/Rican7/define/internal/update"
 	"github.com/Rican7/define/internal/version"
+	"github.com/Rican7/define/internal/vocab"
+	"github.com/Rican7/define/internal/wordlist"
 	"github.com/Rican7/define/registry"
 	"github.com/Rican7/define/source"
-	flag "github.com/ogier/pflag"
-
+	"github.com/Rican7/define/source/datamuse"
+	"github.com/Rican7/define/source/ngram"
+	"github.com/Rican7/define/source/tatoeba"
+	"github.com/Rican7/define/source/wikipedia"
+	"github.com/Rican7/define/source/wiktionary"
+
+	_ "github.com/Rican7/define/source/customhttp"
+	_ "github.com/Rican7/define/source/execsource"
 	_ "github.com/Rican7/define/source/freedictionaryapi"
+	_ "github.com/Rican7/define/source/gcide"
 	"github.com/Rican7/define/source/oxford"
-	_ "github.com/Rican7/define/source/webster"
+	"github.com/Rican7/define/source/webster"
 )
 
 const (
 	// Configuration defaults
 	defaultIndentationSize = 2
 	defaultPreferredSource = oxford.JSONKey
+	defaultOutputFormat    = printer.FormatText
+
+	// Trim and strip punctuation by default, as users often paste words with
+	// surrounding whitespace and punctuation
+	defaultWordNormalizations = "trim,punctuation"
+
+	defaultSourceFooterSeparator = "-"
+	defaultSourceFooterWidth     = 60
+
+	defaultQuizLength = 5
+
+	// defaultCacheMemorySize is the number of cached responses (and of search
+	// results) to keep in memory, which is enough for the words of a typical
+	// text to be annotated or spellchecked
+	defaultCacheMemorySize = 256
+
+	defaultAnnotateDifficulty = wordlist.DifficultyHard
+	defaultExportFormat       = vocab.FormatMarkdown
+
+	defaultTranslationBackend = translate.BackendLibreTranslate
+
+	// The maximum amount of time to wait, after performing the action, for the
+	// check for a newer release to finish
+	updateCheckWaitTimeout = 2 * time.Second
+
+	// The name of the file, in the data dir, of the bloom filter of the words
+	// of the known word lists, and the rate of false positives it's sized for
+	wordFilterFileName          = "words.bloom"
+	wordFilterFalsePositiveRate = 0.001
+
+	// The overall time limit for downloading a data pack, which is much larger
+	// than the responses of the sources
+	dataDownloadTimeout = 10 * time.Minute
 
 	fallbackSearchResultLimit = 5
+	tatoebaExampleLimit       = 3
+	spellcheckSuggestionLimit = 3
+	wordFinderResultLimit     = 10
+
+	// trendCacheMaxAge is how long usage trends are cached for, without any
+	// request, as the corpus that they're from is only updated every few years
+	trendCacheMaxAge = 30 * 24 * time.Hour
+
+	// searchCacheMaxAge is how long search results are cached for, without any
+	// request, which is shorter than responses are, as suggestions change more
+	// often than definitions do
+	searchCacheMaxAge = time.Hour
+
+	// defaultResultLanguage is the language of results that don't specify
+	// their own
+	defaultResultLanguage = "en"
+
+	// etymologyLanguage is the language of the words whose origins are looked
+	// up in Wiktionary, which has entries for words of many languages
+	etymologyLanguage = "en"
+
+	// minExamples is the number of examples that the primary result of a word
+	// should have, below which examples from Tatoeba are added (if enabled)
+	minExamples = 3
+
+	// maxSuggestedSources is the maximum number of other sources to suggest,
+	// when a source doesn't have results
+	maxSuggestedSources = 2
 )
 
-var (
-	stdErrWriter = defineio.NewPanicWriter(os.Stderr, defaultIndentationSize)
-	stdOutWriter = defineio.NewPanicWriter(os.Stdout, defaultIndentationSize)
+// App is the command-line app, holding the configuration, source, and writers
+// that it's run with.
+type App struct {
+	stdin        io.Reader
+	stdout       io.Writer
+	stdErrWriter *defineio.PanicWriter
+	stdOutWriter *defineio.PanicWriter
+
+	flags              *flag.FlagSet
+	act                *action.Action
+	conf               config.Configuration
+	src                source.Source
+	isDomainSource     bool // Whether the source was chosen for its domain
+	sourceRouter       *routing.Router
+	wordNormalizations []source.WordNormalization
+	level              level.Level
+	pronunciationStyle pronunciation.Style
+	outputFormat       printer.Format
+	usageTracker       *quota.Tracker
+	healthTracker      *health.Tracker
+	responseCache      *httpcache.Transport
+	searchCache        *httpcache.SearchCache
+	trendClient        http.Client          // The client to get usage trends with
+	sourceHealthHint   string               // A hint of what to do about a failing source, if any
+	translator         translate.Translator // The translator of definitions, if requested
+	postProcessHook    *postprocess.Hook    // The hook to transform results with, if configured
+}
 
-	flags *flag.FlagSet
-	act   *action.Action
-	conf  config.Configuration
-	src   source.Source
-)
+// errNoWord is returned when an action needs a word, but none was given.
+var errNoWord = errors.New("no word or phrase was given")
 
-func init() {
-	var err error
+// exitCode is an error that quits the app with an exit code, without printing
+// anything, for when the reason has already been printed.
+type exitCode int
+
+// Error satisfies the error interface by returning a string message.
+func (e exitCode) Error() string {
+	return fmt.Sprintf("exit code %d", int(e))
+}
+
+// sourceError is an error that a source encountered.
+type sourceError struct {
+	source string
+	err    error
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *sourceError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error that the source encountered.
+func (e *sourceError) Unwrap() error {
+	return e.err
+}
+
+// filteredResultError is an error of the configured filters (like
+// --family-friendly) leaving none of a word's results.
+type filteredResultError struct {
+	message string
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *filteredResultError) Error() string {
+	return e.message
+}
+
+// incompleteWordListError is an error of a word not being in the bundled word
+// list, when no data packs with words are installed, so whether the word exists
+// can't be told.
+type incompleteWordListError struct {
+	word string
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *incompleteWordListError) Error() string {
+	return fmt.Sprintf("%q isn't in the bundled word list, which only has the most common words", e.word)
+}
+
+// Remedy returns an actionable suggestion of how to fix the problem.
+func (e *incompleteWordListError) Remedy() string {
+	return "To check all words, install a data pack of words, like with `define data install frequency`."
+}
+
+// remediableError defines the interface for errors that know how they can be
+// fixed (like a config.Error), whose remedy is printed along with them.
+type remediableError interface {
+	error
+
+	// Remedy returns an actionable suggestion of how to fix the problem.
+	Remedy() string
+}
+
+// properNounError is an error of a term that looks like a proper noun not
+// being found, which is common, as dictionaries tend not to define them.
+type properNounError struct {
+	err          error
+	term         string
+	wikiTried    bool     // Whether Wikipedia has already been tried
+	otherSources []string // The names of other ready sources to try
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *properNounError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error of the term not being found.
+func (e *properNounError) Unwrap() error {
+	return e.err
+}
+
+// Remedy returns the alternatives to looking the term up in a dictionary.
+func (e *properNounError) Remedy() string {
+	var alternatives []string
+
+	if !e.wikiTried {
+		alternatives = append(alternatives, fmt.Sprintf("look it up on Wikipedia with \"define --wiki %s\"", e.term))
+	}
+
+	if len(e.otherSources) > 0 {
+		alternatives = append(alternatives, fmt.Sprintf("try another source (like --source=%s)", strings.Join(e.otherSources, " or --source=")))
+	}
+
+	advice := fmt.Sprintf("%q looks like a proper noun, which dictionaries tend not to define.", e.term)
+
+	if len(alternatives) > 0 {
+		advice += " Instead, " + strings.Join(alternatives, ", or ") + "."
+	}
+
+	return advice
+}
+
+func main() {
+	os.Exit(new(App).Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// Run runs the app with the given command line arguments (without the program
+// name) and IO, returning the exit code that the app should exit with.
+func (a *App) Run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	a.stdin = stdin
+	a.stdout = stdout
+	a.stdErrWriter = defineio.NewPanicWriter(stderr, defaultIndentationSize)
+	a.stdOutWriter = defineio.NewPanicWriter(stdout, defaultIndentationSize)
+
+	err := a.setup(args)
+	if err == nil {
+		err = a.perform()
+	}
+
+	code := a.handleError(err)
+
+	// Hint at what to do about a failing source after its error
+	if a.sourceHealthHint != "" {
+		a.stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WriteStringLine(a.sourceHealthHint)
+			writer.WriteNewLine()
+		})
+	}
+
+	return code
+}
 
-	flags = flag.NewFlagSet(version.AppName, flag.ContinueOnError)
-	flags.SetOutput(stdErrWriter)
-	flags.Usage = func() {
-		printUsage(stdErrWriter)
-		quit(2)
+// setup sets up the app's configuration and source from the given command line
+// arguments.
+func (a *App) setup(args []string) error {
+	showSourceFooter := true
+	cacheMemorySize := uint(defaultCacheMemorySize)
+
+	defaults := config.Configuration{
+		IndentationSize: defaultIndentationSize,
+		PreferredSource: defaultPreferredSource,
+
+		WordNormalizations: defaultWordNormalizations,
+
+		SpeechCommand: speech.DefaultCommand(runtime.GOOS),
+
+		ShowSourceFooter:      &showSourceFooter,
+		SourceFooterSeparator: defaultSourceFooterSeparator,
+		SourceFooterWidth:     defaultSourceFooterWidth,
+
+		CacheMemorySize: &cacheMemorySize,
+
+		QuizLength: defaultQuizLength,
+
+		AnnotateDifficulty: string(defaultAnnotateDifficulty),
+		ExportFormat:       string(defaultExportFormat),
+
+		TranslationBackend: string(defaultTranslationBackend),
+	}
+
+	a.flags = flag.NewFlagSet(version.AppName, flag.ContinueOnError)
+	a.flags.SetOutput(a.stdErrWriter)
+	a.flags.Usage = func() {
+		a.printUsage(a.stdErrWriter)
 	}
 
-	act = action.Setup(flags)
+	config.DefineFlags(a.flags, defaults)
+	a.act = action.Setup(a.flags)
 
 	// Configure our registered providers
-	providerConfs := registry.ConfigureProviders(flags)
+	providerConfs, err := registry.ConfigureProviders(a.flags)
+	if err != nil {
+		return err
+	}
+
 	var providerConfsList []registry.Configuration
 
 	if len(providerConfs) < 1 {
-		handleError(fmt.Errorf("no registered source providers"))
+		return errors.New("no registered source providers")
 	}
 
 	for _, providerConf := range providerConfs {
 		providerConfsList = append(providerConfsList, providerConf)
 	}
 
-	conf, err = config.NewFromRuntime(flags, providerConfs, config.Configuration{
-		IndentationSize: defaultIndentationSize,
-		PreferredSource: defaultPreferredSource,
-	})
+	// Parse our flags exactly once, now that they're all defined, so that
+	// every configuration layer is computed from the same parsed values
+	if err := a.flags.Parse(args); err != nil {
+		// Asking for help isn't a failure, and the usage has been printed
+		if errors.Is(err, flag.ErrHelp) {
+			return exitCode(0)
+		}
 
-	// Re-initialize our writers once we have our indentation size configuration
-	stdErrWriter = defineio.NewPanicWriter(os.Stderr, conf.IndentationSize)
-	stdOutWriter = defineio.NewPanicWriter(os.Stdout, conf.IndentationSize)
-	flags.SetOutput(stdErrWriter)
+		return exitCode(2)
+	}
 
-	// Finalize our configurations
-	registry.Finalize(providerConfsList...)
+	a.conf, err = config.NewFromRuntime(a.flags, providerConfs, defaults)
 
-	handleError(err)
+	// Configure our writers as soon as we have our configuration, so that any
+	// output from here on out respects it
+	a.configureWriters()
 
-	if conf.Source != "" {
-		if providerConf, exists := providerConfs[conf.Source]; exists {
-			src, err = registry.Provide(providerConf)
-		} else {
-			handleError(fmt.Errorf("provider/source %q does not exist", conf.Source))
+	if err != nil {
+		return err
+	}
+
+	a.wordNormalizations, err = source.ParseWordNormalizations(a.conf.WordNormalizations)
+	if err != nil {
+		return &config.BadValueError{Key: "WordNormalizations", Value: a.conf.WordNormalizations, Flag: "normalize", EnvName: "DEFINE_APP_NORMALIZE", Err: err}
+	}
+
+	a.level, err = level.Parse(a.conf.Level)
+	if err != nil {
+		return &config.BadValueError{Key: "Level", Value: a.conf.Level, Flag: "level", EnvName: "DEFINE_APP_LEVEL", Err: err}
+	}
+
+	a.pronunciationStyle, err = pronunciation.ParseStyle(a.conf.PronunciationStyle)
+	if err != nil {
+		return &config.BadValueError{Key: "PronunciationStyle", Value: a.conf.PronunciationStyle, Flag: "pronunciation-style", EnvName: "DEFINE_APP_PRONUNCIATION_STYLE", Err: err}
+	}
+
+	// The output format isn't defaulted in the configuration, so that an
+	// explicitly chosen text format isn't replaced by the porcelain format
+	a.outputFormat, err = printer.ParseFormat(cmp.Or(a.conf.OutputFormat, string(defaultOutputFormat)))
+	if err != nil {
+		return &config.BadValueError{Key: "OutputFormat", Value: a.conf.OutputFormat, Flag: "output", EnvName: "DEFINE_APP_OUTPUT_FORMAT", Err: err}
+	}
+
+	a.sourceRouter, err = routing.NewRouter(a.conf.SourceRoutes)
+	if err != nil {
+		return &config.BadValueError{Key: "SourceRoutes", Value: fmt.Sprint(a.conf.SourceRoutes), Err: err}
+	}
+
+	if a.outputFormat == printer.FormatText && a.usePorcelain() {
+		a.outputFormat = printer.FormatPorcelain
+	}
+
+	var transport http.RoundTripper = httpclient.NewTransport()
+
+	var memoryCacheSize int
+	if a.conf.CacheMemorySize != nil {
+		memoryCacheSize = int(*a.conf.CacheMemorySize)
+	}
+
+	// Track the usage of the APIs that sources make requests to, by wrapping the
+	// transport that the sources' HTTP clients use, beneath any cache, so that
+	// only the requests that are actually made are counted
+	if usageFilePath, err := quota.UsageFilePath(); err == nil {
+		a.usageTracker = quota.NewTracker(usageFilePath)
+		transport = quota.NewTransport(transport, a.usageTracker)
+	}
+
+	trendTransport := transport
+
+	if a.conf.Cache {
+		a.responseCache = httpcache.NewTransport(transport, httpcache.DirPath(), source.MaxResponseSize).WithMemoryCache(memoryCacheSize)
+		transport = a.responseCache
+
+		// Cache usage trends aggressively, as they're historical data
+		trendTransport = a.responseCache.WithMaxAge(trendCacheMaxAge)
+	}
+
+	// Track the health of sources, except when dry-running, as no requests are
+	// made to them
+	if healthFilePath, err := health.StateFilePath(); err == nil && !a.conf.DryRun() {
+		a.healthTracker = health.NewTracker(healthFilePath)
+	}
+
+	// Don't make any requests to sources when dry-running
+	if a.conf.DryRun() {
+		transport = &dryrun.Transport{}
+		trendTransport = transport
+	}
+
+	// Cache search results separately, as even conditional requests are a waste
+	// for repeated searches, except when dry-running, as cached results would
+	// hide what requests would be made
+	if a.conf.Cache && !a.conf.DryRun() {
+		a.searchCache = httpcache.NewSearchCache(httpcache.SearchDirPath(), searchCacheMaxAge).WithMemoryCache(memoryCacheSize)
+	}
+
+	// Share a single client between sources, so that connections are reused
+	registry.SetHTTPClient(httpclient.New(transport))
+	a.trendClient = httpclient.New(trendTransport)
+
+	if a.conf.GlossLanguage != "" {
+		backend, err := translate.ParseBackend(a.conf.TranslationBackend)
+		if err != nil {
+			return &config.BadValueError{Key: "TranslationBackend", Value: a.conf.TranslationBackend, Flag: "translation-backend", EnvName: "DEFINE_APP_TRANSLATION_BACKEND", Err: err}
+		}
+
+		a.translator, err = translate.New(backend, registry.HTTPClient(), a.conf.TranslationEndpoint, a.conf.TranslationAPIKey)
+		if err != nil {
+			return &config.BadValueError{Key: "TranslationAPIKey", Value: "", Flag: "translation-api-key", EnvName: "DEFINE_APP_TRANSLATION_API_KEY", Err: err}
+		}
+	}
+
+	if a.conf.PostProcessCmd != "" {
+		a.postProcessHook, err = postprocess.New(a.conf.PostProcessCmd)
+		if err != nil {
+			return &config.BadValueError{Key: "PostProcessCmd", Value: a.conf.PostProcessCmd, Flag: "post-process-cmd", EnvName: "DEFINE_APP_POST_PROCESS_CMD", Err: err}
+		}
+	}
+
+	if a.conf.Source != "" {
+		providerConf, findErr := findProviderConfig(a.conf.Source)
+		if findErr != nil {
+			return findErr
 		}
+
+		a.src, err = registry.Provide(providerConf)
+	} else if domainConf := findDomainProviderConfig(a.conf.Domain); domainConf != nil {
+		a.src, err = registry.Provide(domainConf)
+		a.isDomainSource = true
 	} else {
-		src, err = registry.ProvidePreferred(conf.PreferredSource, providerConfsList)
+		preferredSource := a.conf.PreferredSource
+
+		// Allow the preferred source to be named like any other
+		if preferredSource != "" {
+			preferredConf, findErr := findProviderConfig(preferredSource)
+			if findErr != nil {
+				return &config.BadValueError{Key: "PreferredSource", Value: preferredSource, Flag: "preferred-source", EnvName: "DEFINE_APP_PREFERRED_SOURCE", Err: findErr}
+			}
+
+			preferredSource = preferredConf.JSONKey()
+		}
+
+		// Prefer a learner's dictionary for learners, but only if it can be
+		// provided, so that the preferred source is still used otherwise
+		if learnersConf := providerConfs[webster.LearnersJSONKey]; a.level.PrefersLearnerSources() && learnersConf != nil {
+			if _, learnersErr := registry.Provide(learnersConf); learnersErr == nil {
+				preferredSource = learnersConf.JSONKey()
+			}
+		}
+
+		a.src, err = registry.ProvidePreferred(preferredSource, providerConfsList)
+
+		if err == nil && a.conf.AutoSwitchSource {
+			a.switchFromUnhealthySource(providerConfsList)
+		}
+	}
+
+	if a.src != nil {
+		a.configureSource(a.src)
+	}
+
+	return err
+}
+
+// switchFromUnhealthySource switches the source to another that can be
+// provided, if the source has kept failing, for the session.
+func (a *App) switchFromUnhealthySource(providerConfs []registry.Configuration) {
+	if a.healthTracker == nil {
+		return
+	}
+
+	sourceHealth, err := a.healthTracker.Health(a.src.Name())
+	if err != nil || !sourceHealth.IsUnhealthy() {
+		return
+	}
+
+	providers := registry.Providers()
+
+	otherConfs := slices.DeleteFunc(slices.Clone(providerConfs), func(providerConf registry.Configuration) bool {
+		return providers[providerConf].Name() == a.src.Name()
+	})
+
+	if len(otherConfs) < 1 {
+		return
+	}
+
+	otherSrc, err := registry.ProvidePreferred("", otherConfs)
+	if err != nil || otherSrc == nil {
+		return
+	}
+
+	a.stdErrWriter.WriteStringLine(fmt.Sprintf(
+		"Using %s, as %s has failed the last %d times.",
+		otherSrc.Name(),
+		a.src.Name(),
+		sourceHealth.ConsecutiveFailures,
+	))
+
+	a.src = otherSrc
+}
+
+// configureSource configures a provided source based on the configuration.
+func (a *App) configureSource(configuredSrc source.Source) {
+	// Render any formatting of the source's text for terminals, when printing
+	// plain text (respecting the NO_COLOR convention)
+	if styler, ok := configuredSrc.(source.TextStyler); ok && a.outputFormat == printer.FormatText && a.isTerminal() && os.Getenv("NO_COLOR") == "" {
+		styler.SetTextStyle(source.TextStyleANSI)
+	}
+}
+
+// routeSource switches the source to the one that the configured source routes
+// route the word to, if any, unless a source was explicitly chosen (or chosen
+// for its domain).
+func (a *App) routeSource(word string) error {
+	if a.conf.Source != "" || a.isDomainSource {
+		return nil
+	}
+
+	sourceName, ok := a.sourceRouter.Route(word)
+	if !ok {
+		return nil
 	}
 
-	// Make sure our flags are parsed before entering main
-	handleError(err, flags.Parse(os.Args[1:]))
+	providerConf, err := findProviderConfig(sourceName)
+	if err != nil {
+		return err
+	}
+
+	routedSrc, err := registry.Provide(providerConf)
+	if err != nil {
+		return err
+	}
+
+	a.configureSource(routedSrc)
+
+	a.src = routedSrc
+
+	return nil
+}
+
+// configureWriters configures the app's writers based on the configuration.
+func (a *App) configureWriters() {
+	a.stdErrWriter.SetIndentStepSize(a.conf.IndentationSize)
+	a.stdOutWriter.SetIndentStepSize(a.conf.IndentationSize)
 }
 
 func formatErrorForPrinting(err error) string {
@@ -103,14 +615,14 @@ func formatErrorForPrinting(err error) string {
 	return msg
 }
 
-func printSourceError(source string, err error) {
+func (a *App) printSourceError(source string, err error) {
 	msg := formatErrorForPrinting(err)
 
 	if len(msg) < 1 {
 		return
 	}
 
-	stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+	a.stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
 		if source != "" {
 			sourceMessage := fmt.Sprintf("Source %q encountered an error.", source)
 
@@ -122,39 +634,174 @@ func printSourceError(source string, err error) {
 	})
 }
 
-func handleSourceError(source string, err ...error) {
-	for _, e := range err {
-		if e == nil {
-			continue
+// printNoSourceError prints a report of why each source couldn't be provided,
+// along with how to fix each, so that every missing key can be seen at once.
+func (a *App) printNoSourceError(noSourceErr *registry.NoSourceError) {
+	a.stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("No source could be provided:", 1)
+
+		for i, providerErr := range noSourceErr.Errs {
+			writer.WriteStringLine(fmt.Sprintf("%d. %s: %s", i+1, providerErr.Provider, formatErrorForPrinting(providerErr.Err)))
+
+			var remediableErr remediableError
+			if errors.As(providerErr.Err, &remediableErr) && remediableErr.Remedy() != "" {
+				writer.IndentWritesBy(3, func(writer *defineio.PanicWriter) {
+					writer.WriteStringLine(remediableErr.Remedy())
+				})
+			}
+		}
+
+		writer.WriteNewLine()
+	})
+}
+
+func (a *App) printDryRun(source string, dryRunErr *dryrun.Error) {
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Dry run: no request was made.", 1)
+
+		writer.WriteStringLine(fmt.Sprintf("Source: %q", source))
+		writer.WriteStringLine(fmt.Sprintf("Request: %s %s", dryRunErr.Request.Method, dryRunErr.RedactedURL()))
+
+		header := dryRunErr.RedactedHeader()
+
+		if len(header) > 0 {
+			names := make([]string, 0, len(header))
+			for name := range header {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			writer.WriteStringLine("Headers:")
+
+			writer.IndentWrites(func(writer *defineio.PanicWriter) {
+				for _, name := range names {
+					writer.WriteStringLine(fmt.Sprintf("%s: %s", name, strings.Join(header[name], ", ")))
+				}
+			})
 		}
 
-		printSourceError(source, e)
+		writer.WriteNewLine()
+	})
+}
 
-		quit(1)
+// printRemedy prints a suggestion of how to fix a printed error.
+func (a *App) printRemedy(remedy string) {
+	if remedy == "" {
+		return
 	}
+
+	a.stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteStringLine(remedy)
+		writer.WriteNewLine()
+	})
+}
+
+func (a *App) printParseWarnings(warnings []source.ParseWarning) {
+	a.stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteStringLine(fmt.Sprintf("Parse warnings (%d):", len(warnings)))
+
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			for _, warning := range warnings {
+				writer.WriteStringLine(warning.String())
+			}
+		})
+
+		writer.WriteNewLine()
+	})
 }
 
-func handleError(err ...error) {
-	handleSourceError("", err...)
+// handleError prints the given error, if any, and returns the exit code that
+// the app should exit with for it.
+func (a *App) handleError(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var code exitCode
+	if errors.As(err, &code) {
+		return int(code)
+	}
+
+	if errors.Is(err, errNoWord) {
+		a.printUsage(a.stdOutWriter)
+
+		return 1
+	}
+
+	var noSourceErr *registry.NoSourceError
+	if errors.As(err, &noSourceErr) {
+		a.printNoSourceError(noSourceErr)
+
+		return 1
+	}
+
+	var sourceName string
+
+	var srcErr *sourceError
+	if errors.As(err, &srcErr) {
+		sourceName = srcErr.source
+		err = srcErr.err
+	}
+
+	var dryRunErr *dryrun.Error
+	if errors.As(err, &dryRunErr) {
+		a.printDryRun(sourceName, dryRunErr)
+
+		return 0
+	}
+
+	a.printSourceError(sourceName, err)
+
+	var remediableErr remediableError
+	if errors.As(err, &remediableErr) {
+		a.printRemedy(remediableErr.Remedy())
+	}
+
+	var parseWarningsErr *source.ParseWarningsError
+	if errors.As(err, &parseWarningsErr) {
+		a.printParseWarnings(parseWarningsErr.Warnings)
+	}
+
+	// Exit differently than for an unknown word, as it's unknown if it exists
+	var incompleteWordListErr *incompleteWordListError
+	if errors.As(err, &incompleteWordListErr) {
+		return 2
+	}
+
+	return 1
 }
 
-func quit(code int) {
-	os.Exit(code)
+// configFileDescription returns a printable description of the config file
+// that was loaded.
+func (a *App) configFileDescription() string {
+	if configFilePath := a.conf.FilePath(); configFilePath != "" {
+		return fmt.Sprintf("%q", configFilePath)
+	}
+
+	return "none"
 }
 
-func printConfig() {
-	encoded, err := json.MarshalIndent(conf, "", "    ")
+func (a *App) printConfig() error {
+	encoded, err := json.MarshalIndent(a.conf, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	// Print the provenance to stderr, so that the printed config can still be
+	// piped or redirected into a config file
+	a.stdErrWriter.WriteStringLine(fmt.Sprintf("Config file: %s", a.configFileDescription()))
 
-	handleError(err)
+	a.stdOutWriter.WriteStringLine(string(encoded))
 
-	stdOutWriter.WriteStringLine(string(encoded))
+	return nil
 }
 
-func printConfigDebug() {
-	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+func (a *App) printConfigDebug() {
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
 		writer.WriteNewLine()
 
-		switch configFilePath := conf.FilePath(); configFilePath {
+		switch configFilePath := a.conf.FilePath(); configFilePath {
 		case "":
 			writer.WriteStringLine("No config file was loaded.")
 		default:
@@ -167,112 +814,2760 @@ func printConfigDebug() {
 			writer.WriteStringLine(fmt.Sprintf("%d. %s", i+1, filePath))
 		}
 
+		writer.WriteNewLine()
+		writer.WritePaddedStringLine("Configuration values (including those of sources) take this precedence:", 1)
+
+		for i, layer := range config.Precedence {
+			writer.WriteStringLine(fmt.Sprintf("%d. %s", i+1, layer))
+		}
+
 		writer.WriteNewLine()
 	})
 }
 
-func printSources() {
-	var sourceStrings []string
+func (a *App) printEnv() error {
+	// Providers are loaded in no particular order, so sort their names
+	providerEnvNames := registry.EnvNames()
+	sort.Strings(providerEnvNames)
+
+	envNames := slices.Concat(config.EnvNames(), providerEnvNames, []string{"NO_COLOR"})
 
-	for conf, source := range registry.Providers() {
-		sourceStrings = append(sourceStrings, fmt.Sprintf("%q (%s)", source.Name(), conf.JSONKey()))
+	isSet := make(map[string]bool, len(envNames))
+	for _, envName := range envNames {
+		_, isSet[envName] = os.LookupEnv(envName)
 	}
 
-	sort.Strings(sourceStrings)
+	if a.outputFormat == printer.FormatJSON {
+		encoded, err := json.MarshalIndent(isSet, "", "    ")
+		if err != nil {
+			return err
+		}
 
-	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
-		writer.WritePaddedStringLine("Available sources:", 1)
+		a.stdOutWriter.WriteStringLine(string(encoded))
+		return nil
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Environment variables (values redacted):", 1)
 
-		for i, source := range sourceStrings {
-			writer.WriteStringLine(fmt.Sprintf("%d. %s", i+1, source))
+		for _, envName := range envNames {
+			status := "not set"
+			if isSet[envName] {
+				status = "set"
+			}
+
+			writer.WriteStringLine(fmt.Sprintf("%s: %s", envName, status))
 		}
 
 		writer.WriteNewLine()
 	})
+
+	return nil
 }
 
-func printVersion() {
-	stdOutWriter.WriteStringLine(version.Printable())
+// sourceStatus describes an available source, and whether it's ready to use.
+type sourceStatus struct {
+	Name         string
+	JSONKey      string
+	Aliases      []string
+	Domains      []string `json:",omitempty"` // The domains of a specialty source
+	Ready        bool
+	Problem      string   `json:",omitempty"` // Why the source isn't ready, if it isn't
+	Capabilities []string // What the source can do, only known once it's ready
+
+	// The required configuration value that's missing, if that's the problem
+	MissingConfig *registry.RequiredConfigError `json:",omitempty"`
 }
 
-func printUsage(writer *defineio.PanicWriter) {
-	writer.IndentWrites(func(w *defineio.PanicWriter) {
-		flags.SetOutput(w)
+// sourceStatuses returns the statuses of the available sources, in order of
+// their names.
+func sourceStatuses() []sourceStatus {
+	var statuses []sourceStatus
+
+	for providerConf, provider := range registry.Providers() {
+		status := sourceStatus{
+			Name:    provider.Name(),
+			JSONKey: providerConf.JSONKey(),
+			Aliases: registry.Aliases(provider),
+			Domains: registry.Domains(provider),
+		}
 
-		w.WritePaddedStringLine(fmt.Sprintf("Usage: %s [<options>...] <word>", version.AppName), 1)
+		// Provide the source, to diagnose whether it's configured (this doesn't
+		// make any requests)
+		src, err := registry.Provide(providerConf)
 
-		w.WriteStringLine("Options:")
-		flags.PrintDefaults()
-		w.WriteNewLine()
-	})
-}
+		switch {
+		case err == nil:
+			status.Ready = true
+			status.Capabilities = []string{"Define"}
 
-func defineWord(word string) {
-	searcher, isSearcher := src.(source.Searcher)
+			if _, isSearcher := src.(source.Searcher); isSearcher {
+				status.Capabilities = append(status.Capabilities, "Search")
+			}
+		case errors.As(err, &status.MissingConfig):
+			status.Problem = fmt.Sprintf("Missing %q", status.MissingConfig.Key)
+		default:
+			// Use the provider's own error, without the registry's wrapping
+			if providerErr := errors.Unwrap(err); providerErr != nil {
+				err = providerErr
+			}
 
-	dictionaryResults, err := src.Define(word)
-	var searchResults source.SearchResults
+			status.Problem = err.Error()
+		}
 
-	if err == nil {
-		// Validate our results
-		err = source.ValidateDictionaryResults(word, dictionaryResults)
+		statuses = append(statuses, status)
 	}
 
-	emptyResultError, isEmptyDictionaryResult := err.(*source.EmptyResultError)
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
 
-	if isEmptyDictionaryResult && isSearcher {
-		searchResults, err = searcher.Search(word, fallbackSearchResultLimit)
+	return statuses
+}
 
-		if err == nil {
-			// Validate our results
-			err = source.ValidateSearchResults(word, searchResults)
+func (a *App) printSources() error {
+	statuses := sourceStatuses()
+
+	if a.outputFormat == printer.FormatJSON {
+		encoded, err := json.MarshalIndent(statuses, "", "    ")
+		if err != nil {
+			return err
 		}
+
+		a.stdOutWriter.WriteStringLine(string(encoded))
+		return nil
 	}
 
-	handleSourceError(src.Name(), err)
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Available sources:", 1)
 
-	resultPrinter := printer.NewResultPrinter(stdOutWriter)
+		for i, status := range statuses {
+			sourceString := fmt.Sprintf("%q (%s)", status.Name, status.JSONKey)
+
+			if len(status.Aliases) > 0 {
+				sourceString = fmt.Sprintf("%q (%s, or %s)", status.Name, status.JSONKey, strings.Join(status.Aliases, ", "))
+			}
+
+			writer.WriteStringLine(fmt.Sprintf("%d. %s", i+1, sourceString))
+
+			writer.IndentWritesBy(3, func(writer *defineio.PanicWriter) {
+				switch {
+				case status.Ready:
+					writer.WriteStringLine("Status: Ready")
+				case status.MissingConfig != nil && status.MissingConfig.Flag == "":
+					// Some values (like structured ones) can only be set in
+					// the config file
+					writer.WriteStringLine(fmt.Sprintf(
+						"Status: %s (set it under %q in the config file)",
+						status.Problem,
+						status.MissingConfig.JSONKey,
+					))
+				case status.MissingConfig != nil:
+					writer.WriteStringLine(fmt.Sprintf(
+						"Status: %s (pass --%s or set %s)",
+						status.Problem,
+						status.MissingConfig.Flag,
+						status.MissingConfig.EnvName,
+					))
+				default:
+					writer.WriteStringLine(fmt.Sprintf("Status: Unavailable (%s)", status.Problem))
+				}
+
+				if len(status.Domains) > 0 {
+					writer.WriteStringLine(fmt.Sprintf("Domains: %s", strings.Join(status.Domains, ", ")))
+				}
+
+				if status.Ready {
+					if slices.Contains(status.Capabilities, "Search") {
+						writer.WriteStringLine("Search: Supported")
+					} else {
+						writer.WriteStringLine("Search: Not supported")
+					}
+				}
+			})
+		}
+
+		writer.WriteNewLine()
+	})
+
+	return nil
+}
+
+func (a *App) printVersion() {
+	build := version.Build()
+
+	var sourceNames []string
+	for _, provider := range registry.Providers() {
+		sourceNames = append(sourceNames, fmt.Sprintf("%q", provider.Name()))
+	}
+
+	sort.Strings(sourceNames)
+
+	revision := build.Revision
+	if revision != "" && build.Modified != nil && *build.Modified {
+		revision += " (modified)"
+	}
+
+	a.stdOutWriter.WriteStringLine(version.Printable())
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		for _, detail := range []struct {
+			name  string
+			value string
+		}{
+			{"Built", build.Date},
+			{"Go version", build.GoVersion},
+			{"Commit", revision},
+			{"Sources", strings.Join(sourceNames, ", ")},
+			{"Config file", a.configFileDescription()},
+		} {
+			if detail.value != "" {
+				writer.WriteStringLine(fmt.Sprintf("%s: %s", detail.name, detail.value))
+			}
+		}
+	})
+}
+
+func newUpdater() *update.Updater {
+	// Use a client of our own, as update checks shouldn't be cached or count
+	// towards the sources' API usage
+	return update.NewUpdater(httpclient.New(httpclient.NewTransport()), update.LatestReleaseURL)
+}
+
+func (a *App) latestRelease() (*update.Updater, *update.Release, error) {
+	updater := newUpdater()
+
+	release, err := updater.LatestRelease()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return updater, release, nil
+}
+
+func (a *App) checkUpdate() error {
+	_, release, err := a.latestRelease()
+	if err != nil {
+		return err
+	}
+
+	if !update.IsNewer(version.Name(), release.TagName) {
+		a.stdOutWriter.WriteStringLine(fmt.Sprintf("%s is up to date (latest release: %s)", version.Printable(), release.TagName))
+		return nil
+	}
+
+	a.stdOutWriter.WriteStringLine(fmt.Sprintf("A newer release is available: %s (current: %s)", release.TagName, version.Name()))
+	a.stdOutWriter.WriteStringLine(fmt.Sprintf("Run `%s --self-update` to update, or download it from %s", version.AppName, release.URL))
+
+	return nil
+}
+
+func (a *App) selfUpdate() error {
+	updater, release, err := a.latestRelease()
+	if err != nil {
+		return err
+	}
+
+	if !update.IsNewer(version.Name(), release.TagName) {
+		a.stdOutWriter.WriteStringLine(fmt.Sprintf("%s is up to date (latest release: %s)", version.Printable(), release.TagName))
+		return nil
+	}
+
+	executablePath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	contents, err := updater.Download(release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+
+	if err := update.ReplaceExecutable(executablePath, contents); err != nil {
+		return err
+	}
+
+	a.stdOutWriter.WriteStringLine(fmt.Sprintf("Updated %s from %s to %s", version.AppName, version.Name(), release.TagName))
+
+	return nil
+}
+
+// dataPackStatus describes a data pack, and whether it's installed.
+type dataPackStatus struct {
+	datapack.Pack
+
+	Installed *datapack.Installed `json:",omitempty"`
+	Problem   string              `json:",omitempty"` // Why the install is broken, if it is
+}
+
+// manageData performs a command to manage the offline data packs (like
+// "install") on the data packs of the given names.
+func (a *App) manageData(command string, packNames []string) error {
+	// Use a client of our own, as the downloads shouldn't be cached or count
+	// towards the sources' API usage
+	var transport http.RoundTripper = httpclient.NewTransport()
+	if a.conf.DryRun() {
+		transport = &dryrun.Transport{}
+	}
+
+	client := httpclient.New(transport)
+	client.Timeout = dataDownloadTimeout
+
+	manager := datapack.NewManager(client, datapack.DirPath())
+
+	if command == action.DataCommandList {
+		return a.printDataPacks(manager)
+	}
+
+	if len(packNames) < 1 {
+		return fmt.Errorf("no data pack was given (available: %s)", strings.Join(datapack.Names(), ", "))
+	}
+
+	for _, name := range packNames {
+		pack, ok := datapack.Find(name)
+		if !ok {
+			return fmt.Errorf("unknown data pack %q (available: %s)", name, strings.Join(datapack.Names(), ", "))
+		}
+
+		var err error
+
+		switch command {
+		case action.DataCommandInstall:
+			err = a.installDataPack(manager, pack)
+		case action.DataCommandRemove:
+			err = a.removeDataPack(manager, pack)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Keep the word filter in sync with the installed word lists
+	return writeWordFilter(manager)
+}
+
+func (a *App) installDataPack(manager *datapack.Manager, pack datapack.Pack) error {
+	// Don't download a pack again if the same version is installed intact
+	if installed, err := manager.Installed(pack.Name); err == nil && installed.Version == pack.Version && manager.Verify(installed) == nil {
+		a.stdOutWriter.WriteStringLine(fmt.Sprintf("%s %s is already installed", pack.Name, pack.Version))
+		return nil
+	}
+
+	a.stdOutWriter.WriteStringLine(fmt.Sprintf("Downloading %s %s from %s", pack.Name, pack.Version, pack.URL))
+
+	if _, err := manager.Install(pack); err != nil {
+		return &sourceError{source: pack.Name, err: err}
+	}
+
+	a.stdOutWriter.WriteStringLine(fmt.Sprintf("Installed %s %s to %q", pack.Name, pack.Version, manager.PackDirPath(pack.Name)))
+
+	return nil
+}
+
+func (a *App) removeDataPack(manager *datapack.Manager, pack datapack.Pack) error {
+	if err := manager.Remove(pack.Name); err != nil {
+		if errors.Is(err, datapack.ErrNotInstalled) {
+			return fmt.Errorf("the data pack %q isn't installed", pack.Name)
+		}
+
+		return err
+	}
+
+	a.stdOutWriter.WriteStringLine(fmt.Sprintf("Removed %s", pack.Name))
+
+	return nil
+}
+
+// checkExists checks whether a word exists in the known word lists, returning
+// an exit code (without printing anything) if it doesn't.
+//
+// The bundled word list only has the most common words, so without the words of
+// an installed data pack, an unknown word fails with an incompleteWordListError
+// instead, as whether it exists can't be told.
+func (a *App) checkExists(word string) error {
+	filter, hasPackWords, err := readWordFilter()
+	if err != nil {
+		return err
+	}
+
+	if !filter.Contains(strings.ToLower(word)) {
+		if !hasPackWords {
+			return &incompleteWordListError{word: word}
+		}
+
+		return exitCode(1)
+	}
+
+	return nil
+}
+
+// newWordFilter returns a bloom filter of the words of the bundled word list,
+// and of the given words.
+func newWordFilter(words []string) (*bloom.Filter, error) {
+	bundledWords, err := wordlist.Words()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bloom.New(len(bundledWords)+len(words), wordFilterFalsePositiveRate)
+
+	for _, bundledWord := range bundledWords {
+		filter.Add(strings.ToLower(bundledWord.Text))
+	}
+
+	for _, word := range words {
+		filter.Add(strings.ToLower(word))
+	}
+
+	return filter, nil
+}
+
+// readWordFilter reads the bloom filter of the known word lists that was
+// written when data packs were last installed or removed, or returns a filter
+// of the bundled word list alone if none was, along with whether the filter has
+// the words of any installed data packs.
+func readWordFilter() (*bloom.Filter, bool, error) {
+	data, err := os.ReadFile(filepath.Join(datapack.DirPath(), wordFilterFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		filter, err := newWordFilter(nil)
+
+		return filter, false, err
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	var filter bloom.Filter
+
+	if err := filter.UnmarshalBinary(data); err != nil {
+		return nil, false, err
+	}
+
+	return &filter, true, nil
+}
+
+// writeWordFilter writes the bloom filter of the bundled word list and the
+// words of the installed data packs, so that it's built once rather than on
+// each check.
+func writeWordFilter(manager *datapack.Manager) error {
+	words, err := manager.InstalledWords()
+	if err != nil {
+		return err
+	}
+
+	// Without any words of installed packs, the bundled word list is built on
+	// each check instead, so that a filter is only written when it's needed
+	if len(words) < 1 {
+		if err := os.Remove(filepath.Join(datapack.DirPath(), wordFilterFileName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		return nil
+	}
+
+	filter, err := newWordFilter(words)
+	if err != nil {
+		return err
+	}
+
+	data, err := filter.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(datapack.DirPath(), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(datapack.DirPath(), wordFilterFileName), data, 0o600)
+}
+
+// dataPackStatuses returns the statuses of the data packs in the catalog.
+func dataPackStatuses(manager *datapack.Manager) []dataPackStatus {
+	statuses := make([]dataPackStatus, 0, len(datapack.Catalog))
+
+	for _, pack := range datapack.Catalog {
+		status := dataPackStatus{Pack: pack}
+
+		installed, err := manager.Installed(pack.Name)
+
+		switch {
+		case err == nil:
+			status.Installed = installed
+
+			if verifyErr := manager.Verify(installed); verifyErr != nil {
+				status.Problem = verifyErr.Error()
+			}
+		case !errors.Is(err, datapack.ErrNotInstalled):
+			status.Problem = err.Error()
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+func (a *App) printDataPacks(manager *datapack.Manager) error {
+	statuses := dataPackStatuses(manager)
+
+	if a.outputFormat == printer.FormatJSON {
+		encoded, err := json.MarshalIndent(statuses, "", "    ")
+		if err != nil {
+			return err
+		}
+
+		a.stdOutWriter.WriteStringLine(string(encoded))
+		return nil
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Data packs:", 1)
+
+		for i, status := range statuses {
+			writer.WriteStringLine(fmt.Sprintf("%d. %s (%s)", i+1, status.Name, status.Description))
+
+			writer.IndentWritesBy(3, func(writer *defineio.PanicWriter) {
+				writer.WriteStringLine(fmt.Sprintf("Version: %s", status.Version))
+				writer.WriteStringLine(fmt.Sprintf("License: %s", status.License))
+
+				switch {
+				case status.Problem != "":
+					writer.WriteStringLine(fmt.Sprintf("Status: Broken (%s); install it again to repair it", status.Problem))
+				case status.Installed == nil:
+					writer.WriteStringLine("Status: Not installed")
+				case status.Installed.Version != status.Version:
+					writer.WriteStringLine(fmt.Sprintf("Status: Installed (version %s); install it again to update it", status.Installed.Version))
+				default:
+					writer.WriteStringLine("Status: Installed")
+				}
+			})
+		}
+
+		writer.WriteNewLine()
+	})
+
+	return nil
+}
+
+// startUpdateCheck starts checking for a newer release in the background,
+// returning a channel that receives the newer release's version, if any.
+//
+// Any failure to check is ignored, as the check is only a courtesy.
+func startUpdateCheck() <-chan string {
+	newerVersion := make(chan string, 1)
+
+	go func() {
+		defer close(newerVersion)
+
+		stateFilePath, err := update.StateFilePath()
+		if err != nil {
+			return
+		}
+
+		latestVersion, err := newUpdater().LatestVersion(stateFilePath, time.Now())
+		if err == nil && update.IsNewer(version.Name(), latestVersion) {
+			newerVersion <- latestVersion
+		}
+	}()
+
+	return newerVersion
+}
+
+func (a *App) printUpdateNotice(newerVersion <-chan string) {
+	select {
+	case latestVersion, ok := <-newerVersion:
+		if !ok {
+			return
+		}
+
+		a.stdErrWriter.WriteStringLine(fmt.Sprintf(
+			"A newer release of %s is available: %s (current: %s). Run `%s --self-update` to update.",
+			version.AppName,
+			latestVersion,
+			version.Name(),
+			version.AppName,
+		))
+	case <-time.After(updateCheckWaitTimeout):
+	}
+}
+
+// printCacheStats prints the hit and miss counts of the in-memory caches to
+// stderr, so that they don't mix with the output of the action.
+func (a *App) printCacheStats() {
+	a.stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Cache stats:", 1)
+
+		if a.responseCache == nil {
+			writer.WriteStringLine("Caching is disabled (see --cache).")
+			writer.WriteNewLine()
+			return
+		}
+
+		responseStats := a.responseCache.Stats()
+		writer.WriteStringLine(fmt.Sprintf("Responses in memory: %d hits, %d misses", responseStats.Hits, responseStats.Misses))
+
+		if a.searchCache != nil {
+			searchStats := a.searchCache.Stats()
+			writer.WriteStringLine(fmt.Sprintf("Search results in memory: %d hits, %d misses", searchStats.Hits, searchStats.Misses))
+		}
+
+		writer.WriteNewLine()
+	})
+}
+
+func (a *App) printQuota() error {
+	if a.usageTracker == nil {
+		return errors.New("API usage can't be tracked in this environment")
+	}
+
+	usages, err := a.usageTracker.Usages()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for host, usage := range usages {
+		usages[host] = usage.Current(now)
+	}
+
+	if a.outputFormat == printer.FormatJSON {
+		encoded, err := json.MarshalIndent(usages, "", "    ")
+		if err != nil {
+			return err
+		}
+
+		a.stdOutWriter.WriteStringLine(string(encoded))
+		return nil
+	}
+
+	hosts := make([]string, 0, len(usages))
+	for host := range usages {
+		hosts = append(hosts, host)
+	}
+
+	sort.Strings(hosts)
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("API usage:", 1)
+
+		if len(hosts) < 1 {
+			writer.WriteStringLine("No API requests have been tracked yet.")
+			writer.WriteNewLine()
+			return
+		}
+
+		for i, host := range hosts {
+			usage := usages[host]
+
+			writer.WriteStringLine(fmt.Sprintf("%d. %s", i+1, host))
+
+			writer.IndentWritesBy(3, func(writer *defineio.PanicWriter) {
+				writer.WriteStringLine(fmt.Sprintf("Requests today: %d", usage.Today))
+				writer.WriteStringLine(fmt.Sprintf("Requests this month: %d", usage.ThisMonth))
+
+				if rateLimit := usage.RateLimit; rateLimit != nil {
+					writer.WriteStringLine(fmt.Sprintf(
+						"Reported quota: %d of %d remaining (as of %s)",
+						rateLimit.Remaining,
+						rateLimit.Limit,
+						rateLimit.UpdatedAt.Local().Format(time.DateTime),
+					))
+				}
+			})
+		}
+
+		writer.WriteNewLine()
+	})
+
+	return nil
+}
+
+func (a *App) printUsage(writer *defineio.PanicWriter) {
+	writer.IndentWrites(func(w *defineio.PanicWriter) {
+		a.flags.SetOutput(w)
+
+		w.WritePaddedStringLine(fmt.Sprintf("Usage: %s [<options>...] <word or phrase>", version.AppName), 1)
+
+		w.WriteStringLine("Options:")
+		a.flags.PrintDefaults()
+		w.WriteNewLine()
+	})
+}
+
+func (a *App) defineWord(word string) (err error) {
+	defer func() {
+		a.recordSourceHealth(err)
+	}()
+
+	searcher, isSearcher := a.src.(source.Searcher)
+
+	dictionaryResults, err := a.src.Define(word)
+	var searchResults source.SearchResults
+
+	if err == nil {
+		// Validate our results
+		err = source.ValidateDictionaryResults(word, dictionaryResults)
+	}
+
+	redirectedWord := dictionaryResults.RedirectedWord(word)
+
+	// Treat results for a different word as empty, when exact results are
+	// wanted, so that the redirected word is instead suggested
+	if err == nil && redirectedWord != "" && a.conf.Exact {
+		dictionaryResults, redirectedWord = nil, ""
+		err = &source.EmptyResultError{Word: word}
+	}
+
+	emptyResultError, isEmptyDictionaryResult := err.(*source.EmptyResultError)
+
+	if isEmptyDictionaryResult && isSearcher {
+		searchResults, err = a.search(searcher, word, fallbackSearchResultLimit)
+
+		if err == nil {
+			// Validate our results
+			err = source.ValidateSearchResults(word, searchResults)
+		}
+	}
+
+	if err != nil {
+		// Dictionaries tend not to define proper nouns, so fall back to an
+		// encyclopedic summary of them (keeping the source's error if there
+		// isn't one either), or advise on the alternatives
+		if _, isEmptyResult := err.(*source.EmptyResultError); isEmptyResult && looksLikeProperNoun(word) {
+			if a.conf.WikiFallback {
+				if summaryErr := a.printWikiSummary(word); summaryErr == nil {
+					return nil
+				}
+			}
+
+			err = &properNounError{
+				err:          err,
+				term:         word,
+				wikiTried:    a.conf.WikiFallback,
+				otherSources: a.otherReadySources(maxSuggestedSources),
+			}
+		}
+
+		return &sourceError{source: a.src.Name(), err: err}
+	}
+
+	if !isEmptyDictionaryResult {
+		if err := a.checkParseWarnings(word, dictionaryResults); err != nil {
+			return err
+		}
+
+		if dictionaryResults, err = a.processResults(word, dictionaryResults); err != nil {
+			return err
+		}
+	}
+
+	if a.conf.Speak && !isEmptyDictionaryResult {
+		// Speak once the results are printed, failing if nothing else did
+		defer func() {
+			if speakErr := a.speakWord(dictionaryResults); err == nil {
+				err = speakErr
+			}
+		}()
+	}
+
+	result := printer.Result{
+		Word:              word,
+		Source:            a.src.Name(),
+		ShowingResultsFor: redirectedWord,
+		DictionaryResults: dictionaryResults,
+		SearchResults:     searchResults,
+	}
+
+	if a.postProcessHook != nil {
+		var processed printer.Result
+
+		if err := a.postProcessHook.Process(result, &processed); err != nil {
+			return err
+		}
+
+		// Print (and speak) the results as they were transformed
+		result = processed
+		redirectedWord = result.ShowingResultsFor
+		dictionaryResults = result.DictionaryResults
+		searchResults = result.SearchResults
+	}
+
+	if printed, err := a.printFormattedResult(result); printed || err != nil {
+		return err
+	}
+
+	resultPrinter := a.newResultPrinter()
+
+	switch isEmptyDictionaryResult {
+	case true:
+		a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WritePaddedStringLine(formatErrorForPrinting(emptyResultError), 1)
+			writer.WritePaddedStringLine("Did you mean one of these?", 1)
+		})
+
+		resultPrinter.PrintSearchResults(searchResults)
+	case false:
+		if redirectedWord != "" {
+			a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+				writer.WritePaddedStringLine(fmt.Sprintf("Showing results for %q (searched for %q)", redirectedWord, word), 1)
+			})
+		}
+
+		resultPrinter.PrintDictionaryResults(dictionaryResults)
+	}
+
+	resultPrinter.PrintSourceName(a.src, dictionaryResults.Attributions()...)
+
+	return nil
+}
+
+// processResults filters, simplifies, sorts, and adds to a word's results, as
+// configured, so that they're output the same way no matter how the word was
+// defined. A filteredResultError is returned if the filters leave no results.
+func (a *App) processResults(word string, dictionaryResults source.DictionaryResults) (source.DictionaryResults, error) {
+	if a.conf.ResultLanguage != "" {
+		dictionaryResults = dictionaryResults.FilterByLanguage(a.conf.ResultLanguage)
+
+		if len(dictionaryResults) < 1 {
+			return nil, &filteredResultError{fmt.Sprintf("no results of %q found in the %q language (see --result-lang)", word, a.conf.ResultLanguage)}
+		}
+	}
+
+	// A source chosen for its domain only has senses in that domain
+	if a.conf.Domain != "" && !a.isDomainSource {
+		dictionaryResults = dictionaryResults.FilterByCategory(a.conf.Domain)
+
+		if len(dictionaryResults) < 1 {
+			return nil, &filteredResultError{fmt.Sprintf("no senses of %q found in the %q domain", word, a.conf.Domain)}
+		}
+	}
+
+	if a.conf.FamilyFriendly {
+		dictionaryResults = dictionaryResults.FilterOffensive()
+
+		if len(dictionaryResults) < 1 {
+			return nil, &filteredResultError{fmt.Sprintf("no senses of %q found that aren't offensive (see --family-friendly)", word)}
+		}
+	}
+
+	dictionaryResults = a.level.Simplify(dictionaryResults)
+
+	dictionaryResults.SortForPrimaryResult(word)
+	if err := a.sortByContext(word, dictionaryResults); err != nil {
+		return nil, err
+	}
+
+	pronunciation.NormalizeResults(dictionaryResults, a.pronunciationStyle)
+	pronunciation.AnnotateResults(dictionaryResults)
+	wordlist.AnnotateResults(dictionaryResults)
+	confusables.AnnotateResults(dictionaryResults)
+
+	if a.conf.TatoebaExamples {
+		addTatoebaExamples(dictionaryResults)
+	}
+
+	if a.conf.ShowTrend {
+		a.addUsageTrend(dictionaryResults)
+	}
+
+	// Censor once all of the examples have been added
+	if a.conf.FamilyFriendly {
+		profanity.CensorResults(dictionaryResults)
+	}
+
+	if a.translator != nil {
+		if err := a.translateDefinitions(dictionaryResults); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.conf.ShowInflections {
+		morphology.AnnotateResults(dictionaryResults)
+	}
+
+	return dictionaryResults, nil
+}
+
+// recordSourceHealth records whether the source succeeded in defining a word,
+// given the error of defining it, if it was chosen by preference. When the
+// source keeps failing, a hint is set to be printed, once, suggesting what to
+// do. Words that the source has no results for aren't failures of the source,
+// so they leave its health unchanged.
+//
+// Tracking health is a best-effort, so any failure to do so is ignored.
+func (a *App) recordSourceHealth(defineErr error) {
+	if a.healthTracker == nil || a.conf.Source != "" || a.isDomainSource {
+		return
+	}
+
+	var srcErr *sourceError
+
+	switch {
+	case defineErr == nil:
+		_ = a.healthTracker.RecordSuccess(a.src.Name())
+	case errors.As(defineErr, &srcErr) && isSourceFailure(srcErr.err):
+		sourceHealth, err := a.healthTracker.RecordFailure(srcErr.source, time.Now(), srcErr.err)
+		if err != nil || !sourceHealth.IsUnhealthy() || sourceHealth.HintShown {
+			return
+		}
+
+		a.sourceHealthHint = fmt.Sprintf(
+			"%s has failed the last %d times. Consider choosing another with --preferred-source (see --list-sources for those that are ready), checking the configuration with --debug-config, or passing --auto-switch-source to switch away from it automatically.",
+			srcErr.source,
+			sourceHealth.ConsecutiveFailures,
+		)
+
+		_ = a.healthTracker.RecordHintShown(srcErr.source)
+	}
+}
+
+// isSourceFailure returns true if an error of a source is a failure of the
+// source itself, like a failed request, a rejected key, or an exhausted quota,
+// rather than a word that it doesn't have results for.
+func isSourceFailure(err error) bool {
+	var urlErr *url.Error
+	var authErr *source.AuthenticationError
+	var entitlementErr *source.EntitlementError
+	var invalidResponseErr *source.InvalidResponseError
+	var emptyResponseErr *source.EmptyResponseError
+
+	return errors.As(err, &urlErr) ||
+		errors.As(err, &authErr) ||
+		errors.As(err, &entitlementErr) ||
+		errors.As(err, &invalidResponseErr) ||
+		errors.As(err, &emptyResponseErr)
+}
+
+// sortByContext sorts the results of a word by the lexical category that the
+// word is used as in the context sentence, if one was passed.
+func (a *App) sortByContext(word string, dictionaryResults source.DictionaryResults) error {
+	context := a.act.Context()
+	if context == "" {
+		return nil
+	}
+
+	tag, found := postag.Guess(context, word)
+	if !found {
+		return fmt.Errorf("%q isn't used in the context %q", word, context)
+	}
+
+	postag.SortResults(dictionaryResults, tag)
+
+	return nil
+}
+
+// addTatoebaExamples adds example sentences from Tatoeba to the primary result
+// of a list of dictionary results, if it has few examples of its own, in place.
+//
+// As the examples are an optional enrichment, any error getting them is
+// ignored, leaving the results as they were.
+func addTatoebaExamples(dictionaryResults source.DictionaryResults) {
+	if len(dictionaryResults) < 1 || countExamples(dictionaryResults[0]) >= minExamples {
+		return
+	}
+
+	primaryResult := &dictionaryResults[0]
+
+	examples, err := tatoeba.New(registry.HTTPClient()).Examples(primaryResult.Word, primaryResult.Language, tatoebaExampleLimit)
+	if err != nil {
+		return
+	}
+
+	mergeExamples(primaryResult, examples)
+}
+
+// addUsageTrend adds the usage trend of the word of the primary result of a
+// list of dictionary results to it, in place.
+//
+// As the trend is an optional enrichment, any error getting it is ignored.
+func (a *App) addUsageTrend(dictionaryResults source.DictionaryResults) {
+	if len(dictionaryResults) < 1 {
+		return
+	}
+
+	primaryResult := &dictionaryResults[0]
+
+	trend, err := ngram.New(a.trendClient).Trend(primaryResult.Word, primaryResult.Language)
+	if err != nil {
+		return
+	}
+
+	primaryResult.Trend = trend
+}
+
+// translateDefinitions translates the definitions of the senses (and
+// sub-senses) of a list of dictionary results into the gloss language, in
+// place, with a single request to the translator.
+func (a *App) translateDefinitions(dictionaryResults source.DictionaryResults) error {
+	var collect func(list []source.Sense) []*source.Sense
+
+	collect = func(list []source.Sense) []*source.Sense {
+		var collected []*source.Sense
+
+		for i := range list {
+			collected = append(collected, &list[i])
+			collected = append(collected, collect(list[i].SubSenses)...)
+		}
+
+		return collected
+	}
+
+	var senses []*source.Sense
+	var definitions []string
+
+	for _, result := range dictionaryResults {
+		for _, entry := range result.Entries {
+			for _, sense := range collect(entry.Senses) {
+				senses = append(senses, sense)
+				definitions = append(definitions, sense.Definitions...)
+			}
+		}
+	}
+
+	if len(definitions) < 1 {
+		return nil
+	}
+
+	language := cmp.Or(dictionaryResults[0].Language, defaultResultLanguage)
+
+	translations, err := a.translator.Translate(definitions, language, a.conf.GlossLanguage)
+	if err != nil {
+		return fmt.Errorf("translating the definitions into %q failed: %w", a.conf.GlossLanguage, err)
+	}
+
+	for _, sense := range senses {
+		sense.Translations = translations[:len(sense.Definitions)]
+		translations = translations[len(sense.Definitions):]
+	}
+
+	return nil
+}
+
+// countExamples returns the number of examples of the senses (and sub-senses)
+// of a dictionary result.
+func countExamples(result source.DictionaryResult) int {
+	var count func(senses []source.Sense) int
+
+	count = func(senses []source.Sense) int {
+		total := 0
+
+		for _, sense := range senses {
+			total += len(sense.Examples) + count(sense.SubSenses)
+		}
+
+		return total
+	}
+
+	total := 0
+
+	for _, entry := range result.Entries {
+		total += count(entry.Senses)
+	}
+
+	return total
+}
+
+// mergeExamples adds example sentences to the senses of a dictionary result,
+// in place.
+//
+// The sentences aren't of any particular sense, so each is added to the first
+// (and most common) sense of the entry of the part of speech that the word is
+// guessed to be used as in it, or of the first entry if there isn't one.
+func mergeExamples(result *source.DictionaryResult, examples []source.AttributedText) {
+	for _, example := range examples {
+		entryIndex := -1
+
+		partOfSpeech, _ := postag.Guess(example.Text, result.Word)
+
+		for i, entry := range result.Entries {
+			if len(entry.Senses) < 1 {
+				continue
+			}
+
+			if entryIndex < 0 {
+				entryIndex = i
+			}
+
+			if partOfSpeech != source.PartOfSpeechUnknown && entry.PartOfSpeech == partOfSpeech {
+				entryIndex = i
+				break
+			}
+		}
+
+		if entryIndex < 0 {
+			return
+		}
+
+		sense := &result.Entries[entryIndex].Senses[0]
+		sense.Examples = append(sense.Examples, example)
+	}
+}
+
+// speakWord speaks the word of the primary result aloud, with its IPA
+// pronunciation if one is known.
+func (a *App) speakWord(dictionaryResults source.DictionaryResults) error {
+	if len(dictionaryResults) < 1 {
+		return nil
+	}
+
+	primaryResult := dictionaryResults[0]
+	text := speech.Text{Word: primaryResult.Word}
+
+	for _, entry := range primaryResult.Entries {
+		if len(entry.Pronunciations) < 1 {
+			continue
+		}
+
+		notation := entry.NotationOf(entry.Pronunciations[0])
+		ipa := pronunciation.Convert(entry.Pronunciations[0].Spelling, notation, source.PronunciationNotationIPA)
+
+		if notation == source.PronunciationNotationIPA || ipa != entry.Pronunciations[0].Spelling {
+			text.IPA = ipa
+			break
+		}
+	}
+
+	speaker, err := speech.NewSpeaker(a.conf.SpeechCommand)
+	if err != nil {
+		return err
+	}
+
+	return speaker.Speak(text)
+}
+
+// checkParseWarnings fails with the parse warnings of the results of a word, if
+// there are any and strict mode is enabled.
+func (a *App) checkParseWarnings(word string, results source.DictionaryResults) error {
+	if !a.conf.Strict {
+		return nil
+	}
+
+	if warnings := results.ParseWarnings(); len(warnings) > 0 {
+		return &sourceError{source: a.src.Name(), err: &source.ParseWarningsError{Word: word, Warnings: warnings}}
+	}
+
+	return nil
+}
+
+func (a *App) defineRandomWord() error {
+	difficulty, err := wordlist.ParseDifficulty(a.conf.RandomWordDifficulty)
+	if err != nil {
+		return err
+	}
+
+	word, err := wordlist.Random(difficulty)
+	if err != nil {
+		return err
+	}
+
+	return a.defineWord(word.Text)
+}
+
+// quizWords returns the list of words to quiz on, from either the configured
+// word list file or the bundled word list.
+func (a *App) quizWords() ([]string, error) {
+	if a.conf.QuizWordListPath != "" {
+		words, err := wordlist.ReadFile(a.conf.QuizWordListPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return words, nil
+	}
+
+	difficulty, err := wordlist.ParseDifficulty(a.conf.RandomWordDifficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	allWords, err := wordlist.Words()
+	if err != nil {
+		return nil, err
+	}
+
+	var words []string
+
+	for _, word := range allWords {
+		if difficulty.Includes(word.Zipf) {
+			words = append(words, word.Text)
+		}
+	}
+
+	return words, nil
+}
+
+func (a *App) runQuiz() error {
+	words, err := a.quizWords()
+	if err != nil {
+		return err
+	}
+
+	if len(words) < 1 {
+		return errors.New("no words to quiz on")
+	}
+
+	scoresFilePath, err := quiz.ScoresFilePath()
+	if err != nil {
+		return err
+	}
+
+	scores, err := quiz.LoadScores(scoresFilePath)
+	if err != nil {
+		return err
+	}
+
+	input := bufio.NewScanner(a.stdin)
+
+	var sessionScore quiz.Score
+	var asked uint
+
+	for _, i := range rand.Perm(len(words)) {
+		if asked >= a.conf.QuizLength {
+			break
+		}
+
+		word := words[i]
+
+		results, err := a.src.Define(word)
+		if err == nil {
+			err = source.ValidateDictionaryResults(word, results)
+		}
+
+		// Skip any words that the source doesn't have definitions for
+		if _, isEmptyResult := err.(*source.EmptyResultError); isEmptyResult {
+			continue
+		}
+
+		if err != nil {
+			return &sourceError{source: a.src.Name(), err: err}
+		}
+
+		// Skip any words that the filters leave without results, too
+		results, err = a.processResults(word, results)
+		if _, isFiltered := err.(*filteredResultError); isFiltered {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var distractors []string
+
+		if a.conf.QuizChoices > 1 {
+			distractors = quiz.Distractors(word, words, int(a.conf.QuizChoices)-1)
+		}
+
+		question, err := quiz.NewQuestion(word, results, distractors...)
+		if err != nil {
+			continue
+		}
+
+		asked++
+
+		a.printQuizQuestion(asked, question)
+
+		if !input.Scan() {
+			break
+		}
+
+		correct := question.Check(input.Text())
+
+		sessionScore.Record(correct)
+		scores.Record(word, correct)
+
+		a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+			switch correct {
+			case true:
+				writer.WritePaddedStringLine("Correct!", 1)
+			case false:
+				writer.WritePaddedStringLine(fmt.Sprintf("Incorrect. The word was %q.", word), 1)
+			}
+		})
+	}
+
+	if err := errors.Join(input.Err(), scores.Save(scoresFilePath)); err != nil {
+		return err
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteStringLine(fmt.Sprintf("Score: %d/%d", sessionScore.Correct, sessionScore.Total()))
+		writer.WriteStringLine(fmt.Sprintf("All-time score: %d/%d", scores.Correct, scores.Total()))
+		writer.WriteNewLine()
+	})
+
+	return nil
+}
+
+// saveWord saves a word to review with spaced repetition, along with a
+// definition from its results, so that reviews don't need to look it up, and
+// tags it with any given tags.
+func (a *App) saveWord(word string, tags []string) error {
+	results, err := a.src.Define(word)
+	if err == nil {
+		err = source.ValidateDictionaryResults(word, results)
+	}
+
+	if err != nil {
+		return &sourceError{source: a.src.Name(), err: err}
+	}
+
+	if results, err = a.processResults(word, results); err != nil {
+		return err
+	}
+
+	definition := quiz.FirstDefinition(results)
+	if definition == "" {
+		return fmt.Errorf("unable to save %q: no definitions found", word)
+	}
+
+	deckFilePath, err := review.DeckFilePath()
+	if err != nil {
+		return err
+	}
+
+	deck, err := review.LoadDeck(deckFilePath)
+	if err != nil {
+		return err
+	}
+
+	isNew := deck.Add(word, definition, tags, time.Now())
+
+	var taggedText string
+	if card := deck.Find(word); len(card.Tags) > 0 {
+		taggedText = fmt.Sprintf(", tagged %s", strings.Join(card.Tags, ", "))
+	}
+
+	if err := deck.Save(deckFilePath); err != nil {
+		return err
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		switch isNew {
+		case true:
+			writer.WritePaddedStringLine(fmt.Sprintf("Saved %q for review%s (%d saved in total).", word, taggedText, len(deck.Cards)), 1)
+		case false:
+			writer.WritePaddedStringLine(fmt.Sprintf("%q was already saved for review%s.", word, taggedText), 1)
+		}
+	})
+
+	return nil
+}
+
+// reviewSavedWords quizzes on the saved words that are due for review (and
+// that are tagged with any of the given tags, if any are given), and schedules
+// their next reviews by how well they were recalled.
+func (a *App) reviewSavedWords(tags []string) error {
+	deck, deckFilePath, err := loadSavedWords(tags)
+	if err != nil {
+		return err
+	}
+
+	due := deck.Due(time.Now(), tags...)
+
+	if len(due) < 1 {
+		a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WritePaddedStringLine(fmt.Sprintf("No words are due for review. The next is due at %s.", deck.NextDue(tags...).Local().Format(time.DateTime)), 1)
+		})
+
+		return nil
+	}
+
+	input := bufio.NewScanner(a.stdin)
+
+	var sessionScore quiz.Score
+
+	for i, card := range due {
+		question := quiz.NewDefinitionQuestion(card.Word, card.Definition)
+
+		a.printReviewQuestion(uint(i+1), uint(len(due)), question)
+
+		if !input.Scan() {
+			break
+		}
+
+		correct := question.Check(input.Text())
+		sessionScore.Record(correct)
+
+		quality := review.Incorrect
+		if correct {
+			quality = review.Correct
+		}
+
+		card.Review(quality, time.Now())
+
+		a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+			switch correct {
+			case true:
+				writer.WritePaddedStringLine(fmt.Sprintf("Correct! Next review on %s.", card.Due.Local().Format(time.DateOnly)), 1)
+			case false:
+				writer.WritePaddedStringLine(fmt.Sprintf("Incorrect. The word was %q. Next review on %s.", card.Word, card.Due.Local().Format(time.DateOnly)), 1)
+			}
+		})
+	}
+
+	if err := errors.Join(input.Err(), deck.Save(deckFilePath)); err != nil {
+		return err
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteStringLine(fmt.Sprintf("Score: %d/%d", sessionScore.Correct, sessionScore.Total()))
+		writer.WriteNewLine()
+	})
+
+	return nil
+}
+
+// listSavedWords prints the saved words (that are tagged with any of the given
+// tags, if any are given), with their tags and when they're next due for
+// review.
+func (a *App) listSavedWords(tags []string) error {
+	deck, _, err := loadSavedWords(tags)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteNewLine()
+
+		for _, card := range deck.Saved(tags...) {
+			line := card.Word
+
+			if len(card.Tags) > 0 {
+				line += fmt.Sprintf(" [%s]", strings.Join(card.Tags, ", "))
+			}
+
+			switch card.IsDue(now) {
+			case true:
+				line += " (due now)"
+			case false:
+				line += fmt.Sprintf(" (due %s)", card.Due.Local().Format(time.DateOnly))
+			}
+
+			writer.WriteStringLine(line)
+		}
+
+		writer.WriteNewLine()
+	})
+
+	return nil
+}
+
+// exportSavedWords exports the saved words (that are tagged with any of the
+// given tags, if any are given) along with their definitions, in the
+// configured export format.
+func (a *App) exportSavedWords(tags []string, outputPath string) error {
+	format, err := vocab.ParseFormat(a.conf.ExportFormat)
+	if err != nil {
+		return err
+	}
+
+	deck, _, err := loadSavedWords(tags)
+	if err != nil {
+		return err
+	}
+
+	var entries []vocab.Entry
+
+	for _, card := range deck.Saved(tags...) {
+		entries = append(entries, vocab.Entry{
+			Word:       vocab.Word{Word: card.Word, Timestamp: card.SavedAt.UnixMilli()},
+			Definition: card.Definition,
+		})
+	}
+
+	var exported strings.Builder
+	if err := vocab.Write(&exported, format, entries); err != nil {
+		return err
+	}
+
+	return a.writeOutputFile(outputPath, exported.String())
+}
+
+// loadSavedWords loads the deck of saved words, along with the path of its
+// file, and returns an error if no words are saved (that are tagged with any
+// of the given tags, if any are given).
+func loadSavedWords(tags []string) (review.Deck, string, error) {
+	deckFilePath, err := review.DeckFilePath()
+	if err != nil {
+		return review.Deck{}, "", err
+	}
+
+	deck, err := review.LoadDeck(deckFilePath)
+	if err != nil {
+		return review.Deck{}, "", err
+	}
+
+	if len(deck.Saved(tags...)) < 1 {
+		if len(tags) > 0 {
+			return deck, deckFilePath, fmt.Errorf("no saved words are tagged %s", strings.Join(tags, " or "))
+		}
+
+		return deck, deckFilePath, errors.New("no words are saved (save them with \"define --save <word>\")")
+	}
+
+	return deck, deckFilePath, nil
+}
+
+func (a *App) printReviewQuestion(number uint, total uint, question quiz.Question) {
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Review %d of %d:", number, total), 1)
+
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WriteStringLine(question.Definition)
+		})
+
+		writer.WriteNewLine()
+		writer.WriteString("Answer: ")
+	})
+}
+
+func (a *App) printQuizQuestion(number uint, question quiz.Question) {
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Question %d of %d:", number, a.conf.QuizLength), 1)
+
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WriteStringLine(question.Definition)
+
+			if question.IsMultipleChoice() {
+				writer.WriteNewLine()
+
+				for i, choice := range question.Choices {
+					writer.WriteStringLine(fmt.Sprintf("%d. %s", i+1, choice))
+				}
+			}
+		})
+
+		writer.WriteNewLine()
+		writer.WriteString("Answer: ")
+	})
+}
+
+func (a *App) printScrabble(word string) error {
+	var validWords scrabble.WordList
+
+	if a.conf.ScrabbleWordListPath != "" {
+		var err error
+
+		validWords, err = scrabble.LoadWordList(a.conf.ScrabbleWordListPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteNewLine()
+
+		for _, scoring := range scrabble.Scorings {
+			switch score, err := scoring.Score(word); err {
+			case nil:
+				writer.WriteStringLine(fmt.Sprintf("%s score: %d", scoring.Name, score))
+			default:
+				writer.WriteStringLine(fmt.Sprintf("%s score: unplayable (%s)", scoring.Name, err))
+			}
+		}
+
+		if validWords != nil {
+			switch validWords.Contains(word) {
+			case true:
+				writer.WriteStringLine(fmt.Sprintf("Valid: yes (found in %q)", a.conf.ScrabbleWordListPath))
+			case false:
+				writer.WriteStringLine(fmt.Sprintf("Valid: no (not found in %q)", a.conf.ScrabbleWordListPath))
+			}
+		}
+	})
+
+	return a.defineWord(word)
+}
+
+func (a *App) printHyphenation(word string) error {
+	parts, fromSource, err := a.hyphenateWord(word)
+	if err != nil {
+		return err
+	}
+
+	if a.outputFormat == printer.FormatJSON {
+		encoded, err := json.MarshalIndent(struct {
+			Word        string
+			Hyphenation []string
+			Source      string
+		}{
+			Word:        word,
+			Hyphenation: parts,
+			Source:      a.hyphenationSourceName(fromSource),
+		}, "", "    ")
+		if err != nil {
+			return err
+		}
+
+		a.stdOutWriter.WriteStringLine(string(encoded))
+		return nil
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(strings.Join(parts, "-"), 1)
+
+		if len(parts) > 1 {
+			var breakPoints []string
+
+			offset := 0
+			for _, part := range parts[:len(parts)-1] {
+				offset += utf8.RuneCountInString(part)
+				breakPoints = append(breakPoints, strconv.Itoa(offset))
+			}
+
+			writer.WriteStringLine(fmt.Sprintf("Break points (after letter): %s", strings.Join(breakPoints, ", ")))
+		}
+	})
+
+	if fromSource {
+		a.newResultPrinter().PrintSourceName(a.src)
+		return nil
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteNewLine()
+		writer.WriteStringLine(fmt.Sprintf("Hyphenated by: %s", a.hyphenationSourceName(fromSource)))
+		writer.WriteNewLine()
+	})
+
+	return nil
+}
+
+// hyphenateWord returns the parts of a word split at its hyphenation points,
+// and whether they were provided by the source.
+//
+// The syllable markers of the source's entries are preferred, falling back to
+// the configured hyphenation patterns if the source doesn't have any.
+func (a *App) hyphenateWord(word string) ([]string, bool, error) {
+	dictionaryResults, err := a.src.Define(word)
+	if err == nil {
+		err = source.ValidateDictionaryResults(word, dictionaryResults)
+	}
+
+	if err == nil {
+		dictionaryResults.SortForPrimaryResult(word)
+
+		for _, result := range dictionaryResults {
+			for _, entry := range result.Entries {
+				if len(entry.Hyphenation) > 0 && source.EqualFoldPlain(strings.Join(entry.Hyphenation, ""), word) {
+					return entry.Hyphenation, true, nil
+				}
+			}
+		}
+	}
+
+	if a.conf.HyphenationPatterns == "" {
+		if err != nil {
+			return nil, false, &sourceError{source: a.src.Name(), err: err}
+		}
+
+		return nil, false, fmt.Errorf("%q provided no hyphenation points for %q (configure hyphenation patterns to hyphenate words without them)", a.src.Name(), word)
+	}
+
+	hyphenator, err := hyphenation.Load(a.conf.HyphenationPatterns)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return hyphenator.Hyphenate(word), false, nil
+}
+
+// hyphenationSourceName returns the name of what provided hyphenation points.
+func (a *App) hyphenationSourceName(fromSource bool) string {
+	if fromSource {
+		return a.src.Name()
+	}
+
+	return fmt.Sprintf("hyphenation patterns (%s)", a.conf.HyphenationPatterns)
+}
+
+func (a *App) printBestSense(word string, context []string) error {
+	dictionaryResults, err := a.src.Define(word)
+	if err == nil {
+		err = source.ValidateDictionaryResults(word, dictionaryResults)
+	}
+
+	if err != nil {
+		return &sourceError{source: a.src.Name(), err: err}
+	}
+
+	if err := a.checkParseWarnings(word, dictionaryResults); err != nil {
+		return err
+	}
+
+	// Sort by the context sentence first (as processing does), so that senses
+	// of the lexical category the word is used as win ties
+	if dictionaryResults, err = a.processResults(word, dictionaryResults); err != nil {
+		return err
+	}
+
+	if a.act.Context() != "" {
+		context = append(context, a.act.Context())
+	}
+
+	best, found := dictionaryResults.BestSense(word, context)
+	if !found {
+		return fmt.Errorf("no definitions of %q found", word)
+	}
+
+	// Print the sense as the only one of its results, so that it's printed
+	// the same way as any other results, in any output format
+	bestResults := source.DictionaryResults{
+		{
+			Word: best.Word,
+			Entries: []source.DictionaryEntry{
+				{
+					Entry: source.Entry{
+						Word:            best.Word,
+						LexicalCategory: best.LexicalCategory,
+						PartOfSpeech:    best.PartOfSpeech,
+					},
+					Senses: []source.Sense{best.Sense},
+				},
+			},
+		},
+	}
+
+	result := printer.Result{
+		Word:              word,
+		Source:            a.src.Name(),
+		DictionaryResults: bestResults,
+	}
+
+	if printed, err := a.printFormattedResult(result); printed || err != nil {
+		return err
+	}
+
+	resultPrinter := a.newResultPrinter()
+	resultPrinter.PrintDictionaryResults(bestResults)
+	resultPrinter.PrintSourceName(a.src, dictionaryResults.Attributions()...)
+
+	return nil
+}
+
+func (a *App) defineEach(text string) error {
+	words := sentence.Words(text, sentence.ParseStopWords(a.conf.StopWords))
+	if len(words) < 1 {
+		return errors.New("no words to define")
+	}
+
+	var allResults source.DictionaryResults
+
+	for _, word := range words {
+		dictionaryResults, err := a.src.Define(word)
+		if err == nil {
+			err = source.ValidateDictionaryResults(word, dictionaryResults)
+		}
+
+		// Keep going for any words that the source doesn't have definitions
+		// for, so that they're summarized as having no results
+		if _, isEmptyResult := err.(*source.EmptyResultError); !isEmptyResult {
+			if err != nil {
+				return &sourceError{source: a.src.Name(), err: err}
+			}
+
+			if err := a.checkParseWarnings(word, dictionaryResults); err != nil {
+				return err
+			}
+
+			// As with those without definitions, summarize the words that the
+			// filters leave without results as having none
+			dictionaryResults, err = a.processResults(word, dictionaryResults)
+			if _, isFiltered := err.(*filteredResultError); err != nil && !isFiltered {
+				return err
+			}
+
+			allResults = append(allResults, dictionaryResults...)
+		}
+
+		result := printer.Result{
+			Word:              word,
+			Source:            a.src.Name(),
+			DictionaryResults: dictionaryResults,
+		}
+
+		printed, err := a.printFormattedResult(result)
+		if err != nil {
+			return err
+		}
+
+		if printed {
+			continue
+		}
+
+		a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WriteStringLine(printer.Summarize(result))
+		})
+	}
+
+	if a.outputFormat == printer.FormatText {
+		a.newResultPrinter().PrintSourceName(a.src, allResults.Attributions()...)
+	}
+
+	return nil
+}
+
+// sourceKeys returns the sorted JSON keys of the available sources, which they
+// can be named by.
+func sourceKeys() []string {
+	var keys []string
+
+	for providerConf := range registry.Providers() {
+		keys = append(keys, providerConf.JSONKey())
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// findProviderConfig returns the configuration of the provider with the given
+// name, matching it case-insensitively against the JSON keys, names, and
+// aliases of the providers, and accepting any unambiguous part of one of them.
+func findProviderConfig(name string) (registry.Configuration, error) {
+	var exactMatches, partialMatches []registry.Configuration
+
+	needle := normalizeSourceName(name)
+	bestDistance := maxSourceSuggestionDistance(needle) + 1
+	var suggestion string
+
+	// Match all of the exact keys, names, and aliases first, so that an exact
+	// selector (like the "webster" alias) is never ambiguous with a source that
+	// only contains it (like "webster-medical")
+	for providerConf, provider := range registry.Providers() {
+		key := providerConf.JSONKey()
+
+		if key == name {
+			return providerConf, nil
+		}
+
+		candidates := append([]string{key, provider.Name()}, registry.Aliases(provider)...)
+
+		for _, candidate := range candidates {
+			if normalizeSourceName(candidate) == needle {
+				exactMatches = append(exactMatches, providerConf)
+				break
+			}
+		}
+	}
+
+	// Otherwise, accept any unambiguous part of one of them
+	for providerConf, provider := range registry.Providers() {
+		if len(exactMatches) > 0 {
+			break
+		}
+
+		key := providerConf.JSONKey()
+		candidates := append([]string{key, provider.Name()}, registry.Aliases(provider)...)
+
+		for _, candidate := range candidates {
+			candidate = normalizeSourceName(candidate)
+
+			if strings.Contains(candidate, needle) {
+				partialMatches = append(partialMatches, providerConf)
+				break
+			}
+
+			if distance := fuzzy.SubstringDistance(needle, candidate); distance < bestDistance || (distance == bestDistance && key < suggestion) {
+				suggestion, bestDistance = key, distance
+			}
+		}
+	}
+
+	matches := exactMatches
+	if len(matches) < 1 {
+		matches = partialMatches
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &config.UnknownSourceError{Source: name, Sources: sourceKeys(), Suggestion: suggestion}
+	case 1:
+		return matches[0], nil
+	default:
+		keys := make([]string, 0, len(matches))
+		for _, match := range matches {
+			keys = append(keys, fmt.Sprintf("%q", match.JSONKey()))
+		}
+
+		sort.Strings(keys)
+
+		return nil, fmt.Errorf("provider/source %q is ambiguous (matches %s)", name, strings.Join(keys, ", "))
+	}
+}
+
+// findDomainProviderConfig returns the configuration of the provider of the
+// specialty source that specializes in the given domain (ignoring case), or
+// nil if there isn't one.
+func findDomainProviderConfig(domain string) registry.Configuration {
+	if domain == "" {
+		return nil
+	}
+
+	for providerConf, provider := range registry.Providers() {
+		if slices.Contains(registry.Domains(provider), strings.ToLower(domain)) {
+			return providerConf
+		}
+	}
+
+	return nil
+}
+
+// normalizeSourceName normalizes a source name for matching, by lower-casing it
+// and removing anything that isn't a letter or a number.
+func normalizeSourceName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			return -1
+		}
+
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// maxSourceSuggestionDistance returns the maximum edit distance that a source
+// name can be from a source for it to be suggested, which grows with the
+// length of the name so that short names don't match everything.
+func maxSourceSuggestionDistance(name string) int {
+	return max(1, len(name)/3)
+}
+
+func (a *App) compareSources(word string, sourceNames []string) error {
+	if len(sourceNames) < 2 {
+		return errors.New("at least two sources are needed to compare")
+	}
+
+	sources := make([]source.Source, 0, len(sourceNames))
+
+	for _, name := range sourceNames {
+		providerConf, err := findProviderConfig(name)
+		if err != nil {
+			return err
+		}
+
+		compareSrc, err := registry.Provide(providerConf)
+		if err != nil {
+			return err
+		}
+
+		a.configureSource(compareSrc)
+
+		sources = append(sources, compareSrc)
+	}
+
+	results := make([]printer.Result, len(sources))
+	errs := make([]error, len(sources))
+
+	var wg sync.WaitGroup
+
+	for i, compareSrc := range sources {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			dictionaryResults, err := compareSrc.Define(word)
+			if err == nil {
+				err = source.ValidateDictionaryResults(word, dictionaryResults)
+			}
+
+			results[i] = printer.Result{
+				Word:              word,
+				Source:            compareSrc.Name(),
+				DictionaryResults: dictionaryResults,
+			}
+			errs[i] = err
+		}()
+	}
+
+	wg.Wait()
+
+	// Process the results once they've all been defined, rather than
+	// concurrently, as processing can use the app's shared state
+	for i, result := range results {
+		if errs[i] != nil {
+			continue
+		}
+
+		results[i].DictionaryResults, errs[i] = a.processResults(word, result.DictionaryResults)
+	}
+
+	var failures int
+	var dryRun bool
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		failures++
+
+		var dryRunErr *dryrun.Error
+		if errors.As(err, &dryRunErr) {
+			a.printDryRun(sources[i].Name(), dryRunErr)
+			dryRun = true
+
+			continue
+		}
+
+		// Keep comparing the other sources, with this one showing no results
+		a.printSourceError(sources[i].Name(), err)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	// Each source's error has already been printed
+	if failures == len(sources) {
+		return exitCode(1)
+	}
+
+	if a.outputFormat != printer.FormatText {
+		for _, result := range results {
+			if _, err := a.printFormattedResult(result); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	resultPrinter := a.newResultPrinter()
+	resultPrinter.PrintComparison(results)
+
+	for _, compareSrc := range sources {
+		resultPrinter.PrintSourceName(compareSrc)
+	}
+
+	return nil
+}
+
+func (a *App) annotateFile(inputPath string, outputPath string) error {
+	difficulty, err := wordlist.ParseDifficulty(a.conf.AnnotateDifficulty)
+	if err != nil {
+		return err
+	}
+
+	contents, err := os.ReadFile(inputPath)
+	if err != nil {
+		return err
+	}
+
+	text := string(contents)
+
+	var notes []annotate.Note
+
+	for _, word := range sentence.Words(text, sentence.ParseStopWords(a.conf.StopWords)) {
+		// Words that aren't in the bundled word list are assumed to be rare
+		var zipf float64
+		if listed, exists := wordlist.Lookup(word); exists {
+			zipf = listed.Zipf
+		}
+
+		if !difficulty.IncludesOrHarder(zipf) {
+			continue
+		}
+
+		dictionaryResults, err := a.src.Define(word)
+		if err == nil {
+			err = source.ValidateDictionaryResults(word, dictionaryResults)
+		}
+
+		// Skip any words that the source doesn't have definitions for
+		if _, isEmptyResult := err.(*source.EmptyResultError); isEmptyResult {
+			continue
+		}
+
+		if err != nil {
+			return &sourceError{source: a.src.Name(), err: err}
+		}
+
+		// Skip any words that the filters leave without results, too
+		dictionaryResults, err = a.processResults(word, dictionaryResults)
+		if _, isFiltered := err.(*filteredResultError); isFiltered {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		notes = append(notes, annotate.Note{
+			Word: word,
+			Text: printer.Summarize(printer.Result{Word: word, DictionaryResults: dictionaryResults}),
+		})
+	}
+
+	return a.writeOutputFile(outputPath, annotate.Markdown(text, notes))
+}
+
+// spellcheckFile checks the words of a file against the known word lists,
+// and then the source, printing a diagnostic for each unknown word with the
+// suggestions of the source's search (if it supports it), and returning an
+// exit code if there were any.
+func (a *App) spellcheckFile(inputPath string) error {
+	contents, err := os.ReadFile(inputPath)
+	if err != nil {
+		return err
+	}
+
+	filter, _, err := readWordFilter()
+	if err != nil {
+		return err
+	}
+
+	// Ask the source about each word once, however many times it's used
+	type lookup struct {
+		isKnown     bool
+		suggestions []string
+	}
 
-	switch isEmptyDictionaryResult {
-	case true:
-		stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
-			writer.WritePaddedStringLine(formatErrorForPrinting(emptyResultError), 1)
-			writer.WritePaddedStringLine("Did you mean one of these?", 1)
+	lookups := make(map[string]lookup)
+
+	isKnown := func(word string) bool {
+		return filter.Contains(strings.ToLower(word))
+	}
+
+	var diagnostics []spellcheck.Diagnostic
+
+	for _, diagnostic := range spellcheck.Check(string(contents), isKnown) {
+		key := strings.ToLower(diagnostic.Word)
+
+		wordLookup, looked := lookups[key]
+		if !looked {
+			wordLookup.isKnown, wordLookup.suggestions, err = a.lookupSpelling(diagnostic.Word)
+			if err != nil {
+				return &sourceError{source: a.src.Name(), err: err}
+			}
+
+			lookups[key] = wordLookup
+		}
+
+		if wordLookup.isKnown {
+			continue
+		}
+
+		diagnostic.Suggestions = wordLookup.suggestions
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	if a.outputFormat == printer.FormatJSON {
+		if diagnostics == nil {
+			diagnostics = []spellcheck.Diagnostic{}
+		}
+
+		encoded, err := json.MarshalIndent(diagnostics, "", "    ")
+		if err != nil {
+			return err
+		}
+
+		a.stdOutWriter.WriteStringLine(string(encoded))
+	} else {
+		for _, diagnostic := range diagnostics {
+			message := fmt.Sprintf("%s:%d:%d: unknown word %q", inputPath, diagnostic.Line, diagnostic.Column, diagnostic.Word)
+
+			if len(diagnostic.Suggestions) > 0 {
+				quoted := make([]string, 0, len(diagnostic.Suggestions))
+				for _, suggestion := range diagnostic.Suggestions {
+					quoted = append(quoted, strconv.Quote(suggestion))
+				}
+
+				message += fmt.Sprintf("; did you mean %s?", strings.Join(quoted, ", "))
+			}
+
+			a.stdOutWriter.WriteStringLine(message)
+		}
+	}
+
+	if len(diagnostics) > 0 {
+		return exitCode(1)
+	}
+
+	return nil
+}
+
+// search searches a source for a word, serving the search results from the
+// cache, if they're cached, and caching them otherwise.
+func (a *App) search(searcher source.Searcher, word string, limit uint) (source.SearchResults, error) {
+	if a.searchCache == nil {
+		return searcher.Search(word, limit)
+	}
+
+	if searchResults, isCached := a.searchCache.Get(a.src.Name(), word, limit); isCached {
+		return searchResults, nil
+	}
+
+	searchResults, err := searcher.Search(word, limit)
+	if err == nil {
+		// Caching is a best-effort, so failing to write shouldn't fail the
+		// search
+		_ = a.searchCache.Set(a.src.Name(), word, limit, searchResults)
+	}
+
+	return searchResults, err
+}
+
+// lookupSpelling returns whether the source knows a word that isn't in the
+// known word lists, by defining it, and the words that it suggests instead if
+// it doesn't, by searching for it (if the source supports search).
+func (a *App) lookupSpelling(word string) (bool, []string, error) {
+	dictionaryResults, err := a.src.Define(word)
+	if err == nil {
+		err = source.ValidateDictionaryResults(word, dictionaryResults)
+	}
+
+	if err == nil {
+		return true, nil, nil
+	}
+
+	if _, isEmptyResult := err.(*source.EmptyResultError); !isEmptyResult {
+		return false, nil, err
+	}
+
+	searcher, isSearcher := a.src.(source.Searcher)
+	if !isSearcher {
+		return false, nil, nil
+	}
+
+	searchResults, err := a.search(searcher, word, spellcheckSuggestionLimit)
+	if _, isEmptyResult := err.(*source.EmptyResultError); isEmptyResult {
+		return false, nil, nil
+	}
+
+	if err != nil {
+		return false, nil, err
+	}
+
+	suggestions := make([]string, 0, len(searchResults))
+
+	for _, searchResult := range searchResults {
+		if strings.EqualFold(string(searchResult), word) {
+			return true, nil, nil
+		}
+
+		suggestions = append(suggestions, string(searchResult))
+	}
+
+	return false, suggestions, nil
+}
+
+func (a *App) importVocab(inputPath string, outputPath string) error {
+	format, err := vocab.ParseFormat(a.conf.ExportFormat)
+	if err != nil {
+		return err
+	}
+
+	words, err := vocab.ReadKindle(inputPath)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]vocab.Entry, 0, len(words))
+
+	for _, word := range words {
+		lookupWord := word.LookupWord()
+
+		dictionaryResults, err := a.src.Define(lookupWord)
+		if err == nil {
+			err = source.ValidateDictionaryResults(lookupWord, dictionaryResults)
+		}
+
+		// Skip any words that the source doesn't have definitions for
+		if _, isEmptyResult := err.(*source.EmptyResultError); isEmptyResult {
+			a.stdErrWriter.WriteStringLine(fmt.Sprintf("Skipping %q: no results", lookupWord))
+			continue
+		}
+
+		if err != nil {
+			return &sourceError{source: a.src.Name(), err: err}
+		}
+
+		// Skip any words that the filters leave without results, too
+		dictionaryResults, err = a.processResults(lookupWord, dictionaryResults)
+		if filteredErr, isFiltered := err.(*filteredResultError); isFiltered {
+			a.stdErrWriter.WriteStringLine(fmt.Sprintf("Skipping %q: %s", lookupWord, filteredErr))
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, vocab.Entry{
+			Word:       word,
+			Definition: printer.Summarize(printer.Result{Word: lookupWord, DictionaryResults: dictionaryResults}),
 		})
+	}
 
-		resultPrinter.PrintSearchResults(searchResults)
-	case false:
-		dictionaryResults.SortForPrimaryResult(word)
+	var exported strings.Builder
+	if err := vocab.Write(&exported, format, entries); err != nil {
+		return err
+	}
 
-		resultPrinter.PrintDictionaryResults(dictionaryResults)
+	return a.writeOutputFile(outputPath, exported.String())
+}
+
+// writeOutputFile writes the contents to the file at the given path, or to
+// stdout if the path is empty.
+func (a *App) writeOutputFile(path string, contents string) error {
+	if path == "" {
+		a.stdOutWriter.WriteString(contents)
+		return nil
 	}
 
-	resultPrinter.PrintSourceName(src)
+	return os.WriteFile(path, []byte(contents), 0o644)
 }
 
-func main() {
-	// Get the word from our first non-flag argument
-	word := flags.Arg(0)
+// printFormattedResult prints a result in the configured output format, if
+// it's a non-text format, and returns true if the result was printed.
+func (a *App) printFormattedResult(result printer.Result) (bool, error) {
+	switch a.outputFormat {
+	case printer.FormatJSON:
+		return true, printer.NewJSONPrinter(a.stdOutWriter).PrintResult(result)
+	case printer.FormatOneLine:
+		return true, printer.NewOneLinePrinter(a.stdOutWriter).PrintResult(result)
+	case printer.FormatPorcelain:
+		return true, printer.NewPorcelainPrinter(a.stdOutWriter).PrintResult(result)
+	default:
+		return false, nil
+	}
+}
+
+// usePorcelain returns true if the porcelain output format should be used in
+// place of the text format, based on the configuration, or whether stdout is
+// a terminal if it's not configured and no output format was chosen.
+func (a *App) usePorcelain() bool {
+	if a.conf.Porcelain != nil {
+		return *a.conf.Porcelain
+	}
+
+	return a.conf.OutputFormat == "" && !a.isTerminal()
+}
+
+// isTerminal returns true if the standard output is a terminal (a character
+// device).
+func (a *App) isTerminal() bool {
+	file, isFile := a.stdout.(*os.File)
+	if !isFile {
+		return false
+	}
+
+	stat, err := file.Stat()
+
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+func (a *App) newResultPrinter() *printer.ResultPrinter {
+	return printer.NewResultPrinter(a.stdOutWriter, printer.Options{
+		ShowSyllables:        a.conf.ShowSyllables,
+		ShowInflections:      a.conf.ShowInflections,
+		SimpleSenseNumbering: a.conf.SimpleNumbering,
+		MaxSenseDepth:        a.conf.MaxSenseDepth,
+
+		HideSourceFooter:      a.conf.ShowSourceFooter != nil && !*a.conf.ShowSourceFooter,
+		SourceFooterSeparator: a.conf.SourceFooterSeparator,
+		SourceFooterWidth:     a.conf.SourceFooterWidth,
+	})
+}
+
+func (a *App) printSoundsLike(word string) error {
+	finder := datamuse.New(registry.HTTPClient())
+
+	results, err := finder.SoundsLike(word, wordFinderResultLimit)
+	if err != nil {
+		return &sourceError{source: finder.Name(), err: err}
+	}
+
+	return a.printRelatedWords(finder, word, fmt.Sprintf("Words that sound like %q:", word), results)
+}
+
+func (a *App) printReverseLookup(description string) error {
+	finder := datamuse.New(registry.HTTPClient())
+
+	results, err := finder.MeansLike(description, wordFinderResultLimit)
+	if err != nil {
+		return &sourceError{source: finder.Name(), err: err}
+	}
+
+	return a.printRelatedWords(finder, description, fmt.Sprintf("Words matching %q:", description), results)
+}
+
+func (a *App) printCollocations(word string) error {
+	finder := datamuse.New(registry.HTTPClient())
+
+	groups, err := finder.Collocations(word, wordFinderResultLimit)
+	if err != nil {
+		return &sourceError{source: finder.Name(), err: err}
+	}
+
+	result := printer.Result{
+		Word:              word,
+		Source:            finder.Name(),
+		RelatedWordGroups: groups,
+	}
+
+	if printed, err := a.printFormattedResult(result); printed || err != nil {
+		return err
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Collocations of %q:", word), 1)
+	})
+
+	resultPrinter := a.newResultPrinter()
+	resultPrinter.PrintRelatedWordGroups(groups)
+	resultPrinter.PrintSourceName(finder)
+
+	return nil
+}
+
+func (a *App) printWikiSummary(term string) error {
+	encyclopedia := wikipedia.New(registry.HTTPClient())
+
+	summary, err := encyclopedia.Summary(term)
+	if err != nil {
+		return &sourceError{source: encyclopedia.Name(), err: err}
+	}
+
+	result := printer.Result{
+		Word:    term,
+		Source:  encyclopedia.Name(),
+		Summary: summary,
+	}
+
+	if printed, err := a.printFormattedResult(result); printed || err != nil {
+		return err
+	}
+
+	resultPrinter := a.newResultPrinter()
+	resultPrinter.PrintSummary(*summary)
+	resultPrinter.PrintSourceName(encyclopedia, summary.Attribution)
+
+	return nil
+}
+
+// printEtymologies prints the origins of a word, as the source describes them.
+func (a *App) printEtymologies(word string) error {
+	dictionaryResults, err := a.src.Define(word)
+	if err == nil {
+		err = source.ValidateDictionaryResults(word, dictionaryResults)
+	}
+
+	if err != nil {
+		return &sourceError{source: a.src.Name(), err: err}
+	}
+
+	dictionaryResults.SortForPrimaryResult(word)
+
+	var etymologies []string
+
+	for _, result := range dictionaryResults {
+		for _, entry := range result.Entries {
+			for _, etymology := range entry.Etymologies {
+				if !slices.Contains(etymologies, etymology) {
+					etymologies = append(etymologies, etymology)
+				}
+			}
+		}
+	}
+
+	if len(etymologies) < 1 {
+		return fmt.Errorf("no origins of %q found (try --deep for Wiktionary's)", word)
+	}
+
+	if a.outputFormat == printer.FormatJSON {
+		encoded, err := json.MarshalIndent(struct {
+			Word        string
+			Etymologies []string
+			Source      string
+		}{
+			Word:        word,
+			Etymologies: etymologies,
+			Source:      a.src.Name(),
+		}, "", "    ")
+		if err != nil {
+			return err
+		}
+
+		a.stdOutWriter.WriteStringLine(string(encoded))
+		return nil
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(word, 1)
+
+		for _, etymology := range etymologies {
+			writer.WriteStringLine(etymology)
+		}
+	})
+
+	a.newResultPrinter().PrintSourceName(a.src, dictionaryResults.Attributions()...)
+
+	return nil
+}
+
+// printEtymologyTrees prints the origins of a word as trees of the words that
+// it comes from, from Wiktionary.
+func (a *App) printEtymologyTrees(word string) error {
+	etymologySource := wiktionary.New(registry.HTTPClient())
+
+	trees, err := etymologySource.Etymologies(word, etymologyLanguage)
+	if err != nil {
+		return &sourceError{source: etymologySource.Name(), err: err}
+	}
+
+	if a.outputFormat == printer.FormatJSON {
+		encoded, err := json.MarshalIndent(struct {
+			Word        string
+			Etymologies []source.Etymon
+			Source      string
+		}{
+			Word:        word,
+			Etymologies: trees,
+			Source:      etymologySource.Name(),
+		}, "", "    ")
+		if err != nil {
+			return err
+		}
+
+		a.stdOutWriter.WriteStringLine(string(encoded))
+		return nil
+	}
+
+	resultPrinter := a.newResultPrinter()
+	resultPrinter.PrintEtymologyTrees(trees)
+	resultPrinter.PrintSourceName(etymologySource, wiktionary.Attribution(word))
+
+	return nil
+}
+
+// otherReadySources returns the names (by alias, if they have one) of up to a
+// limited number of sources that are ready to use, other than the app's.
+func (a *App) otherReadySources(limit int) []string {
+	var names []string
+
+	for _, status := range sourceStatuses() {
+		if len(names) >= limit {
+			break
+		}
+
+		if !status.Ready || status.Name == a.src.Name() || len(status.Domains) > 0 {
+			continue
+		}
+
+		if len(status.Aliases) > 0 {
+			names = append(names, status.Aliases[0])
+		} else {
+			names = append(names, status.JSONKey)
+		}
+	}
+
+	return names
+}
+
+// looksLikeProperNoun returns whether a term looks like a proper noun (like
+// "Oxford" or "New York"), by every one of its words starting with an upper
+// case letter.
+func looksLikeProperNoun(term string) bool {
+	words := strings.Fields(term)
+
+	for _, word := range words {
+		if first, _ := utf8.DecodeRuneInString(word); !unicode.IsUpper(first) {
+			return false
+		}
+	}
+
+	return len(words) > 0
+}
+
+// printRelatedWords prints the results of a word-finding operation (such as
+// finding words that sound like another) in the configured output format.
+func (a *App) printRelatedWords(finder printer.Named, query string, header string, results source.RelatedWords) error {
+	result := printer.Result{
+		Word:         query,
+		Source:       finder.Name(),
+		RelatedWords: results,
+	}
+
+	if printed, err := a.printFormattedResult(result); printed || err != nil {
+		return err
+	}
+
+	a.stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(header, 1)
+	})
+
+	resultPrinter := a.newResultPrinter()
+	resultPrinter.PrintRelatedWords(results)
+	resultPrinter.PrintSourceName(finder)
+
+	return nil
+}
+
+// perform performs the action that the app was run with.
+func (a *App) perform() error {
+	// Get the word from our non-flag arguments, joined so that multi-word
+	// phrases (like phrasal verbs and idioms) can be defined
+	word := source.NormalizeWord(strings.Join(a.flags.Args(), " "), a.wordNormalizations...)
+
+	actionType := a.act.Type()
+
+	// Check for a newer release while performing the action, unless the action
+	// is about updating already (or we shouldn't be making requests)
+	var newerVersion <-chan string
+	if a.conf.CheckForUpdates && !a.conf.DryRun() && actionType != action.CheckUpdate && actionType != action.SelfUpdate {
+		newerVersion = startUpdateCheck()
+	}
+
+	var err error
 
 	// Decide what to perform
-	switch act.Type() {
+	switch actionType {
 	case action.PrintConfig:
-		printConfig()
+		err = a.printConfig()
 	case action.DebugConfig:
-		printConfigDebug()
+		a.printConfigDebug()
+	case action.PrintEnv:
+		err = a.printEnv()
 	case action.ListSources:
-		printSources()
+		err = a.printSources()
 	case action.PrintVersion:
-		printVersion()
+		a.printVersion()
+	case action.PrintSchema:
+		a.stdOutWriter.WriteString(printer.Schema)
+	case action.CheckUpdate:
+		err = a.checkUpdate()
+	case action.SelfUpdate:
+		err = a.selfUpdate()
+	case action.ManageData:
+		err = a.manageData(a.act.DataCommand())
+	case action.PrintQuota:
+		err = a.printQuota()
+	case action.DefineRandomWord:
+		err = a.defineRandomWord()
+	case action.Quiz:
+		err = a.runQuiz()
+	case action.SaveWord:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.saveWord(word, a.act.Tags())
+	case action.Review:
+		err = a.reviewSavedWords(a.act.Tags())
+	case action.ListSaved:
+		err = a.listSavedWords(a.act.Tags())
+	case action.ExportSaved:
+		err = a.exportSavedWords(a.act.Tags(), a.act.OutputFilePath())
+	case action.CheckExists:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.checkExists(word)
+	case action.Scrabble:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.printScrabble(word)
+	case action.Hyphenate:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.printHyphenation(word)
+	case action.SoundsLike:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.printSoundsLike(word)
+	case action.ReverseLookup:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.printReverseLookup(word)
+	case action.Collocations:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.printCollocations(word)
+	case action.WikiSummary:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.printWikiSummary(word)
+	case action.Etymology:
+		if word == "" {
+			return errNoWord
+		}
+
+		if a.act.IsDeep() {
+			err = a.printEtymologyTrees(word)
+		} else {
+			err = a.printEtymologies(word)
+		}
+	case action.BestSense:
+		if word == "" {
+			return errNoWord
+		}
+
+		// The first argument is the word, and any others are its context
+		err = a.printBestSense(source.NormalizeWord(a.flags.Arg(0), a.wordNormalizations...), a.flags.Args()[1:])
+	case action.DefineEach:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.defineEach(word)
+	case action.Compare:
+		if word == "" {
+			return errNoWord
+		}
+
+		err = a.compareSources(word, a.act.CompareSources())
+	case action.Annotate:
+		err = a.annotateFile(a.act.AnnotateFilePath(), a.act.OutputFilePath())
+	case action.Spellcheck:
+		err = a.spellcheckFile(a.act.SpellcheckFilePath())
+	case action.ImportVocab:
+		err = a.importVocab(a.act.ImportVocabFilePath(), a.act.OutputFilePath())
 	case action.DefineWord:
 		fallthrough
 	default:
 		if word == "" {
-			// Show our usage
-			printUsage(stdOutWriter)
-			quit(1)
-		} else {
-			defineWord(word)
+			return errNoWord
+		}
+
+		err = a.routeSource(word)
+		if err == nil {
+			err = a.defineWord(word)
 		}
 	}
+
+	if a.act.ShowStats() {
+		a.printCacheStats()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if newerVersion != nil {
+		a.printUpdateNotice(newerVersion)
+	}
+
+	return nil
 }
@@ -0,0 +1,7 @@
+//go:build mock
+
+package main
+
+// Register the mock source only in builds with the "mock" tag, so that it's
+// available for demos and testing, without being shown to regular users.
+import _ "github.com/Rican7/define/source/mock"
@@ -0,0 +1,588 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/internal/action"
+	"github.com/Rican7/define/internal/config"
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/internal/health"
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/internal/version"
+	"github.com/Rican7/define/source"
+	"github.com/Rican7/define/source/freedictionaryapi"
+	"github.com/Rican7/define/source/webster"
+	"github.com/adrg/xdg"
+)
+
+func TestAppRun(t *testing.T) {
+	testData := map[string]struct {
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		"version": {
+			args:       []string{"--version"},
+			wantCode:   0,
+			wantStdout: version.AppName,
+		},
+		"no word": {
+			args:       []string{},
+			wantCode:   1,
+			wantStdout: "Usage:",
+		},
+		"no word for an action": {
+			args:       []string{"--hyphenate"},
+			wantCode:   1,
+			wantStdout: "Usage:",
+		},
+		"unknown flag": {
+			args:       []string{"--not-a-real-flag"},
+			wantCode:   2,
+			wantStderr: "Usage:",
+		},
+		"unknown source": {
+			args:       []string{"--source=NotARealSource", "test"},
+			wantCode:   1,
+			wantStderr: `"NotARealSource" does not exist`,
+		},
+		"unknown source remedy": {
+			args:       []string{"--source=NotARealSource", "test"},
+			wantCode:   1,
+			wantStderr: "Available sources:",
+		},
+		"source typo": {
+			args:       []string{"--source=FreeDictonaryAPI", "test"},
+			wantCode:   1,
+			wantStderr: "did you mean FreeDictionaryAPI?",
+		},
+		"source in a different case": {
+			args:       []string{"--dry-run", "--source=freedictionaryapi", "test"},
+			wantCode:   0,
+			wantStdout: "Dry run: no request was made.",
+		},
+		"source alias": {
+			args:       []string{"--dry-run", "--source=freedict", "test"},
+			wantCode:   0,
+			wantStdout: "Dry run: no request was made.",
+		},
+		"source alias shared by other sources": {
+			args:       []string{"--dry-run", "--source=webster", "--merriam-webster-dictionary-app-key=key", "test"},
+			wantCode:   0,
+			wantStdout: "/collegiate/",
+		},
+		"preferred source alias": {
+			args:       []string{"--dry-run", "--preferred-source=mw", "--merriam-webster-dictionary-app-key=key", "test"},
+			wantCode:   0,
+			wantStdout: "/collegiate/",
+		},
+		"unknown preferred source": {
+			args:       []string{"--dry-run", "--preferred-source=NotARealSource", "test"},
+			wantCode:   1,
+			wantStderr: "correct the value of --preferred-source",
+		},
+		"word after terminator named like a command": {
+			args:       []string{"--dry-run", "--source=" + freedictionaryapi.JSONKey, "--", "review"},
+			wantCode:   0,
+			wantStdout: "/review",
+		},
+		"list sources": {
+			args:       []string{"--list-sources"},
+			wantCode:   0,
+			wantStdout: "Status: Ready",
+		},
+		"list sources as JSON": {
+			args:       []string{"--list-sources", "--output=json"},
+			wantCode:   0,
+			wantStdout: `"JSONKey": "FreeDictionaryAPI"`,
+		},
+		"schema": {
+			args:       []string{"--schema"},
+			wantCode:   0,
+			wantStdout: `"$schema": "https://json-schema.org/draft/2020-12/schema"`,
+		},
+		"bad output format": {
+			args:       []string{"--output=xml", "test"},
+			wantCode:   1,
+			wantStderr: "correct the value of --output, DEFINE_APP_OUTPUT_FORMAT",
+		},
+		"wiki dry run": {
+			args:       []string{"--dry-run", "--wiki", "New", "York"},
+			wantCode:   0,
+			wantStdout: "en.wikipedia.org/api/rest_v1/page/summary/New_York",
+		},
+		"deep etymology dry run": {
+			args:       []string{"--dry-run", "--etymology", "--deep", "word"},
+			wantCode:   0,
+			wantStdout: "en.wiktionary.org/w/api.php",
+		},
+		"bad translation backend": {
+			args:       []string{"--gloss-lang=de", "--translation-backend=babelfish", "test"},
+			wantCode:   1,
+			wantStderr: "correct the value of --translation-backend, DEFINE_APP_TRANSLATION_BACKEND",
+		},
+		"translation backend without its key": {
+			args:       []string{"--gloss-lang=de", "--translation-backend=deepl", "test"},
+			wantCode:   1,
+			wantStderr: "--translation-api-key",
+		},
+		"bad post-process command": {
+			args:       []string{"--post-process-cmd=jq '.", "test"},
+			wantCode:   1,
+			wantStderr: "correct the value of --post-process-cmd, DEFINE_APP_POST_PROCESS_CMD",
+		},
+		"bad level": {
+			args:       []string{"--level=expert", "test"},
+			wantCode:   1,
+			wantStderr: "correct the value of --level, DEFINE_APP_LEVEL",
+		},
+		"learner's source for a beginner": {
+			args:       []string{"--dry-run", "--level=beginner", "--merriam-webster-dictionary-app-key=key", "--merriam-webster-learners-dictionary-app-key=key", "--preferred-source=webster", "test"},
+			wantCode:   0,
+			wantStdout: "dictionaryapi.com/api/v3/references/learners/json/test",
+		},
+		"domain specialty source": {
+			args:       []string{"--dry-run", "--domain=Medical", "--merriam-webster-medical-dictionary-app-key=key", "test"},
+			wantCode:   0,
+			wantStdout: "dictionaryapi.com/api/v3/references/medical/json/test",
+		},
+		"domain specialty source without its key": {
+			args:       []string{"--domain=medical", "test"},
+			wantCode:   1,
+			wantStderr: "--merriam-webster-medical-dictionary-app-key",
+		},
+		"word exists": {
+			args:     []string{"--exists", "the"},
+			wantCode: 0,
+		},
+		"word isn't in the bundled word list": {
+			args:       []string{"--exists", "zzqx"},
+			wantCode:   2,
+			wantStderr: "define data install frequency",
+		},
+		"word is in the bundled word list": {
+			args:     []string{"--exists", "the"},
+			wantCode: 0,
+		},
+		"spellcheck dry run": {
+			args:       []string{"--dry-run", "--source=" + freedictionaryapi.JSONKey, "--spellcheck=README.md"},
+			wantCode:   0,
+			wantStdout: "Dry run: no request was made.",
+		},
+		"spellcheck missing file": {
+			args:       []string{"--spellcheck=not-a-real-file.txt"},
+			wantCode:   1,
+			wantStderr: "no such file or directory",
+		},
+		"cache stats": {
+			args:       []string{"--dry-run", "--stats", "--source=" + freedictionaryapi.JSONKey, "test"},
+			wantCode:   0,
+			wantStderr: "Caching is disabled (see --cache).",
+		},
+		"dry run": {
+			args:       []string{"--dry-run", "--source=" + freedictionaryapi.JSONKey, "test"},
+			wantCode:   0,
+			wantStdout: "Dry run: no request was made.",
+		},
+	}
+
+	// Don't read any data packs installed in the user's data directory
+	t.Cleanup(xdg.Reload)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	xdg.Reload()
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			args := append([]string{"--no-config-file"}, testData.args...)
+
+			code := new(App).Run(args, strings.NewReader(""), &stdout, &stderr)
+
+			if code != testData.wantCode {
+				t.Errorf("Run returned wrong exit code. Got %#v. Want %#v.", code, testData.wantCode)
+			}
+
+			if !strings.Contains(stdout.String(), testData.wantStdout) {
+				t.Errorf("Run wrote wrong stdout. Got %#v. Want it to contain %#v.", stdout.String(), testData.wantStdout)
+			}
+
+			if !strings.Contains(stderr.String(), testData.wantStderr) {
+				t.Errorf("Run wrote wrong stderr. Got %#v. Want it to contain %#v.", stderr.String(), testData.wantStderr)
+			}
+		})
+	}
+}
+
+func TestFindProviderConfig(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		"key":                         {name: webster.JSONKey, want: webster.JSONKey},
+		"alias":                       {name: "webster", want: webster.JSONKey},
+		"short alias":                 {name: "mw", want: webster.JSONKey},
+		"alias of a specialty source": {name: "webster-medical", want: webster.MedicalJSONKey},
+		"unambiguous part":            {name: "medical", want: webster.MedicalJSONKey},
+		"ambiguous part":              {name: "merriam", wantErr: true},
+		"unknown":                     {name: "NotARealSource", wantErr: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := findProviderConfig(testData.name)
+			if (err != nil) != testData.wantErr {
+				t.Fatalf("findProviderConfig returned wrong error. Got %#v. Want error: %#v.", err, testData.wantErr)
+			}
+
+			if err == nil && got.JSONKey() != testData.want {
+				t.Errorf("findProviderConfig returned wrong value. Got %#v. Want %#v.", got.JSONKey(), testData.want)
+			}
+		})
+	}
+}
+
+func TestLooksLikeProperNoun(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		term string
+		want bool
+	}{
+		"empty":              {term: "", want: false},
+		"lower case":         {term: "oxford", want: false},
+		"capitalized":        {term: "Oxford", want: true},
+		"all capitals":       {term: "NASA", want: true},
+		"capitalized words":  {term: "New York", want: true},
+		"partly capitalized": {term: "Ace in the hole", want: false},
+		"non-Latin":          {term: "Москва", want: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := looksLikeProperNoun(testData.term); got != testData.want {
+				t.Errorf("looksLikeProperNoun returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestProperNounErrorRemedy(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		err  *properNounError
+		want string
+	}{
+		"all alternatives": {
+			err:  &properNounError{term: "Oxford", otherSources: []string{"freedict", "mw"}},
+			want: `"Oxford" looks like a proper noun, which dictionaries tend not to define. Instead, look it up on Wikipedia with "define --wiki Oxford", or try another source (like --source=freedict or --source=mw).`,
+		},
+		"wiki already tried": {
+			err:  &properNounError{term: "Oxford", wikiTried: true, otherSources: []string{"freedict"}},
+			want: `"Oxford" looks like a proper noun, which dictionaries tend not to define. Instead, try another source (like --source=freedict).`,
+		},
+		"no alternatives": {
+			err:  &properNounError{term: "Oxford", wikiTried: true},
+			want: `"Oxford" looks like a proper noun, which dictionaries tend not to define.`,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.err.Remedy(); got != testData.want {
+				t.Errorf("Remedy returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestMergeExamples(t *testing.T) {
+	newResult := func() source.DictionaryResult {
+		return source.DictionaryResult{
+			Word: "refuse",
+			Entries: []source.DictionaryEntry{
+				{Entry: source.Entry{Word: "refuse", PartOfSpeech: source.PartOfSpeechVerb}},
+				{Entry: source.Entry{Word: "refuse", PartOfSpeech: source.PartOfSpeechVerb}, Senses: []source.Sense{{Definitions: []string{"to decline"}}}},
+				{Entry: source.Entry{Word: "refuse", PartOfSpeech: source.PartOfSpeechNoun}, Senses: []source.Sense{{Definitions: []string{"waste"}}, {Definitions: []string{"trash"}}}},
+			},
+		}
+	}
+
+	for testName, testData := range map[string]struct {
+		example   string
+		wantEntry int
+	}{
+		"guessed part of speech": {example: "Take out the refuse.", wantEntry: 2},
+		"unknown part of speech": {example: "Refuse it.", wantEntry: 1},
+		"word not used":          {example: "Something else.", wantEntry: 1},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			result := newResult()
+			example := source.AttributedText{Text: testData.example}
+
+			mergeExamples(&result, []source.AttributedText{example})
+
+			for i, entry := range result.Entries {
+				var got []source.AttributedText
+				if len(entry.Senses) > 0 {
+					got = entry.Senses[0].Examples
+				}
+
+				var want []source.AttributedText
+				if i == testData.wantEntry {
+					want = []source.AttributedText{example}
+				}
+
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("mergeExamples merged wrong examples into entry %d. Got %#v. Want %#v.", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// testSource is a source that defines the words of its results.
+type testSource map[string]source.DictionaryResults
+
+func (s testSource) Name() string {
+	return "Test"
+}
+
+func (s testSource) Define(word string) (source.DictionaryResults, error) {
+	return s[word], nil
+}
+
+// newTestResults returns the results of a word with a single sense, in an
+// entry that's marked as offensive or not.
+func newTestResults(word string, offensive bool) source.DictionaryResults {
+	return source.DictionaryResults{
+		{
+			Word: word,
+			Entries: []source.DictionaryEntry{
+				{
+					Entry:     source.Entry{Word: word, PartOfSpeech: source.PartOfSpeechNoun},
+					Senses:    []source.Sense{{Definitions: []string{"a thing called " + word}}},
+					Offensive: offensive,
+				},
+			},
+		},
+	}
+}
+
+// searchingTestSource is a test source that also searches, always finding the
+// same words.
+type searchingTestSource struct {
+	testSource
+	searchResults source.SearchResults
+}
+
+func (s searchingTestSource) Search(word string, limit uint) (source.SearchResults, error) {
+	return s.searchResults, nil
+}
+
+func TestSpellcheckFile(t *testing.T) {
+	// Don't read any data packs installed in the user's data directory
+	t.Cleanup(xdg.Reload)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	xdg.Reload()
+
+	inputPath := filepath.Join(t.TempDir(), "input.txt")
+
+	// Only "the" is in the bundled word list
+	if err := os.WriteFile(inputPath, []byte("the dog ran hello zorbl"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	words := testSource{
+		"dog":   newTestResults("dog", false),
+		"ran":   newTestResults("ran", false),
+		"hello": newTestResults("hello", false),
+	}
+
+	for testName, testData := range map[string]struct {
+		src source.Source
+	}{
+		"source without search": {src: words},
+		"source with search":    {src: searchingTestSource{testSource: words, searchResults: source.SearchResults{"zorb", "orbl", "zorro"}}},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var stdout bytes.Buffer
+
+			app := &App{src: testData.src, stdOutWriter: defineio.NewPanicWriter(&stdout, defaultIndentationSize)}
+
+			if err := app.spellcheckFile(inputPath); err != exitCode(1) {
+				t.Fatalf("spellcheckFile returned wrong error. Got %#v. Want %#v.", err, exitCode(1))
+			}
+
+			for _, word := range []string{"the", "dog", "ran", "hello"} {
+				if strings.Contains(stdout.String(), strconv.Quote(word)) {
+					t.Errorf("spellcheckFile reported a known word. Got %#v. Want it to not contain %#v.", stdout.String(), word)
+				}
+			}
+
+			if !strings.Contains(stdout.String(), `unknown word "zorbl"`) {
+				t.Errorf("spellcheckFile didn't report an unknown word. Got %#v. Want it to contain %#v.", stdout.String(), "zorbl")
+			}
+		})
+	}
+}
+
+func TestAnnotateFileFamilyFriendly(t *testing.T) {
+	src := testSource{
+		"zorbl": newTestResults("zorbl", false),
+		"flurg": newTestResults("flurg", true),
+	}
+
+	inputPath := filepath.Join(t.TempDir(), "input.md")
+
+	if err := os.WriteFile(inputPath, []byte("zorbl flurg"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for testName, testData := range map[string]struct {
+		familyFriendly bool
+		want           string
+	}{
+		"all words":       {familyFriendly: false, want: "zorbl[^1] flurg[^2]"},
+		"family friendly": {familyFriendly: true, want: "zorbl[^1] flurg\n"},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			act := action.Setup(flags)
+
+			if err := flags.Parse(nil); err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+
+			app := &App{act: act, src: src, conf: config.Configuration{FamilyFriendly: testData.familyFriendly}}
+			outputPath := filepath.Join(t.TempDir(), "output.md")
+
+			if err := app.annotateFile(inputPath, outputPath); err != nil {
+				t.Fatalf("annotateFile returned an error: %v", err)
+			}
+
+			output, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(string(output), testData.want) {
+				t.Errorf("annotateFile wrote wrong output. Got %#v. Want it to contain %#v.", string(output), testData.want)
+			}
+		})
+	}
+}
+
+func TestImportVocabFamilyFriendly(t *testing.T) {
+	src := testSource{
+		"ubiquitous":  newTestResults("ubiquitous", false),
+		"perambulate": newTestResults("perambulate", true),
+	}
+
+	for testName, testData := range map[string]struct {
+		familyFriendly bool
+		wantWords      []string
+		wantSkipped    []string
+	}{
+		"all words":       {familyFriendly: false, wantWords: []string{"perambulating", "ubiquitous"}},
+		"family friendly": {familyFriendly: true, wantWords: []string{"ubiquitous"}, wantSkipped: []string{"perambulating"}},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			act := action.Setup(flags)
+
+			if err := flags.Parse(nil); err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+
+			var stderr bytes.Buffer
+
+			app := &App{
+				act:          act,
+				src:          src,
+				conf:         config.Configuration{FamilyFriendly: testData.familyFriendly, ExportFormat: "markdown"},
+				stdErrWriter: defineio.NewPanicWriter(&stderr, defaultIndentationSize),
+			}
+			outputPath := filepath.Join(t.TempDir(), "output.md")
+
+			if err := app.importVocab("internal/vocab/testdata/vocab.db", outputPath); err != nil {
+				t.Fatalf("importVocab returned an error: %v", err)
+			}
+
+			output, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, word := range testData.wantWords {
+				if !strings.Contains(string(output), word) {
+					t.Errorf("importVocab wrote wrong output. Got %#v. Want it to contain %#v.", string(output), word)
+				}
+			}
+
+			for _, word := range testData.wantSkipped {
+				if strings.Contains(string(output), word) {
+					t.Errorf("importVocab wrote wrong output. Got %#v. Want it to not contain %#v.", string(output), word)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordSourceHealth(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		err          error
+		wantFailures uint
+	}{
+		"success":        {err: nil, wantFailures: 0},
+		"no results":     {err: &source.EmptyResultError{Word: "tset"}, wantFailures: 0},
+		"filtered":       {err: &filteredResultError{"no senses found"}, wantFailures: 0},
+		"authentication": {err: &source.AuthenticationError{}, wantFailures: health.FailureThreshold},
+		"invalid":        {err: &source.InvalidResponseError{}, wantFailures: health.FailureThreshold},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			src := testSource{}
+			tracker := health.NewTracker(filepath.Join(t.TempDir(), "health.json"))
+			app := &App{src: src, healthTracker: tracker}
+
+			for i := 0; i < health.FailureThreshold; i++ {
+				var err error
+				if testData.err != nil {
+					err = &sourceError{source: src.Name(), err: testData.err}
+				}
+
+				app.recordSourceHealth(err)
+			}
+
+			sourceHealth, err := tracker.Health(src.Name())
+			if err != nil {
+				t.Fatalf("Health returned an error: %v", err)
+			}
+
+			if sourceHealth.ConsecutiveFailures != testData.wantFailures {
+				t.Errorf("recordSourceHealth recorded wrong failures. Got %#v. Want %#v.", sourceHealth.ConsecutiveFailures, testData.wantFailures)
+			}
+		})
+	}
+}
+
+func TestUsePorcelain(t *testing.T) {
+	porcelain := true
+
+	for testName, testData := range map[string]struct {
+		conf config.Configuration
+		want bool
+	}{
+		"no format chosen":    {conf: config.Configuration{}, want: true},
+		"text format chosen":  {conf: config.Configuration{OutputFormat: "text"}, want: false},
+		"porcelain requested": {conf: config.Configuration{OutputFormat: "text", Porcelain: &porcelain}, want: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Output to a buffer, which isn't a terminal
+			app := &App{conf: testData.conf, stdout: new(bytes.Buffer)}
+
+			if got := app.usePorcelain(); got != testData.want {
+				t.Errorf("usePorcelain returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -6,7 +6,6 @@ require (
 	dario.cat/mergo v1.0.0
 	github.com/adrg/xdg v0.4.0
 	github.com/fatih/structs v1.1.0
-	github.com/ogier/pflag v0.0.0-20160129220114-45c278ab3607
 	golang.org/x/text v0.14.0
 )
 
@@ -5,7 +5,9 @@
 package action
 
 import (
-	flag "github.com/ogier/pflag"
+	"strings"
+
+	"github.com/Rican7/define/internal/flag"
 )
 
 // List of actions to perform.
@@ -13,10 +15,47 @@ const (
 	DefineWord Type = iota
 	PrintConfig
 	DebugConfig
+	PrintEnv
 	ListSources
 	PrintVersion
+	PrintSchema
+	PrintQuota
+	DefineRandomWord
+	Quiz
+	SaveWord
+	Review
+	ListSaved
+	ExportSaved
+	Scrabble
+	Hyphenate
+	SoundsLike
+	ReverseLookup
+	Collocations
+	WikiSummary
+	BestSense
+	DefineEach
+	Compare
+	Annotate
+	ImportVocab
+	CheckUpdate
+	SelfUpdate
+	ManageData
+	CheckExists
+	Spellcheck
+	Etymology
+)
+
+// The name of the command to manage data packs, and its subcommands
+const (
+	dataCommand        = "data"
+	DataCommandInstall = "install"
+	DataCommandList    = "list"
+	DataCommandRemove  = "remove"
 )
 
+// The name of the command to review saved words
+const reviewCommand = "review"
+
 // Type defines the type of action intended for the app to perform.
 type Type uint
 
@@ -26,8 +65,39 @@ type Action struct {
 	flag    struct {
 		printConfig  bool
 		debugConfig  bool
+		printEnv     bool
 		listSources  bool
 		printVersion bool
+		printSchema  bool
+		printQuota   bool
+		randomWord   bool
+		quiz         bool
+		save         bool
+		review       bool
+		listSaved    bool
+		exportSaved  bool
+		tag          string
+		scrabble     bool
+		hyphenate    bool
+		soundsLike   bool
+		reverse      bool
+		collocations bool
+		wiki         bool
+		etymology    bool
+		deep         bool
+		best         bool
+		each         bool
+		compare      string
+		annotate     string
+		spellcheck   string
+		importVocab  string
+		outputFile   string
+		context      string
+		checkUpdate  bool
+		selfUpdate   bool
+		installData  string
+		exists       bool
+		stats        bool
 	}
 }
 
@@ -41,8 +111,39 @@ func Setup(flags *flag.FlagSet) *Action {
 	// Define our flags
 	flags.BoolVar(&act.flag.printConfig, "print-config", false, "To print the current configuration")
 	flags.BoolVar(&act.flag.debugConfig, "debug-config", false, "To print debug info about the configuration")
+	flags.BoolVar(&act.flag.printEnv, "print-env", false, "To print the environment variables that are read for configuration, and whether they're set (without their values)")
 	flags.BoolVar(&act.flag.listSources, "list-sources", false, "To print the available sources")
 	flags.BoolVar(&act.flag.printVersion, "version", false, "To print the app's version info")
+	flags.BoolVar(&act.flag.printSchema, "schema", false, "To print the JSON Schema of the JSON output (--output=json)")
+	flags.BoolVar(&act.flag.printQuota, "quota", false, "To print the tracked usage of the APIs that sources make requests to")
+	flags.BoolVar(&act.flag.randomWord, "random", false, "To define a random word from the bundled word list")
+	flags.BoolVar(&act.flag.quiz, "quiz", false, "To be quizzed on the definitions of words")
+	flags.BoolVar(&act.flag.save, "save", false, "To save a word to review with spaced repetition, along with its definition")
+	flags.BoolVar(&act.flag.review, "review", false, "To review the saved words that are due, with spaced repetition, the same as \"review\"")
+	flags.BoolVar(&act.flag.listSaved, "list-saved", false, "To print the saved words, with their tags and when they're next due for review")
+	flags.BoolVar(&act.flag.exportSaved, "export-saved", false, "To export the saved words, along with their definitions")
+	flags.StringVar(&act.flag.tag, "tag", "", "The tags to save words with, or to only list, export, or review the saved words with, comma separated (like \"gre,favorite\")")
+	flags.BoolVar(&act.flag.exists, "exists", false, "To check whether a word exists in the known word lists, without a source, exiting with a non-zero code if it doesn't (for scripting)")
+	flags.BoolVar(&act.flag.scrabble, "scrabble", false, "To print the word game scores and validity of a word, along with its definition")
+	flags.BoolVar(&act.flag.hyphenate, "hyphenate", false, "To print the points at which a word may be hyphenated")
+	flags.BoolVar(&act.flag.soundsLike, "sounds-like", false, "To print words that sound like a word (homophones and near-homophones)")
+	flags.BoolVar(&act.flag.reverse, "reverse", false, "To print words that match a description (a reverse dictionary lookup)")
+	flags.BoolVar(&act.flag.collocations, "collocations", false, "To print words that are commonly used with a word")
+	flags.BoolVar(&act.flag.wiki, "wiki", false, "To print a summary of a term's Wikipedia article, for terms (like proper nouns) that dictionaries don't define")
+	flags.BoolVar(&act.flag.etymology, "etymology", false, "To print the origins of a word")
+	flags.BoolVar(&act.flag.deep, "deep", false, "To print the origins of a word as a tree of the words that it comes from, from Wiktionary (with --etymology)")
+	flags.BoolVar(&act.flag.best, "best", false, "To print only the most relevant definition of a word, given any words after it as context (like \"define --best bank river fishing\")")
+	flags.BoolVar(&act.flag.each, "each", false, "To print a short definition of each word in a sentence, skipping stop words")
+	flags.StringVar(&act.flag.compare, "compare", "", "The sources to compare the definitions of a word between, comma separated (like \"oxford,webster\")")
+	flags.StringVar(&act.flag.annotate, "annotate", "", "The path of a text file to annotate with footnoted definitions of its uncommon words, as Markdown")
+	flags.StringVar(&act.flag.spellcheck, "spellcheck", "", "The path of a text file to spellcheck, printing the line and column of each unknown word with suggestions, and exiting with a non-zero code if any are found")
+	flags.StringVar(&act.flag.importVocab, "import-vocab", "", "The path of an e-reader's vocabulary database (a Kindle's vocab.db) to define and export the words of")
+	flags.StringVarP(&act.flag.outputFile, "output-file", "o", "", "The path of the file to write annotated text or exported vocabulary to (stdout if empty)")
+	flags.StringVar(&act.flag.context, "context", "", "A sentence that the word is used in, to order its results by the lexical category it's used as (like \"He tried to refuse the offer\")")
+	flags.BoolVar(&act.flag.checkUpdate, "check-update", false, "To check whether a newer release of the app is available, without updating")
+	flags.BoolVar(&act.flag.selfUpdate, "self-update", false, "To update the app to the latest release, after verifying its checksum")
+	flags.BoolVar(&act.flag.stats, "stats", false, "To print the hit and miss counts of the in-memory caches to stderr, after performing the action (with --cache)")
+	flags.StringVar(&act.flag.installData, "install-data", "", "The name of an offline data pack to download and install (like \"gcide\", for an offline dictionary source), the same as \"data install <pack>\"")
 
 	// Pass our flagset, so we can be diligent about parse checking later
 	act.flagSet = flags
@@ -66,11 +167,182 @@ func (a *Action) Type() Type {
 		return PrintConfig
 	case a.flag.debugConfig:
 		return DebugConfig
+	case a.flag.printEnv:
+		return PrintEnv
 	case a.flag.listSources:
 		return ListSources
 	case a.flag.printVersion:
 		return PrintVersion
+	case a.flag.printSchema:
+		return PrintSchema
+	case a.flag.checkUpdate:
+		return CheckUpdate
+	case a.flag.selfUpdate:
+		return SelfUpdate
+	case a.flag.installData != "" || a.isDataCommand():
+		return ManageData
+	case a.flag.printQuota:
+		return PrintQuota
+	case a.flag.randomWord:
+		return DefineRandomWord
+	case a.flag.quiz:
+		return Quiz
+	case a.flag.save:
+		return SaveWord
+	case a.flag.review:
+		return Review
+	case a.flag.listSaved:
+		return ListSaved
+	case a.flag.exportSaved:
+		return ExportSaved
+	case a.flag.exists:
+		return CheckExists
+	case a.flag.scrabble:
+		return Scrabble
+	case a.flag.hyphenate:
+		return Hyphenate
+	case a.flag.soundsLike:
+		return SoundsLike
+	case a.flag.reverse:
+		return ReverseLookup
+	case a.flag.collocations:
+		return Collocations
+	case a.flag.wiki:
+		return WikiSummary
+	case a.flag.etymology:
+		return Etymology
+	case a.flag.best:
+		return BestSense
+	case a.flag.each:
+		return DefineEach
+	case a.flag.compare != "":
+		return Compare
+	case a.flag.annotate != "":
+		return Annotate
+	case a.flag.spellcheck != "":
+		return Spellcheck
+	case a.flag.importVocab != "":
+		return ImportVocab
+	case a.isReviewCommand():
+		return Review
 	default:
 		return DefineWord
 	}
 }
+
+// CompareSources returns the names of the sources to compare, as passed.
+func (a *Action) CompareSources() []string {
+	a.validateState()
+
+	var names []string
+
+	for _, name := range strings.Split(a.flag.compare, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Tags returns the tags of saved words, as passed.
+func (a *Action) Tags() []string {
+	a.validateState()
+
+	var tags []string
+
+	for _, tag := range strings.Split(a.flag.tag, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// AnnotateFilePath returns the path of the file to annotate, as passed.
+func (a *Action) AnnotateFilePath() string {
+	a.validateState()
+
+	return a.flag.annotate
+}
+
+// SpellcheckFilePath returns the path of the file to spellcheck, as passed.
+func (a *Action) SpellcheckFilePath() string {
+	a.validateState()
+
+	return a.flag.spellcheck
+}
+
+// ImportVocabFilePath returns the path of the vocabulary database to import,
+// as passed.
+func (a *Action) ImportVocabFilePath() string {
+	a.validateState()
+
+	return a.flag.importVocab
+}
+
+// isDataCommand returns whether the arguments are a command to manage data
+// packs (like "data install gcide"), rather than words to define.
+func (a *Action) isDataCommand() bool {
+	if a.flagSet.Arg(0) != dataCommand {
+		return false
+	}
+
+	switch a.flagSet.Arg(1) {
+	case DataCommandInstall, DataCommandList, DataCommandRemove:
+		return true
+	default:
+		return false
+	}
+}
+
+// isReviewCommand returns whether the arguments are the command to review saved
+// words, rather than a word to define. Arguments after a "--" are always words,
+// so that "review" itself can still be defined.
+func (a *Action) isReviewCommand() bool {
+	return a.flagSet.NArg() == 1 && a.flagSet.Arg(0) == reviewCommand && a.flagSet.ArgsLenAtDash() != 0
+}
+
+// DataCommand returns the subcommand to manage data packs with (like
+// "install"), and the names of the packs to manage, as passed.
+func (a *Action) DataCommand() (string, []string) {
+	a.validateState()
+
+	if !a.isDataCommand() {
+		return DataCommandInstall, []string{a.flag.installData}
+	}
+
+	return a.flagSet.Arg(1), a.flagSet.Args()[2:]
+}
+
+// OutputFilePath returns the path of the file to write output to, as passed,
+// or an empty string if output should be written to stdout.
+func (a *Action) OutputFilePath() string {
+	a.validateState()
+
+	return a.flag.outputFile
+}
+
+// IsDeep returns whether the origins of a word should be printed as a tree of
+// the words that it comes from, as passed.
+func (a *Action) IsDeep() bool {
+	a.validateState()
+
+	return a.flag.deep
+}
+
+// ShowStats returns whether the stats of the in-memory caches should be printed
+// after performing the action, as passed.
+func (a *Action) ShowStats() bool {
+	a.validateState()
+
+	return a.flag.stats
+}
+
+// Context returns the sentence that the word is used in, as passed.
+func (a *Action) Context() string {
+	a.validateState()
+
+	return a.flag.context
+}
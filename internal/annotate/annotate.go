@@ -0,0 +1,69 @@
+// Package annotate provides operations for annotating a text with notes on
+// its words, such as their definitions.
+package annotate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rican7/define/internal/sentence"
+)
+
+// Note defines the structure of a note on a word of a text.
+type Note struct {
+	Word string
+	Text string
+}
+
+// Markdown returns the text annotated with the given notes as Markdown
+// footnotes.
+//
+// A footnote reference is placed after the first occurrence of each noted
+// word (ignoring case), and the footnotes are listed after the text, in the
+// order that their words appear. Notes on words that don't appear in the text
+// are skipped.
+func Markdown(text string, notes []Note) string {
+	notesByWord := make(map[string]Note, len(notes))
+	for _, note := range notes {
+		notesByWord[strings.ToLower(note.Word)] = note
+	}
+
+	var annotated strings.Builder
+	var footnotes []string
+
+	lastEnd := 0
+
+	for _, token := range sentence.Tokens(text) {
+		key := strings.ToLower(token.Text)
+
+		note, exists := notesByWord[key]
+		if !exists {
+			continue
+		}
+
+		// Only reference the first occurrence
+		delete(notesByWord, key)
+
+		number := len(footnotes) + 1
+
+		annotated.WriteString(text[lastEnd:token.End])
+		annotated.WriteString(fmt.Sprintf("[^%d]", number))
+		lastEnd = token.End
+
+		footnotes = append(footnotes, fmt.Sprintf("[^%d]: %s", number, collapseLines(note.Text)))
+	}
+
+	annotated.WriteString(text[lastEnd:])
+
+	if len(footnotes) < 1 {
+		return annotated.String()
+	}
+
+	return strings.TrimRight(annotated.String(), "\n") + "\n\n" + strings.Join(footnotes, "\n") + "\n"
+}
+
+// collapseLines collapses the whitespace of a text, so that it fits on a
+// single line.
+func collapseLines(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
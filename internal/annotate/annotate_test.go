@@ -0,0 +1,35 @@
+package annotate
+
+import (
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text  string
+		notes []Note
+		want  string
+	}{
+		"no notes": {
+			text: "A plain text.\n",
+			want: "A plain text.\n",
+		},
+		"notes": {
+			text: "The Ubiquitous cat was ubiquitous; its gait was ungainly.\n",
+			notes: []Note{
+				{Word: "ungainly", Text: "ungainly: clumsy;\n awkward"},
+				{Word: "ubiquitous", Text: "ubiquitous (adjective): found everywhere"},
+				{Word: "missing", Text: "not in the text"},
+			},
+			want: "The Ubiquitous[^1] cat was ubiquitous; its gait was ungainly[^2].\n\n" +
+				"[^1]: ubiquitous (adjective): found everywhere\n" +
+				"[^2]: ungainly: clumsy; awkward\n",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := Markdown(testData.text, testData.notes); got != testData.want {
+				t.Errorf("Markdown returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package bloom provides a Bloom filter of strings, for answering whether a
+// string is in a large set without storing the set.
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+)
+
+// headerSize is the size of the encoded header of a filter, holding its
+// number of hash functions and its number of bits.
+const headerSize = 8 + 8
+
+// ErrMalformed is returned when decoding a malformed filter.
+var ErrMalformed = errors.New("the bloom filter is malformed")
+
+// Filter is a Bloom filter of strings.
+//
+// A filter may report that a string was added when it wasn't (a false
+// positive), at about the rate that it was sized for, but never the opposite.
+type Filter struct {
+	hashCount uint64
+	bits      []uint64
+}
+
+// New returns a new, empty Filter, sized to hold the given number of strings
+// with the given rate of false positives (like 0.001).
+func New(count int, falsePositiveRate float64) *Filter {
+	count = max(count, 1)
+
+	// See https://en.wikipedia.org/wiki/Bloom_filter#Optimal_number_of_hash_functions
+	bitCount := math.Ceil(-float64(count) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashCount := math.Round(bitCount / float64(count) * math.Ln2)
+
+	return &Filter{
+		hashCount: uint64(max(hashCount, 1)),
+		bits:      make([]uint64, (uint64(bitCount)+63)/64),
+	}
+}
+
+// Add adds a string to the filter.
+func (f *Filter) Add(value string) {
+	for _, bit := range f.bitIndexes(value) {
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// Contains returns whether a string may have been added to the filter. A false
+// return value means that it definitely wasn't.
+func (f *Filter) Contains(value string) bool {
+	for _, bit := range f.bitIndexes(value) {
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MarshalBinary encodes the filter into a binary form.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, headerSize, headerSize+len(f.bits)*8)
+
+	binary.LittleEndian.PutUint64(data[0:], f.hashCount)
+	binary.LittleEndian.PutUint64(data[8:], uint64(len(f.bits)))
+
+	for _, word := range f.bits {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter from the binary form of MarshalBinary.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize {
+		return ErrMalformed
+	}
+
+	hashCount := binary.LittleEndian.Uint64(data[0:])
+	wordCount := binary.LittleEndian.Uint64(data[8:])
+	data = data[headerSize:]
+
+	if hashCount < 1 || wordCount < 1 || uint64(len(data)) != wordCount*8 {
+		return ErrMalformed
+	}
+
+	f.hashCount = hashCount
+	f.bits = make([]uint64, wordCount)
+
+	for i := range f.bits {
+		f.bits[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+
+	return nil
+}
+
+// bitIndexes returns the indexes of the bits of a string in the filter.
+//
+// The indexes are derived from two hashes of the string, by double hashing,
+// rather than computing a hash per index.
+func (f *Filter) bitIndexes(value string) []uint64 {
+	hasher := fnv.New128a()
+	hasher.Write([]byte(value))
+	sum := hasher.Sum(nil)
+
+	first := binary.LittleEndian.Uint64(sum[0:8])
+	second := binary.LittleEndian.Uint64(sum[8:16]) | 1 // Odd, so it cycles all of the bits
+
+	bitCount := uint64(len(f.bits)) * 64
+	indexes := make([]uint64, f.hashCount)
+
+	for i := range indexes {
+		indexes[i] = (first + uint64(i)*second) % bitCount
+	}
+
+	return indexes
+}
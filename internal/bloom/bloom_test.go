@@ -0,0 +1,78 @@
+package bloom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	const count = 10000
+	const falsePositiveRate = 0.01
+
+	filter := New(count, falsePositiveRate)
+
+	for i := 0; i < count; i++ {
+		filter.Add(fmt.Sprintf("word-%d", i))
+	}
+
+	for i := 0; i < count; i++ {
+		if value := fmt.Sprintf("word-%d", i); !filter.Contains(value) {
+			t.Fatalf("Contains returned wrong value for %q. Got false. Want true.", value)
+		}
+	}
+
+	falsePositives := 0
+
+	for i := 0; i < count; i++ {
+		if filter.Contains(fmt.Sprintf("other-%d", i)) {
+			falsePositives++
+		}
+	}
+
+	// Allow for some variance from the rate that the filter was sized for
+	if rate := float64(falsePositives) / count; rate > falsePositiveRate*2 {
+		t.Errorf("Contains returned too many false positives. Got a rate of %v. Want about %v.", rate, falsePositiveRate)
+	}
+}
+
+func TestFilterBinary(t *testing.T) {
+	filter := New(100, 0.001)
+	filter.Add("apple")
+	filter.Add("banana")
+
+	data, err := filter.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary returned an error: %s", err)
+	}
+
+	var decoded Filter
+
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary returned an error: %s", err)
+	}
+
+	for value, want := range map[string]bool{"apple": true, "banana": true, "cherry": false} {
+		if got := decoded.Contains(value); got != want {
+			t.Errorf("Contains returned wrong value for %q. Got %#v. Want %#v.", value, got, want)
+		}
+	}
+}
+
+func TestFilterUnmarshalBinaryMalformed(t *testing.T) {
+	valid, _ := New(100, 0.001).MarshalBinary()
+
+	for testName, data := range map[string][]byte{
+		"empty":     nil,
+		"header":    valid[:headerSize],
+		"truncated": valid[:len(valid)-1],
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var filter Filter
+
+			if err := filter.UnmarshalBinary(data); !errors.Is(err, ErrMalformed) {
+				t.Errorf("UnmarshalBinary returned wrong error. Got %#v. Want %#v.", err, ErrMalformed)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Package command provides handling of the external commands that are
+// configured to be run, like text-to-speech commands.
+package command
+
+import (
+	"errors"
+	"strings"
+)
+
+// Split splits a command line into its arguments, by whitespace, with single
+// or double quotes grouping arguments containing whitespace.
+func Split(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+
+	inArg := false
+
+	for _, r := range command {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("the command has an unterminated quote")
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
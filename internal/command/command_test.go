@@ -0,0 +1,37 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	testData := map[string]struct {
+		command string
+		want    []string
+		wantErr bool
+	}{
+		"empty":              {command: "", want: nil},
+		"single":             {command: "say", want: []string{"say"}},
+		"placeholder":        {command: "espeak-ng -v en {word}", want: []string{"espeak-ng", "-v", "en", "{word}"}},
+		"extra whitespace":   {command: "  say \t {word} ", want: []string{"say", "{word}"}},
+		"double quotes":      {command: `sh -c "echo {word}"`, want: []string{"sh", "-c", "echo {word}"}},
+		"single quotes":      {command: `sh -c 'say "{word}"'`, want: []string{"sh", "-c", `say "{word}"`}},
+		"empty quotes":       {command: `say ""`, want: []string{"say", ""}},
+		"unterminated quote": {command: `say "{word}`, wantErr: true},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			got, err := Split(testData.command)
+
+			if (err != nil) != testData.wantErr {
+				t.Fatalf("Split returned wrong error. Got %#v. Want error: %#v.", err, testData.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Split returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -7,41 +7,252 @@ package config
 import (
 	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/internal/routing"
 	"github.com/Rican7/define/registry"
 	"github.com/fatih/structs"
-	flag "github.com/ogier/pflag"
 
 	"dario.cat/mergo"
 )
 
+// oneLineOutputFormat is the output format that the "one-line" flag selects
+const oneLineOutputFormat = "one-line"
+
+// cacheMemorySizeFlag is the name of the flag of the number of cache entries to
+// keep in memory, which is optional, so that it can be set to 0
+const cacheMemorySizeFlag = "cache-memory-size"
+
 // Configuration defines the application's configuration structure
 type Configuration struct {
-	IndentationSize uint
-	PreferredSource string
-	Source          string
+	Profile            string
+	Profiles           map[string]json.RawMessage
+	IndentationSize    uint
+	PreferredSource    string
+	AutoSwitchSource   bool
+	Source             string
+	SourceRoutes       []routing.Rule
+	WordNormalizations string
+	Domain             string
+	ResultLanguage     string
+	FamilyFriendly     bool
+	Level              string
+	PronunciationStyle string
+	ShowSyllables      bool
+	ShowTrend          bool
+	ShowInflections    bool
+	Speak              bool
+	SpeechCommand      string
+	SimpleNumbering    bool
+	MaxSenseDepth      uint
+	Cache              bool
+	CacheMemorySize    *uint // The number of cache entries to keep in memory, or nil for the default
+	CheckForUpdates    bool
+	Exact              bool
+	Strict             bool
+	WikiFallback       bool
+	TatoebaExamples    bool
+	GlossLanguage      string
+	PostProcessCmd     string
+	OutputFormat       string
+	Porcelain          *bool // Whether to use porcelain output, or nil to detect
+
+	RandomWordDifficulty string
+	QuizWordListPath     string
+	QuizLength           uint
+	QuizChoices          uint
+	ScrabbleWordListPath string
+	StopWords            string
+	AnnotateDifficulty   string
+	ExportFormat         string
+	HyphenationPatterns  string
+
+	TranslationBackend  string
+	TranslationEndpoint string
+	TranslationAPIKey   string
+
+	ShowSourceFooter      *bool
+	SourceFooterSeparator string
+	SourceFooterWidth     uint
 
 	// Private fields that shouldn't be externally set or output
 	providerConfigs map[string]registry.Configuration
 	configFilePath  string
 	noConfigFile    bool
+	noSourceFooter  bool
+	oneLine         bool
+	porcelain       bool
+	noPorcelain     bool
+	cacheMemorySize uint
+	dryRun          bool
 }
 
-// initializeCommandLineConfig initializes the command line configuration.
-func initializeCommandLineConfig(flags *flag.FlagSet, defaults Configuration) *Configuration {
-	var conf Configuration
+// Precedence is the list of the sources of configuration values, in the order
+// that they take precedence when merged, for both the app's and the source
+// providers' values.
+var Precedence = []string{
+	"Command line flags",
+	"Environment variables",
+	"The config file's selected profile",
+	"The config file",
+	"Default values",
+}
+
+// envNames is the list of the names of environment variables that have been
+// read for configuration values.
+var envNames []string
+
+// DefineFlags defines the configuration's flags on the given flag set, with
+// the given default values shown in their usage.
+//
+// The flags' values are read from the flag set by NewFromRuntime, once it's
+// parsed. Only the flags that were passed are read, so that the defaults don't
+// take priority over the other sources of configuration values.
+func DefineFlags(flags *flag.FlagSet, defaults Configuration) {
+	// Show our first found default location as the config file path
+	defaults.configFilePath = findConfigFile()
+
+	defineFlags(flags, &Configuration{}, defaults)
+}
+
+// defineFlags defines the configuration's flags on the given flag set, with
+// the given default values, setting their values on the given configuration.
+func defineFlags(flags *flag.FlagSet, conf *Configuration, defaults Configuration) {
+	var defaultCacheMemorySize uint
+	if defaults.CacheMemorySize != nil {
+		defaultCacheMemorySize = *defaults.CacheMemorySize
+	}
 
 	// Define our flags
 	flags.StringVarP(&conf.configFilePath, "config-file", "c", defaults.configFilePath, "The path of the config file to use")
 	flags.BoolVar(&conf.noConfigFile, "no-config-file", false, "To not load any config file")
+	flags.StringVar(&conf.Profile, "profile", defaults.Profile, "The name of the profile in the config file to use the values of")
+	flags.BoolVar(&conf.dryRun, "dry-run", false, "To print the request that would be made to the source, instead of making it")
 	flags.UintVar(&conf.IndentationSize, "indent-size", defaults.IndentationSize, "The number of spaces to indent output by")
 	flags.StringVar(&conf.PreferredSource, "preferred-source", defaults.PreferredSource, "The preferred source to use, if available and able to be provided")
-	flags.StringVarP(&conf.Source, "source", "s", defaults.Source, "The source to use (will error if unavailable or unable to be provided)")
+	flags.BoolVar(&conf.AutoSwitchSource, "auto-switch-source", defaults.AutoSwitchSource, "To use another source, for the session, when the preferred source has kept failing")
+	flags.StringVarP(&conf.Source, "source", "s", defaults.Source, "The source to use, by any unambiguous part of its key or name (will error if unavailable or unable to be provided)")
+	flags.StringVar(&conf.WordNormalizations, "normalize", defaults.WordNormalizations, "The normalizations to apply to words before looking them up, comma separated (\"trim\", \"lowercase\", \"punctuation\", \"diacritics\", or \"none\")")
+	flags.StringVar(&conf.ResultLanguage, "result-lang", defaults.ResultLanguage, "The language (like \"en\", or \"en-gb\" for a regional variant) to only show results in, when a source returns results in several languages")
+	flags.StringVar(&conf.Domain, "domain", defaults.Domain, "The domain (or other category) to only show senses in, like \"Law\" or \"Music\", or to use a specialty source of, like \"medical\"")
+	flags.StringVar(&conf.Level, "level", defaults.Level, "The level of English of the definitions (\"beginner\", \"intermediate\", or \"advanced\"), preferring a learner's dictionary below advanced")
+	flags.BoolVar(&conf.FamilyFriendly, "family-friendly", defaults.FamilyFriendly, "To hide entries and senses that are flagged or labelled as offensive, and censor vulgar words in examples")
+	flags.StringVar(&conf.PronunciationStyle, "pronunciation-style", defaults.PronunciationStyle, "The notation to display pronunciations in (\"ipa\" or \"respell\"), instead of the source's")
+	flags.BoolVar(&conf.ShowSyllables, "show-syllables", defaults.ShowSyllables, "To show the syllable count and stress of pronunciations")
+	flags.BoolVar(&conf.ShowTrend, "trend", defaults.ShowTrend, "To show a sparkline of the use of words in books over time, from the Google Books Ngram Viewer")
+	flags.BoolVar(&conf.ShowInflections, "show-inflections", defaults.ShowInflections, "To show the inflected forms of words (like plurals and past tenses), generating them if the source doesn't provide them")
+	flags.UintVar(&conf.MaxSenseDepth, "max-sense-depth", defaults.MaxSenseDepth, "The maximum depth of sub-senses to print, with 1 being only the top-level senses (0 for no limit)")
+	flags.BoolVar(&conf.Speak, "speak", defaults.Speak, "To speak the word aloud after defining it, with the speech command")
+	flags.StringVar(&conf.SpeechCommand, "speech-command", defaults.SpeechCommand, "The text-to-speech command to speak words with, where \"{word}\" and \"{ipa}\" are replaced by the word and its IPA pronunciation (the word is written to stdin, if neither is used)")
+	flags.BoolVar(&conf.SimpleNumbering, "simple-numbering", defaults.SimpleNumbering, "To number senses sequentially, instead of with the source's own labels (like \"1a\")")
+	flags.BoolVar(&conf.Exact, "exact", defaults.Exact, "To only show results for the exact word, instead of any word that the source redirects to")
+	flags.BoolVar(&conf.Strict, "strict", defaults.Strict, "To fail when a source's response can't be fully parsed, printing what was dropped")
+	flags.BoolVar(&conf.WikiFallback, "wiki-fallback", defaults.WikiFallback, "To print a Wikipedia summary of a capitalized term (like a proper noun) that the source has no results for")
+	flags.BoolVar(&conf.TatoebaExamples, "tatoeba-examples", defaults.TatoebaExamples, "To add example sentences from Tatoeba to results that have few examples of their own")
+	flags.StringVar(&conf.GlossLanguage, "gloss-lang", defaults.GlossLanguage, "The language (as an ISO 639-1 code, like \"de\") to translate definitions into, printing each translation beneath its definition")
+	flags.StringVar(&conf.TranslationBackend, "translation-backend", defaults.TranslationBackend, "The machine translation backend to translate definitions with (\"libretranslate\" or \"deepl\")")
+	flags.StringVar(&conf.TranslationEndpoint, "translation-endpoint", defaults.TranslationEndpoint, "The endpoint of the translation backend, like that of a self-hosted LibreTranslate instance (the backend's default if empty)")
+	flags.StringVar(&conf.TranslationAPIKey, "translation-api-key", defaults.TranslationAPIKey, "The API key for the translation backend")
+	flags.BoolVar(&conf.Cache, "cache", defaults.Cache, "To cache source responses, refreshing them with conditional requests, and search results, for a short time without any request")
+	flags.UintVar(&conf.cacheMemorySize, cacheMemorySizeFlag, defaultCacheMemorySize, "The number of cached responses (and of search results) to also keep in memory, in front of the disk, with --cache (0 to not keep any)")
+	flags.BoolVar(&conf.CheckForUpdates, "check-for-updates", defaults.CheckForUpdates, "To check for a newer release of the app (at most once a day), and print a notice if one is available")
+	flags.StringVar(&conf.PostProcessCmd, "post-process-cmd", defaults.PostProcessCmd, "The command to pipe results through, as JSON on its stdin, to transform them before they're printed (it must write the results, as JSON, to its stdout)")
+	flags.StringVar(&conf.OutputFormat, "output", defaults.OutputFormat, "The format to output results in (\"text\", \"json\", \"one-line\", or \"porcelain\"), which is text, or porcelain when output isn't a terminal, by default")
+	flags.BoolVar(&conf.porcelain, "porcelain", false, "To output results in a stable, easily parsed format (the default when output isn't a terminal)")
+	flags.BoolVar(&conf.noPorcelain, "no-porcelain", false, "To not output results in the porcelain format, even when output isn't a terminal")
+	flags.BoolVar(&conf.oneLine, "one-line", false, "To output a single line summary of the results (shorthand for --output=one-line)")
+	flags.StringVar(&conf.RandomWordDifficulty, "difficulty", defaults.RandomWordDifficulty, "The difficulty of random and quiz words (\"easy\", \"medium\", or \"hard\")")
+	flags.StringVar(&conf.QuizWordListPath, "quiz-word-list", defaults.QuizWordListPath, "The path of a file of words (one per line) to quiz on, instead of the bundled word list")
+	flags.UintVar(&conf.QuizLength, "quiz-length", defaults.QuizLength, "The number of questions to ask in a quiz")
+	flags.UintVar(&conf.QuizChoices, "quiz-choices", defaults.QuizChoices, "The number of choices to give for multiple-choice quiz questions (0 to type the answer)")
+	flags.StringVar(&conf.ScrabbleWordListPath, "scrabble-word-list", defaults.ScrabbleWordListPath, "The path of a file of valid words (one per line) to check word game validity against")
+	flags.StringVar(&conf.StopWords, "stop-words", defaults.StopWords, "The words to skip when defining each word of a sentence, comma separated (\"none\" to skip no words, or empty for the bundled list)")
+	flags.StringVar(&conf.AnnotateDifficulty, "annotate-difficulty", defaults.AnnotateDifficulty, "The minimum difficulty of words to annotate (\"easy\", \"medium\", or \"hard\"), with words not in the bundled word list being hard")
+	flags.StringVar(&conf.ExportFormat, "export-format", defaults.ExportFormat, "The format to export imported vocabulary or saved words in (\"markdown\" or \"anki\")")
+	flags.StringVar(&conf.HyphenationPatterns, "hyphenation-patterns", defaults.HyphenationPatterns, "The path of a file of TeX hyphenation patterns to hyphenate words with, when the source doesn't provide hyphenation points")
+	flags.BoolVar(&conf.noSourceFooter, "no-source-footer", false, "To not print the footer that names the source of the results")
+	flags.StringVar(&conf.SourceFooterSeparator, "source-footer-separator", defaults.SourceFooterSeparator, "The character to draw the source footer's separator line with")
+	flags.UintVar(&conf.SourceFooterWidth, "source-footer-width", defaults.SourceFooterWidth, "The maximum width of the source footer's separator line")
+}
+
+// initializeCommandLineConfig initializes the command line configuration from
+// the flags that were passed when parsing the given flag set.
+func initializeCommandLineConfig(flags *flag.FlagSet) (Configuration, error) {
+	var conf Configuration
+	var err error
+
+	// Define our flags again, without defaults, and set only the passed ones
+	passedFlags := flag.NewFlagSet(flags.Name(), flag.ContinueOnError)
+	defineFlags(passedFlags, &conf, Configuration{})
+
+	err = setPassedFlags(passedFlags, flags)
+
+	applyShorthandFlags(&conf, flags)
+
+	return conf, err
+}
+
+// overrideWithPassedFlags sets the values of the flags that were passed when
+// parsing the given flag set on a merged configuration.
+//
+// Merging skips zero values, so this ensures that a passed zero value (like
+// that of "--no-cache" or "--quiz-choices=0") still takes precedence over the
+// non-zero values of the other sources of configuration values.
+func overrideWithPassedFlags(conf *Configuration, flags *flag.FlagSet) error {
+	// Define our flags again, with the merged values as their defaults, so
+	// that only the passed ones are changed
+	passedFlags := flag.NewFlagSet(flags.Name(), flag.ContinueOnError)
+	defineFlags(passedFlags, conf, *conf)
 
-	return &conf
+	err := setPassedFlags(passedFlags, flags)
+
+	applyShorthandFlags(conf, flags)
+
+	return err
+}
+
+// setPassedFlags sets the flags that were passed when parsing the given flag
+// set on the given flag set to set them on.
+func setPassedFlags(setFlags *flag.FlagSet, flags *flag.FlagSet) error {
+	var err error
+
+	flags.Visit(func(f *flag.Flag) {
+		if setFlags.Lookup(f.Name) != nil {
+			err = errors.Join(err, setFlags.Set(f.Name, f.Value.String()))
+		}
+	})
+
+	return err
+}
+
+// applyShorthandFlags sets the values of the configuration that the shorthand
+// flags (like "--one-line") stand for, and those of the flags for optional
+// values, if they were passed when parsing the given flag set.
+func applyShorthandFlags(conf *Configuration, flags *flag.FlagSet) {
+	if conf.oneLine {
+		conf.OutputFormat = oneLineOutputFormat
+	}
+
+	if conf.porcelain || conf.noPorcelain {
+		porcelain := conf.porcelain && !conf.noPorcelain
+		conf.Porcelain = &porcelain
+	}
+
+	if conf.noSourceFooter {
+		showSourceFooter := false
+		conf.ShowSourceFooter = &showSourceFooter
+	}
+
+	if flags.Changed(cacheMemorySizeFlag) {
+		cacheMemorySize := conf.cacheMemorySize
+		conf.CacheMemorySize = &cacheMemorySize
+	}
 }
 
 // initializeEnvironmentConfig initializes the environment configuration from
@@ -49,106 +260,361 @@ func initializeCommandLineConfig(flags *flag.FlagSet, defaults Configuration) *C
 func initializeEnvironmentConfig() Configuration {
 	var conf Configuration
 
-	if val, err := strconv.ParseUint(os.Getenv("DEFINE_APP_INDENT_SIZE"), 10, 0); err == nil {
+	applyEnvironmentConfig(&conf)
+
+	return conf
+}
+
+// applyEnvironmentConfig sets the values of the environment variables that are
+// set in the application's environment on the given configuration, leaving
+// the values of any others as they are.
+//
+// Merging skips zero values, so this is applied again once merged, to ensure
+// that an environment variable set to a zero value (like "false" or "0") still
+// takes precedence over the values of the config file and defaults.
+func applyEnvironmentConfig(conf *Configuration) {
+	if val, err := strconv.ParseUint(getenv("DEFINE_APP_INDENT_SIZE"), 10, 0); err == nil {
 		conf.IndentationSize = uint(val)
 	}
 
-	conf.PreferredSource = os.Getenv("DEFINE_APP_PREFERRED_SOURCE")
-	conf.Source = os.Getenv("DEFINE_APP_SOURCE")
+	setStringFromEnv(&conf.Profile, "DEFINE_APP_PROFILE")
+	setStringFromEnv(&conf.PreferredSource, "DEFINE_APP_PREFERRED_SOURCE")
 
-	return conf
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_AUTO_SWITCH_SOURCE")); err == nil {
+		conf.AutoSwitchSource = val
+	}
+
+	setStringFromEnv(&conf.Source, "DEFINE_APP_SOURCE")
+	setStringFromEnv(&conf.WordNormalizations, "DEFINE_APP_NORMALIZE")
+	setStringFromEnv(&conf.Domain, "DEFINE_APP_DOMAIN")
+	setStringFromEnv(&conf.ResultLanguage, "DEFINE_APP_RESULT_LANG")
+	setStringFromEnv(&conf.Level, "DEFINE_APP_LEVEL")
+	setStringFromEnv(&conf.PronunciationStyle, "DEFINE_APP_PRONUNCIATION_STYLE")
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_FAMILY_FRIENDLY")); err == nil {
+		conf.FamilyFriendly = val
+	}
+
+	setStringFromEnv(&conf.OutputFormat, "DEFINE_APP_OUTPUT_FORMAT")
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_PORCELAIN")); err == nil {
+		conf.Porcelain = &val
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_SHOW_SYLLABLES")); err == nil {
+		conf.ShowSyllables = val
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_SHOW_TREND")); err == nil {
+		conf.ShowTrend = val
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_SHOW_INFLECTIONS")); err == nil {
+		conf.ShowInflections = val
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_SPEAK")); err == nil {
+		conf.Speak = val
+	}
+
+	setStringFromEnv(&conf.SpeechCommand, "DEFINE_APP_SPEECH_COMMAND")
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_EXACT")); err == nil {
+		conf.Exact = val
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_STRICT")); err == nil {
+		conf.Strict = val
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_WIKI_FALLBACK")); err == nil {
+		conf.WikiFallback = val
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_TATOEBA_EXAMPLES")); err == nil {
+		conf.TatoebaExamples = val
+	}
+
+	setStringFromEnv(&conf.GlossLanguage, "DEFINE_APP_GLOSS_LANGUAGE")
+	setStringFromEnv(&conf.PostProcessCmd, "DEFINE_APP_POST_PROCESS_CMD")
+	setStringFromEnv(&conf.TranslationBackend, "DEFINE_APP_TRANSLATION_BACKEND")
+	setStringFromEnv(&conf.TranslationEndpoint, "DEFINE_APP_TRANSLATION_ENDPOINT")
+	setStringFromEnv(&conf.TranslationAPIKey, "DEFINE_APP_TRANSLATION_API_KEY")
+
+	if val, err := strconv.ParseUint(getenv("DEFINE_APP_MAX_SENSE_DEPTH"), 10, 0); err == nil {
+		conf.MaxSenseDepth = uint(val)
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_SIMPLE_NUMBERING")); err == nil {
+		conf.SimpleNumbering = val
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_CACHE")); err == nil {
+		conf.Cache = val
+	}
+
+	if val, err := strconv.ParseUint(getenv("DEFINE_APP_CACHE_MEMORY_SIZE"), 10, 0); err == nil {
+		cacheMemorySize := uint(val)
+		conf.CacheMemorySize = &cacheMemorySize
+	}
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_CHECK_FOR_UPDATES")); err == nil {
+		conf.CheckForUpdates = val
+	}
+
+	setStringFromEnv(&conf.RandomWordDifficulty, "DEFINE_APP_RANDOM_WORD_DIFFICULTY")
+	setStringFromEnv(&conf.QuizWordListPath, "DEFINE_APP_QUIZ_WORD_LIST")
+
+	if val, err := strconv.ParseUint(getenv("DEFINE_APP_QUIZ_LENGTH"), 10, 0); err == nil {
+		conf.QuizLength = uint(val)
+	}
+
+	if val, err := strconv.ParseUint(getenv("DEFINE_APP_QUIZ_CHOICES"), 10, 0); err == nil {
+		conf.QuizChoices = uint(val)
+	}
+
+	setStringFromEnv(&conf.ScrabbleWordListPath, "DEFINE_APP_SCRABBLE_WORD_LIST")
+	setStringFromEnv(&conf.StopWords, "DEFINE_APP_STOP_WORDS")
+	setStringFromEnv(&conf.AnnotateDifficulty, "DEFINE_APP_ANNOTATE_DIFFICULTY")
+	setStringFromEnv(&conf.ExportFormat, "DEFINE_APP_EXPORT_FORMAT")
+	setStringFromEnv(&conf.HyphenationPatterns, "DEFINE_APP_HYPHENATION_PATTERNS")
+
+	if val, err := strconv.ParseBool(getenv("DEFINE_APP_SHOW_SOURCE_FOOTER")); err == nil {
+		conf.ShowSourceFooter = &val
+	}
+
+	setStringFromEnv(&conf.SourceFooterSeparator, "DEFINE_APP_SOURCE_FOOTER_SEPARATOR")
+
+	if val, err := strconv.ParseUint(getenv("DEFINE_APP_SOURCE_FOOTER_WIDTH"), 10, 0); err == nil {
+		conf.SourceFooterWidth = uint(val)
+	}
 }
 
-// initializeFileConfig initializes the file configuration by loading the
-// configuration from a file at the given path.
-func initializeFileConfig(filePath string) (Configuration, error) {
-	var conf Configuration
+// setStringFromEnv sets the given string value to the value of an environment
+// variable, if it's set to a non-empty value.
+func setStringFromEnv(value *string, envName string) {
+	if val := getenv(envName); val != "" {
+		*value = val
+	}
+}
+
+// getenv returns the value of an environment variable, recording its name so
+// that the environment variables that are read for configuration can be
+// listed.
+func getenv(envName string) string {
+	if !slices.Contains(envNames, envName) {
+		envNames = append(envNames, envName)
+	}
+
+	return os.Getenv(envName)
+}
+
+// fileProfiles holds the profile values of a config file, which are read
+// before the rest of the file, so that a profile can be selected and loaded
+// first.
+type fileProfiles struct {
+	Profile  string
+	Profiles map[string]json.RawMessage
+}
 
-	filePath = tryExpandUserPath(filePath)
+// readConfigFile reads the contents of the config file at the given path,
+// along with its profiles.
+func readConfigFile(filePath string) ([]byte, fileProfiles, error) {
+	var profiles fileProfiles
 
-	fileContents, err := os.ReadFile(filePath)
+	fileContents, err := os.ReadFile(tryExpandUserPath(filePath))
 	if err != nil {
-		return conf, err
+		return fileContents, profiles, err
 	}
 
+	if len(fileContents) > 0 {
+		err = json.Unmarshal(fileContents, &profiles)
+	}
+
+	return fileContents, profiles, err
+}
+
+// initializeFileConfig initializes the file configuration from the contents of
+// the file at the given path, with any values that the given raw profile
+// configuration sets replacing the file's own, even if they're zero-values.
+func initializeFileConfig(filePath string, fileContents []byte, rawProfile json.RawMessage) (Configuration, error) {
+	var conf Configuration
+	var err error
+
 	if len(fileContents) > 0 {
 		err = json.Unmarshal(fileContents, &conf)
 	}
 
-	conf.configFilePath = filePath
+	if err == nil && len(rawProfile) > 0 {
+		profile, profiles := conf.Profile, conf.Profiles
+
+		err = json.Unmarshal(rawProfile, &conf)
+
+		// Profiles can't select or contain other profiles
+		conf.Profile, conf.Profiles = profile, profiles
+	}
+
+	conf.configFilePath = tryExpandUserPath(filePath)
 
 	return conf, err
 }
 
+// initializeProfileConfig initializes the configuration of the named profile,
+// from the given profiles of a config file. An empty name selects no profile,
+// and so returns an empty configuration.
+func initializeProfileConfig(profiles map[string]json.RawMessage, name string) (Configuration, error) {
+	var conf Configuration
+
+	if name == "" {
+		return conf, nil
+	}
+
+	rawConf, exists := profiles[name]
+	if !exists {
+		err := errors.New("no profile of that name is in the config file")
+
+		if len(profiles) > 0 {
+			names := make([]string, 0, len(profiles))
+			for profileName := range profiles {
+				names = append(names, profileName)
+			}
+
+			slices.Sort(names)
+
+			err = fmt.Errorf("no profile of that name is in the config file (available: %s)", strings.Join(names, ", "))
+		}
+
+		return conf, &BadValueError{Key: "Profile", Value: name, Flag: "profile", EnvName: "DEFINE_APP_PROFILE", Err: err}
+	}
+
+	if err := json.Unmarshal(rawConf, &conf); err != nil {
+		return conf, fmt.Errorf("error reading profile %q of the config file with error: %s", name, err)
+	}
+
+	// Profiles can't select or contain other profiles
+	conf.Profile = name
+	conf.Profiles = nil
+
+	return conf, nil
+}
+
 // mergeConfigurations merges multiple configurations values together, from left
 // to right argument position, by filling any of the left arguments zero-values
 // with any non-zero-values from the right.
+//
+// Pointer values (for optional values) are only filled in when they're nil, so
+// that a set zero-value (like a cache memory size of 0) takes precedence.
 func mergeConfigurations(confs ...Configuration) (Configuration, error) {
 	var merged Configuration
 
 	for _, conf := range confs {
-		if err := mergo.Merge(&merged, conf); err != nil {
+		if err := mergo.Merge(&merged, conf, mergo.WithoutDereference); err != nil {
 			return merged, err
 		}
 
 		// Set private (unexported values), as mergo can't handle those.
 		merged.configFilePath = cmp.Or(merged.configFilePath, conf.configFilePath)
 		merged.noConfigFile = cmp.Or(merged.noConfigFile, conf.noConfigFile)
+		merged.dryRun = cmp.Or(merged.dryRun, conf.dryRun)
 	}
 
 	return merged, nil
 }
 
 // NewFromRuntime builds a Configuration by merging values from multiple
-// different sources. It accepts a Configuration containing default values to
-// fill in any empty/blank configuration values found when merging from the
-// different sources.
+// different sources, reading the command line values from the given flag set,
+// which must have its flags defined by DefineFlags and already be parsed. It
+// accepts a Configuration containing default values to fill in any
+// empty/blank configuration values found when merging from the different
+// sources.
 //
-// The merging of values from different sources will take this priority:
+// The merging of values from different sources, including the values of the
+// given provider configurations, will take this priority (see Precedence):
 // 1. Command line arguments
 // 2. Environment variables
-// 3. A loaded config file, if available
-// 4. Passed in default values
+// 3. The selected profile of a loaded config file, if any
+// 4. A loaded config file, if available
+// 5. Passed in default values
+//
+// The profile is selected by name, by the "Profile" value of the first of
+// these that sets it.
 func NewFromRuntime(
 	flags *flag.FlagSet,
 	providerConfigs map[string]registry.Configuration,
 	defaults Configuration,
 ) (Configuration, error) {
 	var conf Configuration
-	var err error
-
 	var fileConfig Configuration
 
-	// Set our config file path based on our first found default location.
-	defaults.configFilePath = findConfigFile()
+	if !flags.Parsed() {
+		return conf, errors.New("the command line flags must be parsed before the configuration is built")
+	}
+
+	commandLineConfig, err := initializeCommandLineConfig(flags)
+	if err != nil {
+		return conf, err
+	}
 
-	commandLineConfig := initializeCommandLineConfig(flags, defaults)
+	// Load the providers' environment values, before their config file values,
+	// so that they take the same precedence as ours
+	for _, providerConfig := range providerConfigs {
+		registry.LoadEnv(providerConfig)
+	}
 
-	// Parse our flag set, as we need the values from the commandLineConfig
-	err = flags.Parse(os.Args[1:])
+	// Load the config file from either the passed path or our first found
+	// default location
+	defaults.configFilePath = findConfigFile()
 
-	if err == nil && !commandLineConfig.noConfigFile {
-		// This path should have either the user-passed value or a found default
-		configFilePath := tryExpandUserPath(commandLineConfig.configFilePath)
+	var configFilePath string
+	var fileContents []byte
+	var profiles fileProfiles
+
+	if !commandLineConfig.noConfigFile {
+		configFilePath = tryExpandUserPath(cmp.Or(commandLineConfig.configFilePath, defaults.configFilePath))
 
 		// If we have a config file to load
 		if configFilePath != "" {
-			fileConfig, err = initializeFileConfig(configFilePath)
+			fileContents, profiles, err = readConfigFile(configFilePath)
 			if err != nil {
-				err = fmt.Errorf("error reading config file %q with error: %s", configFilePath, err)
+				return conf, fmt.Errorf("error reading config file %q with error: %s", configFilePath, err)
 			}
 		}
 	}
 
-	if err == nil {
-		conf, err = mergeConfigurations(
-			*commandLineConfig,
-			initializeEnvironmentConfig(),
-			fileConfig,
-			defaults,
-		)
+	environmentConfig := initializeEnvironmentConfig()
+
+	profileName := cmp.Or(commandLineConfig.Profile, environmentConfig.Profile, profiles.Profile)
+
+	// Load the profile before the rest of the config file, as the providers
+	// only fill in their empty values, so that its values (including those of
+	// sources) take precedence over the file's own
+	profileConfig, err := initializeProfileConfig(profiles.Profiles, profileName)
+	if err != nil {
+		return conf, err
+	}
+
+	if configFilePath != "" {
+		fileConfig, err = initializeFileConfig(configFilePath, fileContents, profiles.Profiles[profileName])
+		if err != nil {
+			return conf, fmt.Errorf("error reading config file %q with error: %s", configFilePath, err)
+		}
+	}
+
+	conf, err = mergeConfigurations(
+		commandLineConfig,
+		environmentConfig,
+		profileConfig,
+		fileConfig,
+		defaults,
+	)
+	if err != nil {
+		return conf, err
 	}
 
+	applyEnvironmentConfig(&conf)
+
+	err = overrideWithPassedFlags(&conf, flags)
+
 	conf.providerConfigs = providerConfigs
 
 	return conf, err
@@ -165,9 +631,26 @@ func (c Configuration) ProviderConfigs() []registry.Configuration {
 	return list
 }
 
-// FilePath returns the path of the file that was loaded for the configuration.
+// FilePath returns the path of the file that was loaded for the configuration,
+// or an empty string if no file was loaded.
 func (c Configuration) FilePath() string {
-	return c.configFilePath
+	if c.noConfigFile {
+		return ""
+	}
+
+	return tryExpandUserPath(c.configFilePath)
+}
+
+// EnvNames returns the names of the environment variables that configuration
+// values are read from, in the order that they're read.
+func EnvNames() []string {
+	return slices.Clone(envNames)
+}
+
+// DryRun returns true if requests to sources should only be printed, rather
+// than made.
+func (c Configuration) DryRun() bool {
+	return c.dryRun
 }
 
 // MarshalJSON defines how the configuration should be JSON marshalled.
@@ -0,0 +1,435 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+func TestNewFromRuntimePrecedence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+
+	if err := os.WriteFile(filePath, []byte(`{"IndentationSize": 3, "Domain": "Law", "Profiles": {"study": {"IndentationSize": 6}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := Configuration{IndentationSize: 2, Domain: "Music"}
+
+	testData := map[string]struct {
+		args       []string
+		env        map[string]string
+		wantIndent uint
+		wantDomain string
+	}{
+		"defaults": {
+			args:       []string{"--no-config-file"},
+			wantIndent: 2,
+			wantDomain: "Music",
+		},
+		"file over defaults": {
+			args:       []string{"--config-file=" + filePath},
+			wantIndent: 3,
+			wantDomain: "Law",
+		},
+		"env over file": {
+			args:       []string{"--config-file=" + filePath},
+			env:        map[string]string{"DEFINE_APP_INDENT_SIZE": "4"},
+			wantIndent: 4,
+			wantDomain: "Law",
+		},
+		"profile over file": {
+			args:       []string{"--config-file=" + filePath, "--profile=study"},
+			wantIndent: 6,
+			wantDomain: "Law",
+		},
+		"env over profile": {
+			args:       []string{"--config-file=" + filePath},
+			env:        map[string]string{"DEFINE_APP_PROFILE": "study", "DEFINE_APP_INDENT_SIZE": "4"},
+			wantIndent: 4,
+			wantDomain: "Law",
+		},
+		"flag over env": {
+			args:       []string{"--config-file=" + filePath, "--indent-size=5"},
+			env:        map[string]string{"DEFINE_APP_INDENT_SIZE": "4", "DEFINE_APP_DOMAIN": "Music"},
+			wantIndent: 5,
+			wantDomain: "Music",
+		},
+		"flag set to its default over env": {
+			args:       []string{"--no-config-file", "--indent-size=2"},
+			env:        map[string]string{"DEFINE_APP_INDENT_SIZE": "4"},
+			wantIndent: 2,
+			wantDomain: "Music",
+		},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			for envName, value := range testData.env {
+				t.Setenv(envName, value)
+			}
+
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+
+			DefineFlags(flags, defaults)
+
+			if err := flags.Parse(testData.args); err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+
+			conf, err := NewFromRuntime(flags, nil, defaults)
+			if err != nil {
+				t.Fatalf("NewFromRuntime returned an error: %v", err)
+			}
+
+			if conf.IndentationSize != testData.wantIndent {
+				t.Errorf("NewFromRuntime returned wrong IndentationSize. Got %#v. Want %#v.", conf.IndentationSize, testData.wantIndent)
+			}
+
+			if conf.Domain != testData.wantDomain {
+				t.Errorf("NewFromRuntime returned wrong Domain. Got %#v. Want %#v.", conf.Domain, testData.wantDomain)
+			}
+		})
+	}
+}
+
+type testProvider struct{}
+
+type testProviderConfig struct {
+	AppID string
+}
+
+func (p *testProvider) Name() string {
+	return "Test"
+}
+
+func (p *testProvider) Provide(registry.Configuration) (source.Source, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *testProviderConfig) JSONKey() string {
+	return "Test"
+}
+
+func (c *testProviderConfig) UnmarshalJSON(data []byte) error {
+	type alias testProviderConfig
+	copy := &alias{}
+
+	if err := json.Unmarshal(data, copy); err != nil {
+		return err
+	}
+
+	registry.FillEmpty(&c.AppID, copy.AppID)
+
+	return nil
+}
+
+func TestNewFromRuntimeProfileOverFile(t *testing.T) {
+	registry.Register(func(*flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+		return &testProvider{}, &testProviderConfig{}
+	})
+
+	filePath := filepath.Join(t.TempDir(), "config.json")
+
+	fileContents := `{
+		"FamilyFriendly": true,
+		"QuizChoices": 4,
+		"Test": {"AppID": "fileid"},
+		"Profiles": {"work": {"FamilyFriendly": false, "QuizChoices": 0, "Test": {"AppID": "profid"}}}
+	}`
+
+	if err := os.WriteFile(filePath, []byte(fileContents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for testName, testData := range map[string]struct {
+		args               []string
+		wantFamilyFriendly bool
+		wantQuizChoices    uint
+		wantAppID          string
+	}{
+		"file": {
+			args:               []string{"--config-file=" + filePath},
+			wantFamilyFriendly: true,
+			wantQuizChoices:    4,
+			wantAppID:          "fileid",
+		},
+		"profile over file": {
+			args:               []string{"--config-file=" + filePath, "--profile=work"},
+			wantFamilyFriendly: false,
+			wantQuizChoices:    0,
+			wantAppID:          "profid",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+
+			providerConfigs, err := registry.ConfigureProviders(flags)
+			if err != nil {
+				t.Fatalf("ConfigureProviders returned an error: %v", err)
+			}
+
+			DefineFlags(flags, Configuration{})
+
+			if err := flags.Parse(testData.args); err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+
+			conf, err := NewFromRuntime(flags, providerConfigs, Configuration{})
+			if err != nil {
+				t.Fatalf("NewFromRuntime returned an error: %v", err)
+			}
+
+			if conf.FamilyFriendly != testData.wantFamilyFriendly {
+				t.Errorf("NewFromRuntime returned wrong FamilyFriendly. Got %#v. Want %#v.", conf.FamilyFriendly, testData.wantFamilyFriendly)
+			}
+
+			if conf.QuizChoices != testData.wantQuizChoices {
+				t.Errorf("NewFromRuntime returned wrong QuizChoices. Got %#v. Want %#v.", conf.QuizChoices, testData.wantQuizChoices)
+			}
+
+			if appID := providerConfigs["Test"].(*testProviderConfig).AppID; appID != testData.wantAppID {
+				t.Errorf("NewFromRuntime returned wrong provider AppID. Got %#v. Want %#v.", appID, testData.wantAppID)
+			}
+		})
+	}
+}
+
+func TestNewFromRuntimePassedZeroValues(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+
+	if err := os.WriteFile(filePath, []byte(`{"Cache": true, "FamilyFriendly": true, "QuizChoices": 4}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for testName, testData := range map[string]struct {
+		args               []string
+		wantCache          bool
+		wantFamilyFriendly bool
+		wantQuizChoices    uint
+	}{
+		"file": {
+			args:               []string{"--config-file=" + filePath},
+			wantCache:          true,
+			wantFamilyFriendly: true,
+			wantQuizChoices:    4,
+		},
+		"negated flag over file": {
+			args:               []string{"--config-file=" + filePath, "--no-cache"},
+			wantCache:          false,
+			wantFamilyFriendly: true,
+			wantQuizChoices:    4,
+		},
+		"false flag over file": {
+			args:               []string{"--config-file=" + filePath, "--family-friendly=false"},
+			wantCache:          true,
+			wantFamilyFriendly: false,
+			wantQuizChoices:    4,
+		},
+		"zero flag over file": {
+			args:               []string{"--config-file=" + filePath, "--quiz-choices=0"},
+			wantCache:          true,
+			wantFamilyFriendly: true,
+			wantQuizChoices:    0,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+
+			DefineFlags(flags, Configuration{})
+
+			if err := flags.Parse(testData.args); err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+
+			conf, err := NewFromRuntime(flags, nil, Configuration{})
+			if err != nil {
+				t.Fatalf("NewFromRuntime returned an error: %v", err)
+			}
+
+			if conf.Cache != testData.wantCache {
+				t.Errorf("NewFromRuntime returned wrong Cache. Got %#v. Want %#v.", conf.Cache, testData.wantCache)
+			}
+
+			if conf.FamilyFriendly != testData.wantFamilyFriendly {
+				t.Errorf("NewFromRuntime returned wrong FamilyFriendly. Got %#v. Want %#v.", conf.FamilyFriendly, testData.wantFamilyFriendly)
+			}
+
+			if conf.QuizChoices != testData.wantQuizChoices {
+				t.Errorf("NewFromRuntime returned wrong QuizChoices. Got %#v. Want %#v.", conf.QuizChoices, testData.wantQuizChoices)
+			}
+		})
+	}
+}
+
+func TestNewFromRuntimeCacheMemorySize(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+
+	if err := os.WriteFile(filePath, []byte(`{"CacheMemorySize": 0}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	defaultSize := uint(256)
+	defaults := Configuration{CacheMemorySize: &defaultSize}
+
+	for testName, testData := range map[string]struct {
+		args []string
+		env  map[string]string
+		want uint
+	}{
+		"default": {
+			args: []string{"--no-config-file"},
+			want: 256,
+		},
+		"disabled by flag": {
+			args: []string{"--no-config-file", "--cache-memory-size=0"},
+			want: 0,
+		},
+		"disabled by env": {
+			args: []string{"--no-config-file"},
+			env:  map[string]string{"DEFINE_APP_CACHE_MEMORY_SIZE": "0"},
+			want: 0,
+		},
+		"disabled by file": {
+			args: []string{"--config-file=" + filePath},
+			want: 0,
+		},
+		"flag over file": {
+			args: []string{"--config-file=" + filePath, "--cache-memory-size=16"},
+			want: 16,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			for envName, value := range testData.env {
+				t.Setenv(envName, value)
+			}
+
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+
+			DefineFlags(flags, defaults)
+
+			if err := flags.Parse(testData.args); err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+
+			conf, err := NewFromRuntime(flags, nil, defaults)
+			if err != nil {
+				t.Fatalf("NewFromRuntime returned an error: %v", err)
+			}
+
+			if conf.CacheMemorySize == nil || *conf.CacheMemorySize != testData.want {
+				t.Errorf("NewFromRuntime returned wrong CacheMemorySize. Got %#v. Want %#v.", conf.CacheMemorySize, testData.want)
+			}
+		})
+	}
+}
+
+func TestNewFromRuntimeEnvironmentZeroValues(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+
+	if err := os.WriteFile(filePath, []byte(`{"Cache": true, "QuizChoices": 4}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for testName, testData := range map[string]struct {
+		args            []string
+		env             map[string]string
+		wantCache       bool
+		wantQuizChoices uint
+	}{
+		"file": {
+			args:            []string{"--config-file=" + filePath},
+			wantCache:       true,
+			wantQuizChoices: 4,
+		},
+		"false and 0 by env over file": {
+			args:            []string{"--config-file=" + filePath},
+			env:             map[string]string{"DEFINE_APP_CACHE": "false", "DEFINE_APP_QUIZ_CHOICES": "0"},
+			wantCache:       false,
+			wantQuizChoices: 0,
+		},
+		"flag over env": {
+			args:            []string{"--config-file=" + filePath, "--cache", "--quiz-choices=2"},
+			env:             map[string]string{"DEFINE_APP_CACHE": "false", "DEFINE_APP_QUIZ_CHOICES": "0"},
+			wantCache:       true,
+			wantQuizChoices: 2,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			for envName, value := range testData.env {
+				t.Setenv(envName, value)
+			}
+
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+
+			DefineFlags(flags, Configuration{})
+
+			if err := flags.Parse(testData.args); err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+
+			conf, err := NewFromRuntime(flags, nil, Configuration{})
+			if err != nil {
+				t.Fatalf("NewFromRuntime returned an error: %v", err)
+			}
+
+			if conf.Cache != testData.wantCache {
+				t.Errorf("NewFromRuntime returned wrong Cache. Got %#v. Want %#v.", conf.Cache, testData.wantCache)
+			}
+
+			if conf.QuizChoices != testData.wantQuizChoices {
+				t.Errorf("NewFromRuntime returned wrong QuizChoices. Got %#v. Want %#v.", conf.QuizChoices, testData.wantQuizChoices)
+			}
+		})
+	}
+}
+
+func TestNewFromRuntimeUnparsedFlags(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	DefineFlags(flags, Configuration{})
+
+	if _, err := NewFromRuntime(flags, nil, Configuration{}); err == nil {
+		t.Error("NewFromRuntime didn't return an error for unparsed flags")
+	}
+}
+
+func TestNewFromRuntimeUnknownProfile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.json")
+
+	if err := os.WriteFile(filePath, []byte(`{"Profiles": {"work": {}, "study": {}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	DefineFlags(flags, Configuration{})
+
+	if err := flags.Parse([]string{"--config-file=" + filePath, "--profile=play"}); err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+
+	_, err := NewFromRuntime(flags, nil, Configuration{})
+
+	var badValueErr *BadValueError
+	if !errors.As(err, &badValueErr) {
+		t.Fatalf("NewFromRuntime returned wrong error. Got %#v. Want a %T.", err, badValueErr)
+	}
+
+	if want := "(available: study, work)"; !strings.Contains(err.Error(), want) {
+		t.Errorf("NewFromRuntime returned wrong error. Got %q. Want it to contain %q.", err, want)
+	}
+}
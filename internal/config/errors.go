@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+// Error defines the interface for errors of configuration problems, which
+// know how they can be fixed.
+type Error interface {
+	error
+
+	// Remedy returns an actionable suggestion of how to fix the problem.
+	Remedy() string
+}
+
+// Ensure our types satisfy the interface at compile-time
+var (
+	_ Error = (*MissingProviderError)(nil)
+	_ Error = (*UnknownSourceError)(nil)
+	_ Error = (*BadValueError)(nil)
+
+	// Sources' errors of bad credentials and plans are configuration problems
+	// too, so they satisfy the interface as well
+	_ Error = (*source.AuthenticationError)(nil)
+	_ Error = (*source.EntitlementError)(nil)
+)
+
+// MissingProviderError is returned when a source can't be provided, as a
+// required configuration value of its provider is missing.
+type MissingProviderError = registry.RequiredConfigError
+
+// UnknownSourceError is returned when a source is named that doesn't exist.
+type UnknownSourceError struct {
+	Source     string   // The name of the source, as given
+	Sources    []string // The names of the sources that do exist
+	Suggestion string   // The name of the most similar source, if any
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *UnknownSourceError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("provider/source %q does not exist (did you mean %s?)", e.Source, e.Suggestion)
+	}
+
+	return fmt.Sprintf("provider/source %q does not exist", e.Source)
+}
+
+// Remedy returns the sources that can be named instead.
+func (e *UnknownSourceError) Remedy() string {
+	if len(e.Sources) < 1 {
+		return "No sources are available."
+	}
+
+	names := make([]string, 0, len(e.Sources))
+	for _, name := range e.Sources {
+		names = append(names, fmt.Sprintf("%q", name))
+	}
+
+	return fmt.Sprintf("Available sources: %s (see --list-sources).", strings.Join(names, ", "))
+}
+
+// BadValueError is returned when a configuration value is invalid.
+type BadValueError struct {
+	Key     string // The configuration key of the value
+	Value   string // The invalid value
+	Flag    string // The name of the flag that sets the value, if any
+	EnvName string // The environment variable that sets the value, if any
+	Err     error  // The reason that the value is invalid
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *BadValueError) Error() string {
+	return fmt.Sprintf("invalid %q configuration value %q: %s", e.Key, e.Value, e.Err)
+}
+
+// Unwrap returns the reason that the value is invalid.
+func (e *BadValueError) Unwrap() error {
+	return e.Err
+}
+
+// Remedy returns the places that the invalid value may have been set.
+func (e *BadValueError) Remedy() string {
+	if e.Flag == "" || e.EnvName == "" {
+		return fmt.Sprintf("To fix this, correct %q in the config file.", e.Key)
+	}
+
+	return fmt.Sprintf("To fix this, correct the value of --%s, %s, or %q in the config file, whichever is set.", e.Flag, e.EnvName, e.Key)
+}
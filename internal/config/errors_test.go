@@ -0,0 +1,53 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorRemedy(t *testing.T) {
+	testData := map[string]struct {
+		err  Error
+		want string
+	}{
+		"missing provider": {
+			err: &MissingProviderError{
+				Key:     "AppID",
+				Flag:    "oxford-dictionary-app-id",
+				EnvName: "OXFORD_DICTIONARY_APP_ID",
+				JSONKey: "OxfordDictionary",
+			},
+			want: `To fix this, pass --oxford-dictionary-app-id, set OXFORD_DICTIONARY_APP_ID, or set "AppID" under "OxfordDictionary" in the config file.`,
+		},
+		"unknown source": {
+			err:  &UnknownSourceError{Source: "Nope", Sources: []string{"A", "B"}},
+			want: `Available sources: "A", "B" (see --list-sources).`,
+		},
+		"unknown source without sources": {
+			err:  &UnknownSourceError{Source: "Nope"},
+			want: "No sources are available.",
+		},
+		"bad value": {
+			err: &BadValueError{
+				Key:     "OutputFormat",
+				Value:   "xml",
+				Flag:    "output",
+				EnvName: "DEFINE_APP_OUTPUT_FORMAT",
+				Err:     errors.New("unknown"),
+			},
+			want: `To fix this, correct the value of --output, DEFINE_APP_OUTPUT_FORMAT, or "OutputFormat" in the config file, whichever is set.`,
+		},
+		"bad value only in file": {
+			err:  &BadValueError{Key: "SourceRoutes", Value: "[]", Err: errors.New("unknown")},
+			want: `To fix this, correct "SourceRoutes" in the config file.`,
+		},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.err.Remedy(); got != testData.want {
+				t.Errorf("Remedy returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/adrg/xdg"
 )
@@ -31,9 +32,15 @@ func userHomeDir() string {
 
 // tryExpandUserPath takes a path and expands it if it's user home prefixed (~).
 // If the path isn't user home prefixed, then the original path is returned.
+//
+// Any of the OS's path separators are accepted after the prefix, so that paths
+// like "~/config.json" work on Windows as well as "~\config.json".
 func tryExpandUserPath(path string) string {
-	if len(path) > 1 && path[0] == '~' && path[1] == filepath.Separator {
-		path = filepath.Join(userHomeDir(), path[1:])
+	switch {
+	case path == "~":
+		path = userHomeDir()
+	case len(path) > 1 && path[0] == '~' && os.IsPathSeparator(path[1]):
+		path = filepath.Join(userHomeDir(), path[2:])
 	}
 
 	return path
@@ -62,9 +69,11 @@ func findConfigFile() string {
 // This is useful for self-documentation, to provide clarity to users for where
 // their config file may be loaded from.
 func FilePaths() []string {
-	// Length of filePaths is the XDG config home, plus config home, plus the
-	// old default file path.
-	filePathsLen := len(xdg.ConfigDirs) + 2
+	platformDirs := platformConfigDirs()
+
+	// Length of filePaths is the XDG config dirs, plus the platform dirs, plus
+	// config home, plus the old default file path.
+	filePathsLen := len(xdg.ConfigDirs) + len(platformDirs) + 2
 	filePaths := make([]string, 0, filePathsLen)
 
 	defaultXDGConfigRelPath := filepath.Join(xdgBaseName, defaultXDGConfigFileName)
@@ -72,13 +81,28 @@ func FilePaths() []string {
 	// First we try the user's XDG config home
 	filePaths = append(filePaths, filepath.Join(xdg.ConfigHome, defaultXDGConfigRelPath))
 
+	// Then we try any platform-specific user config dirs
+	for _, configDir := range platformDirs {
+		filePaths = appendUniquePath(filePaths, filepath.Join(configDir, defaultXDGConfigRelPath))
+	}
+
 	// Then we fall back to the old default path
-	filePaths = append(filePaths, tryExpandUserPath(oldDefaultConfigFilePath))
+	filePaths = appendUniquePath(filePaths, tryExpandUserPath(oldDefaultConfigFilePath))
 
 	// Finally, we defer to the XDG config dirs (as those are likely global)
 	for _, configDir := range xdg.ConfigDirs {
-		filePaths = append(filePaths, filepath.Join(configDir, defaultXDGConfigRelPath))
+		filePaths = appendUniquePath(filePaths, filepath.Join(configDir, defaultXDGConfigRelPath))
 	}
 
 	return filePaths
 }
+
+// appendUniquePath appends a path to a list of paths, unless the list already
+// contains the path.
+func appendUniquePath(paths []string, path string) []string {
+	if slices.Contains(paths, path) {
+		return paths
+	}
+
+	return append(paths, path)
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package config
+
+// platformConfigDirs returns the user config dirs specific to the platform.
+//
+// Non-Windows platforms are fully covered by the XDG config locations.
+func platformConfigDirs() []string {
+	return nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/adrg/xdg"
+)
+
+func TestTryExpandUserPath(t *testing.T) {
+	homeDir := filepath.Join(string(filepath.Separator)+"home", "test")
+
+	originalHomeDirPath := userHomeDirPath
+	userHomeDirPath = homeDir
+	t.Cleanup(func() { userHomeDirPath = originalHomeDirPath })
+
+	for testName, testData := range map[string]struct {
+		path string
+		want string
+	}{
+		"empty": {
+			path: "",
+			want: "",
+		},
+		"home only": {
+			path: "~",
+			want: homeDir,
+		},
+		"home prefixed with OS separator": {
+			path: "~" + string(filepath.Separator) + ".define.conf.json",
+			want: filepath.Join(homeDir, ".define.conf.json"),
+		},
+		"home prefixed with forward slash": {
+			path: "~/.config/define/config.json",
+			want: filepath.Join(homeDir, ".config", "define", "config.json"),
+		},
+		"tilde named file": {
+			path: "~file.json",
+			want: "~file.json",
+		},
+		"relative": {
+			path: "config.json",
+			want: "config.json",
+		},
+		"absolute": {
+			path: filepath.Join(homeDir, "config.json"),
+			want: filepath.Join(homeDir, "config.json"),
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := tryExpandUserPath(testData.path); got != testData.want {
+				t.Errorf("tryExpandUserPath returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestFilePaths(t *testing.T) {
+	filePaths := FilePaths()
+
+	if len(filePaths) < 2 {
+		t.Fatalf("FilePaths returned too few paths. Got %#v.", filePaths)
+	}
+
+	wantFirst := filepath.Join(xdg.ConfigHome, xdgBaseName, defaultXDGConfigFileName)
+	if filePaths[0] != wantFirst {
+		t.Errorf("FilePaths returned wrong first path. Got %#v. Want %#v.", filePaths[0], wantFirst)
+	}
+
+	for _, configDir := range platformConfigDirs() {
+		want := filepath.Join(configDir, xdgBaseName, defaultXDGConfigFileName)
+
+		if !slices.Contains(filePaths, want) {
+			t.Errorf("FilePaths is missing platform path %#v. Got %#v.", want, filePaths)
+		}
+	}
+
+	for i, filePath := range filePaths {
+		if slices.Contains(filePaths[i+1:], filePath) {
+			t.Errorf("FilePaths returned duplicate path %#v.", filePath)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package config
+
+import "os"
+
+// platformConfigDirs returns the user config dirs specific to the platform.
+//
+// On Windows, the XDG config home defaults to the "local" app data directory,
+// but users conventionally keep their settings in the "roaming" app data
+// directory (%APPDATA%), so we make sure that's searched as well.
+func platformConfigDirs() []string {
+	appDataDir, err := os.UserConfigDir()
+	if err != nil || appDataDir == "" {
+		return nil
+	}
+
+	return []string{appDataDir}
+}
@@ -0,0 +1,126 @@
+// Package confusables provides a bundled, curated list of English words that
+// are commonly confused with each other (like "affect" and "effect").
+package confusables
+
+import (
+	_ "embed" // Needed for embedding the confusables data
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Rican7/define/source"
+)
+
+const (
+	// commentPrefix is the prefix of comment lines in the confusables data
+	commentPrefix = "#"
+
+	// wordsSeparator is the separator of the words of a group from its note
+	wordsSeparator = ":"
+
+	// wordSeparator is the separator of the words of a group
+	wordSeparator = ","
+)
+
+// Group defines the structure of a group of words that are commonly confused
+// with each other.
+type Group struct {
+	Words []string
+	Note  string // A brief note of how to tell the words apart
+}
+
+//go:embed confusables.txt
+var confusablesData string
+
+var (
+	loadGroups sync.Once
+	groups     []Group
+	groupsErr  error
+
+	loadGroupIndex sync.Once
+	groupIndex     map[string][]Group
+)
+
+// Groups returns the bundled list of groups of commonly confused words.
+func Groups() ([]Group, error) {
+	loadGroups.Do(func() {
+		groups, groupsErr = parse(confusablesData)
+	})
+
+	return groups, groupsErr
+}
+
+// Lookup returns the words that the given word (case-insensitively) is
+// commonly confused with, by each group that the word is in.
+func Lookup(word string) []source.Confusable {
+	loadGroupIndex.Do(func() {
+		allGroups, _ := Groups()
+
+		groupIndex = make(map[string][]Group)
+
+		for _, group := range allGroups {
+			for _, groupWord := range group.Words {
+				key := strings.ToLower(groupWord)
+				groupIndex[key] = append(groupIndex[key], group)
+			}
+		}
+	})
+
+	var confusables []source.Confusable
+
+	for _, group := range groupIndex[strings.ToLower(word)] {
+		confusable := source.Confusable{Note: group.Note}
+
+		for _, groupWord := range group.Words {
+			if !strings.EqualFold(groupWord, word) {
+				confusable.Words = append(confusable.Words, groupWord)
+			}
+		}
+
+		confusables = append(confusables, confusable)
+	}
+
+	return confusables
+}
+
+// AnnotateResults takes a list of dictionary results and sets the words that
+// each result's word is commonly confused with, in place.
+func AnnotateResults(results source.DictionaryResults) {
+	for i := range results {
+		results[i].Confusables = Lookup(results[i].Word)
+	}
+}
+
+// parse parses confusables data into a list of groups.
+func parse(data string) ([]Group, error) {
+	var parsed []Group
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, commentPrefix) {
+			continue
+		}
+
+		wordList, note, found := strings.Cut(line, wordsSeparator)
+		if !found || strings.TrimSpace(note) == "" {
+			return nil, fmt.Errorf("invalid confusables data on line %d", i+1)
+		}
+
+		group := Group{Note: strings.TrimSpace(note)}
+
+		for _, word := range strings.Split(wordList, wordSeparator) {
+			if word = strings.TrimSpace(word); word != "" {
+				group.Words = append(group.Words, word)
+			}
+		}
+
+		if len(group.Words) < 2 {
+			return nil, fmt.Errorf("invalid confusables group on line %d: fewer than 2 words", i+1)
+		}
+
+		parsed = append(parsed, group)
+	}
+
+	return parsed, nil
+}
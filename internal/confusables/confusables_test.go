@@ -0,0 +1,87 @@
+package confusables
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestGroups(t *testing.T) {
+	groups, err := Groups()
+	if err != nil {
+		t.Fatalf("Groups returned an error: %s", err)
+	}
+
+	if len(groups) < 1 {
+		t.Fatal("Groups returned no groups")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	affectNote := `"affect" is usually a verb meaning to influence; "effect" is usually a noun meaning a result`
+
+	for testName, testData := range map[string]struct {
+		word string
+		want []source.Confusable
+	}{
+		"not confusable": {
+			word: "cat",
+			want: nil,
+		},
+		"confusable": {
+			word: "affect",
+			want: []source.Confusable{{Words: []string{"effect"}, Note: affectNote}},
+		},
+		"different case": {
+			word: "Effect",
+			want: []source.Confusable{{Words: []string{"affect"}, Note: affectNote}},
+		},
+		"group of three": {
+			word: "there",
+			want: []source.Confusable{{
+				Words: []string{"their", "they're"},
+				Note:  `"their" is possessive; "there" refers to a place; "they're" is short for "they are"`,
+			}},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := Lookup(testData.word); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Lookup returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		data    string
+		want    []Group
+		wantErr bool
+	}{
+		"comments and blank lines": {
+			data: "# A comment\n\nloose, lose: a note\n",
+			want: []Group{{Words: []string{"loose", "lose"}, Note: "a note"}},
+		},
+		"no note": {
+			data:    "loose, lose\n",
+			wantErr: true,
+		},
+		"one word": {
+			data:    "loose: a note\n",
+			wantErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := parse(testData.data)
+
+			if (err != nil) != testData.wantErr {
+				t.Fatalf("parse returned wrong error. Got %#v. Want an error: %#v.", err, testData.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("parse returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package datapack
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// regexpGCIDEHeadwords is a regular expression for matching the headwords of
+// the GCIDE's entries (like "<ent>Abandon</ent>"), capturing the headword.
+var regexpGCIDEHeadwords = regexp.MustCompile(`<ent>([^<&]+)</ent>`)
+
+// Catalog is the list of the data packs that can be installed.
+var Catalog = []Pack{
+	{
+		Name:        "gcide",
+		Description: "GNU Collaborative International Dictionary of English, an offline dictionary source",
+		Version:     "0.53",
+		URL:         "https://ftp.gnu.org/gnu/gcide/gcide-0.53.tar.gz",
+		License:     "GPL-3.0-or-later",
+		Format:      FormatTarGzip,
+		IsDataFile: func(name string) bool {
+			// The words are split into files by their first letter (like "CIDE.A")
+			letter, found := strings.CutPrefix(path.Base(name), "CIDE.")
+
+			return found && len(letter) == 1 && letter[0] >= 'A' && letter[0] <= 'Z'
+		},
+		ListWords: func(dirPath string) ([]string, error) {
+			filePaths, err := filepath.Glob(filepath.Join(dirPath, "CIDE.*"))
+			if err != nil {
+				return nil, err
+			}
+
+			return listMatches(filePaths, func(line string) string {
+				if match := regexpGCIDEHeadwords.FindStringSubmatch(line); match != nil {
+					return match[1]
+				}
+
+				return ""
+			})
+		},
+	},
+	{
+		Name:        "wordnet",
+		Description: "WordNet lexical database of English",
+		Version:     "3.0",
+		URL:         "https://wordnetcode.princeton.edu/3.0/WNdb-3.0.tar.gz",
+		License:     "WordNet-3.0",
+		Format:      FormatTarGzip,
+		IsDataFile: func(name string) bool {
+			return path.Base(path.Dir(name)) == "dict"
+		},
+		ListWords: func(dirPath string) ([]string, error) {
+			var filePaths []string
+			for _, partOfSpeech := range []string{"noun", "verb", "adj", "adv"} {
+				filePaths = append(filePaths, filepath.Join(dirPath, "index."+partOfSpeech))
+			}
+
+			// Each line of an index is a lemma (like "ice_cream") and its data,
+			// except for the license lines, which are indented
+			return listMatches(filePaths, func(line string) string {
+				if strings.HasPrefix(line, " ") {
+					return ""
+				}
+
+				lemma, _, _ := strings.Cut(line, " ")
+
+				return strings.ReplaceAll(lemma, "_", " ")
+			})
+		},
+	},
+	{
+		Name:        "frequency",
+		Description: "Frequencies of the 50,000 most used English words, from OpenSubtitles",
+		Version:     "2018",
+		URL:         "https://raw.githubusercontent.com/hermitdave/FrequencyWords/master/content/2018/en/en_50k.txt",
+		License:     "CC-BY-SA-4.0",
+		Format:      FormatFile,
+		FileName:    "en_50k.txt",
+		ListWords: func(dirPath string) ([]string, error) {
+			// Each line is a word and its number of uses
+			return listMatches([]string{filepath.Join(dirPath, "en_50k.txt")}, func(line string) string {
+				word, _, _ := strings.Cut(line, " ")
+
+				return word
+			})
+		},
+	},
+}
+
+// listMatches returns the non-empty words that the given function matches in
+// the lines of the files at the given paths.
+func listMatches(filePaths []string, match func(line string) string) ([]string, error) {
+	var words []string
+
+	for _, filePath := range filePaths {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(nil, maxLineSize)
+
+		for scanner.Scan() {
+			if word := match(scanner.Text()); word != "" {
+				words = append(words, word)
+			}
+		}
+
+		file.Close()
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return words, nil
+}
+
+// Find returns the pack in the catalog with the given name (ignoring case),
+// and whether it was found.
+func Find(name string) (Pack, bool) {
+	for _, pack := range Catalog {
+		if strings.EqualFold(pack.Name, name) {
+			return pack, true
+		}
+	}
+
+	return Pack{}, false
+}
+
+// Names returns the names of the packs in the catalog.
+func Names() []string {
+	names := make([]string, 0, len(Catalog))
+
+	for _, pack := range Catalog {
+		names = append(names, pack.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,49 @@
+package datapack
+
+import "testing"
+
+func TestCatalog(t *testing.T) {
+	names := make(map[string]bool)
+
+	for _, pack := range Catalog {
+		if pack.Name == "" || pack.Version == "" || pack.URL == "" || pack.License == "" {
+			t.Errorf("Catalog has an incomplete pack. Got %#v.", pack)
+		}
+
+		if names[pack.Name] {
+			t.Errorf("Catalog has a duplicate pack name. Got %#v.", pack.Name)
+		}
+
+		names[pack.Name] = true
+
+		switch pack.Format {
+		case FormatFile:
+			if pack.FileName == "" {
+				t.Errorf("Catalog has a file pack without a file name. Got %#v.", pack.Name)
+			}
+		case FormatTarGzip:
+			if pack.IsDataFile == nil {
+				t.Errorf("Catalog has an archive pack without a data file filter. Got %#v.", pack.Name)
+			}
+		default:
+			t.Errorf("Catalog has a pack of an unknown format. Got %#v.", pack.Format)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		name   string
+		wantOK bool
+	}{
+		"exact":          {name: "gcide", wantOK: true},
+		"different case": {name: "GCIDE", wantOK: true},
+		"unknown":        {name: "nope", wantOK: false},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if _, ok := Find(testData.name); ok != testData.wantOK {
+				t.Errorf("Find returned wrong value. Got %#v. Want %#v.", ok, testData.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+// Package datapack provides types and operations for managing offline data
+// packs, like dictionaries and word frequency tables, which are downloaded and
+// installed to the app's XDG data dir.
+package datapack
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+// List of download formats of packs.
+const (
+	FormatFile    Format = "file"   // A single data file
+	FormatTarGzip Format = "tar.gz" // A gzipped tar archive of data files
+)
+
+const (
+	// xdgBaseName is the name of the app's directory in the XDG base dirs
+	xdgBaseName = "define"
+
+	// manifestFileName is the name of the file, in the directory of an
+	// installed pack, that describes the install
+	manifestFileName = "manifest.json"
+
+	// maxDataFileSize is the maximum size of a data file that will be
+	// installed.
+	maxDataFileSize = 64 << 20
+
+	// maxLineSize is the maximum size of a line of a data file that words are
+	// listed from.
+	maxLineSize = 1 << 20
+)
+
+// ErrNotInstalled is returned when a pack isn't installed.
+var ErrNotInstalled = errors.New("the data pack isn't installed")
+
+// ErrNoChecksum is returned when a pack is installed without a pinned checksum
+// of its download, as it couldn't be verified.
+var ErrNoChecksum = errors.New("the data pack has no checksum to verify its download against")
+
+// Format defines the format that a pack is downloaded in.
+type Format string
+
+// Pack defines the structure of a data pack that can be installed.
+type Pack struct {
+	Name        string
+	Description string
+	Version     string
+	URL         string
+	License     string
+	Format      Format
+
+	// SHA256 is the hex-encoded checksum of the download, which it's verified
+	// against. A pack without one can't be installed.
+	SHA256 string `json:",omitempty"`
+
+	// FileName is the name that a single data file is installed as
+	FileName string `json:",omitempty"`
+
+	// IsDataFile returns whether a file of an archive, by its path in the
+	// archive, is one of the pack's data files
+	IsDataFile func(name string) bool `json:"-"`
+
+	// ListWords returns the words of the pack installed in the directory at the
+	// given path, if the pack is of words
+	ListWords func(dirPath string) ([]string, error) `json:"-"`
+}
+
+// Installed defines the structure of the manifest of an installed pack.
+type Installed struct {
+	Name        string
+	Version     string
+	URL         string
+	SHA256      string // The checksum of the download
+	InstalledAt time.Time
+
+	// Files maps the names of the installed data files to their checksums
+	Files map[string]string
+}
+
+// ChecksumMismatchError is returned when a download or an installed file
+// doesn't match its checksum.
+type ChecksumMismatchError struct {
+	Name string
+	Want string
+	Got  string
+}
+
+// Manager installs, lists, and removes packs in a directory.
+type Manager struct {
+	httpClient http.Client
+	dirPath    string
+}
+
+// DirPath returns the path of the directory that packs are installed to.
+func DirPath() string {
+	return filepath.Join(xdg.DataHome, xdgBaseName)
+}
+
+// NewManager returns a new Manager that downloads packs with the given client
+// and installs them to the directory at the given path.
+func NewManager(httpClient http.Client, dirPath string) *Manager {
+	return &Manager{
+		httpClient: httpClient,
+		dirPath:    dirPath,
+	}
+}
+
+// PackDirPath returns the path of the directory that the pack of the given
+// name is installed to.
+func (m *Manager) PackDirPath(name string) string {
+	return filepath.Join(m.dirPath, name)
+}
+
+// Install downloads the given pack, verifies it, and installs its data files,
+// replacing any install of the pack that was there before.
+//
+// ErrNoChecksum is returned, without downloading anything, if the pack has no
+// checksum to verify its download against.
+func (m *Manager) Install(pack Pack) (*Installed, error) {
+	if pack.SHA256 == "" {
+		return nil, ErrNoChecksum
+	}
+
+	response, err := m.httpClient.Get(pack.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %q failed: %s", pack.URL, response.Status)
+	}
+
+	if err := os.MkdirAll(m.dirPath, 0o700); err != nil {
+		return nil, err
+	}
+
+	// Install into a temporary directory first, so that a failed install
+	// doesn't leave any partial data behind
+	tempDirPath, err := os.MkdirTemp(m.dirPath, "."+pack.Name+"-")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(tempDirPath)
+
+	downloadHash := sha256.New()
+	download := io.TeeReader(response.Body, downloadHash)
+
+	installed := &Installed{
+		Name:        pack.Name,
+		Version:     pack.Version,
+		URL:         pack.URL,
+		InstalledAt: time.Now().UTC(),
+		Files:       make(map[string]string),
+	}
+
+	switch pack.Format {
+	case FormatFile:
+		err = installFile(download, filepath.Join(tempDirPath, pack.FileName), installed)
+	case FormatTarGzip:
+		err = installArchive(download, tempDirPath, pack.IsDataFile, installed)
+	default:
+		err = fmt.Errorf("unknown format %q", pack.Format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("installing %q failed: %w", pack.Name, err)
+	}
+
+	// Read the rest of the download, like the padding of an archive, so that
+	// the checksum is of all of it
+	if _, err := io.Copy(io.Discard, download); err != nil {
+		return nil, err
+	}
+
+	installed.SHA256 = hex.EncodeToString(downloadHash.Sum(nil))
+
+	if installed.SHA256 != pack.SHA256 {
+		return nil, &ChecksumMismatchError{Name: path.Base(pack.URL), Want: pack.SHA256, Got: installed.SHA256}
+	}
+
+	if err := writeManifest(tempDirPath, installed); err != nil {
+		return nil, err
+	}
+
+	packDirPath := m.PackDirPath(pack.Name)
+
+	if err := os.RemoveAll(packDirPath); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(tempDirPath, packDirPath); err != nil {
+		return nil, err
+	}
+
+	return installed, nil
+}
+
+// Installed returns the manifest of the installed pack of the given name, or
+// ErrNotInstalled if it isn't installed.
+func (m *Manager) Installed(name string) (*Installed, error) {
+	contents, err := os.ReadFile(filepath.Join(m.PackDirPath(name), manifestFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotInstalled
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var installed Installed
+
+	if err := json.Unmarshal(contents, &installed); err != nil {
+		return nil, err
+	}
+
+	return &installed, nil
+}
+
+// Verify verifies that the data files of an installed pack match the
+// checksums that they were installed with.
+func (m *Manager) Verify(installed *Installed) error {
+	names := make([]string, 0, len(installed.Files))
+	for name := range installed.Files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		got, err := fileChecksum(filepath.Join(m.PackDirPath(installed.Name), name))
+		if err != nil {
+			return err
+		}
+
+		if want := installed.Files[name]; got != want {
+			return &ChecksumMismatchError{Name: name, Want: want, Got: got}
+		}
+	}
+
+	return nil
+}
+
+// InstalledWords returns the words of the installed packs of the catalog that
+// are of words.
+func (m *Manager) InstalledWords() ([]string, error) {
+	var words []string
+
+	for _, pack := range Catalog {
+		if pack.ListWords == nil {
+			continue
+		}
+
+		if _, err := m.Installed(pack.Name); errors.Is(err, ErrNotInstalled) {
+			continue
+		}
+
+		packWords, err := pack.ListWords(m.PackDirPath(pack.Name))
+		if err != nil {
+			return nil, fmt.Errorf("listing the words of %q failed: %w", pack.Name, err)
+		}
+
+		words = append(words, packWords...)
+	}
+
+	return words, nil
+}
+
+// Remove removes the installed pack of the given name, or returns
+// ErrNotInstalled if it isn't installed.
+func (m *Manager) Remove(name string) error {
+	packDirPath := m.PackDirPath(name)
+
+	if _, err := os.Stat(packDirPath); errors.Is(err, fs.ErrNotExist) {
+		return ErrNotInstalled
+	}
+
+	return os.RemoveAll(packDirPath)
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum of %q doesn't match: got %s, want %s", e.Name, e.Got, e.Want)
+}
+
+// installArchive installs the data files of a gzipped tar archive into the
+// directory at the given path, recording them in the given manifest.
+func installArchive(archive io.Reader, dirPath string, isDataFile func(string) bool, installed *Installed) error {
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return err
+	}
+
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg || !isDataFile(header.Name) {
+			continue
+		}
+
+		if err := installFile(tarReader, filepath.Join(dirPath, path.Base(header.Name)), installed); err != nil {
+			return err
+		}
+	}
+
+	if len(installed.Files) < 1 {
+		return errors.New("the archive has no data files")
+	}
+
+	return nil
+}
+
+// installFile writes a data file to the given path, recording it in the given
+// manifest.
+func installFile(contents io.Reader, filePath string, installed *Installed) error {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	fileHash := sha256.New()
+
+	written, err := io.Copy(io.MultiWriter(file, fileHash), io.LimitReader(contents, maxDataFileSize+1))
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if written > maxDataFileSize {
+		return fmt.Errorf("the data file %q is larger than %d bytes", filepath.Base(filePath), maxDataFileSize)
+	}
+
+	installed.Files[filepath.Base(filePath)] = hex.EncodeToString(fileHash.Sum(nil))
+
+	return nil
+}
+
+// writeManifest writes the manifest of an install into the directory at the
+// given path.
+func writeManifest(dirPath string, installed *Installed) error {
+	encoded, err := json.MarshalIndent(installed, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dirPath, manifestFileName), encoded, 0o600)
+}
+
+// fileChecksum returns the hex-encoded SHA-256 checksum of the file at the
+// given path.
+func fileChecksum(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	fileHash := sha256.New()
+
+	if _, err := io.Copy(fileHash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(fileHash.Sum(nil)), nil
+}
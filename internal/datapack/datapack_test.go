@@ -0,0 +1,242 @@
+package datapack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func newTestArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for name, contents := range files {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(contents)), Typeflag: tar.TypeReg}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tarWriter.Write([]byte(contents)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func newTestManager(t *testing.T, download []byte) *Manager {
+	t.Helper()
+
+	httpClient := http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(download))}, nil
+	})}
+
+	return NewManager(httpClient, t.TempDir())
+}
+
+func checksum(contents string) string {
+	sum := sha256.Sum256([]byte(contents))
+
+	return hex.EncodeToString(sum[:])
+}
+
+var testArchivePack = Pack{
+	Name:    "test",
+	Version: "1.0",
+	URL:     "https://example.com/test-1.0.tar.gz",
+	Format:  FormatTarGzip,
+	IsDataFile: func(name string) bool {
+		return path.Ext(name) == ".dat"
+	},
+}
+
+func TestManagerInstall(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		pack      Pack
+		download  func(t *testing.T) []byte
+		unpinned  bool // Whether to install the pack without pinning its checksum
+		wantFiles map[string]string
+		wantErr   bool
+	}{
+		"archive": {
+			pack: testArchivePack,
+			download: func(t *testing.T) []byte {
+				return newTestArchive(t, map[string]string{
+					"test-1.0/a.dat":   "a",
+					"test-1.0/b.dat":   "b",
+					"test-1.0/COPYING": "license",
+				})
+			},
+			wantFiles: map[string]string{"a.dat": checksum("a"), "b.dat": checksum("b")},
+		},
+		"archive without data files": {
+			pack: testArchivePack,
+			download: func(t *testing.T) []byte {
+				return newTestArchive(t, map[string]string{"test-1.0/COPYING": "license"})
+			},
+			wantErr: true,
+		},
+		"file": {
+			pack: Pack{Name: "test", Version: "1.0", URL: "https://example.com/words.txt", Format: FormatFile, FileName: "words.txt"},
+			download: func(*testing.T) []byte {
+				return []byte("words")
+			},
+			wantFiles: map[string]string{"words.txt": checksum("words")},
+		},
+		"file without a checksum": {
+			pack: Pack{Name: "test", Version: "1.0", URL: "https://example.com/words.txt", Format: FormatFile, FileName: "words.txt"},
+			download: func(*testing.T) []byte {
+				return []byte("words")
+			},
+			unpinned: true,
+			wantErr:  true,
+		},
+		"file with a mismatched checksum": {
+			pack: Pack{Name: "test", Version: "1.0", URL: "https://example.com/words.txt", Format: FormatFile, FileName: "words.txt", SHA256: checksum("other")},
+			download: func(*testing.T) []byte {
+				return []byte("words")
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			download := testData.download(t)
+			manager := newTestManager(t, download)
+
+			pack := testData.pack
+			if pack.SHA256 == "" && !testData.unpinned {
+				pack.SHA256 = checksum(string(download))
+			}
+
+			installed, err := manager.Install(pack)
+			if (err != nil) != testData.wantErr {
+				t.Fatalf("Install returned an unexpected error. Got %#v.", err)
+			}
+
+			if testData.wantErr {
+				// Nothing should be left behind by a failed install
+				if entries, _ := os.ReadDir(manager.dirPath); len(entries) > 0 {
+					t.Errorf("Install left files behind. Got %d entries.", len(entries))
+				}
+
+				return
+			}
+
+			if len(installed.Files) != len(testData.wantFiles) {
+				t.Fatalf("Install installed wrong files. Got %#v. Want %#v.", installed.Files, testData.wantFiles)
+			}
+
+			for name, want := range testData.wantFiles {
+				if got := installed.Files[name]; got != want {
+					t.Errorf("Install recorded wrong checksum for %q. Got %#v. Want %#v.", name, got, want)
+				}
+			}
+
+			read, err := manager.Installed(testData.pack.Name)
+			if err != nil {
+				t.Fatalf("Installed returned an error: %s", err)
+			}
+
+			if read.Version != testData.pack.Version || read.SHA256 != installed.SHA256 {
+				t.Errorf("Installed returned wrong manifest. Got %#v. Want %#v.", read, installed)
+			}
+
+			if err := manager.Verify(read); err != nil {
+				t.Errorf("Verify returned an error: %s", err)
+			}
+		})
+	}
+}
+
+func TestManagerVerifyCorrupted(t *testing.T) {
+	manager := newTestManager(t, []byte("words"))
+
+	installed, err := manager.Install(Pack{Name: "test", Version: "1.0", Format: FormatFile, FileName: "words.txt", SHA256: checksum("words")})
+	if err != nil {
+		t.Fatalf("Install returned an error: %s", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(manager.PackDirPath("test"), "words.txt"), []byte("changed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var mismatchErr *ChecksumMismatchError
+	if err := manager.Verify(installed); !errors.As(err, &mismatchErr) {
+		t.Errorf("Verify returned wrong error. Got %#v. Want a %T.", err, mismatchErr)
+	}
+}
+
+func TestManagerRemove(t *testing.T) {
+	manager := newTestManager(t, []byte("words"))
+
+	if err := manager.Remove("test"); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("Remove returned wrong error. Got %#v. Want %#v.", err, ErrNotInstalled)
+	}
+
+	if _, err := manager.Install(Pack{Name: "test", Version: "1.0", Format: FormatFile, FileName: "words.txt", SHA256: checksum("words")}); err != nil {
+		t.Fatalf("Install returned an error: %s", err)
+	}
+
+	if err := manager.Remove("test"); err != nil {
+		t.Errorf("Remove returned an error: %s", err)
+	}
+
+	if _, err := manager.Installed("test"); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("Installed returned wrong error. Got %#v. Want %#v.", err, ErrNotInstalled)
+	}
+}
+
+func TestManagerInstalledWords(t *testing.T) {
+	const download = "hello 123\nworld 45\n"
+
+	manager := newTestManager(t, []byte(download))
+
+	if words, err := manager.InstalledWords(); err != nil || len(words) > 0 {
+		t.Errorf("InstalledWords returned wrong value. Got %#v, %#v. Want none.", words, err)
+	}
+
+	pack, _ := Find("frequency")
+	pack.SHA256 = checksum(download)
+
+	if _, err := manager.Install(pack); err != nil {
+		t.Fatalf("Install returned an error: %s", err)
+	}
+
+	words, err := manager.InstalledWords()
+	if err != nil {
+		t.Fatalf("InstalledWords returned an error: %s", err)
+	}
+
+	if want := []string{"hello", "world"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("InstalledWords returned wrong value. Got %#v. Want %#v.", words, want)
+	}
+}
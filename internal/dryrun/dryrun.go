@@ -0,0 +1,79 @@
+// Package dryrun provides an HTTP transport that doesn't perform requests, so
+// that the requests that would be made can be inspected instead.
+package dryrun
+
+import (
+	"net/http"
+	"strings"
+)
+
+// redactedValue is the value that sensitive values are replaced with
+const redactedValue = "REDACTED"
+
+// sensitiveNameParts is the list of parts of query parameter and header names
+// that mark their values as sensitive, like API keys.
+var sensitiveNameParts = []string{"key", "token", "secret", "password", "auth"}
+
+// Error represents the error returned instead of performing a request, which
+// holds the request that would have been made.
+type Error struct {
+	Request *http.Request
+}
+
+// Transport is an http.RoundTripper that doesn't perform any requests, but
+// instead returns an Error holding the request.
+type Transport struct{}
+
+func (e *Error) Error() string {
+	return "dry run: the request was not performed"
+}
+
+// RoundTrip returns an Error holding the request, without performing it.
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return nil, &Error{Request: request}
+}
+
+// RedactedURL returns the URL of the request, with the values of any
+// sensitive query parameters redacted.
+func (e *Error) RedactedURL() string {
+	redacted := *e.Request.URL
+	queryParams := redacted.Query()
+
+	for name := range queryParams {
+		if isSensitive(name) {
+			queryParams.Set(name, redactedValue)
+		}
+	}
+
+	redacted.RawQuery = queryParams.Encode()
+
+	return redacted.String()
+}
+
+// RedactedHeader returns the header of the request, with the values of any
+// sensitive headers redacted.
+func (e *Error) RedactedHeader() http.Header {
+	redacted := e.Request.Header.Clone()
+
+	for name := range redacted {
+		if isSensitive(name) {
+			redacted.Set(name, redactedValue)
+		}
+	}
+
+	return redacted
+}
+
+// isSensitive returns true if a query parameter or header name is likely to
+// have a sensitive value.
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+
+	return false
+}
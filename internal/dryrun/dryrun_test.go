@@ -0,0 +1,40 @@
+package dryrun
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTransport(t *testing.T) {
+	client := http.Client{Transport: &Transport{}}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://example.com/define/test?key=secret&q=test", nil)
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("app_key", "secret")
+
+	response, err := client.Do(request)
+	if response != nil {
+		t.Errorf("Transport returned a response. Got %#v.", response)
+	}
+
+	var dryRunErr *Error
+	if !errors.As(err, &dryRunErr) {
+		t.Fatalf("Transport returned wrong error. Got %#v.", err)
+	}
+
+	wantURL := "https://example.com/define/test?key=REDACTED&q=test"
+	if got := dryRunErr.RedactedURL(); got != wantURL {
+		t.Errorf("Error.RedactedURL returned wrong value. Got %#v. Want %#v.", got, wantURL)
+	}
+
+	wantHeader := http.Header{"Accept": {"application/json"}, "App_key": {"REDACTED"}}
+	if got := dryRunErr.RedactedHeader(); !reflect.DeepEqual(got, wantHeader) {
+		t.Errorf("Error.RedactedHeader returned wrong value. Got %#v. Want %#v.", got, wantHeader)
+	}
+
+	if request.Header.Get("app_key") != "secret" {
+		t.Error("Error.RedactedHeader modified the original request")
+	}
+}
@@ -0,0 +1,518 @@
+// Package flag provides a command line flag parser, in the style of the
+// standard library's flag package, with GNU-style long and short flags.
+//
+// Along with the "--name=value", "--name value", "-n value", and "-nvalue"
+// forms, boolean flags can be negated with a "no-" prefix (like "--no-cache"),
+// boolean shorthands can be combined (like "-ab"), an argument of "--" ends
+// the flags, and flags can be grouped under a heading in the usage (like the
+// flags of each source).
+//
+// Any Value of the standard library's flag package can be used as a Value.
+package flag
+
+import (
+	"errors"
+	goflag "flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Rican7/define/internal/fuzzy"
+)
+
+// ErrorHandling defines how a FlagSet handles a parse error.
+type ErrorHandling int
+
+// List of the ways that a parse error can be handled.
+const (
+	// ContinueOnError returns the error from Parse
+	ContinueOnError ErrorHandling = iota
+
+	// ExitOnError exits with a status of 2 (or 0 for ErrHelp)
+	ExitOnError
+
+	// PanicOnError panics with the error
+	PanicOnError
+)
+
+// maxSuggestionDistance is the maximum edit distance between an unknown flag
+// and a defined flag for the defined flag to be suggested in its place.
+const maxSuggestionDistance = 2
+
+// ErrHelp is returned when a help flag was passed, but isn't defined.
+//
+// It's the same error as the standard library's, so that it matches either.
+var ErrHelp = goflag.ErrHelp
+
+// Value is the value of a flag, which is set from its argument.
+//
+// It's the same interface as the standard library's flag.Value.
+type Value interface {
+	String() string
+	Set(string) error
+}
+
+// boolFlag is a Value that doesn't need an argument, as it's set to "true"
+// when none is passed.
+//
+// It's the same optional interface as the standard library's.
+type boolFlag interface {
+	Value
+	IsBoolFlag() bool
+}
+
+// Flag defines the structure of a single flag.
+type Flag struct {
+	Name      string // The long name, used as "--name"
+	Shorthand string // The optional single character name, used as "-n"
+	Usage     string
+	Group     string // The group that the flag is listed under in the usage
+	Value     Value
+	DefValue  string // The default value, as text
+	Changed   bool   // Whether the flag was set when parsing
+}
+
+// UnknownFlagError is returned when an argument names a flag that isn't
+// defined.
+type UnknownFlagError struct {
+	Flag       string // The flag, as passed (like "--name" or "-n")
+	Suggestion string // A similar flag that is defined, if any
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *UnknownFlagError) Error() string {
+	msg := fmt.Sprintf("unknown flag: %s", e.Flag)
+
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %s?)", e.Suggestion)
+	}
+
+	return msg
+}
+
+// FlagSet is a set of defined flags.
+type FlagSet struct {
+	// Usage is called when parsing fails, to print the usage. If nil, a
+	// default usage listing the flags is printed.
+	Usage func()
+
+	name          string
+	errorHandling ErrorHandling
+	output        io.Writer
+	parsed        bool
+	formal        map[string]*Flag
+	shorthands    map[string]*Flag
+	args          []string
+	argsLenAtDash int // The number of arguments before a "--", or -1 if none
+}
+
+// NewFlagSet returns a new, empty FlagSet with the given name and error
+// handling.
+func NewFlagSet(name string, errorHandling ErrorHandling) *FlagSet {
+	return &FlagSet{
+		name:          name,
+		errorHandling: errorHandling,
+		formal:        make(map[string]*Flag),
+		shorthands:    make(map[string]*Flag),
+	}
+}
+
+// Name returns the name of the flag set.
+func (f *FlagSet) Name() string {
+	return f.name
+}
+
+// SetOutput sets the writer that the usage and errors are printed to.
+func (f *FlagSet) SetOutput(output io.Writer) {
+	f.output = output
+}
+
+// Output returns the writer that the usage and errors are printed to,
+// defaulting to stderr.
+func (f *FlagSet) Output() io.Writer {
+	if f.output == nil {
+		return os.Stderr
+	}
+
+	return f.output
+}
+
+// AddFlag adds an already defined flag to the flag set, such as one from
+// another flag set.
+//
+// It panics if the name or shorthand of the flag is already defined.
+func (f *FlagSet) AddFlag(flag *Flag) {
+	if _, exists := f.formal[flag.Name]; exists {
+		panic(fmt.Sprintf("%s flag redefined: --%s", f.name, flag.Name))
+	}
+
+	if len(flag.Shorthand) > 1 {
+		panic(fmt.Sprintf("%s shorthand is more than one character: -%s", f.name, flag.Shorthand))
+	}
+
+	if _, exists := f.shorthands[flag.Shorthand]; exists && flag.Shorthand != "" {
+		panic(fmt.Sprintf("%s shorthand redefined: -%s", f.name, flag.Shorthand))
+	}
+
+	f.formal[flag.Name] = flag
+
+	if flag.Shorthand != "" {
+		f.shorthands[flag.Shorthand] = flag
+	}
+}
+
+// Var defines a flag with the given name and usage, that sets the value.
+func (f *FlagSet) Var(value Value, name string, usage string) {
+	f.VarP(value, name, "", usage)
+}
+
+// VarP is like Var, but with a shorthand.
+func (f *FlagSet) VarP(value Value, name string, shorthand string, usage string) {
+	f.AddFlag(&Flag{
+		Name:      name,
+		Shorthand: shorthand,
+		Usage:     usage,
+		Value:     value,
+		DefValue:  value.String(),
+	})
+}
+
+// Lookup returns the flag with the given name, or nil if it isn't defined.
+func (f *FlagSet) Lookup(name string) *Flag {
+	return f.formal[name]
+}
+
+// Changed returns true if the flag with the given name was set when parsing.
+func (f *FlagSet) Changed(name string) bool {
+	flag := f.Lookup(name)
+
+	return flag != nil && flag.Changed
+}
+
+// Set sets the value of the flag with the given name, as if it was parsed.
+func (f *FlagSet) Set(name string, value string) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return f.unknownFlagError("--" + name)
+	}
+
+	return f.set(flag, value)
+}
+
+// VisitAll calls the given func for each flag, in order of their names.
+func (f *FlagSet) VisitAll(fn func(*Flag)) {
+	for _, flag := range f.sortedFlags() {
+		fn(flag)
+	}
+}
+
+// Visit calls the given func for each flag that was set when parsing, in
+// order of their names.
+func (f *FlagSet) Visit(fn func(*Flag)) {
+	f.VisitAll(func(flag *Flag) {
+		if flag.Changed {
+			fn(flag)
+		}
+	})
+}
+
+// NFlag returns the number of flags that were set when parsing.
+func (f *FlagSet) NFlag() int {
+	var count int
+
+	f.Visit(func(*Flag) { count++ })
+
+	return count
+}
+
+// Parsed returns true if the flag set has been parsed.
+func (f *FlagSet) Parsed() bool {
+	return f.parsed
+}
+
+// Args returns the non-flag arguments that remained after parsing.
+func (f *FlagSet) Args() []string {
+	return f.args
+}
+
+// NArg returns the number of non-flag arguments that remained after parsing.
+func (f *FlagSet) NArg() int {
+	return len(f.args)
+}
+
+// ArgsLenAtDash returns the number of non-flag arguments that came before an
+// argument of "--", or -1 if there wasn't one.
+func (f *FlagSet) ArgsLenAtDash() int {
+	return f.argsLenAtDash
+}
+
+// Arg returns the non-flag argument at the given index, or an empty string if
+// there isn't one.
+func (f *FlagSet) Arg(i int) string {
+	if i < 0 || i >= len(f.args) {
+		return ""
+	}
+
+	return f.args[i]
+}
+
+// Parse parses the given arguments (without the program name), setting the
+// values of the flags that they name.
+//
+// Flags and non-flag arguments may be interspersed, until an argument of "--"
+// ends the flags.
+func (f *FlagSet) Parse(arguments []string) error {
+	f.parsed = true
+	f.args = nil
+	f.argsLenAtDash = -1
+
+	for len(arguments) > 0 {
+		arg := arguments[0]
+		arguments = arguments[1:]
+
+		var err error
+
+		switch {
+		case arg == "--":
+			f.argsLenAtDash = len(f.args)
+			f.args = append(f.args, arguments...)
+			arguments = nil
+		case strings.HasPrefix(arg, "--"):
+			arguments, err = f.parseLong(arg[2:], arguments)
+		case len(arg) > 1 && arg[0] == '-':
+			arguments, err = f.parseShort(arg[1:], arguments)
+		default:
+			f.args = append(f.args, arg)
+		}
+
+		if err != nil {
+			return f.fail(err)
+		}
+	}
+
+	return nil
+}
+
+// parseLong parses a long flag (without its "--" prefix), returning the
+// arguments that remain after any that it consumed.
+func (f *FlagSet) parseLong(arg string, arguments []string) ([]string, error) {
+	name, value, hasValue := strings.Cut(arg, "=")
+
+	if name == "" || strings.HasPrefix(name, "-") {
+		return arguments, fmt.Errorf("bad flag syntax: --%s", arg)
+	}
+
+	flag, exists := f.formal[name]
+	negated := false
+
+	// Negate boolean flags with a "no-" prefix, unless that's defined itself
+	if baseName, hasPrefix := strings.CutPrefix(name, "no-"); !exists && hasPrefix {
+		if baseFlag, baseExists := f.formal[baseName]; baseExists {
+			if !isBoolFlag(baseFlag) {
+				return arguments, fmt.Errorf("flag can't be negated, as it isn't boolean: --%s", baseName)
+			}
+
+			flag, exists, negated = baseFlag, true, true
+		}
+	}
+
+	if !exists {
+		if name == "help" {
+			return arguments, ErrHelp
+		}
+
+		return arguments, f.unknownFlagError("--" + name)
+	}
+
+	switch {
+	case negated:
+		if hasValue {
+			return arguments, fmt.Errorf("flag doesn't take a value: --%s", name)
+		}
+
+		value = "false"
+	case isBoolFlag(flag):
+		if !hasValue {
+			value = "true"
+		}
+	case !hasValue:
+		if len(arguments) < 1 {
+			return arguments, fmt.Errorf("flag needs an argument: --%s", name)
+		}
+
+		value, arguments = arguments[0], arguments[1:]
+	}
+
+	return arguments, f.set(flag, value)
+}
+
+// parseShort parses one or more shorthand flags (without their "-" prefix),
+// returning the arguments that remain after any that it consumed.
+func (f *FlagSet) parseShort(arg string, arguments []string) ([]string, error) {
+	for i := 0; i < len(arg); i++ {
+		shorthand := arg[i : i+1]
+
+		flag, exists := f.shorthands[shorthand]
+		if !exists {
+			if shorthand == "h" {
+				return arguments, ErrHelp
+			}
+
+			return arguments, f.unknownFlagError("-" + shorthand)
+		}
+
+		remaining := arg[i+1:]
+
+		switch {
+		case strings.HasPrefix(remaining, "="):
+			return arguments, f.set(flag, remaining[1:])
+		case isBoolFlag(flag):
+			// Allow combining boolean shorthands, like "-ab"
+			if err := f.set(flag, "true"); err != nil {
+				return arguments, err
+			}
+		case remaining != "":
+			return arguments, f.set(flag, remaining)
+		case len(arguments) > 0:
+			return arguments[1:], f.set(flag, arguments[0])
+		default:
+			return arguments, fmt.Errorf("flag needs an argument: -%s", shorthand)
+		}
+	}
+
+	return arguments, nil
+}
+
+// set sets the value of a flag, marking it as changed.
+func (f *FlagSet) set(flag *Flag, value string) error {
+	if err := flag.Value.Set(value); err != nil {
+		return fmt.Errorf("invalid value %q for flag --%s: %w", value, flag.Name, err)
+	}
+
+	flag.Changed = true
+
+	return nil
+}
+
+// fail handles a parse error according to the flag set's error handling.
+func (f *FlagSet) fail(err error) error {
+	if !errors.Is(err, ErrHelp) {
+		fmt.Fprintln(f.Output(), err)
+	}
+
+	f.usage()
+
+	switch f.errorHandling {
+	case ExitOnError:
+		if errors.Is(err, ErrHelp) {
+			os.Exit(0)
+		}
+
+		os.Exit(2)
+	case PanicOnError:
+		panic(err)
+	}
+
+	return err
+}
+
+// unknownFlagError returns an error for an unknown flag, suggesting the most
+// similar defined flag.
+func (f *FlagSet) unknownFlagError(arg string) error {
+	unknownErr := &UnknownFlagError{Flag: arg}
+
+	name, isLong := strings.CutPrefix(arg, "--")
+	if !isLong {
+		return unknownErr
+	}
+
+	bestDistance := maxSuggestionDistance + 1
+
+	for _, flag := range f.sortedFlags() {
+		if distance := fuzzy.Distance(name, flag.Name); distance < bestDistance {
+			unknownErr.Suggestion = "--" + flag.Name
+			bestDistance = distance
+		}
+	}
+
+	return unknownErr
+}
+
+func (f *FlagSet) usage() {
+	if f.Usage != nil {
+		f.Usage()
+		return
+	}
+
+	fmt.Fprintf(f.Output(), "Usage of %s:\n", f.name)
+	f.PrintDefaults()
+}
+
+// PrintDefaults prints the usage and default value of each flag, listing the
+// flags of each group under its own heading, after the ungrouped flags.
+func (f *FlagSet) PrintDefaults() {
+	flagsByGroup := make(map[string][]*Flag)
+
+	for _, flag := range f.sortedFlags() {
+		flagsByGroup[flag.Group] = append(flagsByGroup[flag.Group], flag)
+	}
+
+	for _, flag := range flagsByGroup[""] {
+		f.printFlag(flag)
+	}
+
+	groups := make([]string, 0, len(flagsByGroup))
+	for group := range flagsByGroup {
+		if group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		fmt.Fprintln(f.Output())
+		fmt.Fprintf(f.Output(), "%s options:\n", group)
+
+		for _, flag := range flagsByGroup[group] {
+			f.printFlag(flag)
+		}
+	}
+}
+
+func (f *FlagSet) printFlag(flag *Flag) {
+	prefix := "      "
+	if flag.Shorthand != "" {
+		prefix = fmt.Sprintf("  -%s, ", flag.Shorthand)
+	}
+
+	defValue := flag.DefValue
+	if _, isString := flag.Value.(*stringValue); isString {
+		defValue = strconv.Quote(defValue)
+	}
+
+	fmt.Fprintf(f.Output(), "%s--%s=%s: %s\n", prefix, flag.Name, defValue, flag.Usage)
+}
+
+// sortedFlags returns the flags, in order of their names.
+func (f *FlagSet) sortedFlags() []*Flag {
+	flags := make([]*Flag, 0, len(f.formal))
+	for _, flag := range f.formal {
+		flags = append(flags, flag)
+	}
+
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+
+	return flags
+}
+
+func isBoolFlag(flag *Flag) bool {
+	boolValue, isBool := flag.Value.(boolFlag)
+
+	return isBool && boolValue.IsBoolFlag()
+}
@@ -0,0 +1,223 @@
+package flag
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type testValues struct {
+	verbose bool
+	cache   bool
+	source  string
+	limit   uint
+}
+
+func newTestFlagSet(values *testValues) *FlagSet {
+	flags := NewFlagSet("test", ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	flags.BoolVarP(&values.verbose, "verbose", "v", false, "")
+	flags.BoolVar(&values.cache, "cache", true, "")
+	flags.StringVarP(&values.source, "source", "s", "", "")
+	flags.UintVarP(&values.limit, "limit", "l", 5, "")
+
+	return flags
+}
+
+func TestFlagSetParse(t *testing.T) {
+	testData := map[string]struct {
+		args       []string
+		wantValues testValues
+		wantArgs   []string
+	}{
+		"no arguments": {
+			args:       nil,
+			wantValues: testValues{cache: true, limit: 5},
+		},
+		"long flags": {
+			args:       []string{"--verbose", "--source=webster", "--limit", "3"},
+			wantValues: testValues{verbose: true, cache: true, source: "webster", limit: 3},
+		},
+		"short flags": {
+			args:       []string{"-v", "-s", "webster", "-l3"},
+			wantValues: testValues{verbose: true, cache: true, source: "webster", limit: 3},
+		},
+		"short flags with equals": {
+			args:       []string{"-s=webster"},
+			wantValues: testValues{cache: true, source: "webster", limit: 5},
+		},
+		"combined short flags": {
+			args:       []string{"-vs", "webster"},
+			wantValues: testValues{verbose: true, cache: true, source: "webster", limit: 5},
+		},
+		"bool flag with value": {
+			args:       []string{"--cache=false"},
+			wantValues: testValues{cache: false, limit: 5},
+		},
+		"negated bool flag": {
+			args:       []string{"--no-cache"},
+			wantValues: testValues{cache: false, limit: 5},
+		},
+		"interspersed arguments": {
+			args:       []string{"look", "--verbose", "up"},
+			wantValues: testValues{verbose: true, cache: true, limit: 5},
+			wantArgs:   []string{"look", "up"},
+		},
+		"terminator": {
+			args:       []string{"--verbose", "--", "--source", "-v"},
+			wantValues: testValues{verbose: true, cache: true, limit: 5},
+			wantArgs:   []string{"--source", "-v"},
+		},
+		"single dash": {
+			args:       []string{"-"},
+			wantValues: testValues{cache: true, limit: 5},
+			wantArgs:   []string{"-"},
+		},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			var values testValues
+
+			flags := newTestFlagSet(&values)
+
+			if err := flags.Parse(testData.args); err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+
+			if values != testData.wantValues {
+				t.Errorf("Parse set wrong values. Got %#v. Want %#v.", values, testData.wantValues)
+			}
+
+			if got := flags.Args(); !reflect.DeepEqual(got, testData.wantArgs) {
+				t.Errorf("Parse left wrong arguments. Got %#v. Want %#v.", got, testData.wantArgs)
+			}
+		})
+	}
+}
+
+func TestFlagSetArgsLenAtDash(t *testing.T) {
+	testData := map[string]struct {
+		args []string
+		want int
+	}{
+		"no terminator":           {args: []string{"look", "up"}, want: -1},
+		"terminator first":        {args: []string{"--", "look", "up"}, want: 0},
+		"terminator between args": {args: []string{"look", "--verbose", "--", "up"}, want: 1},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			var values testValues
+
+			flags := newTestFlagSet(&values)
+
+			if err := flags.Parse(testData.args); err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+
+			if got := flags.ArgsLenAtDash(); got != testData.want {
+				t.Errorf("ArgsLenAtDash returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestFlagSetParseErrors(t *testing.T) {
+	testData := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"unknown long flag": {
+			args:    []string{"--nope"},
+			wantErr: "unknown flag: --nope",
+		},
+		"unknown long flag with suggestion": {
+			args:    []string{"--sorce=webster"},
+			wantErr: "unknown flag: --sorce (did you mean --source?)",
+		},
+		"unknown short flag": {
+			args:    []string{"-x"},
+			wantErr: "unknown flag: -x",
+		},
+		"missing argument": {
+			args:    []string{"--source"},
+			wantErr: "flag needs an argument: --source",
+		},
+		"missing short argument": {
+			args:    []string{"-s"},
+			wantErr: "flag needs an argument: -s",
+		},
+		"negated non-bool flag": {
+			args:    []string{"--no-source"},
+			wantErr: "flag can't be negated, as it isn't boolean: --source",
+		},
+		"negated flag with value": {
+			args:    []string{"--no-cache=true"},
+			wantErr: "flag doesn't take a value: --no-cache",
+		},
+		"invalid value": {
+			args:    []string{"--limit=many"},
+			wantErr: `invalid value "many" for flag --limit: strconv.ParseUint: parsing "many": invalid syntax`,
+		},
+		"bad syntax": {
+			args:    []string{"---verbose"},
+			wantErr: "bad flag syntax: ---verbose",
+		},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			var values testValues
+
+			err := newTestFlagSet(&values).Parse(testData.args)
+
+			if err == nil || err.Error() != testData.wantErr {
+				t.Errorf("Parse returned wrong error. Got %v. Want %q.", err, testData.wantErr)
+			}
+		})
+	}
+}
+
+func TestFlagSetParseHelp(t *testing.T) {
+	for _, arg := range []string{"--help", "-h"} {
+		t.Run(arg, func(t *testing.T) {
+			var values testValues
+			var usageCalled bool
+
+			flags := newTestFlagSet(&values)
+			flags.Usage = func() { usageCalled = true }
+
+			if err := flags.Parse([]string{arg}); !errors.Is(err, ErrHelp) {
+				t.Errorf("Parse returned wrong error. Got %#v. Want %#v.", err, ErrHelp)
+			}
+
+			if !usageCalled {
+				t.Error("Parse didn't call the usage func")
+			}
+		})
+	}
+}
+
+func TestFlagSetChanged(t *testing.T) {
+	var values testValues
+
+	flags := newTestFlagSet(&values)
+
+	if err := flags.Parse([]string{"--no-cache", "-s", "webster"}); err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"cache":   true,
+		"source":  true,
+		"verbose": false,
+		"nope":    false,
+	} {
+		if got := flags.Changed(name); got != want {
+			t.Errorf("Changed(%q) returned wrong value. Got %#v. Want %#v.", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package flag
+
+import "strconv"
+
+type boolValue bool
+
+func newBoolValue(value bool, p *bool) *boolValue {
+	*p = value
+	return (*boolValue)(p)
+}
+
+func (b *boolValue) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+
+	*b = boolValue(v)
+	return nil
+}
+
+func (b *boolValue) String() string { return strconv.FormatBool(bool(*b)) }
+
+func (b *boolValue) IsBoolFlag() bool { return true }
+
+type stringValue string
+
+func newStringValue(value string, p *string) *stringValue {
+	*p = value
+	return (*stringValue)(p)
+}
+
+func (s *stringValue) Set(v string) error {
+	*s = stringValue(v)
+	return nil
+}
+
+func (s *stringValue) String() string { return string(*s) }
+
+type uintValue uint
+
+func newUintValue(value uint, p *uint) *uintValue {
+	*p = value
+	return (*uintValue)(p)
+}
+
+func (u *uintValue) Set(s string) error {
+	v, err := strconv.ParseUint(s, 0, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+
+	*u = uintValue(v)
+	return nil
+}
+
+func (u *uintValue) String() string { return strconv.FormatUint(uint64(*u), 10) }
+
+// BoolVar defines a bool flag with the given name, default value, and usage,
+// that sets the value that p points to.
+func (f *FlagSet) BoolVar(p *bool, name string, value bool, usage string) {
+	f.VarP(newBoolValue(value, p), name, "", usage)
+}
+
+// BoolVarP is like BoolVar, but with a shorthand.
+func (f *FlagSet) BoolVarP(p *bool, name string, shorthand string, value bool, usage string) {
+	f.VarP(newBoolValue(value, p), name, shorthand, usage)
+}
+
+// Bool defines a bool flag with the given name, default value, and usage,
+// returning a pointer to its value.
+func (f *FlagSet) Bool(name string, value bool, usage string) *bool {
+	return f.BoolP(name, "", value, usage)
+}
+
+// BoolP is like Bool, but with a shorthand.
+func (f *FlagSet) BoolP(name string, shorthand string, value bool, usage string) *bool {
+	p := new(bool)
+	f.BoolVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// StringVar defines a string flag with the given name, default value, and
+// usage, that sets the value that p points to.
+func (f *FlagSet) StringVar(p *string, name string, value string, usage string) {
+	f.VarP(newStringValue(value, p), name, "", usage)
+}
+
+// StringVarP is like StringVar, but with a shorthand.
+func (f *FlagSet) StringVarP(p *string, name string, shorthand string, value string, usage string) {
+	f.VarP(newStringValue(value, p), name, shorthand, usage)
+}
+
+// String defines a string flag with the given name, default value, and usage,
+// returning a pointer to its value.
+func (f *FlagSet) String(name string, value string, usage string) *string {
+	return f.StringP(name, "", value, usage)
+}
+
+// StringP is like String, but with a shorthand.
+func (f *FlagSet) StringP(name string, shorthand string, value string, usage string) *string {
+	p := new(string)
+	f.StringVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// UintVar defines a uint flag with the given name, default value, and usage,
+// that sets the value that p points to.
+func (f *FlagSet) UintVar(p *uint, name string, value uint, usage string) {
+	f.VarP(newUintValue(value, p), name, "", usage)
+}
+
+// UintVarP is like UintVar, but with a shorthand.
+func (f *FlagSet) UintVarP(p *uint, name string, shorthand string, value uint, usage string) {
+	f.VarP(newUintValue(value, p), name, shorthand, usage)
+}
+
+// Uint defines a uint flag with the given name, default value, and usage,
+// returning a pointer to its value.
+func (f *FlagSet) Uint(name string, value uint, usage string) *uint {
+	return f.UintP(name, "", value, usage)
+}
+
+// UintP is like Uint, but with a shorthand.
+func (f *FlagSet) UintP(name string, shorthand string, value uint, usage string) *uint {
+	p := new(uint)
+	f.UintVarP(p, name, shorthand, value, usage)
+	return p
+}
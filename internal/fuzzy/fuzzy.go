@@ -0,0 +1,47 @@
+// Package fuzzy provides approximate string matching, for suggesting what a
+// mistyped name was meant to be.
+package fuzzy
+
+// Distance returns the Levenshtein distance between two strings.
+func Distance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// SubstringDistance returns the smallest Levenshtein distance between the
+// needle and any substring of the haystack of the same length, so that a
+// mistyped part of a longer name can be matched.
+func SubstringDistance(needle string, haystack string) int {
+	if len(needle) >= len(haystack) {
+		return Distance(needle, haystack)
+	}
+
+	best := len(needle)
+
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		best = min(best, Distance(needle, haystack[i:i+len(needle)]))
+	}
+
+	return best
+}
@@ -0,0 +1,47 @@
+package fuzzy
+
+import "testing"
+
+func TestDistance(t *testing.T) {
+	testData := map[string]struct {
+		a    string
+		b    string
+		want int
+	}{
+		"equal":         {a: "source", b: "source", want: 0},
+		"empty":         {a: "", b: "source", want: 6},
+		"substitution":  {a: "sourse", b: "source", want: 1},
+		"insertion":     {a: "sorce", b: "source", want: 1},
+		"deletion":      {a: "sources", b: "source", want: 1},
+		"transposition": {a: "osurce", b: "source", want: 2},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			if got := Distance(testData.a, testData.b); got != testData.want {
+				t.Errorf("Distance returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestSubstringDistance(t *testing.T) {
+	testData := map[string]struct {
+		needle   string
+		haystack string
+		want     int
+	}{
+		"exact substring":    {needle: "webster", haystack: "merriamwebsterdictionary", want: 0},
+		"mistyped substring": {needle: "webstr", haystack: "merriamwebsterdictionary", want: 1},
+		"longer needle":      {needle: "oxforddictionaries", haystack: "oxford", want: 12},
+		"unrelated":          {needle: "xyz", haystack: "oxford", want: 2},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			if got := SubstringDistance(testData.needle, testData.haystack); got != testData.want {
+				t.Errorf("SubstringDistance returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
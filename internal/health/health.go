@@ -0,0 +1,151 @@
+// Package health provides types and operations for tracking the health of
+// sources, by their consecutive failures, so that users can be told when a
+// source keeps failing them.
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+const (
+	xdgBaseName   = "define"
+	stateFileName = "health.json"
+
+	// FailureThreshold is the number of consecutive failures after which a
+	// source is considered unhealthy.
+	FailureThreshold = 5
+)
+
+// SourceHealth defines the structure of the tracked health of a source.
+type SourceHealth struct {
+	ConsecutiveFailures uint
+	LastFailure         string    `json:",omitempty"` // The message of the last failure
+	LastFailedAt        time.Time `json:",omitempty"`
+
+	// HintShown is whether the user has been told that the source is
+	// unhealthy, since it last succeeded
+	HintShown bool `json:",omitempty"`
+}
+
+// SourceHealths defines the structure of the tracked health of sources, keyed
+// by the name of the source.
+type SourceHealths map[string]SourceHealth
+
+// Tracker tracks the health of sources in a file.
+type Tracker struct {
+	filePath string
+}
+
+// NewTracker returns a new Tracker that tracks health in the file at the given
+// path.
+func NewTracker(filePath string) *Tracker {
+	return &Tracker{filePath: filePath}
+}
+
+// StateFilePath returns the path of the file that health is tracked in, within
+// the user's XDG state directory.
+func StateFilePath() (string, error) {
+	return xdg.StateFile(filepath.Join(xdgBaseName, stateFileName))
+}
+
+// IsUnhealthy returns true if the source has failed at least FailureThreshold
+// times in a row.
+func (h SourceHealth) IsUnhealthy() bool {
+	return h.ConsecutiveFailures >= FailureThreshold
+}
+
+// Healths returns the tracked health of sources. If no health has been tracked
+// yet, empty healths are returned.
+func (t *Tracker) Healths() (SourceHealths, error) {
+	healths := make(SourceHealths)
+
+	fileContents, err := os.ReadFile(t.filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return healths, nil
+	}
+
+	if err != nil {
+		return healths, err
+	}
+
+	if len(fileContents) > 0 {
+		err = json.Unmarshal(fileContents, &healths)
+	}
+
+	return healths, err
+}
+
+// Health returns the tracked health of the named source.
+func (t *Tracker) Health(sourceName string) (SourceHealth, error) {
+	healths, err := t.Healths()
+
+	return healths[sourceName], err
+}
+
+// RecordSuccess records that the named source succeeded, resetting its health.
+func (t *Tracker) RecordSuccess(sourceName string) error {
+	healths, err := t.Healths()
+	if err != nil {
+		return err
+	}
+
+	// Avoid writing the file when there's nothing to reset
+	if _, exists := healths[sourceName]; !exists {
+		return nil
+	}
+
+	delete(healths, sourceName)
+
+	return t.write(healths)
+}
+
+// RecordFailure records that the named source failed at the given time with
+// the given error, and returns its updated health.
+func (t *Tracker) RecordFailure(sourceName string, at time.Time, failure error) (SourceHealth, error) {
+	healths, err := t.Healths()
+	if err != nil {
+		return SourceHealth{}, err
+	}
+
+	sourceHealth := healths[sourceName]
+	sourceHealth.ConsecutiveFailures++
+	sourceHealth.LastFailure = failure.Error()
+	sourceHealth.LastFailedAt = at
+
+	healths[sourceName] = sourceHealth
+
+	return sourceHealth, t.write(healths)
+}
+
+// RecordHintShown records that the user has been told that the named source is
+// unhealthy, so that they aren't told again until it next succeeds.
+func (t *Tracker) RecordHintShown(sourceName string) error {
+	healths, err := t.Healths()
+	if err != nil {
+		return err
+	}
+
+	sourceHealth := healths[sourceName]
+	sourceHealth.HintShown = true
+
+	healths[sourceName] = sourceHealth
+
+	return t.write(healths)
+}
+
+// write writes the given healths to the tracker's file.
+func (t *Tracker) write(healths SourceHealths) error {
+	encoded, err := json.MarshalIndent(healths, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.filePath, encoded, 0o644)
+}
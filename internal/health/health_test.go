@@ -0,0 +1,49 @@
+package health
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTracker(t *testing.T) {
+	at := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+	failure := errors.New("no results")
+
+	tracker := NewTracker(filepath.Join(t.TempDir(), stateFileName))
+
+	for i := uint(1); i <= FailureThreshold; i++ {
+		sourceHealth, err := tracker.RecordFailure("Source", at, failure)
+		if err != nil {
+			t.Fatalf("RecordFailure returned an error: %v", err)
+		}
+
+		if sourceHealth.ConsecutiveFailures != i {
+			t.Errorf("RecordFailure returned wrong ConsecutiveFailures. Got %#v. Want %#v.", sourceHealth.ConsecutiveFailures, i)
+		}
+
+		if want := i >= FailureThreshold; sourceHealth.IsUnhealthy() != want {
+			t.Errorf("IsUnhealthy returned wrong value after %d failures. Got %#v. Want %#v.", i, sourceHealth.IsUnhealthy(), want)
+		}
+	}
+
+	if err := tracker.RecordHintShown("Source"); err != nil {
+		t.Fatalf("RecordHintShown returned an error: %v", err)
+	}
+
+	want := SourceHealth{ConsecutiveFailures: FailureThreshold, LastFailure: failure.Error(), LastFailedAt: at, HintShown: true}
+
+	if got, err := tracker.Health("Source"); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Health returned wrong value. Got %#v (error: %v). Want %#v.", got, err, want)
+	}
+
+	if err := tracker.RecordSuccess("Source"); err != nil {
+		t.Fatalf("RecordSuccess returned an error: %v", err)
+	}
+
+	if got, err := tracker.Health("Source"); err != nil || !reflect.DeepEqual(got, SourceHealth{}) {
+		t.Errorf("Health returned wrong value after a success. Got %#v (error: %v). Want %#v.", got, err, SourceHealth{})
+	}
+}
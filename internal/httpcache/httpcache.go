@@ -0,0 +1,279 @@
+// Package httpcache provides an HTTP transport that caches responses on disk
+// along with their validators (ETag and Last-Modified), so that refreshing
+// them can be done with cheap, quota-friendly conditional requests.
+//
+// It also provides a cache of the search results of sources, which are served
+// without any request until they expire.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+const (
+	xdgBaseName  = "define"
+	cacheDirName = "http"
+
+	entryFileExtension = ".json"
+	entryFilePerms     = 0600
+	entryDirPerms      = 0700
+
+	etagHeaderName            = "ETag"
+	lastModifiedHeaderName    = "Last-Modified"
+	ifNoneMatchHeaderName     = "If-None-Match"
+	ifModifiedSinceHeaderName = "If-Modified-Since"
+	contentLengthHeaderName   = "Content-Length"
+)
+
+// Entry defines a cached response, along with its validators.
+type Entry struct {
+	ETag         string      `json:",omitempty"`
+	LastModified string      `json:",omitempty"`
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	StoredAt     time.Time   `json:"storedAt"`
+}
+
+// Transport is an http.RoundTripper that caches the responses of GET requests
+// that return validators, and sends conditional requests to refresh them.
+type Transport struct {
+	inner   http.RoundTripper
+	dirPath string
+	maxSize int64
+	maxAge  time.Duration
+	memory  *memoryCache[*Entry]
+}
+
+// DirPath returns the path of the directory that cached responses are stored
+// in.
+func DirPath() string {
+	return filepath.Join(xdg.CacheHome, xdgBaseName, cacheDirName)
+}
+
+// NewTransport returns a new Transport that wraps an inner http.RoundTripper,
+// storing cached responses in a given directory. Responses with bodies larger
+// than the given max size aren't cached.
+func NewTransport(inner http.RoundTripper, dirPath string, maxSize int64) *Transport {
+	return &Transport{inner: inner, dirPath: dirPath, maxSize: maxSize}
+}
+
+// WithMaxAge returns a copy of the Transport that caches responses even if
+// they don't return validators, and serves cached responses without making any
+// request until they're older than the given max age.
+//
+// This is meant for data that rarely (if ever) changes, like historical
+// statistics, where even a conditional request is a waste.
+func (t *Transport) WithMaxAge(maxAge time.Duration) *Transport {
+	withMaxAge := *t
+	withMaxAge.maxAge = maxAge
+
+	return &withMaxAge
+}
+
+// WithMemoryCache returns a copy of the Transport that also keeps up to a given
+// number of cached responses in memory, in front of the disk, so that the
+// responses of hot requests are served without reading or decoding a file.
+//
+// This is meant for long-running processes, or ones that make many requests,
+// where the same responses are likely to be requested more than once.
+func (t *Transport) WithMemoryCache(size int) *Transport {
+	withMemoryCache := *t
+	withMemoryCache.memory = newMemoryCache[*Entry](size)
+
+	return &withMemoryCache
+}
+
+// Stats returns the counts of the lookups of the Transport's in-memory cache,
+// which are zero if it doesn't have one.
+func (t *Transport) Stats() Stats {
+	return t.memory.stats()
+}
+
+// RoundTrip executes a single HTTP transaction, making the request conditional
+// if a cached response exists, and serving the cached response if the source
+// reports that it hasn't been modified.
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet {
+		return t.inner.RoundTrip(request)
+	}
+
+	entryPath := t.entryPath(request)
+
+	entry := t.loadEntry(entryPath)
+
+	if entry != nil && t.maxAge > 0 && time.Since(entry.StoredAt) < t.maxAge {
+		return entry.response(request), nil
+	}
+
+	if entry != nil {
+		request = request.Clone(request.Context())
+
+		if entry.ETag != "" {
+			request.Header.Set(ifNoneMatchHeaderName, entry.ETag)
+		}
+
+		if entry.LastModified != "" {
+			request.Header.Set(ifModifiedSinceHeaderName, entry.LastModified)
+		}
+	}
+
+	response, err := t.inner.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	switch {
+	case response.StatusCode == http.StatusNotModified && entry != nil:
+		response.Body.Close()
+
+		return entry.response(request), nil
+	case response.StatusCode == http.StatusOK:
+		return t.store(entryPath, response)
+	}
+
+	return response, nil
+}
+
+// store caches a response, if it has validators (or the transport has a max
+// age), and returns a response that
+// can still be read from.
+func (t *Transport) store(entryPath string, response *http.Response) (*http.Response, error) {
+	entry := &Entry{
+		ETag:         response.Header.Get(etagHeaderName),
+		LastModified: response.Header.Get(lastModifiedHeaderName),
+		StatusCode:   response.StatusCode,
+		Header:       response.Header,
+		StoredAt:     time.Now().UTC(),
+	}
+
+	hasValidators := entry.ETag != "" || entry.LastModified != ""
+
+	if (!hasValidators && t.maxAge <= 0) || response.ContentLength > t.maxSize {
+		return response, nil
+	}
+
+	// Read one byte past the max size, to know if the body is too large
+	body, err := io.ReadAll(io.LimitReader(response.Body, t.maxSize+1))
+	if err != nil {
+		response.Body.Close()
+
+		return nil, err
+	}
+
+	if int64(len(body)) > t.maxSize {
+		// Stitch the already read part of the body back together with the
+		// rest of it, without caching it
+		response.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), response.Body), response.Body}
+
+		return response, nil
+	}
+
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry.Body = body
+
+	t.memory.add(entryPath, entry)
+
+	// Caching is a best-effort, so failing to write shouldn't fail the request
+	_ = writeEntry(entryPath, entry)
+
+	return response, nil
+}
+
+// loadEntry returns the cache entry at a path, from memory if it's there, or
+// else from the disk, or nil if it isn't cached.
+func (t *Transport) loadEntry(entryPath string) *Entry {
+	if entry, isCached := t.memory.get(entryPath); isCached {
+		return entry
+	}
+
+	// Caching is a best-effort, so a broken cache entry shouldn't fail the
+	// request
+	entry, _ := readEntry(entryPath)
+	if entry != nil {
+		t.memory.add(entryPath, entry)
+	}
+
+	return entry
+}
+
+// entryPath returns the path of the cache entry for a request.
+//
+// The URL is hashed, so that any credentials in it aren't exposed in the name
+// of the file.
+func (t *Transport) entryPath(request *http.Request) string {
+	hash := sha256.Sum256([]byte(request.URL.String()))
+
+	return filepath.Join(t.dirPath, hex.EncodeToString(hash[:])+entryFileExtension)
+}
+
+// response returns a new response from a cached entry, for a given request.
+func (e *Entry) response(request *http.Request) *http.Response {
+	header := e.Header.Clone()
+	header.Set(contentLengthHeaderName, strconv.Itoa(len(e.Body)))
+
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       request,
+	}
+}
+
+// readEntry reads a cache entry from a file. If the file doesn't exist, a nil
+// entry and a nil error are returned.
+func readEntry(filePath string) (*Entry, error) {
+	fileContents, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+
+	if err = json.Unmarshal(fileContents, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// writeEntry writes a cache entry to a file.
+func writeEntry(filePath string, entry *Entry) error {
+	fileContents, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(filePath), entryDirPerms); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, fileContents, entryFilePerms)
+}
@@ -0,0 +1,144 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransport(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		etag            string
+		lastModified    string
+		maxSize         int64
+		wantConditional bool
+	}{
+		"etag": {
+			etag:            `"v1"`,
+			maxSize:         1024,
+			wantConditional: true,
+		},
+		"last modified": {
+			lastModified:    "Wed, 21 Oct 2015 07:28:00 GMT",
+			maxSize:         1024,
+			wantConditional: true,
+		},
+		"no validators": {
+			maxSize:         1024,
+			wantConditional: false,
+		},
+		"too large": {
+			etag:            `"v1"`,
+			maxSize:         2,
+			wantConditional: false,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			const body = "definition"
+
+			var conditionalRequests int
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(ifNoneMatchHeaderName) != "" || r.Header.Get(ifModifiedSinceHeaderName) != "" {
+					conditionalRequests++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+
+				if testData.etag != "" {
+					w.Header().Set(etagHeaderName, testData.etag)
+				}
+
+				if testData.lastModified != "" {
+					w.Header().Set(lastModifiedHeaderName, testData.lastModified)
+				}
+
+				io.WriteString(w, body)
+			}))
+			defer server.Close()
+
+			client := http.Client{
+				Transport: NewTransport(http.DefaultTransport, t.TempDir(), testData.maxSize),
+			}
+
+			for i := 0; i < 2; i++ {
+				response, err := client.Get(server.URL + "/test")
+				if err != nil {
+					t.Fatalf("request returned an error: %s", err)
+				}
+
+				got, err := io.ReadAll(response.Body)
+				response.Body.Close()
+
+				if err != nil {
+					t.Fatalf("reading the response returned an error: %s", err)
+				}
+
+				if response.StatusCode != http.StatusOK || string(got) != body {
+					t.Errorf("RoundTrip returned wrong response. Got %d %#v. Want %d %#v.", response.StatusCode, string(got), http.StatusOK, body)
+				}
+			}
+
+			if gotConditional := conditionalRequests > 0; gotConditional != testData.wantConditional {
+				t.Errorf("RoundTrip sent wrong conditional requests. Got %#v. Want %#v.", gotConditional, testData.wantConditional)
+			}
+		})
+	}
+}
+
+func TestTransport_WithMaxAge(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		maxAge       time.Duration
+		wantRequests int
+	}{
+		"fresh": {
+			maxAge:       time.Hour,
+			wantRequests: 1,
+		},
+		"stale": {
+			maxAge:       time.Nanosecond,
+			wantRequests: 2,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			const body = "trend"
+
+			var requests int
+
+			// Respond without validators, which wouldn't otherwise be cached
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				io.WriteString(w, body)
+			}))
+			defer server.Close()
+
+			client := http.Client{
+				Transport: NewTransport(http.DefaultTransport, t.TempDir(), 1024).WithMaxAge(testData.maxAge),
+			}
+
+			for i := 0; i < 2; i++ {
+				response, err := client.Get(server.URL + "/test")
+				if err != nil {
+					t.Fatalf("request returned an error: %s", err)
+				}
+
+				got, err := io.ReadAll(response.Body)
+				response.Body.Close()
+
+				if err != nil {
+					t.Fatalf("reading the response returned an error: %s", err)
+				}
+
+				if string(got) != body {
+					t.Errorf("RoundTrip returned wrong body. Got %#v. Want %#v.", string(got), body)
+				}
+			}
+
+			if requests != testData.wantRequests {
+				t.Errorf("RoundTrip made wrong number of requests. Got %d. Want %d.", requests, testData.wantRequests)
+			}
+		})
+	}
+}
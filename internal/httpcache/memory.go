@@ -0,0 +1,109 @@
+package httpcache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Stats defines the counts of the lookups of an in-memory cache, to gauge how
+// effective it is.
+type Stats struct {
+	Hits   uint64 // The number of lookups that were found in memory
+	Misses uint64 // The number of lookups that had to fall back to the disk
+}
+
+// memoryCache is an in-process cache of a limited number of values, in front
+// of the disk, that evicts the least recently used value when full, so that
+// the values of hot keys are served without reading or decoding a file.
+type memoryCache[V any] struct {
+	mutex    sync.Mutex
+	size     int
+	order    *list.List // The items, from the most to the least recently used
+	elements map[string]*list.Element
+	counts   Stats
+}
+
+// memoryCacheItem is an item of a memoryCache, which keeps its key so that it
+// can be removed from the cache's map when it's evicted.
+type memoryCacheItem[V any] struct {
+	key   string
+	value V
+}
+
+// newMemoryCache returns a new memoryCache of a given size. A nil cache is
+// returned if the size is less than 1, which caches nothing.
+func newMemoryCache[V any](size int) *memoryCache[V] {
+	if size < 1 {
+		return nil
+	}
+
+	return &memoryCache[V]{
+		size:     size,
+		order:    list.New(),
+		elements: make(map[string]*list.Element, size),
+	}
+}
+
+// get returns the value of a key, and whether it was cached, marking it as the
+// most recently used.
+func (c *memoryCache[V]) get(key string) (V, bool) {
+	if c == nil {
+		var zero V
+		return zero, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.elements[key]
+	if !exists {
+		c.counts.Misses++
+
+		var zero V
+		return zero, false
+	}
+
+	c.counts.Hits++
+	c.order.MoveToFront(element)
+
+	return element.Value.(*memoryCacheItem[V]).value, true
+}
+
+// add caches the value of a key, as the most recently used, evicting the least
+// recently used value if the cache is full.
+func (c *memoryCache[V]) add(key string, value V) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, exists := c.elements[key]; exists {
+		element.Value.(*memoryCacheItem[V]).value = value
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.elements[key] = c.order.PushFront(&memoryCacheItem[V]{key: key, value: value})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+
+		c.order.Remove(oldest)
+		delete(c.elements, oldest.Value.(*memoryCacheItem[V]).key)
+	}
+}
+
+// stats returns the counts of the cache's lookups so far.
+func (c *memoryCache[V]) stats() Stats {
+	if c == nil {
+		return Stats{}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.counts
+}
@@ -0,0 +1,86 @@
+package httpcache
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemoryCache(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		size      int
+		add       []string
+		get       []string
+		wantFound []bool
+		wantStats Stats
+	}{
+		"disabled": {
+			size:      0,
+			add:       []string{"a"},
+			get:       []string{"a"},
+			wantFound: []bool{false},
+			wantStats: Stats{},
+		},
+		"hit and miss": {
+			size:      2,
+			add:       []string{"a"},
+			get:       []string{"a", "b"},
+			wantFound: []bool{true, false},
+			wantStats: Stats{Hits: 1, Misses: 1},
+		},
+		"least recently added evicted": {
+			size:      2,
+			add:       []string{"a", "b", "c"},
+			get:       []string{"a", "b", "c"},
+			wantFound: []bool{false, true, true},
+			wantStats: Stats{Hits: 2, Misses: 1},
+		},
+		"least recently used evicted": {
+			size:      2,
+			add:       []string{"a", "b", "", "c"},
+			get:       []string{"b", "a", "c"},
+			wantFound: []bool{false, true, true},
+			wantStats: Stats{Hits: 3, Misses: 1},
+		},
+		"re-added kept": {
+			size:      2,
+			add:       []string{"a", "b", "a", "c"},
+			get:       []string{"a", "b", "c"},
+			wantFound: []bool{true, false, true},
+			wantStats: Stats{Hits: 2, Misses: 1},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			cache := newMemoryCache[string](testData.size)
+
+			for _, key := range testData.add {
+				// An empty key marks using "a" between the adds
+				if key == "" {
+					cache.get("a")
+					continue
+				}
+
+				cache.add(key, key+"-value")
+			}
+
+			var gotFound []bool
+
+			for _, key := range testData.get {
+				value, found := cache.get(key)
+
+				if found && value != key+"-value" {
+					t.Errorf("get returned wrong value. Got %#v. Want %#v.", value, key+"-value")
+				}
+
+				gotFound = append(gotFound, found)
+			}
+
+			if !reflect.DeepEqual(gotFound, testData.wantFound) {
+				t.Errorf("get returned wrong found states. Got %#v. Want %#v.", gotFound, testData.wantFound)
+			}
+
+			if got := cache.stats(); got != testData.wantStats {
+				t.Errorf("stats returned wrong value. Got %#v. Want %#v.", got, testData.wantStats)
+			}
+		})
+	}
+}
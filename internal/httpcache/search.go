@@ -0,0 +1,141 @@
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/Rican7/define/source"
+)
+
+const searchCacheDirName = "search"
+
+// searchEntry defines cached search results.
+type searchEntry struct {
+	Results  source.SearchResults `json:"results"`
+	StoredAt time.Time            `json:"storedAt"`
+}
+
+// SearchCache caches the search results of sources on disk, separately from
+// their responses, so that repeated searches (like those of type-ahead
+// suggestions) are served without making any request until they're older than
+// a max age.
+//
+// Search results change more often than definitions do, so they're meant to be
+// cached for a shorter time than responses are.
+type SearchCache struct {
+	dirPath string
+	maxAge  time.Duration
+	memory  *memoryCache[*searchEntry]
+	now     func() time.Time
+}
+
+// SearchDirPath returns the path of the directory that cached search results
+// are stored in.
+func SearchDirPath() string {
+	return filepath.Join(xdg.CacheHome, xdgBaseName, searchCacheDirName)
+}
+
+// NewSearchCache returns a new SearchCache that stores cached search results in
+// a given directory, for up to a given max age.
+func NewSearchCache(dirPath string, maxAge time.Duration) *SearchCache {
+	return &SearchCache{dirPath: dirPath, maxAge: maxAge, now: time.Now}
+}
+
+// WithMemoryCache returns a copy of the SearchCache that also keeps up to a
+// given number of cached search results in memory, in front of the disk.
+func (c *SearchCache) WithMemoryCache(size int) *SearchCache {
+	withMemoryCache := *c
+	withMemoryCache.memory = newMemoryCache[*searchEntry](size)
+
+	return &withMemoryCache
+}
+
+// Stats returns the counts of the lookups of the SearchCache's in-memory cache,
+// which are zero if it doesn't have one.
+func (c *SearchCache) Stats() Stats {
+	return c.memory.stats()
+}
+
+// Get returns the cached search results of a source for a query and limit, and
+// whether they were cached and aren't yet older than the max age.
+func (c *SearchCache) Get(sourceName string, query string, limit uint) (source.SearchResults, bool) {
+	entryPath := c.entryPath(sourceName, query, limit)
+
+	entry, isCached := c.memory.get(entryPath)
+	if !isCached {
+		// Caching is a best-effort, so a broken cache entry is just a miss
+		entry, _ = readSearchEntry(entryPath)
+		if entry != nil {
+			c.memory.add(entryPath, entry)
+		}
+	}
+
+	if entry == nil || c.now().Sub(entry.StoredAt) >= c.maxAge {
+		return nil, false
+	}
+
+	// Copy the results, so that the cached ones can't be changed
+	return slices.Clone(entry.Results), true
+}
+
+// Set caches the search results of a source for a query and limit.
+func (c *SearchCache) Set(sourceName string, query string, limit uint, results source.SearchResults) error {
+	entry := &searchEntry{
+		Results:  slices.Clone(results),
+		StoredAt: c.now().UTC(),
+	}
+
+	fileContents, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	entryPath := c.entryPath(sourceName, query, limit)
+
+	c.memory.add(entryPath, entry)
+
+	if err = os.MkdirAll(filepath.Dir(entryPath), entryDirPerms); err != nil {
+		return err
+	}
+
+	return os.WriteFile(entryPath, fileContents, entryFilePerms)
+}
+
+// entryPath returns the path of the cache entry for a source's query and limit.
+func (c *SearchCache) entryPath(sourceName string, query string, limit uint) string {
+	key := sourceName + "\x00" + query + "\x00" + strconv.FormatUint(uint64(limit), 10)
+	hash := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dirPath, hex.EncodeToString(hash[:])+entryFileExtension)
+}
+
+// readSearchEntry reads a search cache entry from a file. If the file doesn't
+// exist, a nil entry and a nil error are returned.
+func readSearchEntry(filePath string) (*searchEntry, error) {
+	fileContents, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entry searchEntry
+
+	if err = json.Unmarshal(fileContents, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
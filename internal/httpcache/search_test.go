@@ -0,0 +1,85 @@
+package httpcache
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestSearchCache(t *testing.T) {
+	const (
+		sourceName = "Source"
+		query      = "tset"
+		limit      = 5
+	)
+
+	results := source.SearchResults{"test", "tests"}
+
+	for testName, testData := range map[string]struct {
+		sourceName string
+		query      string
+		limit      uint
+		age        time.Duration
+		want       source.SearchResults
+		wantCached bool
+	}{
+		"fresh": {
+			sourceName: sourceName,
+			query:      query,
+			limit:      limit,
+			age:        time.Minute,
+			want:       results,
+			wantCached: true,
+		},
+		"expired": {
+			sourceName: sourceName,
+			query:      query,
+			limit:      limit,
+			age:        time.Hour,
+			wantCached: false,
+		},
+		"other source": {
+			sourceName: "Other Source",
+			query:      query,
+			limit:      limit,
+			wantCached: false,
+		},
+		"other query": {
+			sourceName: sourceName,
+			query:      "tast",
+			limit:      limit,
+			wantCached: false,
+		},
+		"other limit": {
+			sourceName: sourceName,
+			query:      query,
+			limit:      10,
+			wantCached: false,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			storedAt := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+			cache := NewSearchCache(t.TempDir(), time.Hour)
+			cache.now = func() time.Time { return storedAt }
+
+			if err := cache.Set(sourceName, query, limit, results); err != nil {
+				t.Fatalf("Set returned an error: %v", err)
+			}
+
+			cache.now = func() time.Time { return storedAt.Add(testData.age) }
+
+			got, gotCached := cache.Get(testData.sourceName, testData.query, testData.limit)
+
+			if gotCached != testData.wantCached {
+				t.Errorf("Get returned wrong cached state. Got %#v. Want %#v.", gotCached, testData.wantCached)
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Get returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package httpclient
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	acceptEncodingHeaderName  = "Accept-Encoding"
+	contentEncodingHeaderName = "Content-Encoding"
+	contentLengthHeaderName   = "Content-Length"
+
+	// acceptEncoding is the list of encodings that are requested, in order of
+	// preference
+	acceptEncoding = "gzip, deflate"
+
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// CompressionTransport is an http.RoundTripper that requests compressed
+// responses, and transparently decompresses them.
+type CompressionTransport struct {
+	inner http.RoundTripper
+}
+
+// decompressingBody is a response body that reads from a decompressing reader
+// and closes both it and the original body.
+type decompressingBody struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// NewCompressionTransport returns a new CompressionTransport that wraps an
+// inner http.RoundTripper.
+func NewCompressionTransport(inner http.RoundTripper) *CompressionTransport {
+	return &CompressionTransport{inner: inner}
+}
+
+// RoundTrip executes a single HTTP transaction, requesting a compressed
+// response (unless the request already specifies its accepted encodings) and
+// decompressing it.
+func (t *CompressionTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Header.Get(acceptEncodingHeaderName) != "" {
+		return t.inner.RoundTrip(request)
+	}
+
+	request = request.Clone(request.Context())
+	request.Header.Set(acceptEncodingHeaderName, acceptEncoding)
+
+	response, err := t.inner.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	if request.Method == http.MethodHead || response.StatusCode == http.StatusNoContent || response.StatusCode == http.StatusNotModified {
+		return response, nil
+	}
+
+	var body io.Reader
+	var closer io.Closer
+
+	switch strings.ToLower(strings.TrimSpace(response.Header.Get(contentEncodingHeaderName))) {
+	case encodingGzip:
+		gzipReader, err := gzip.NewReader(response.Body)
+		if err != nil {
+			response.Body.Close()
+			return nil, err
+		}
+
+		body, closer = gzipReader, gzipReader
+	case encodingDeflate:
+		deflateReader, err := newDeflateReader(response.Body)
+		if err != nil {
+			response.Body.Close()
+			return nil, err
+		}
+
+		body, closer = deflateReader, deflateReader
+	default:
+		return response, nil
+	}
+
+	response.Body = &decompressingBody{Reader: body, closers: []io.Closer{closer, response.Body}}
+	response.Header.Del(contentEncodingHeaderName)
+	response.Header.Del(contentLengthHeaderName)
+	response.ContentLength = -1
+	response.Uncompressed = true
+
+	return response, nil
+}
+
+// Close satisfies the io.Closer interface.
+func (b *decompressingBody) Close() error {
+	var err error
+
+	for _, closer := range b.closers {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// newDeflateReader returns a reader that decompresses a "deflate" encoded
+// body.
+//
+// The "deflate" content encoding is defined as zlib wrapped data, but some
+// servers send raw deflate data instead, so both are supported.
+func newDeflateReader(body io.Reader) (io.ReadCloser, error) {
+	bufferedBody := bufio.NewReader(body)
+
+	header, err := bufferedBody.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if isZlibHeader(header) {
+		return zlib.NewReader(bufferedBody)
+	}
+
+	return flate.NewReader(bufferedBody), nil
+}
+
+// isZlibHeader returns true if the given bytes are a valid zlib header.
+//
+// See https://www.rfc-editor.org/rfc/rfc1950#section-2.2
+func isZlibHeader(header []byte) bool {
+	if len(header) < 2 {
+		return false
+	}
+
+	compressionMethod := header[0] & 0x0f
+	checksum := (uint16(header[0])<<8 | uint16(header[1])) % 31
+
+	return compressionMethod == 8 && checksum == 0
+}
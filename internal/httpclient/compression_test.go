@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompressionTransport(t *testing.T) {
+	const body = `{"word":"test","definition":"A procedure intended to establish the quality of something."}`
+
+	compress := func(newWriter func(io.Writer) io.WriteCloser) []byte {
+		var buffer bytes.Buffer
+
+		writer := newWriter(&buffer)
+		io.WriteString(writer, body)
+		writer.Close()
+
+		return buffer.Bytes()
+	}
+
+	for testName, testData := range map[string]struct {
+		encoding string
+		body     []byte
+	}{
+		"identity": {
+			encoding: "",
+			body:     []byte(body),
+		},
+		"gzip": {
+			encoding: "gzip",
+			body: compress(func(w io.Writer) io.WriteCloser {
+				return gzip.NewWriter(w)
+			}),
+		},
+		"zlib deflate": {
+			encoding: "deflate",
+			body: compress(func(w io.Writer) io.WriteCloser {
+				return zlib.NewWriter(w)
+			}),
+		},
+		"raw deflate": {
+			encoding: "deflate",
+			body: compress(func(w io.Writer) io.WriteCloser {
+				writer, _ := flate.NewWriter(w, flate.DefaultCompression)
+				return writer
+			}),
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var gotAcceptEncoding string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAcceptEncoding = r.Header.Get(acceptEncodingHeaderName)
+
+				if testData.encoding != "" {
+					w.Header().Set(contentEncodingHeaderName, testData.encoding)
+				}
+
+				w.Write(testData.body)
+			}))
+			defer server.Close()
+
+			client := http.Client{
+				Transport: NewCompressionTransport(&http.Transport{DisableCompression: true}),
+			}
+
+			response, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("request returned an error: %s", err)
+			}
+
+			got, err := io.ReadAll(response.Body)
+			response.Body.Close()
+
+			if err != nil {
+				t.Fatalf("reading the response returned an error: %s", err)
+			}
+
+			if gotAcceptEncoding != acceptEncoding {
+				t.Errorf("RoundTrip sent wrong Accept-Encoding. Got %#v. Want %#v.", gotAcceptEncoding, acceptEncoding)
+			}
+
+			if string(got) != body {
+				t.Errorf("RoundTrip returned wrong body. Got %#v. Want %#v.", string(got), body)
+			}
+
+			if encoding := response.Header.Get(contentEncodingHeaderName); encoding != "" {
+				t.Errorf("RoundTrip returned wrong Content-Encoding. Got %#v. Want %#v.", encoding, "")
+			}
+		})
+	}
+}
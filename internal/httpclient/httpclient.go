@@ -0,0 +1,64 @@
+// Package httpclient provides a shared, tuned HTTP client for sources to make
+// their API requests with.
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// Timeout is the overall time limit for a request, including reading the
+	// response body.
+	Timeout = 30 * time.Second
+
+	dialTimeout           = 10 * time.Second
+	dialKeepAlive         = 30 * time.Second
+	tlsHandshakeTimeout   = 10 * time.Second
+	responseHeaderTimeout = 15 * time.Second
+	idleConnTimeout       = 90 * time.Second
+	maxIdleConns          = 100
+	maxIdleConnsPerHost   = 10
+)
+
+// NewTransport creates a new http.RoundTripper, tuned for reusing connections
+// to a small number of API hosts, for negotiating HTTP/2 where available, and
+// for requesting and decompressing compressed responses.
+func NewTransport() http.RoundTripper {
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ResponseHeaderTimeout: responseHeaderTimeout,
+		IdleConnTimeout:       idleConnTimeout,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		ExpectContinueTimeout: 1 * time.Second,
+
+		// Compression is handled by our own transport, so that both gzip and
+		// deflate encodings are supported
+		DisableCompression: true,
+	}
+
+	return NewCompressionTransport(transport)
+}
+
+// New creates a new http.Client that uses the given transport. If the
+// transport is nil, a new transport is created with NewTransport.
+func New(transport http.RoundTripper) http.Client {
+	if transport == nil {
+		transport = NewTransport()
+	}
+
+	return http.Client{
+		Transport: transport,
+		Timeout:   Timeout,
+	}
+}
@@ -0,0 +1,39 @@
+package httpclient
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		transport http.RoundTripper
+	}{
+		"nil transport": {
+			transport: nil,
+		},
+		"given transport": {
+			transport: &http.Transport{},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			client := New(testData.transport)
+
+			if client.Timeout != Timeout {
+				t.Errorf("New returned wrong timeout. Got %#v. Want %#v.", client.Timeout, Timeout)
+			}
+
+			switch testData.transport {
+			case nil:
+				transport, ok := client.Transport.(*CompressionTransport)
+				if !ok || !transport.inner.(*http.Transport).ForceAttemptHTTP2 {
+					t.Errorf("New returned wrong transport. Got %#v.", client.Transport)
+				}
+			default:
+				if client.Transport != testData.transport {
+					t.Errorf("New returned wrong transport. Got %#v. Want %#v.", client.Transport, testData.transport)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Package hyphenation provides an implementation of Liang's hyphenation
+// algorithm (as used by TeX), for finding the points at which words may be
+// hyphenated.
+package hyphenation
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const (
+	// The default minimum number of letters before and after a hyphen, as
+	// used by TeX for English
+	defaultMinLeft  = 2
+	defaultMinRight = 3
+)
+
+// Hyphenator hyphenates words by a set of patterns and exceptions.
+type Hyphenator struct {
+	patterns   map[string][]int
+	exceptions map[string][]string
+
+	minLeft  int
+	minRight int
+}
+
+// Load reads hyphenation patterns from the file at the given path.
+//
+// See Parse for the supported format.
+func Load(path string) (*Hyphenator, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(string(contents))
+}
+
+// Parse parses hyphenation patterns, in either the TeX format (with patterns
+// in a "\patterns{...}" group, and optional exceptions in a
+// "\hyphenation{...}" group) or the plain format of whitespace separated
+// patterns (like hyph-utf8's ".pat.txt" files). Comments, starting with a
+// "%", are ignored.
+func Parse(data string) (*Hyphenator, error) {
+	h := &Hyphenator{
+		patterns:   make(map[string][]int),
+		exceptions: make(map[string][]string),
+		minLeft:    defaultMinLeft,
+		minRight:   defaultMinRight,
+	}
+
+	data = stripComments(data)
+
+	patterns, hasPatternsGroup := texGroup(data, `\patterns`)
+	if !hasPatternsGroup {
+		patterns = data
+	}
+
+	for _, pattern := range strings.Fields(patterns) {
+		h.addPattern(pattern)
+	}
+
+	if exceptions, hasExceptionsGroup := texGroup(data, `\hyphenation`); hasExceptionsGroup {
+		for _, exception := range strings.Fields(exceptions) {
+			word := strings.ToLower(exception)
+			h.exceptions[strings.ReplaceAll(word, "-", "")] = strings.Split(word, "-")
+		}
+	}
+
+	if len(h.patterns) < 1 {
+		return nil, errors.New("no hyphenation patterns found")
+	}
+
+	return h, nil
+}
+
+// addPattern adds a pattern, like "hy3ph", which is stored as its letters
+// ("hyph") and the values between them ([0, 0, 3, 0, 0]).
+func (h *Hyphenator) addPattern(pattern string) {
+	var letters []rune
+	values := []int{0}
+
+	for _, r := range strings.ToLower(pattern) {
+		if r >= '0' && r <= '9' {
+			values[len(values)-1] = int(r - '0')
+			continue
+		}
+
+		letters = append(letters, r)
+		values = append(values, 0)
+	}
+
+	if len(letters) > 0 {
+		h.patterns[string(letters)] = values
+	}
+}
+
+// Hyphenate returns the parts of a word, split at the points at which it may
+// be hyphenated.
+func (h *Hyphenator) Hyphenate(word string) []string {
+	lower := []rune(strings.ToLower(word))
+	original := []rune(word)
+
+	if len(original) != len(lower) {
+		// Case changes that change the length can't be mapped back
+		return []string{word}
+	}
+
+	if parts, exists := h.exceptions[string(lower)]; exists {
+		return splitLike(original, parts)
+	}
+
+	if len(lower) < h.minLeft+h.minRight {
+		return []string{word}
+	}
+
+	// Find the highest value between each letter of the word (with the word
+	// marked by periods at each end, as patterns may match the edges)
+	marked := append(append([]rune{'.'}, lower...), '.')
+	values := make([]int, len(marked)+1)
+
+	for start := range marked {
+		for end := start + 1; end <= len(marked); end++ {
+			patternValues, exists := h.patterns[string(marked[start:end])]
+			if !exists {
+				continue
+			}
+
+			for i, value := range patternValues {
+				values[start+i] = max(values[start+i], value)
+			}
+		}
+	}
+
+	var parts []string
+
+	lastBreak := 0
+
+	for i := h.minLeft; i <= len(original)-h.minRight; i++ {
+		// The value before letter i of the word is at i+1 of the values, as
+		// the word is offset by the leading period
+		if values[i+1]%2 == 1 && unicode.IsLetter(original[i-1]) && unicode.IsLetter(original[i]) {
+			parts = append(parts, string(original[lastBreak:i]))
+			lastBreak = i
+		}
+	}
+
+	return append(parts, string(original[lastBreak:]))
+}
+
+// splitLike splits a word into parts of the same lengths as the given parts.
+func splitLike(word []rune, parts []string) []string {
+	split := make([]string, 0, len(parts))
+
+	start := 0
+	for _, part := range parts {
+		end := min(start+len([]rune(part)), len(word))
+
+		split = append(split, string(word[start:end]))
+		start = end
+	}
+
+	return split
+}
+
+// stripComments removes the comments from TeX data.
+func stripComments(data string) string {
+	lines := strings.Split(data, "\n")
+
+	for i, line := range lines {
+		if index := strings.Index(line, "%"); index >= 0 {
+			lines[i] = line[:index]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// texGroup returns the contents of the braced group following the given TeX
+// command, and whether the command was found.
+func texGroup(data string, command string) (string, bool) {
+	index := strings.Index(data, command+"{")
+	if index < 0 {
+		return "", false
+	}
+
+	contents := data[index+len(command)+1:]
+
+	if end := strings.Index(contents, "}"); end >= 0 {
+		contents = contents[:end]
+	}
+
+	return contents, true
+}
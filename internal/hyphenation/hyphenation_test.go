@@ -0,0 +1,63 @@
+package hyphenation
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testPatterns are the patterns from Liang's thesis, which hyphenate the word
+// "hyphenation", along with a couple of others and an exception.
+const testPatterns = `
+% Patterns from "Word Hy-phen-a-tion by Com-put-er"
+\patterns{
+hy3ph he2n hena4 hen5at 1na n2at 1tio 2io o2n
+}
+\hyphenation{
+ta-ble
+}
+`
+
+func TestHyphenate(t *testing.T) {
+	hyphenator, err := Parse(testPatterns)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+
+	testData := map[string]struct {
+		word string
+		want []string
+	}{
+		"patterns":          {word: "hyphenation", want: []string{"hy", "phen", "ation"}},
+		"keeps case":        {word: "Hyphenation", want: []string{"Hy", "phen", "ation"}},
+		"exception":         {word: "table", want: []string{"ta", "ble"}},
+		"too short":         {word: "tab", want: []string{"tab"}},
+		"no matching break": {word: "word", want: []string{"word"}},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			if got := hyphenator.Hyphenate(testData.word); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Hyphenate returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestParsePlain(t *testing.T) {
+	hyphenator, err := Parse("hy3ph he2n hena4 hen5at 1na n2at 1tio 2io o2n\n")
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+
+	want := []string{"hy", "phen", "ation"}
+
+	if got := hyphenator.Hyphenate("hyphenation"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Hyphenate returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	if _, err := Parse("% only a comment\n"); err == nil {
+		t.Error("Parse returned no error for data without patterns")
+	}
+}
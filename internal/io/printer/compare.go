@@ -0,0 +1,89 @@
+package printer
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/source"
+)
+
+// uncategorizedHeader is the header that definitions without a lexical
+// category are compared under.
+const uncategorizedHeader = "(uncategorized)"
+
+// comparedCategory defines the structure of the definitions of a lexical
+// category, as defined by each of the compared results.
+type comparedCategory struct {
+	name string
+
+	// definitions holds the definitions of each compared result, in the same
+	// order as the compared results
+	definitions [][]string
+}
+
+// PrintComparison prints the results of multiple sources side-by-side, by
+// listing each source's definitions under each lexical category (part of
+// speech) that any of the sources define.
+func (p *ResultPrinter) PrintComparison(results []Result) {
+	p.out.IndentWrites(func(writer *defineio.PanicWriter) {
+		for _, category := range compareDefinitions(results) {
+			writer.WritePaddedStringLine(category.name, 1)
+
+			writer.IndentWrites(func(writer *defineio.PanicWriter) {
+				for i, result := range results {
+					writer.WriteStringLine(result.Source)
+
+					writer.IndentWrites(func(writer *defineio.PanicWriter) {
+						if len(category.definitions[i]) < 1 {
+							writer.WriteStringLine("(no definitions)")
+						}
+
+						for index, definition := range category.definitions[i] {
+							writer.WriteStringLine(fmt.Sprintf("%d. %s", index+1, definition))
+						}
+					})
+				}
+			})
+		}
+
+		writer.WriteNewLine()
+	})
+}
+
+// compareDefinitions groups the top-level definitions of each of the results
+// by their part of speech (or lexical category, if unknown), in the order that the categories are first found.
+func compareDefinitions(results []Result) []comparedCategory {
+	var categories []comparedCategory
+	categoryIndices := make(map[string]int)
+
+	for i, result := range results {
+		for _, dictionaryResult := range result.DictionaryResults {
+			for _, entry := range dictionaryResult.Entries {
+				// Group by the normalized part of speech, so that differing
+				// labels (like "Verb" and "transitive verb") are compared
+				name := cmp.Or(string(entry.PartOfSpeech), strings.ToLower(entry.LexicalCategory), uncategorizedHeader)
+
+				index, exists := categoryIndices[name]
+				if !exists {
+					index = len(categories)
+					categoryIndices[name] = index
+
+					categories = append(categories, comparedCategory{
+						name:        name,
+						definitions: make([][]string, len(results)),
+					})
+				}
+
+				for _, sense := range entry.Senses {
+					if definition := source.FirstDefinition([]source.Sense{sense}); definition != "" {
+						categories[index].definitions[i] = append(categories[index].definitions[i], definition)
+					}
+				}
+			}
+		}
+	}
+
+	return categories
+}
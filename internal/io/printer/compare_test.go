@@ -0,0 +1,44 @@
+package printer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestCompareDefinitions(t *testing.T) {
+	results := []Result{
+		{
+			Source: "First",
+			DictionaryResults: source.DictionaryResults{{Entries: []source.DictionaryEntry{
+				{Entry: source.Entry{LexicalCategory: "Noun"}, Senses: []source.Sense{
+					{Definitions: []string{"a procedure"}},
+					{SubSenses: []source.Sense{{Definitions: []string{"an  exam"}}}},
+				}},
+				{Entry: source.Entry{LexicalCategory: "Verb", PartOfSpeech: source.PartOfSpeechVerb}, Senses: []source.Sense{{Definitions: []string{"to try"}}}},
+			}}},
+		},
+		{
+			Source: "Second",
+			DictionaryResults: source.DictionaryResults{{Entries: []source.DictionaryEntry{
+				{Entry: source.Entry{LexicalCategory: "noun"}, Senses: []source.Sense{{Definitions: []string{"a trial"}}}},
+				{
+					Entry:  source.Entry{LexicalCategory: "transitive verb", PartOfSpeech: source.PartOfSpeechVerb},
+					Senses: []source.Sense{{Definitions: []string{"to examine"}}},
+				},
+				{Senses: []source.Sense{{Definitions: []string{"a test"}}}},
+			}}},
+		},
+	}
+
+	want := []comparedCategory{
+		{name: "noun", definitions: [][]string{{"a procedure", "an exam"}, {"a trial"}}},
+		{name: "verb", definitions: [][]string{{"to try"}, {"to examine"}}},
+		{name: uncategorizedHeader, definitions: [][]string{nil, {"a test"}}},
+	}
+
+	if got := compareDefinitions(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("compareDefinitions returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+}
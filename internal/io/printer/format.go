@@ -0,0 +1,47 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// List of output formats.
+const (
+	FormatText      Format = "text"
+	FormatJSON      Format = "json"
+	FormatOneLine   Format = "one-line"
+	FormatPorcelain Format = "porcelain"
+)
+
+// Format defines an output format for printing.
+type Format string
+
+// Result defines the structure of the complete result of a define operation,
+// for printing in structured formats.
+type Result struct {
+	Word   string
+	Source string
+
+	// ShowingResultsFor is the word that the results are actually for, if
+	// the source redirected the looked up word to a different one
+	ShowingResultsFor string `json:",omitempty"`
+
+	DictionaryResults source.DictionaryResults  `json:",omitempty"`
+	SearchResults     source.SearchResults      `json:",omitempty"`
+	RelatedWords      source.RelatedWords       `json:",omitempty"`
+	RelatedWordGroups []source.RelatedWordGroup `json:",omitempty"`
+	Summary           *source.Summary           `json:",omitempty"`
+}
+
+// ParseFormat takes a string and returns the matching Format, or an error if
+// no Format matches.
+func ParseFormat(format string) (Format, error) {
+	switch parsed := Format(strings.ToLower(format)); parsed {
+	case FormatText, FormatJSON, FormatOneLine, FormatPorcelain:
+		return parsed, nil
+	}
+
+	return FormatText, fmt.Errorf("unknown output format %q", format)
+}
@@ -0,0 +1,29 @@
+package printer
+
+import (
+	"encoding/json"
+
+	defineio "github.com/Rican7/define/internal/io"
+)
+
+// JSONPrinter is a printer for Result structures, in the JSON format.
+type JSONPrinter struct {
+	out *defineio.PanicWriter
+}
+
+// NewJSONPrinter creates a new JSONPrinter.
+func NewJSONPrinter(out *defineio.PanicWriter) *JSONPrinter {
+	return &JSONPrinter{out: out}
+}
+
+// PrintResult prints a Result as a JSON document.
+func (p *JSONPrinter) PrintResult(result Result) error {
+	encoded, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	p.out.WriteStringLine(string(encoded))
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/source"
+)
+
+// OneLinePrinter is a printer for Result structures, that summarizes a result
+// on a single line, for use in status bars, modelines, and the like.
+type OneLinePrinter struct {
+	out *defineio.PanicWriter
+}
+
+// NewOneLinePrinter creates a new OneLinePrinter.
+func NewOneLinePrinter(out *defineio.PanicWriter) *OneLinePrinter {
+	return &OneLinePrinter{out: out}
+}
+
+// PrintResult prints a Result as a single line, in the format of:
+// "word (lexical category): first definition"
+func (p *OneLinePrinter) PrintResult(result Result) error {
+	p.out.WriteStringLine(Summarize(result))
+
+	return nil
+}
+
+// Summarize returns a single line summary of a Result.
+func Summarize(result Result) string {
+	if len(result.DictionaryResults) < 1 {
+		if summary := result.Summary; summary != nil {
+			if summary.Description != "" {
+				return fmt.Sprintf("%s: %s", summary.Title, summary.Description)
+			}
+
+			return fmt.Sprintf("%s: %s", summary.Title, strings.Join(strings.Fields(summary.Text), " "))
+		}
+
+		if len(result.RelatedWords) > 0 {
+			return fmt.Sprintf("%s: %s", result.Word, joinRelatedWords(result.RelatedWords))
+		}
+
+		if len(result.RelatedWordGroups) > 0 {
+			groups := make([]string, 0, len(result.RelatedWordGroups))
+			for _, group := range result.RelatedWordGroups {
+				groups = append(groups, fmt.Sprintf("%s: %s", strings.ToLower(group.Name), joinRelatedWords(group.Words)))
+			}
+
+			return fmt.Sprintf("%s: %s", result.Word, strings.Join(groups, "; "))
+		}
+
+		if len(result.SearchResults) < 1 {
+			return fmt.Sprintf("%s: no results", result.Word)
+		}
+
+		suggestions := make([]string, 0, len(result.SearchResults))
+		for _, searchResult := range result.SearchResults {
+			suggestions = append(suggestions, string(searchResult))
+		}
+
+		return fmt.Sprintf("%s: did you mean %s?", result.Word, strings.Join(suggestions, ", "))
+	}
+
+	word := result.DictionaryResults[0].Word
+
+	for _, dictionaryResult := range result.DictionaryResults {
+		for _, entry := range dictionaryResult.Entries {
+			definition := source.FirstDefinition(entry.Senses)
+
+			if definition == "" {
+				continue
+			}
+
+			if entry.LexicalCategory == "" {
+				return fmt.Sprintf("%s: %s", entry.Word, definition)
+			}
+
+			return fmt.Sprintf("%s (%s): %s", entry.Word, entry.LexicalCategory, definition)
+		}
+	}
+
+	return fmt.Sprintf("%s: no definitions", word)
+}
+
+// joinRelatedWords returns a comma separated list of related words.
+func joinRelatedWords(relatedWords source.RelatedWords) string {
+	words := make([]string, 0, len(relatedWords))
+	for _, relatedWord := range relatedWords {
+		words = append(words, relatedWord.Word)
+	}
+
+	return strings.Join(words, ", ")
+}
@@ -0,0 +1,110 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestSummarize(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		result Result
+		want   string
+	}{
+		"empty": {
+			result: Result{Word: "test"},
+			want:   "test: no results",
+		},
+		"search results": {
+			result: Result{Word: "tset", SearchResults: source.SearchResults{"test", "tsetse"}},
+			want:   "tset: did you mean test, tsetse?",
+		},
+		"related words": {
+			result: Result{
+				Word:         "fear of spiders",
+				RelatedWords: source.RelatedWords{{Word: "arachnophobia", Gloss: "an abnormal fear of spiders"}, {Word: "phobia"}},
+			},
+			want: "fear of spiders: arachnophobia, phobia",
+		},
+		"related word groups": {
+			result: Result{
+				Word: "tea",
+				RelatedWordGroups: []source.RelatedWordGroup{
+					{Name: "Adjectives", Words: source.RelatedWords{{Word: "green"}, {Word: "hot"}}},
+					{Name: "Followed by", Words: source.RelatedWords{{Word: "party"}}},
+				},
+			},
+			want: "tea: adjectives: green, hot; followed by: party",
+		},
+		"summary": {
+			result: Result{
+				Word:    "Oxford",
+				Summary: &source.Summary{Title: "Oxford", Description: "City in England", Text: "Oxford is a city."},
+			},
+			want: "Oxford: City in England",
+		},
+		"summary without description": {
+			result: Result{
+				Word:    "Oxford",
+				Summary: &source.Summary{Title: "Oxford", Text: "Oxford is\na city."},
+			},
+			want: "Oxford: Oxford is a city.",
+		},
+		"no definitions": {
+			result: Result{
+				Word: "test",
+				DictionaryResults: source.DictionaryResults{
+					{Word: "test", Entries: []source.DictionaryEntry{{Entry: source.Entry{Word: "test"}}}},
+				},
+			},
+			want: "test: no definitions",
+		},
+		"definition without category": {
+			result: Result{
+				Word: "test",
+				DictionaryResults: source.DictionaryResults{
+					{Word: "test", Entries: []source.DictionaryEntry{{
+						Entry:  source.Entry{Word: "test"},
+						Senses: []source.Sense{{Definitions: []string{"a trial"}}},
+					}}},
+				},
+			},
+			want: "test: a trial",
+		},
+		"definition with category": {
+			result: Result{
+				Word: "test",
+				DictionaryResults: source.DictionaryResults{
+					{Word: "test", Entries: []source.DictionaryEntry{
+						{Entry: source.Entry{Word: "test", LexicalCategory: "verb"}},
+						{
+							Entry:  source.Entry{Word: "test", LexicalCategory: "noun"},
+							Senses: []source.Sense{{Definitions: []string{"a  trial\nof sorts"}}},
+						},
+					}},
+				},
+			},
+			want: "test (noun): a trial of sorts",
+		},
+		"sub-sense definition": {
+			result: Result{
+				Word: "test",
+				DictionaryResults: source.DictionaryResults{
+					{Word: "test", Entries: []source.DictionaryEntry{{
+						Entry: source.Entry{Word: "test", LexicalCategory: "noun"},
+						Senses: []source.Sense{{
+							SubSenses: []source.Sense{{Definitions: []string{"a trial"}}},
+						}},
+					}}},
+				},
+			},
+			want: "test (noun): a trial",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := Summarize(testData.result); got != testData.want {
+				t.Errorf("Summarize returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
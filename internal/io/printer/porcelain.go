@@ -0,0 +1,115 @@
+package printer
+
+import (
+	"strings"
+
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/source"
+)
+
+// List of porcelain record types, which are the first field of each line.
+const (
+	porcelainRedirect   = "redirect"
+	porcelainDefinition = "definition"
+	porcelainSuggestion = "suggestion"
+	porcelainRelated    = "related"
+	porcelainSummary    = "summary"
+	porcelainSource     = "source"
+)
+
+// porcelainFieldSeparator is the separator of the fields of porcelain lines
+const porcelainFieldSeparator = "\t"
+
+// PorcelainPrinter is a printer for Result structures, that prints a stable,
+// easily parsed format, for use in scripts and shell pipelines.
+//
+// Each line is a record of tab-separated fields, with the first field being
+// the type of the record:
+//
+//	redirect	<looked up word>	<word shown>
+//	definition	<word>	<lexical category>	<definition>
+//	suggestion	<word>
+//	related	<word>	<lexical category>	<gloss>	<group name>
+//	summary	<title>	<description>	<text>	<article URL>
+//	source	<source name>
+//
+// There are no blank lines, headers, or separators, and any whitespace within
+// a field is collapsed to a single space.
+type PorcelainPrinter struct {
+	out *defineio.PanicWriter
+}
+
+// NewPorcelainPrinter creates a new PorcelainPrinter.
+func NewPorcelainPrinter(out *defineio.PanicWriter) *PorcelainPrinter {
+	return &PorcelainPrinter{out: out}
+}
+
+// PrintResult prints a Result in the porcelain format.
+func (p *PorcelainPrinter) PrintResult(result Result) error {
+	if result.ShowingResultsFor != "" {
+		p.printRecord(porcelainRedirect, result.Word, result.ShowingResultsFor)
+	}
+
+	for _, dictionaryResult := range result.DictionaryResults {
+		for _, entry := range dictionaryResult.Entries {
+			p.printSenses(entry, entry.Senses)
+		}
+	}
+
+	for _, searchResult := range result.SearchResults {
+		p.printRecord(porcelainSuggestion, string(searchResult))
+	}
+
+	p.printRelatedWords(result.RelatedWords, "")
+
+	for _, group := range result.RelatedWordGroups {
+		p.printRelatedWords(group.Words, group.Name)
+	}
+
+	if summary := result.Summary; summary != nil {
+		var articleURL string
+		if len(summary.Attribution.SourceURLs) > 0 {
+			articleURL = summary.Attribution.SourceURLs[0]
+		}
+
+		p.printRecord(porcelainSummary, summary.Title, summary.Description, summary.Text, articleURL)
+	}
+
+	if result.Source != "" {
+		p.printRecord(porcelainSource, result.Source)
+	}
+
+	return nil
+}
+
+// printSenses prints a definition record for each definition of a list of
+// senses (and their sub-senses).
+func (p *PorcelainPrinter) printSenses(entry source.DictionaryEntry, senses []source.Sense) {
+	for _, sense := range senses {
+		for _, definition := range sense.Definitions {
+			p.printRecord(porcelainDefinition, entry.Word, entry.LexicalCategory, definition)
+		}
+
+		p.printSenses(entry, sense.SubSenses)
+	}
+}
+
+// printRelatedWords prints a related record for each of a list of related
+// words.
+func (p *PorcelainPrinter) printRelatedWords(relatedWords source.RelatedWords, groupName string) {
+	for _, relatedWord := range relatedWords {
+		p.printRecord(porcelainRelated, relatedWord.Word, relatedWord.LexicalCategory, relatedWord.Gloss, groupName)
+	}
+}
+
+// printRecord prints a single record line of a given type and fields.
+func (p *PorcelainPrinter) printRecord(recordType string, fields ...string) {
+	cleaned := make([]string, 0, len(fields)+1)
+	cleaned = append(cleaned, recordType)
+
+	for _, field := range fields {
+		cleaned = append(cleaned, strings.Join(strings.Fields(field), " "))
+	}
+
+	p.out.WriteStringLine(strings.Join(cleaned, porcelainFieldSeparator))
+}
@@ -0,0 +1,107 @@
+package printer
+
+import (
+	"strings"
+	"testing"
+
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/source"
+)
+
+func TestPorcelainPrinter_PrintResult(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		result Result
+		want   string
+	}{
+		"empty": {
+			result: Result{Word: "test"},
+			want:   "",
+		},
+		"definitions": {
+			result: Result{
+				Word:   "test",
+				Source: "Test Source",
+				DictionaryResults: source.DictionaryResults{
+					{
+						Word: "test",
+						Entries: []source.DictionaryEntry{
+							{
+								Entry: source.Entry{Word: "test", LexicalCategory: "noun"},
+								Senses: []source.Sense{
+									{
+										Definitions: []string{"a procedure\tto establish\nquality"},
+										SubSenses:   []source.Sense{{Definitions: []string{"an exam"}}},
+									},
+								},
+							},
+							{
+								Entry:  source.Entry{Word: "test"},
+								Senses: []source.Sense{{Definitions: []string{"to try"}}},
+							},
+						},
+					},
+				},
+			},
+			want: "definition\ttest\tnoun\ta procedure to establish quality\n" +
+				"definition\ttest\tnoun\tan exam\n" +
+				"definition\ttest\t\tto try\n" +
+				"source\tTest Source\n",
+		},
+		"redirected": {
+			result: Result{
+				Word:              "running",
+				ShowingResultsFor: "run",
+				DictionaryResults: source.DictionaryResults{
+					{
+						Word: "run",
+						Entries: []source.DictionaryEntry{
+							{
+								Entry:  source.Entry{Word: "run", LexicalCategory: "verb"},
+								Senses: []source.Sense{{Definitions: []string{"move at speed"}}},
+							},
+						},
+					},
+				},
+			},
+			want: "redirect\trunning\trun\ndefinition\trun\tverb\tmove at speed\n",
+		},
+		"search results": {
+			result: Result{Word: "tset", SearchResults: source.SearchResults{"test", "tsetse"}},
+			want:   "suggestion\ttest\nsuggestion\ttsetse\n",
+		},
+		"related words": {
+			result: Result{
+				Word:              "tea",
+				RelatedWords:      source.RelatedWords{{Word: "tee", LexicalCategory: "noun", Gloss: "a peg"}},
+				RelatedWordGroups: []source.RelatedWordGroup{{Name: "Followers", Words: source.RelatedWords{{Word: "party"}}}},
+			},
+			want: "related\ttee\tnoun\ta peg\t\nrelated\tparty\t\t\tFollowers\n",
+		},
+		"summary": {
+			result: Result{
+				Word:   "Oxford",
+				Source: "Wikipedia",
+				Summary: &source.Summary{
+					Title:       "Oxford",
+					Description: "City in England",
+					Text:        "Oxford is a city.\nIt has a university.",
+					Attribution: source.ResultAttribution{SourceURLs: []string{"https://en.wikipedia.org/wiki/Oxford"}},
+				},
+			},
+			want: "summary\tOxford\tCity in England\tOxford is a city. It has a university.\thttps://en.wikipedia.org/wiki/Oxford\n" +
+				"source\tWikipedia\n",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var out strings.Builder
+
+			if err := NewPorcelainPrinter(defineio.NewPanicWriter(&out, 2)).PrintResult(testData.result); err != nil {
+				t.Fatalf("PrintResult returned an error: %s", err)
+			}
+
+			if got := out.String(); got != testData.want {
+				t.Errorf("PrintResult printed wrong value. Got %q. Want %q.", got, testData.want)
+			}
+		})
+	}
+}
@@ -5,39 +5,94 @@
 package printer
 
 import (
+	"cmp"
 	"fmt"
-	"math"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	defineio "github.com/Rican7/define/internal/io"
 	"github.com/Rican7/define/source"
 )
 
 const (
+	defaultSourceFooterSeparator = "-"
+	defaultSourceFooterWidth     = 60
+
+	// trendWidth is the maximum number of characters of a usage trend's
+	// sparkline, each of which averages a span of years
+	trendWidth = 22
+
 	etymologyHeader = "Origin"
 	synonymHeader   = "Synonyms"
 	antonymHeader   = "Antonyms"
+	seeAlsoHeader   = "See also"
+	usageHeader     = "Usage"
+	confusedHeader  = "Often confused with"
 )
 
 // ResultPrinter is a printer for source.Result structures.
 type ResultPrinter struct {
-	out *defineio.PanicWriter
+	out     *defineio.PanicWriter
+	options Options
+}
+
+// Options defines the options for printing results.
+type Options struct {
+	ShowSyllables bool
+
+	// ShowInflections shows the inflected forms of entries (like plurals)
+	ShowInflections bool
+
+	// SimpleSenseNumbering numbers senses sequentially, instead of using the
+	// source's own labels for them (like "1a" or "(2)")
+	SimpleSenseNumbering bool
+
+	// MaxSenseDepth is the maximum depth of (sub-)senses to print, with 1
+	// being only the top-level senses. A depth of 0 prints all of them.
+	MaxSenseDepth uint
+
+	HideSourceFooter      bool
+	SourceFooterSeparator string // Defaults to a hyphen, if empty
+	SourceFooterWidth     uint   // The maximum width of the separator, if set
+}
+
+// Named defines an interface for anything with a printable, human-readable
+// name, such as a source.Source.
+type Named interface {
+	Name() string
 }
 
 // NewResultPrinter creates a new ResultPrinter.
-func NewResultPrinter(out *defineio.PanicWriter) *ResultPrinter {
-	return &ResultPrinter{out: out}
+func NewResultPrinter(out *defineio.PanicWriter, options Options) *ResultPrinter {
+	return &ResultPrinter{out: out, options: options}
 }
 
-// PrintSourceName prints the name of a source.Source.
-func (p *ResultPrinter) PrintSourceName(src source.Source) {
+// PrintSourceName prints the name of a source (usually a source.Source), along
+// with any given attributions of the source's results.
+//
+// Nothing is printed if the printer's options hide the source footer.
+func (p *ResultPrinter) PrintSourceName(src Named, attributions ...source.ResultAttribution) {
+	if p.options.HideSourceFooter {
+		return
+	}
+
+	separator := cmp.Or(p.options.SourceFooterSeparator, defaultSourceFooterSeparator)
+	maxWidth := cmp.Or(p.options.SourceFooterWidth, defaultSourceFooterWidth)
+
 	p.out.IndentWrites(func(writer *defineio.PanicWriter) {
 		text := fmt.Sprintf("Results provided by: %q", src.Name())
-		separatorSize := int(math.Min(float64(60), float64(len(text))))
+		separatorSize := min(int(maxWidth), utf8.RuneCountInString(text))
 
 		writer.WriteNewLine()
-		writer.WriteStringLine(strings.Repeat("-", separatorSize))
+		writer.WriteStringLine(strings.Repeat(separator, separatorSize))
 		writer.WriteStringLine(text)
+
+		for _, attribution := range attributions {
+			printAttribution(writer, attribution)
+		}
+
 		writer.WriteNewLine()
 	})
 }
@@ -48,12 +103,20 @@ func (p *ResultPrinter) PrintDictionaryResults(results source.DictionaryResults)
 		var lastWord string
 
 		for _, result := range results {
-			resultHeader := getHeader(result)
-			writer.WritePaddedStringLine(resultHeader, 1)
+			resultHeader := p.getHeader(result)
+
+			writer.WriteNewLine()
+			writer.WriteStringLine(withFrequency(resultHeader, result.Frequency))
+
+			if result.Trend != nil {
+				writer.WriteStringLine(formatTrend(*result.Trend))
+			}
+
+			writer.WriteNewLine()
 
 			var lastEntryHeader string
 			for _, entry := range result.Entries {
-				if entryHeader := getEntryHeader(resultHeader, lastEntryHeader, lastWord, entry); entryHeader != "" {
+				if entryHeader := p.getEntryHeader(resultHeader, lastEntryHeader, lastWord, entry); entryHeader != "" {
 					writer.WriteNewLine()
 					writer.WriteNewLine()
 					writer.WriteStringLine(entryHeader)
@@ -62,12 +125,14 @@ func (p *ResultPrinter) PrintDictionaryResults(results source.DictionaryResults)
 				}
 
 				writer.IndentWrites(func(writer *defineio.PanicWriter) {
-					printDictionaryEntry(writer, entry)
+					printDictionaryEntry(writer, entry, p.options)
 				})
 
 				lastWord = entry.Word
 			}
 
+			printConfusables(writer, result.Confusables)
+
 			writer.WriteNewLine()
 		}
 	})
@@ -82,13 +147,130 @@ func (p *ResultPrinter) PrintSearchResults(results source.SearchResults) {
 	})
 }
 
-func printDictionaryEntry(writer *defineio.PanicWriter, entry source.DictionaryEntry) {
+// PrintSummary prints an encyclopedic summary, under its title and description
+func (p *ResultPrinter) PrintSummary(summary source.Summary) {
+	p.out.IndentWrites(func(writer *defineio.PanicWriter) {
+		header := summary.Title
+		if summary.Description != "" {
+			header = fmt.Sprintf("%s (%s)", header, summary.Description)
+		}
+
+		writer.WritePaddedStringLine(header, 1)
+
+		for _, paragraph := range strings.Split(summary.Text, "\n") {
+			if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+				writer.WriteStringLine(paragraph)
+			}
+		}
+
+		if summary.IsDisambiguation {
+			writer.WriteNewLine()
+			writer.WriteStringLine("This term may refer to several subjects, which are listed in the full article.")
+		}
+	})
+}
+
+// PrintEtymologyTrees prints a list of etymology trees, each as its word with
+// the words that it comes from indented beneath it
+func (p *ResultPrinter) PrintEtymologyTrees(trees []source.Etymon) {
+	p.out.IndentWrites(func(writer *defineio.PanicWriter) {
+		for _, tree := range trees {
+			writer.WriteNewLine()
+			writer.WriteStringLine(fmt.Sprintf("%s (%s)", tree.Word, tree.Language))
+
+			printEtymons(writer, tree.Etymons, "")
+		}
+
+		writer.WriteNewLine()
+	})
+}
+
+// printEtymons prints the branches of an etymology tree, recursively, with
+// each line prefixed by the lines of the branches that it's nested in.
+func printEtymons(writer *defineio.PanicWriter, etymons []source.Etymon, prefix string) {
+	for i, etymon := range etymons {
+		branch, nested := "├── ", "│   "
+		if i == len(etymons)-1 {
+			branch, nested = "└── ", "    "
+		}
+
+		writer.WriteStringLine(prefix + branch + formatEtymon(etymon))
+
+		printEtymons(writer, etymon.Etymons, prefix+nested)
+	}
+}
+
+// formatEtymon returns a printable line of a word in an etymology, like
+// `Proto-Germanic *wurdą "word" (inherited)`.
+func formatEtymon(etymon source.Etymon) string {
+	text := etymon.Language
+
+	if etymon.Word != "" {
+		text += " " + etymon.Word
+	}
+
+	if etymon.Gloss != "" {
+		text += fmt.Sprintf(" %q", etymon.Gloss)
+	}
+
+	if etymon.Relation != "" {
+		text += fmt.Sprintf(" (%s)", etymon.Relation)
+	}
+
+	return text
+}
+
+// PrintRelatedWords prints a list of related words, along with their glosses
+func (p *ResultPrinter) PrintRelatedWords(results source.RelatedWords) {
+	p.out.IndentWrites(func(writer *defineio.PanicWriter) {
+		printRelatedWords(writer, results)
+	})
+}
+
+// PrintRelatedWordGroups prints a list of related word groups, as lists under
+// the names of the groups
+func (p *ResultPrinter) PrintRelatedWordGroups(groups []source.RelatedWordGroup) {
+	p.out.IndentWrites(func(writer *defineio.PanicWriter) {
+		for _, group := range groups {
+			writer.WriteStringLine(group.Name + ":")
+
+			writer.IndentWrites(func(writer *defineio.PanicWriter) {
+				printRelatedWords(writer, group.Words)
+			})
+
+			writer.WriteNewLine()
+		}
+	})
+}
+
+func printRelatedWords(writer *defineio.PanicWriter, results source.RelatedWords) {
+	for index, result := range results {
+		text := result.Word
+
+		if result.LexicalCategory != "" {
+			text += fmt.Sprintf(" (%s)", result.LexicalCategory)
+		}
+
+		if result.Gloss != "" {
+			text += ": " + result.Gloss
+		}
+
+		writer.WriteStringLine(fmt.Sprintf("%d. %s", index+1, text))
+	}
+}
+
+func printDictionaryEntry(writer *defineio.PanicWriter, entry source.DictionaryEntry, options Options) {
 	if entry.LexicalCategory != "" {
 		writer.WritePaddedStringLine(fmt.Sprintf("(%s)", entry.LexicalCategory), 1)
 	}
 
+	if options.ShowInflections && len(entry.Inflections) > 0 {
+		writer.WriteStringLine(formatInflections(entry.Inflections))
+		writer.WriteNewLine()
+	}
+
 	for senseIndex, sense := range entry.Senses {
-		prefix := fmt.Sprintf("%d. ", senseIndex+1)
+		prefix := fmt.Sprintf("%s. ", senseLabel(sense, strconv.Itoa(senseIndex+1), options))
 
 		for defIndex, definition := range sense.Definitions {
 			// Change the prefix after the first definition
@@ -102,6 +284,7 @@ func printDictionaryEntry(writer *defineio.PanicWriter, entry source.DictionaryE
 			}
 
 			writer.WriteStringLine(prefix + definition)
+			printTranslation(writer, sense, defIndex, prefix)
 		}
 
 		writer.IndentWritesBy(uint(len(prefix)), func(writer *defineio.PanicWriter) {
@@ -114,30 +297,119 @@ func printDictionaryEntry(writer *defineio.PanicWriter, entry source.DictionaryE
 			}
 		})
 
-		writer.IndentWrites(func(writer *defineio.PanicWriter) {
-			for _, subSense := range sense.SubSenses {
-				prefix := " - "
+		if options.MaxSenseDepth == 0 || options.MaxSenseDepth > 1 {
+			printSubSenses(writer, sense.SubSenses, 2, options)
+		}
+	}
+
+	printEtymologies(writer, entry)
+	printEntryNotes(writer, entry)
+	printThesaurusValues(writer, entry.ThesaurusValues)
+	printSeeAlso(writer, entry)
+}
 
-				if len(subSense.Categories) > 0 {
-					writer.WriteStringLine(prefix + fmt.Sprintf("(%s)", strings.Join(subSense.Categories, " - ")))
-					prefix = strings.Repeat(" ", len(prefix))
+// printSubSenses prints a list of sub-senses at a given depth, and their own
+// sub-senses recursively (with increasing indentation), up to the maximum
+// sense depth of the options.
+func printSubSenses(writer *defineio.PanicWriter, subSenses []source.Sense, depth uint, options Options) {
+	writer.IndentWrites(func(writer *defineio.PanicWriter) {
+		for _, subSense := range subSenses {
+			prefix := " - "
+
+			if label := senseLabel(subSense, "", options); label != "" {
+				prefix = fmt.Sprintf(" %s. ", label)
+			}
+
+			if len(subSense.Categories) > 0 {
+				writer.WriteStringLine(prefix + fmt.Sprintf("(%s)", strings.Join(subSense.Categories, " - ")))
+				prefix = strings.Repeat(" ", len(prefix))
+			}
+
+			for defIndex, definition := range subSense.Definitions {
+				writer.WriteStringLine(prefix + definition)
+				printTranslation(writer, subSense, defIndex, prefix)
+			}
+
+			writer.IndentWritesBy(uint(len(prefix)), func(writer *defineio.PanicWriter) {
+				for _, example := range subSense.Examples {
+					writer.WriteStringLine(example.String())
 				}
 
-				for _, definition := range subSense.Definitions {
-					writer.WriteStringLine(prefix + definition)
+				for _, note := range subSense.Notes {
+					writer.WriteStringLine(fmt.Sprintf("[%s]", note))
 				}
+			})
 
-				writer.IndentWritesBy(uint(len(prefix)), func(writer *defineio.PanicWriter) {
-					if len(subSense.Examples) > 0 {
-						writer.WriteStringLine(subSense.Examples[0].String())
-					}
-				})
+			if options.MaxSenseDepth == 0 || depth < options.MaxSenseDepth {
+				printSubSenses(writer, subSense.SubSenses, depth+1, options)
 			}
-		})
+		}
+	})
+}
+
+// printTranslation prints the translation of a sense's definition (by its
+// index) beneath it, if it has one, aligned with it after the given prefix.
+func printTranslation(writer *defineio.PanicWriter, sense source.Sense, defIndex int, prefix string) {
+	if defIndex >= len(sense.Translations) || sense.Translations[defIndex] == "" {
+		return
 	}
 
-	printEtymologies(writer, entry)
-	printThesaurusValues(writer, entry.ThesaurusValues)
+	writer.IndentWritesBy(uint(len(prefix)), func(writer *defineio.PanicWriter) {
+		writer.WriteStringLine("→ " + sense.Translations[defIndex])
+	})
+}
+
+// formatInflections returns a printable list of inflected forms, noting if
+// they were generated rather than provided by the source.
+func formatInflections(inflections []source.InflectedForm) string {
+	forms := make([]string, 0, len(inflections))
+	generated := true
+
+	for _, inflection := range inflections {
+		form := inflection.Form
+		if inflection.Label != "" {
+			form = fmt.Sprintf("%s (%s)", form, inflection.Label)
+		}
+
+		forms = append(forms, form)
+		generated = generated && inflection.Generated
+	}
+
+	header := "Forms"
+	if generated {
+		header = "Forms (generated)"
+	}
+
+	return fmt.Sprintf("%s: %s", header, strings.Join(forms, ", "))
+}
+
+// senseLabel returns the label to print for a sense, using the source's own
+// label unless simple numbering is enabled, or a given fallback label.
+func senseLabel(sense source.Sense, fallback string, options Options) string {
+	if sense.Label == "" || options.SimpleSenseNumbering {
+		return fallback
+	}
+
+	return sense.Label
+}
+
+func printAttribution(writer *defineio.PanicWriter, attribution source.ResultAttribution) {
+	if attribution.Provider != "" {
+		writer.WriteStringLine(fmt.Sprintf("Data from: %s", attribution.Provider))
+	}
+
+	switch {
+	case attribution.License != "" && attribution.LicenseURL != "":
+		writer.WriteStringLine(fmt.Sprintf("License: %s (%s)", attribution.License, attribution.LicenseURL))
+	case attribution.License != "":
+		writer.WriteStringLine(fmt.Sprintf("License: %s", attribution.License))
+	case attribution.LicenseURL != "":
+		writer.WriteStringLine(fmt.Sprintf("License: %s", attribution.LicenseURL))
+	}
+
+	for _, sourceURL := range attribution.SourceURLs {
+		writer.WriteStringLine(fmt.Sprintf("Source: %s", sourceURL))
+	}
 }
 
 func printEtymologies(writer *defineio.PanicWriter, entry source.DictionaryEntry) {
@@ -152,6 +424,64 @@ func printEtymologies(writer *defineio.PanicWriter, entry source.DictionaryEntry
 	}
 }
 
+func printSeeAlso(writer *defineio.PanicWriter, entry source.DictionaryEntry) {
+	references := seeAlsoReferences(entry.Senses)
+
+	if 0 < len(references) {
+		writer.WritePaddedStringLine(seeAlsoHeader, 1)
+
+		writer.WriteStringLine(strings.Join(references, " ; "))
+
+		writer.WriteNewLine()
+	}
+}
+
+// seeAlsoReferences returns the unique "see also" references of a list of
+// senses (and their sub-senses), in the order that they're first found.
+func seeAlsoReferences(senses []source.Sense) []string {
+	var references []string
+
+	for _, sense := range senses {
+		for _, reference := range sense.SeeAlso {
+			if text := reference.String(); !slices.Contains(references, text) {
+				references = append(references, text)
+			}
+		}
+
+		for _, text := range seeAlsoReferences(sense.SubSenses) {
+			if !slices.Contains(references, text) {
+				references = append(references, text)
+			}
+		}
+	}
+
+	return references
+}
+
+func printEntryNotes(writer *defineio.PanicWriter, entry source.DictionaryEntry) {
+	if 0 < len(entry.EntryNotes) {
+		writer.WritePaddedStringLine(usageHeader, 1)
+
+		for _, note := range entry.EntryNotes {
+			writer.WriteStringLine(note)
+		}
+
+		writer.WriteNewLine()
+	}
+}
+
+func printConfusables(writer *defineio.PanicWriter, confusables []source.Confusable) {
+	if 0 < len(confusables) {
+		writer.WritePaddedStringLine(confusedHeader, 1)
+
+		for _, confusable := range confusables {
+			writer.WriteStringLine(fmt.Sprintf("%s: %s", strings.Join(confusable.Words, ", "), confusable.Note))
+		}
+
+		writer.WriteNewLine()
+	}
+}
+
 func printThesaurusValues(writer *defineio.PanicWriter, values source.ThesaurusValues) {
 	if 0 < len(values.Synonyms) {
 		writer.WritePaddedStringLine(synonymHeader, 1)
@@ -170,22 +500,22 @@ func printThesaurusValues(writer *defineio.PanicWriter, values source.ThesaurusV
 	}
 }
 
-func getHeader(result source.DictionaryResult) string {
+func (p *ResultPrinter) getHeader(result source.DictionaryResult) string {
 	firstEntry := result.Entries[0]
 	header := firstEntry.Word
 
 	if len(firstEntry.Pronunciations) > 0 {
-		header = fmt.Sprintf("%s  %s", header, firstEntry.Pronunciations)
+		header = p.getPronunciationHeader(firstEntry)
 	}
 
 	return header
 }
 
-func getEntryHeader(resultHeader string, lastEntryHeader string, lastWord string, entry source.DictionaryEntry) string {
+func (p *ResultPrinter) getEntryHeader(resultHeader string, lastEntryHeader string, lastWord string, entry source.DictionaryEntry) string {
 	var header string
 
 	if len(entry.Pronunciations) > 0 {
-		header = fmt.Sprintf("%s  %s", entry.Word, entry.Pronunciations)
+		header = p.getPronunciationHeader(entry)
 	} else if entry.Word != lastWord {
 		header = entry.Word
 	}
@@ -196,3 +526,99 @@ func getEntryHeader(resultHeader string, lastEntryHeader string, lastWord string
 
 	return header
 }
+
+func (p *ResultPrinter) getPronunciationHeader(entry source.DictionaryEntry) string {
+	header := fmt.Sprintf("%s  %s", entry.Word, entry.Pronunciations)
+
+	if p.options.ShowSyllables && entry.Syllables != nil {
+		header = fmt.Sprintf("%s  [%s]", header, formatSyllables(*entry.Syllables))
+	}
+
+	return header
+}
+
+// withFrequency returns a header with the band of a frequency appended, if the
+// frequency is known.
+func withFrequency(header string, frequency *source.Frequency) string {
+	if frequency == nil || frequency.Band == "" {
+		return header
+	}
+
+	return fmt.Sprintf("%s  (%s)", header, frequency.Band)
+}
+
+// formatTrend returns a usage trend as a sparkline between its years, like
+// "Usage: 1800 ▁▂▃▅█ 2019".
+func formatTrend(trend source.UsageTrend) string {
+	return fmt.Sprintf("Usage: %d %s %d", trend.StartYear, sparkline(trend.Frequencies, trendWidth), trend.EndYear)
+}
+
+// sparkline returns a sparkline of a list of values, of up to the given width,
+// with each character averaging an equal span of the values.
+func sparkline(values []float64, width int) string {
+	const levels = "▁▂▃▄▅▆▇█"
+
+	width = min(width, len(values))
+	if width < 1 {
+		return ""
+	}
+
+	averages := make([]float64, width)
+
+	for i := range averages {
+		span := values[i*len(values)/width : (i+1)*len(values)/width]
+
+		for _, value := range span {
+			averages[i] += value / float64(len(span))
+		}
+	}
+
+	lowest, highest := slices.Min(averages), slices.Max(averages)
+	levelRunes := []rune(levels)
+
+	var builder strings.Builder
+
+	for _, average := range averages {
+		level := 0
+		if highest > lowest {
+			level = int((average - lowest) / (highest - lowest) * float64(len(levelRunes)-1))
+		}
+
+		builder.WriteRune(levelRunes[level])
+	}
+
+	return builder.String()
+}
+
+func formatSyllables(syllables source.Syllables) string {
+	text := fmt.Sprintf("%d syllables", syllables.Count)
+
+	if syllables.Count == 1 {
+		text = "1 syllable"
+	}
+
+	if syllables.PrimaryStress > 0 && syllables.Count > 1 {
+		text = fmt.Sprintf("%s, stress on %s", text, ordinal(syllables.PrimaryStress))
+	}
+
+	return text
+}
+
+func ordinal(number uint) string {
+	suffix := "th"
+
+	switch number % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+
+	if tens := number % 100; tens >= 11 && tens <= 13 {
+		suffix = "th"
+	}
+
+	return fmt.Sprintf("%d%s", number, suffix)
+}
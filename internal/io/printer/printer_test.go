@@ -0,0 +1,154 @@
+package printer
+
+import (
+	"strings"
+	"testing"
+
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/source"
+)
+
+func TestFormatInflections(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		inflections []source.InflectedForm
+		want        string
+	}{
+		"provided": {
+			inflections: []source.InflectedForm{{Form: "ran", Label: "past"}, {Form: "running"}},
+			want:        "Forms: ran (past), running",
+		},
+		"generated": {
+			inflections: []source.InflectedForm{{Form: "tests", Label: "plural", Generated: true}},
+			want:        "Forms (generated): tests (plural)",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := formatInflections(testData.inflections); got != testData.want {
+				t.Errorf("formatInflections returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestResultPrinter_PrintDictionaryResults_Confusables(t *testing.T) {
+	var out strings.Builder
+
+	results := source.DictionaryResults{
+		{
+			Word:        "affect",
+			Entries:     []source.DictionaryEntry{{Entry: source.Entry{Word: "affect"}, Senses: []source.Sense{{Definitions: []string{"to influence"}}}}},
+			Confusables: []source.Confusable{{Words: []string{"effect"}, Note: "a note"}},
+		},
+	}
+
+	NewResultPrinter(defineio.NewPanicWriter(&out, 2), Options{}).PrintDictionaryResults(results)
+
+	want := "  Often confused with  \n  \n  effect: a note  \n"
+
+	if got := out.String(); !strings.Contains(got, want) {
+		t.Errorf("PrintDictionaryResults printed wrong value. Got %q. Want it to contain %q.", got, want)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		values []float64
+		width  int
+		want   string
+	}{
+		"empty": {
+			values: nil,
+			width:  10,
+			want:   "",
+		},
+		"flat": {
+			values: []float64{2, 2, 2},
+			width:  10,
+			want:   "▁▁▁",
+		},
+		"rising": {
+			values: []float64{0, 1, 2, 3, 4, 5, 6, 7},
+			width:  10,
+			want:   "▁▂▃▄▅▆▇█",
+		},
+		"averaged": {
+			values: []float64{0, 0, 1, 1, 2, 2},
+			width:  3,
+			want:   "▁▄█",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := sparkline(testData.values, testData.width); got != testData.want {
+				t.Errorf("sparkline returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestResultPrinter_PrintEtymologyTrees(t *testing.T) {
+	var out strings.Builder
+
+	trees := []source.Etymon{
+		{
+			Word:     "handbook",
+			Language: "English",
+			Etymons: []source.Etymon{
+				{
+					Word:     "handbok",
+					Language: "Middle English",
+					Relation: "inherited",
+					Etymons: []source.Etymon{
+						{Word: "hand", Language: "Old English", Relation: "component"},
+						{Word: "bōc", Language: "Old English", Gloss: "book", Relation: "component"},
+					},
+				},
+			},
+		},
+	}
+
+	NewResultPrinter(defineio.NewPanicWriter(&out, 2), Options{}).PrintEtymologyTrees(trees)
+
+	want := "  handbook (English)  \n" +
+		"  └── Middle English handbok (inherited)  \n" +
+		"      ├── Old English hand (component)  \n" +
+		"      └── Old English bōc \"book\" (component)  \n"
+
+	if got := out.String(); !strings.Contains(got, want) {
+		t.Errorf("PrintEtymologyTrees printed wrong value. Got %q. Want it to contain %q.", got, want)
+	}
+}
+
+func TestResultPrinter_PrintDictionaryResults_Translations(t *testing.T) {
+	var out strings.Builder
+
+	results := source.DictionaryResults{
+		{
+			Word: "test",
+			Entries: []source.DictionaryEntry{
+				{
+					Entry: source.Entry{Word: "test"},
+					Senses: []source.Sense{
+						{
+							Definitions:  []string{"a procedure", "an exam"},
+							Translations: []string{"ein Verfahren", "eine Prüfung"},
+							SubSenses:    []source.Sense{{Definitions: []string{"a trial"}, Translations: []string{"ein Versuch"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	NewResultPrinter(defineio.NewPanicWriter(&out, 2), Options{}).PrintDictionaryResults(results)
+
+	want := "    1. a procedure    \n" +
+		"       → ein Verfahren       \n" +
+		"     - an exam    \n" +
+		"       → eine Prüfung       \n" +
+		"       - a trial      \n" +
+		"         → ein Versuch         \n"
+
+	if got := out.String(); !strings.Contains(got, want) {
+		t.Errorf("PrintDictionaryResults printed wrong value. Got %q. Want it to contain %q.", got, want)
+	}
+}
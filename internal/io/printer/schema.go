@@ -0,0 +1,12 @@
+package printer
+
+import (
+	_ "embed" // Needed for embedding the schema
+)
+
+// Schema is the JSON Schema of the Result structure, as printed by the
+// JSONPrinter, so that the JSON output can be validated or have types
+// generated from it by consumers.
+//
+//go:embed schema.json
+var Schema string
@@ -0,0 +1,259 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/source"
+)
+
+func TestSchema_ValidatesJSONOutput(t *testing.T) {
+	attribution := source.ResultAttribution{
+		Provider:   "Provider",
+		License:    "CC BY-SA 4.0",
+		LicenseURL: "https://example.com/license",
+		SourceURLs: []string{"https://example.com/test"},
+	}
+
+	sense := source.Sense{
+		ID:           "s1",
+		Label:        "1a",
+		Definitions:  []string{"a procedure to establish quality"},
+		Categories:   []string{"science"},
+		Examples:     []source.AttributedText{{Text: "a test", Attribution: source.Attribution{Author: "Author", Source: "Book", License: "PD", Language: "en"}}},
+		Notes:        []string{"a note"},
+		SeeAlso:      []source.CrossReference{{Word: "trial", EntryID: "e2", SenseNumber: "2"}},
+		Translations: []string{"eine Prüfung"},
+		ThesaurusValues: source.ThesaurusValues{
+			Synonyms: []string{"trial"},
+			Antonyms: []string{"guess"},
+		},
+		SubSenses: []source.Sense{{Definitions: []string{"an exam"}}},
+	}
+
+	for testName, testData := range map[string]struct {
+		result Result
+	}{
+		"empty": {
+			result: Result{},
+		},
+		"empty results": {
+			result: Result{Word: "test", Source: "Source", DictionaryResults: source.DictionaryResults{{}}},
+		},
+		"full": {
+			result: Result{
+				Word:              "tset",
+				Source:            "Source",
+				ShowingResultsFor: "test",
+				DictionaryResults: source.DictionaryResults{
+					{
+						ID:       "r1",
+						Language: "en",
+						Word:     "test",
+						Entries: []source.DictionaryEntry{
+							{
+								ID:                    "e1",
+								Entry:                 source.Entry{Word: "test", LexicalCategory: "Noun", PartOfSpeech: source.PartOfSpeechNoun},
+								Senses:                []source.Sense{sense},
+								Etymologies:           []string{"From Latin testum"},
+								EntryNotes:            []string{"usage note"},
+								Inflections:           []source.InflectedForm{{Form: "tests", Label: "plural", Generated: true}},
+								Hyphenation:           []string{"test"},
+								Offensive:             true,
+								Pronunciations:        source.Pronunciations{{Spelling: "tɛst", Notation: source.PronunciationNotationIPA, Dialect: "British English", AudioURL: "https://example.com/test.mp3"}},
+								PronunciationNotation: source.PronunciationNotationIPA,
+								Syllables:             &source.Syllables{Count: 1, PrimaryStress: 1},
+								ThesaurusValues:       source.ThesaurusValues{Synonyms: []string{"exam"}, Antonyms: []string{"guess"}},
+							},
+						},
+						Frequency:     &source.Frequency{Zipf: 5.2, Band: "common"},
+						Attribution:   attribution,
+						Trend:         &source.UsageTrend{StartYear: 2000, EndYear: 2001, Frequencies: []float64{0.1, 0.2}},
+						Confusables:   []source.Confusable{{Words: []string{"text"}, Note: "a note"}},
+						ParseWarnings: []source.ParseWarning{{Path: "entries[0]", Message: "dropped"}},
+					},
+				},
+				SearchResults:     source.SearchResults{"tests"},
+				RelatedWords:      source.RelatedWords{{Word: "exam", LexicalCategory: "noun", Gloss: "a test"}},
+				RelatedWordGroups: []source.RelatedWordGroup{{Name: "adjectives", Words: source.RelatedWords{{Word: "blind"}}}},
+				Summary:           &source.Summary{Title: "Test", Description: "a procedure", Text: "A test is...", IsDisambiguation: true, Attribution: attribution},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var out strings.Builder
+
+			if err := NewJSONPrinter(defineio.NewPanicWriter(&out, 0)).PrintResult(testData.result); err != nil {
+				t.Fatalf("PrintResult returned an error: %v", err)
+			}
+
+			if err := validateAgainstSchema(t, out.String()); err != nil {
+				t.Errorf("PrintResult printed JSON that doesn't match the schema. Got %v.", err)
+			}
+		})
+	}
+}
+
+func TestSchema_RejectsUnknownFields(t *testing.T) {
+	if err := validateAgainstSchema(t, `{"Word": "test", "Source": "Source", "Unknown": true}`); err == nil {
+		t.Error("Schema validated a document with an unknown field. Want an error.")
+	}
+}
+
+// validateAgainstSchema validates a JSON document against the Schema, with
+// just the keywords that the Schema uses.
+func validateAgainstSchema(t *testing.T, document string) error {
+	t.Helper()
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(Schema), &schema); err != nil {
+		t.Fatalf("Schema isn't valid JSON: %v", err)
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(document), &value); err != nil {
+		t.Fatalf("Document isn't valid JSON: %v", err)
+	}
+
+	defs, _ := schema["$defs"].(map[string]any)
+
+	return validateSchemaValue(defs, schema, value, "$")
+}
+
+func validateSchemaValue(defs map[string]any, schema map[string]any, value any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		def, ok := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: unknown reference %q", path, ref)
+		}
+
+		return validateSchemaValue(defs, def, value, path)
+	}
+
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		var matches int
+		for _, option := range oneOf {
+			if validateSchemaValue(defs, option.(map[string]any), value, path) == nil {
+				matches++
+			}
+		}
+
+		if matches != 1 {
+			return fmt.Errorf("%s: matches %d of the oneOf schemas", path, matches)
+		}
+
+		return nil
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		var found bool
+		for _, allowed := range enum {
+			if allowed == value {
+				found = true
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %#v isn't one of %v", path, value, enum)
+		}
+	}
+
+	if types, ok := schema["type"]; ok {
+		if err := validateSchemaType(types, value); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if minimum, ok := schema["minimum"].(float64); ok {
+		if number, ok := value.(float64); ok && number < minimum {
+			return fmt.Errorf("%s: %v is less than %v", path, number, minimum)
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+
+		required, _ := schema["required"].([]any)
+		for _, key := range required {
+			if _, ok := value[key.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, key)
+			}
+		}
+
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			property, ok := properties[key].(map[string]any)
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: unknown property %q", path, key)
+				}
+
+				continue
+			}
+
+			if err := validateSchemaValue(defs, property, value[key], path+"."+key); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range value {
+				if err := validateSchemaValue(defs, items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateSchemaType(types any, value any) error {
+	var names []any
+
+	switch types := types.(type) {
+	case []any:
+		names = types
+	default:
+		names = []any{types}
+	}
+
+	for _, name := range names {
+		var matches bool
+
+		switch name {
+		case "object":
+			_, matches = value.(map[string]any)
+		case "array":
+			_, matches = value.([]any)
+		case "string":
+			_, matches = value.(string)
+		case "boolean":
+			_, matches = value.(bool)
+		case "number":
+			_, matches = value.(float64)
+		case "integer":
+			number, ok := value.(float64)
+			matches = ok && number == math.Trunc(number)
+		case "null":
+			matches = value == nil
+		}
+
+		if matches {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%#v isn't of type %v", value, types)
+}
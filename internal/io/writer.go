@@ -23,6 +23,15 @@ func NewPanicWriter(writer io.Writer, indentStepSize uint) *PanicWriter {
 	return &PanicWriter{inner: writer, indentStepSize: indentStepSize}
 }
 
+// SetIndentStepSize sets the number of spaces that the writer indents by for
+// each indentation step.
+//
+// This allows a writer to be created before its configuration is known, and
+// configured once it is, without needing to replace any references to it.
+func (w *PanicWriter) SetIndentStepSize(indentStepSize uint) {
+	w.indentStepSize = indentStepSize
+}
+
 // Write satisfies the io.Writer interface.
 func (w *PanicWriter) Write(p []byte) (int, error) {
 	if 0 < w.spaces {
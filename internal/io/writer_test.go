@@ -317,6 +317,33 @@ func TestIndentWrites(t *testing.T) {
 	})
 }
 
+func TestSetIndentStepSize(t *testing.T) {
+	w := &strings.Builder{}
+	pw := NewPanicWriter(w, 2)
+
+	pw.SetIndentStepSize(4)
+
+	if pw.indentStepSize != 4 {
+		t.Errorf(
+			"Writer has incorrect indent step size. Got %d. Want %d.",
+			pw.indentStepSize,
+			4,
+		)
+	}
+
+	pw.IndentWrites(func(pw *PanicWriter) {
+		pw.WriteString("test")
+	})
+
+	if want := "    test"; w.String() != want {
+		t.Errorf(
+			"Writer wrote incorrect value. Got %q. Want %q.",
+			w.String(),
+			want,
+		)
+	}
+}
+
 func TestIndentWritesBy(t *testing.T) {
 	indentSize := uint(2)
 
@@ -0,0 +1,142 @@
+// Package keypool provides rotation through a pool of the credentials of an
+// API, so that requests can continue to be made with another set once one's
+// quota is exhausted.
+package keypool
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxDrainSize is the maximum size of a refused response's body that's read,
+// so that its connection can be reused
+const maxDrainSize = 4 << 10
+
+// remainingHeaderNames is the list of the names of the headers that APIs
+// commonly report the remainder of their quotas in.
+var remainingHeaderNames = []string{
+	"RateLimit-Remaining",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Remaining-Month",
+	"X-RateLimit-Remaining-Day",
+}
+
+// Signer signs a request with a set of credentials, replacing any that the
+// request was already signed with.
+type Signer func(request *http.Request)
+
+// Transport is an http.RoundTripper that signs requests with one of a pool of
+// credentials, rotating to the next when a response shows that the quota of
+// the current one is exhausted, and retrying the request with it.
+type Transport struct {
+	inner   http.RoundTripper
+	signers []Signer
+
+	mutex   sync.Mutex
+	current int
+}
+
+// NewTransport returns a new Transport that wraps an inner http.RoundTripper,
+// or http.DefaultTransport if it's nil, and signs requests with the given
+// signers, in rotation.
+func NewTransport(inner http.RoundTripper, signers ...Signer) *Transport {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+
+	return &Transport{inner: inner, signers: signers}
+}
+
+// RoundTrip executes a single HTTP transaction, signed with the current
+// credentials. If their quota is exhausted, it's retried with each of the
+// others, until one isn't, and the last response is returned.
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if len(t.signers) < 1 {
+		return t.inner.RoundTrip(request)
+	}
+
+	index := t.currentIndex()
+
+	for attempt := 1; ; attempt++ {
+		signed, err := cloneRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		t.signers[index](signed)
+
+		response, err := t.inner.RoundTrip(signed)
+
+		// Requests with bodies that can't be read again can't be retried
+		canRetry := request.Body == nil || request.GetBody != nil
+
+		if err != nil || !isExhausted(response) || attempt >= len(t.signers) || !canRetry {
+			return response, err
+		}
+
+		io.CopyN(io.Discard, response.Body, maxDrainSize)
+		response.Body.Close()
+
+		index = t.rotate(index)
+	}
+}
+
+// currentIndex returns the index of the current signer.
+func (t *Transport) currentIndex() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.current
+}
+
+// rotate rotates the current signer from the one at the given index to the
+// next, unless a concurrent request already has, and returns the index of the
+// new current signer.
+func (t *Transport) rotate(from int) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.current == from {
+		t.current = (from + 1) % len(t.signers)
+	}
+
+	return t.current
+}
+
+// cloneRequest returns a copy of a request that can be signed without
+// modifying the original, with a fresh copy of its body, if it has one.
+func cloneRequest(request *http.Request) (*http.Request, error) {
+	clone := request.Clone(request.Context())
+
+	if request.Body != nil && request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		clone.Body = body
+	}
+
+	return clone, nil
+}
+
+// isExhausted returns true if a response shows that the quota of the
+// credentials that its request was signed with is exhausted.
+func isExhausted(response *http.Response) bool {
+	if response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if response.StatusCode < http.StatusBadRequest {
+		return false
+	}
+
+	for _, headerName := range remainingHeaderNames {
+		if response.Header.Get(headerName) == "0" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,135 @@
+package keypool
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTransport_RoundTrip(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		exhausted  map[string]http.Header // The headers of keys' exhausted responses
+		keys       []string
+		requests   int
+		wantKeys   []string // The keys that requests were made with, in order
+		wantStatus int
+	}{
+		"no rotation": {
+			keys:       []string{"a", "b"},
+			requests:   2,
+			wantKeys:   []string{"a", "a"},
+			wantStatus: http.StatusOK,
+		},
+		"rotation on too many requests": {
+			exhausted:  map[string]http.Header{"a": nil},
+			keys:       []string{"a", "b"},
+			requests:   2,
+			wantKeys:   []string{"a", "b", "b"},
+			wantStatus: http.StatusOK,
+		},
+		"rotation on an exhausted quota": {
+			exhausted:  map[string]http.Header{"a": {"X-RateLimit-Remaining-Month": {"0"}}},
+			keys:       []string{"a", "b"},
+			requests:   1,
+			wantKeys:   []string{"a", "b"},
+			wantStatus: http.StatusOK,
+		},
+		"all exhausted": {
+			exhausted:  map[string]http.Header{"a": nil, "b": nil},
+			keys:       []string{"a", "b"},
+			requests:   1,
+			wantKeys:   []string{"a", "b"},
+			wantStatus: http.StatusTooManyRequests,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var gotKeys []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				key := r.URL.Query().Get("key")
+				gotKeys = append(gotKeys, key)
+
+				header, isExhausted := testData.exhausted[key]
+				if !isExhausted {
+					return
+				}
+
+				for name, values := range header {
+					w.Header()[name] = values
+				}
+
+				if header == nil {
+					w.WriteHeader(http.StatusTooManyRequests)
+				} else {
+					w.WriteHeader(http.StatusForbidden)
+				}
+			}))
+			defer server.Close()
+
+			signers := make([]Signer, len(testData.keys))
+			for i, key := range testData.keys {
+				signers[i] = func(request *http.Request) {
+					query := request.URL.Query()
+					query.Set("key", key)
+					request.URL.RawQuery = query.Encode()
+				}
+			}
+
+			client := http.Client{Transport: NewTransport(nil, signers...)}
+
+			var gotStatus int
+
+			for range testData.requests {
+				response, err := client.Get(server.URL)
+				if err != nil {
+					t.Fatalf("Get returned an error: %v", err)
+				}
+
+				response.Body.Close()
+				gotStatus = response.StatusCode
+			}
+
+			if !reflect.DeepEqual(gotKeys, testData.wantKeys) {
+				t.Errorf("RoundTrip made requests with wrong keys. Got %#v. Want %#v.", gotKeys, testData.wantKeys)
+			}
+
+			if gotStatus != testData.wantStatus {
+				t.Errorf("RoundTrip returned wrong status. Got %#v. Want %#v.", gotStatus, testData.wantStatus)
+			}
+		})
+	}
+}
+
+func TestTransport_RoundTripBody(t *testing.T) {
+	var gotRequests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotRequests = append(gotRequests, r.Header.Get("Key")+": "+string(body))
+
+		if r.Header.Get("Key") == "a" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	sign := func(key string) Signer {
+		return func(request *http.Request) { request.Header.Set("Key", key) }
+	}
+
+	client := http.Client{Transport: NewTransport(nil, sign("a"), sign("b"))}
+
+	response, err := client.Post(server.URL, "text/plain", strings.NewReader("body"))
+	if err != nil {
+		t.Fatalf("Post returned an error: %v", err)
+	}
+
+	response.Body.Close()
+
+	if want := []string{"a: body", "b: body"}; !reflect.DeepEqual(gotRequests, want) {
+		t.Errorf("RoundTrip made wrong requests. Got %#v. Want %#v.", gotRequests, want)
+	}
+}
@@ -0,0 +1,105 @@
+// Package level provides the levels of proficiency of learners of English that
+// definitions can be targeted at.
+package level
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// List of levels.
+const (
+	// Any targets no level, for the sources' full definitions
+	Any          Level = ""
+	Beginner     Level = "beginner"
+	Intermediate Level = "intermediate"
+	Advanced     Level = "advanced"
+)
+
+const (
+	// beginnerMaxSenses is the maximum number of senses of each entry that are
+	// kept for beginners, like the short definitions of learner's dictionaries
+	beginnerMaxSenses = 3
+)
+
+// Level defines a level of proficiency.
+type Level string
+
+// Parse takes a string and returns the matching Level, or an error if no Level
+// matches.
+func Parse(level string) (Level, error) {
+	switch parsed := Level(strings.ToLower(level)); parsed {
+	case Any, Beginner, Intermediate, Advanced:
+		return parsed, nil
+	}
+
+	return Any, fmt.Errorf("unknown level %q", level)
+}
+
+// PrefersLearnerSources returns true if sources that are written for learners
+// (like learner's dictionaries) should be preferred for the level.
+func (l Level) PrefersLearnerSources() bool {
+	return l == Beginner || l == Intermediate
+}
+
+// Simplify returns a copy of the results, simplified for the level.
+//
+// For beginners, each entry is trimmed to its first few senses, with only
+// their first definition and example, and without sub-senses or the entry's
+// etymologies and notes. Results for other levels are returned as they are.
+func (l Level) Simplify(results source.DictionaryResults) source.DictionaryResults {
+	if l != Beginner {
+		return results
+	}
+
+	simplified := make(source.DictionaryResults, len(results))
+
+	for i, result := range results {
+		result.Entries = make([]source.DictionaryEntry, len(results[i].Entries))
+
+		for j, entry := range results[i].Entries {
+			entry.Etymologies = nil
+			entry.EntryNotes = nil
+			entry.Senses = simplifySenses(entry.Senses)
+
+			result.Entries[j] = entry
+		}
+
+		simplified[i] = result
+	}
+
+	return simplified
+}
+
+// simplifySenses returns the first few senses, with only their first
+// definition and example, and without sub-senses or notes.
+func simplifySenses(senses []source.Sense) []source.Sense {
+	var simplified []source.Sense
+
+	for _, sense := range senses {
+		if len(simplified) >= beginnerMaxSenses {
+			break
+		}
+
+		// Skip senses without definitions of their own, like those that only
+		// group sub-senses
+		if len(sense.Definitions) < 1 {
+			continue
+		}
+
+		sense.Definitions = sense.Definitions[:1]
+		sense.Examples = sense.Examples[:min(len(sense.Examples), 1)]
+		sense.Notes = nil
+		sense.SubSenses = nil
+
+		if len(sense.Translations) > 1 {
+			sense.Translations = sense.Translations[:1]
+		}
+
+		simplified = append(simplified, sense)
+	}
+
+	return simplified
+}
@@ -0,0 +1,100 @@
+package level
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestParse(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		level   string
+		want    Level
+		wantErr bool
+	}{
+		"any":      {level: "", want: Any},
+		"beginner": {level: "Beginner", want: Beginner},
+		"advanced": {level: "advanced", want: Advanced},
+		"unknown":  {level: "expert", want: Any, wantErr: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := Parse(testData.level)
+
+			if (err != nil) != testData.wantErr {
+				t.Errorf("Parse returned wrong error. Got %#v. Want an error: %#v.", err, testData.wantErr)
+			}
+
+			if got != testData.want {
+				t.Errorf("Parse returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestLevel_Simplify(t *testing.T) {
+	sense := func(definition string) source.Sense {
+		return source.Sense{
+			Definitions: []string{definition, definition + " (more formally)"},
+			Examples:    []source.AttributedText{{Text: "a first example"}, {Text: "a second example"}},
+			Notes:       []string{"a note"},
+			SubSenses:   []source.Sense{{Definitions: []string{"a sub-sense"}}},
+		}
+	}
+
+	simplified := func(definition string) source.Sense {
+		return source.Sense{
+			Definitions: []string{definition},
+			Examples:    []source.AttributedText{{Text: "a first example"}},
+		}
+	}
+
+	results := source.DictionaryResults{
+		{
+			Word: "test",
+			Entries: []source.DictionaryEntry{
+				{
+					Senses:      []source.Sense{{SubSenses: []source.Sense{sense("a group")}}, sense("one"), sense("two"), sense("three"), sense("four")},
+					Etymologies: []string{"an etymology"},
+					EntryNotes:  []string{"a note"},
+				},
+			},
+		},
+	}
+
+	for testName, testData := range map[string]struct {
+		level Level
+		want  source.DictionaryResults
+	}{
+		"any": {
+			level: Any,
+			want:  results,
+		},
+		"advanced": {
+			level: Advanced,
+			want:  results,
+		},
+		"beginner": {
+			level: Beginner,
+			want: source.DictionaryResults{
+				{
+					Word: "test",
+					Entries: []source.DictionaryEntry{
+						{Senses: []source.Sense{simplified("one"), simplified("two"), simplified("three")}},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.level.Simplify(results); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Simplify returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+
+	// Make sure the original results weren't modified
+	if got := len(results[0].Entries[0].Senses); got != 5 {
+		t.Errorf("Simplify modified the original results. Got %d senses. Want %d.", got, 5)
+	}
+}
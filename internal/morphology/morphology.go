@@ -0,0 +1,318 @@
+// Package morphology provides a simple, rule-based generator of the inflected
+// forms of English words, for when a source doesn't provide them.
+package morphology
+
+import (
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// List of the labels of generated forms.
+const (
+	LabelPlural            = "plural"
+	LabelThirdPerson       = "third person singular"
+	LabelPast              = "past"
+	LabelPastParticiple    = "past participle"
+	LabelPresentParticiple = "present participle"
+	LabelComparative       = "comparative"
+	LabelSuperlative       = "superlative"
+)
+
+// irregularPlurals is a list of common nouns with irregular plurals.
+var irregularPlurals = map[string]string{
+	"child":  "children",
+	"foot":   "feet",
+	"goose":  "geese",
+	"louse":  "lice",
+	"man":    "men",
+	"mouse":  "mice",
+	"ox":     "oxen",
+	"person": "people",
+	"tooth":  "teeth",
+	"woman":  "women",
+	"leaf":   "leaves",
+	"life":   "lives",
+	"knife":  "knives",
+	"wife":   "wives",
+	"half":   "halves",
+	"wolf":   "wolves",
+	"sheep":  "sheep",
+	"deer":   "deer",
+	"fish":   "fish",
+	"series": "series",
+}
+
+// irregularVerbs is a list of common verbs with irregular past tenses, along
+// with their past tenses and past participles.
+var irregularVerbs = map[string][2]string{
+	"be":     {"was", "been"},
+	"begin":  {"began", "begun"},
+	"break":  {"broke", "broken"},
+	"bring":  {"brought", "brought"},
+	"buy":    {"bought", "bought"},
+	"choose": {"chose", "chosen"},
+	"come":   {"came", "come"},
+	"do":     {"did", "done"},
+	"drink":  {"drank", "drunk"},
+	"drive":  {"drove", "driven"},
+	"eat":    {"ate", "eaten"},
+	"fall":   {"fell", "fallen"},
+	"feel":   {"felt", "felt"},
+	"find":   {"found", "found"},
+	"fly":    {"flew", "flown"},
+	"forget": {"forgot", "forgotten"},
+	"get":    {"got", "gotten"},
+	"give":   {"gave", "given"},
+	"go":     {"went", "gone"},
+	"have":   {"had", "had"},
+	"hear":   {"heard", "heard"},
+	"keep":   {"kept", "kept"},
+	"know":   {"knew", "known"},
+	"leave":  {"left", "left"},
+	"make":   {"made", "made"},
+	"meet":   {"met", "met"},
+	"pay":    {"paid", "paid"},
+	"put":    {"put", "put"},
+	"read":   {"read", "read"},
+	"ride":   {"rode", "ridden"},
+	"ring":   {"rang", "rung"},
+	"run":    {"ran", "run"},
+	"say":    {"said", "said"},
+	"see":    {"saw", "seen"},
+	"sell":   {"sold", "sold"},
+	"send":   {"sent", "sent"},
+	"sing":   {"sang", "sung"},
+	"sit":    {"sat", "sat"},
+	"sleep":  {"slept", "slept"},
+	"speak":  {"spoke", "spoken"},
+	"spend":  {"spent", "spent"},
+	"stand":  {"stood", "stood"},
+	"swim":   {"swam", "swum"},
+	"take":   {"took", "taken"},
+	"teach":  {"taught", "taught"},
+	"tell":   {"told", "told"},
+	"think":  {"thought", "thought"},
+	"throw":  {"threw", "thrown"},
+	"wear":   {"wore", "worn"},
+	"win":    {"won", "won"},
+	"write":  {"wrote", "written"},
+}
+
+// irregularThirdPersons is a list of verbs with irregular third person
+// singular forms.
+var irregularThirdPersons = map[string]string{
+	"be":   "is",
+	"have": "has",
+}
+
+// irregularComparisons is a list of adjectives with irregular comparative and
+// superlative forms.
+var irregularComparisons = map[string][2]string{
+	"bad":    {"worse", "worst"},
+	"far":    {"farther", "farthest"},
+	"good":   {"better", "best"},
+	"little": {"less", "least"},
+	"many":   {"more", "most"},
+	"much":   {"more", "most"},
+	"well":   {"better", "best"},
+}
+
+// Inflect returns the generated inflected forms of a word of the given lexical
+// category (like "noun" or "verb"). Nothing is returned for words of other
+// categories, or for phrases.
+func Inflect(word string, lexicalCategory string) []source.InflectedForm {
+	if word == "" || strings.ContainsAny(word, " -") || strings.ToLower(word) != word {
+		// Phrases, hyphenated compounds, and proper nouns are left alone
+		return nil
+	}
+
+	var forms []source.InflectedForm
+
+	switch source.ParsePartOfSpeech(lexicalCategory) {
+	case source.PartOfSpeechNoun:
+		forms = []source.InflectedForm{{Form: Plural(word), Label: LabelPlural}}
+	case source.PartOfSpeechVerb:
+		past, pastParticiple := PastTense(word)
+
+		forms = []source.InflectedForm{
+			{Form: ThirdPerson(word), Label: LabelThirdPerson},
+			{Form: past, Label: LabelPast},
+			{Form: pastParticiple, Label: LabelPastParticiple},
+			{Form: PresentParticiple(word), Label: LabelPresentParticiple},
+		}
+	case source.PartOfSpeechAdjective:
+		comparative, superlative := Comparison(word)
+
+		forms = []source.InflectedForm{
+			{Form: comparative, Label: LabelComparative},
+			{Form: superlative, Label: LabelSuperlative},
+		}
+	}
+
+	for i := range forms {
+		forms[i].Generated = true
+	}
+
+	return forms
+}
+
+// AnnotateResults takes a list of dictionary results and sets the generated
+// inflected forms of each entry that doesn't have any, in place.
+func AnnotateResults(results source.DictionaryResults) {
+	for i := range results {
+		for j := range results[i].Entries {
+			entry := &results[i].Entries[j]
+
+			if len(entry.Inflections) < 1 {
+				entry.Inflections = Inflect(entry.Word, entry.LexicalCategory)
+			}
+		}
+	}
+}
+
+// Plural returns the plural form of a noun.
+func Plural(noun string) string {
+	if plural, exists := irregularPlurals[noun]; exists {
+		return plural
+	}
+
+	return withSuffixS(noun)
+}
+
+// ThirdPerson returns the third person singular present form of a verb.
+func ThirdPerson(verb string) string {
+	if form, exists := irregularThirdPersons[verb]; exists {
+		return form
+	}
+
+	return withSuffixS(verb)
+}
+
+// PastTense returns the past tense and past participle forms of a verb.
+func PastTense(verb string) (string, string) {
+	if forms, exists := irregularVerbs[verb]; exists {
+		return forms[0], forms[1]
+	}
+
+	var past string
+
+	switch {
+	case strings.HasSuffix(verb, "e"):
+		past = verb + "d"
+	case endsWithConsonantY(verb):
+		past = verb[:len(verb)-1] + "ied"
+	case shouldDoubleFinalConsonant(verb):
+		past = verb + verb[len(verb)-1:] + "ed"
+	default:
+		past = verb + "ed"
+	}
+
+	return past, past
+}
+
+// PresentParticiple returns the present participle form of a verb.
+func PresentParticiple(verb string) string {
+	switch {
+	case verb == "be":
+		return "being"
+	case strings.HasSuffix(verb, "ie"):
+		return verb[:len(verb)-2] + "ying"
+	case strings.HasSuffix(verb, "e") && !strings.HasSuffix(verb, "ee") && !strings.HasSuffix(verb, "ye") && !strings.HasSuffix(verb, "oe"):
+		return verb[:len(verb)-1] + "ing"
+	case shouldDoubleFinalConsonant(verb):
+		return verb + verb[len(verb)-1:] + "ing"
+	}
+
+	return verb + "ing"
+}
+
+// Comparison returns the comparative and superlative forms of an adjective.
+//
+// Short adjectives (of one syllable, or of two ending in "y") are inflected
+// with suffixes, while longer adjectives are compared with "more" and "most".
+func Comparison(adjective string) (string, string) {
+	if forms, exists := irregularComparisons[adjective]; exists {
+		return forms[0], forms[1]
+	}
+
+	syllables := countVowelGroups(adjective)
+
+	switch {
+	case endsWithConsonantY(adjective) && syllables <= 2:
+		stem := adjective[:len(adjective)-1]
+		return stem + "ier", stem + "iest"
+	case syllables > 1:
+		return "more " + adjective, "most " + adjective
+	case strings.HasSuffix(adjective, "e"):
+		return adjective + "r", adjective + "st"
+	case shouldDoubleFinalConsonant(adjective):
+		last := adjective[len(adjective)-1:]
+		return adjective + last + "er", adjective + last + "est"
+	}
+
+	return adjective + "er", adjective + "est"
+}
+
+// withSuffixS returns a word with an "s" suffix, as used for plurals and third
+// person singular forms.
+func withSuffixS(word string) string {
+	switch {
+	case endsWithConsonantY(word):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	}
+
+	return word + "s"
+}
+
+// endsWithConsonantY returns true if a word ends with a consonant followed by
+// a "y".
+func endsWithConsonantY(word string) bool {
+	return len(word) > 1 && strings.HasSuffix(word, "y") && !isVowel(word[len(word)-2])
+}
+
+// shouldDoubleFinalConsonant returns true if the final consonant of a word
+// should be doubled before a vowel suffix, which is the case for words of one
+// syllable that end in a single vowel followed by a single consonant (like
+// "stop" becoming "stopped").
+func shouldDoubleFinalConsonant(word string) bool {
+	if len(word) < 3 || countVowelGroups(word) != 1 {
+		return false
+	}
+
+	last, middle, first := word[len(word)-1], word[len(word)-2], word[len(word)-3]
+
+	return !isVowel(last) && !strings.ContainsRune("wxy", rune(last)) && isVowel(middle) && !isVowel(first)
+}
+
+// countVowelGroups returns the number of groups of consecutive vowels in a
+// word, as an approximation of its number of syllables.
+func countVowelGroups(word string) int {
+	var count int
+	var inGroup bool
+
+	for i := 0; i < len(word); i++ {
+		vowel := isVowel(word[i]) || (word[i] == 'y' && i > 0)
+
+		if vowel && !inGroup {
+			count++
+		}
+
+		inGroup = vowel
+	}
+
+	// A silent, final "e" doesn't make a syllable
+	if count > 1 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && !isVowel(word[len(word)-2]) {
+		count--
+	}
+
+	return count
+}
+
+// isVowel returns true if the byte is a lower-case vowel.
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
@@ -0,0 +1,118 @@
+package morphology
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestPlural(t *testing.T) {
+	for noun, want := range map[string]string{
+		"test":   "tests",
+		"box":    "boxes",
+		"church": "churches",
+		"city":   "cities",
+		"day":    "days",
+		"child":  "children",
+		"sheep":  "sheep",
+	} {
+		if got := Plural(noun); got != want {
+			t.Errorf("Plural(%q) returned wrong value. Got %#v. Want %#v.", noun, got, want)
+		}
+	}
+}
+
+func TestPastTense(t *testing.T) {
+	for verb, want := range map[string][2]string{
+		"walk":  {"walked", "walked"},
+		"like":  {"liked", "liked"},
+		"try":   {"tried", "tried"},
+		"play":  {"played", "played"},
+		"stop":  {"stopped", "stopped"},
+		"visit": {"visited", "visited"},
+		"fix":   {"fixed", "fixed"},
+		"write": {"wrote", "written"},
+	} {
+		past, pastParticiple := PastTense(verb)
+
+		if got := [2]string{past, pastParticiple}; got != want {
+			t.Errorf("PastTense(%q) returned wrong value. Got %#v. Want %#v.", verb, got, want)
+		}
+	}
+}
+
+func TestPresentParticiple(t *testing.T) {
+	for verb, want := range map[string]string{
+		"walk": "walking",
+		"make": "making",
+		"see":  "seeing",
+		"die":  "dying",
+		"run":  "running",
+		"open": "opening",
+		"be":   "being",
+	} {
+		if got := PresentParticiple(verb); got != want {
+			t.Errorf("PresentParticiple(%q) returned wrong value. Got %#v. Want %#v.", verb, got, want)
+		}
+	}
+}
+
+func TestComparison(t *testing.T) {
+	for adjective, want := range map[string][2]string{
+		"tall":      {"taller", "tallest"},
+		"large":     {"larger", "largest"},
+		"big":       {"bigger", "biggest"},
+		"happy":     {"happier", "happiest"},
+		"beautiful": {"more beautiful", "most beautiful"},
+		"good":      {"better", "best"},
+	} {
+		comparative, superlative := Comparison(adjective)
+
+		if got := [2]string{comparative, superlative}; got != want {
+			t.Errorf("Comparison(%q) returned wrong value. Got %#v. Want %#v.", adjective, got, want)
+		}
+	}
+}
+
+func TestInflect(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		word     string
+		category string
+		want     []source.InflectedForm
+	}{
+		"noun": {
+			word:     "test",
+			category: "Noun",
+			want:     []source.InflectedForm{{Form: "tests", Label: LabelPlural, Generated: true}},
+		},
+		"verb": {
+			word:     "test",
+			category: "verb",
+			want: []source.InflectedForm{
+				{Form: "tests", Label: LabelThirdPerson, Generated: true},
+				{Form: "tested", Label: LabelPast, Generated: true},
+				{Form: "tested", Label: LabelPastParticiple, Generated: true},
+				{Form: "testing", Label: LabelPresentParticiple, Generated: true},
+			},
+		},
+		"adverb": {
+			word:     "quickly",
+			category: "adverb",
+		},
+		"phrase": {
+			word:     "give up",
+			category: "phrasal verb",
+		},
+		"proper noun": {
+			word:     "Paris",
+			category: "proper noun",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := Inflect(testData.word, testData.category); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Inflect returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Package postag provides a simple part-of-speech tagger, for guessing the
+// part of speech of a word from the (English) sentence that it's used in.
+package postag
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Rican7/define/internal/sentence"
+	"github.com/Rican7/define/source"
+)
+
+// List of the words that hint at the part of speech of the word that follows
+// them.
+var (
+	// verbHints are words that are usually followed by a verb, like modals
+	// and subject pronouns
+	verbHints = newWordSet(
+		"to", "will", "would", "shall", "should", "can", "could", "may",
+		"might", "must", "do", "does", "did", "don't", "doesn't", "didn't",
+		"won't", "can't", "i", "you", "he", "she", "we", "they", "let's",
+		"please",
+	)
+
+	// nounHints are words that are usually followed by a noun, like
+	// determiners and prepositions
+	nounHints = newWordSet(
+		"a", "an", "the", "this", "that", "these", "those", "my", "your",
+		"his", "her", "its", "our", "their", "some", "any", "every", "each",
+		"no", "of", "in", "on", "at", "for", "with", "from", "by", "about",
+		"into", "under", "over",
+	)
+
+	// adjectiveHints are words that are usually followed by an adjective,
+	// like intensifiers and copulas
+	adjectiveHints = newWordSet(
+		"very", "too", "so", "quite", "rather", "extremely", "more", "most",
+		"less", "least", "is", "are", "am", "was", "were", "be", "been",
+		"being", "seems", "seemed", "looks", "looked", "became", "becomes",
+	)
+)
+
+// verbSuffixes are the suffixes of inflected verbs
+var verbSuffixes = []string{"ed", "ing"}
+
+// wordSet defines a set of lowercase words.
+type wordSet map[string]struct{}
+
+func newWordSet(words ...string) wordSet {
+	set := make(wordSet, len(words))
+
+	for _, word := range words {
+		set[word] = struct{}{}
+	}
+
+	return set
+}
+
+func (s wordSet) contains(word string) bool {
+	_, contains := s[strings.ToLower(word)]
+
+	return contains
+}
+
+// Guess returns the guessed part of speech of a word as it's used in a text,
+// and whether the word (or an inflection of it) was found in the text.
+//
+// The guess is based on the word just before it (like "to" or "the") and on
+// how the word is inflected, which is simple but enough to tell apart common
+// ambiguities, like "refuse" in "they refuse" and "the refuse".
+func Guess(text string, word string) (source.PartOfSpeech, bool) {
+	words := sentence.Tokenize(text)
+
+	index, suffix := findWord(words, word)
+	if index < 0 {
+		return source.PartOfSpeechUnknown, false
+	}
+
+	if slices.Contains(verbSuffixes, suffix) {
+		return source.PartOfSpeechVerb, true
+	}
+
+	if index > 0 {
+		previous := words[index-1]
+
+		switch {
+		case verbHints.contains(previous):
+			return source.PartOfSpeechVerb, true
+		case nounHints.contains(previous):
+			return source.PartOfSpeechNoun, true
+		case adjectiveHints.contains(previous):
+			return source.PartOfSpeechAdjective, true
+		}
+	}
+
+	if strings.HasSuffix(strings.ToLower(word), "ly") {
+		return source.PartOfSpeechAdverb, true
+	}
+
+	return source.PartOfSpeechUnknown, true
+}
+
+// findWord returns the index of the word in the list of words, or of an
+// inflection of it along with its suffix, or -1 if the word isn't found.
+func findWord(words []string, word string) (int, string) {
+	for i, candidate := range words {
+		if source.EqualFoldPlain(candidate, word) {
+			return i, ""
+		}
+	}
+
+	lowerWord := strings.ToLower(word)
+
+	for i, candidate := range words {
+		candidate = strings.ToLower(candidate)
+
+		// Allow for a dropped final "e", like "refusing" for "refuse"
+		for _, stem := range []string{lowerWord, strings.TrimSuffix(lowerWord, "e")} {
+			if suffix, isInflection := strings.CutPrefix(candidate, stem); isInflection {
+				if suffix, isInflection = inflectionSuffix(suffix); isInflection {
+					return i, suffix
+				}
+			}
+		}
+	}
+
+	return -1, ""
+}
+
+// inflectionSuffix returns the normalized form of a suffix (like "ed" for "d"),
+// and whether the suffix is that of an inflection.
+func inflectionSuffix(suffix string) (string, bool) {
+	switch suffix {
+	case "s", "es":
+		return "s", true
+	case "d", "ed":
+		return "ed", true
+	case "ing":
+		return "ing", true
+	}
+
+	return "", false
+}
+
+// SortResults sorts the entries of each of the results so that the entries of
+// the part of speech come first, and then sorts the results so that those
+// with matching entries come first. The sorts are stable, so the order is
+// otherwise kept.
+func SortResults(results source.DictionaryResults, partOfSpeech source.PartOfSpeech) {
+	if partOfSpeech == source.PartOfSpeechUnknown {
+		return
+	}
+
+	for _, result := range results {
+		slices.SortStableFunc(result.Entries, func(a, b source.DictionaryEntry) int {
+			return compareMatches(a.PartOfSpeech == partOfSpeech, b.PartOfSpeech == partOfSpeech)
+		})
+	}
+
+	slices.SortStableFunc(results, func(a, b source.DictionaryResult) int {
+		return compareMatches(hasMatchingEntry(a, partOfSpeech), hasMatchingEntry(b, partOfSpeech))
+	})
+}
+
+// hasMatchingEntry returns true if any entry of the result is of the part of
+// speech.
+func hasMatchingEntry(result source.DictionaryResult, partOfSpeech source.PartOfSpeech) bool {
+	return slices.ContainsFunc(result.Entries, func(entry source.DictionaryEntry) bool {
+		return entry.PartOfSpeech == partOfSpeech
+	})
+}
+
+// compareMatches compares whether two values match, for sorting matches
+// first.
+func compareMatches(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return -1
+	}
+
+	return 1
+}
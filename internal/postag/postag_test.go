@@ -0,0 +1,65 @@
+package postag
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestGuess(t *testing.T) {
+	testData := map[string]struct {
+		text      string
+		word      string
+		want      source.PartOfSpeech
+		wantFound bool
+	}{
+		"after to":          {text: "He tried to refuse the offer", word: "refuse", want: source.PartOfSpeechVerb, wantFound: true},
+		"after determiner":  {text: "They collected the refuse.", word: "refuse", want: source.PartOfSpeechNoun, wantFound: true},
+		"after pronoun":     {text: "They refuse everything", word: "refuse", want: source.PartOfSpeechVerb, wantFound: true},
+		"after preposition": {text: "A pile of refuse", word: "refuse", want: source.PartOfSpeechNoun, wantFound: true},
+		"after intensifier": {text: "It was very close", word: "close", want: source.PartOfSpeechAdjective, wantFound: true},
+		"past inflection":   {text: "She refused it", word: "refuse", want: source.PartOfSpeechVerb, wantFound: true},
+		"ing inflection":    {text: "Refusing is rude", word: "refuse", want: source.PartOfSpeechVerb, wantFound: true},
+		"adverb suffix":     {text: "Run quickly", word: "quickly", want: source.PartOfSpeechAdverb, wantFound: true},
+		"case-insensitive":  {text: "THE REFUSE", word: "refuse", want: source.PartOfSpeechNoun, wantFound: true},
+		"no hint":           {text: "Refuse", word: "refuse", want: source.PartOfSpeechUnknown, wantFound: true},
+		"not found":         {text: "He tried to decline", word: "refuse", want: source.PartOfSpeechUnknown, wantFound: false},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			got, found := Guess(testData.text, testData.word)
+
+			if got != testData.want {
+				t.Errorf("Guess returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+
+			if found != testData.wantFound {
+				t.Errorf("Guess returned wrong found value. Got %#v. Want %#v.", found, testData.wantFound)
+			}
+		})
+	}
+}
+
+func TestSortResults(t *testing.T) {
+	noun := source.DictionaryEntry{Entry: source.Entry{Word: "refuse", LexicalCategory: "noun", PartOfSpeech: source.PartOfSpeechNoun}}
+	verb := source.DictionaryEntry{Entry: source.Entry{Word: "refuse", LexicalCategory: "transitive verb", PartOfSpeech: source.PartOfSpeechVerb}}
+	adjective := source.DictionaryEntry{Entry: source.Entry{Word: "Refuse", LexicalCategory: "adjective", PartOfSpeech: source.PartOfSpeechAdjective}}
+
+	results := source.DictionaryResults{
+		{Word: "Refuse", Entries: []source.DictionaryEntry{adjective}},
+		{Word: "refuse", Entries: []source.DictionaryEntry{noun, verb}},
+	}
+
+	SortResults(results, source.PartOfSpeechVerb)
+
+	want := source.DictionaryResults{
+		{Word: "refuse", Entries: []source.DictionaryEntry{verb, noun}},
+		{Word: "Refuse", Entries: []source.DictionaryEntry{adjective}},
+	}
+
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("SortResults sorted wrong. Got %#v. Want %#v.", results, want)
+	}
+}
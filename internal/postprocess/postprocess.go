@@ -0,0 +1,84 @@
+// Package postprocess provides a hook to transform results before they're
+// printed, by piping them as JSON through an external command.
+package postprocess
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Rican7/define/internal/command"
+)
+
+// commandTimeout is how long the command may run for a value before it's killed
+const commandTimeout = 30 * time.Second
+
+// Hook transforms values by running a command.
+type Hook struct {
+	args    []string
+	timeout time.Duration
+}
+
+// New returns a new Hook that runs the given command.
+//
+// The command is split into arguments by whitespace, with single or double
+// quotes grouping arguments containing whitespace.
+func New(commandLine string) (*Hook, error) {
+	args, err := command.Split(commandLine)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) < 1 {
+		return nil, errors.New("the post-processing command is empty")
+	}
+
+	return &Hook{args: args, timeout: commandTimeout}, nil
+}
+
+// Process writes the given value, encoded as JSON, to the command's stdin, and
+// decodes the JSON that the command writes to its stdout into the given out
+// value, waiting for the command to finish, or killing it if it takes too long.
+func (h *Hook) Process(in any, out any) error {
+	encoded, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, h.args[0], h.args[1:]...)
+	cmd.Stdin = bytes.NewReader(encoded)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// Don't wait for any processes that the command started, and that still
+	// hold its output open, once it's killed
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("the post-processing command didn't finish within %s", h.timeout)
+		}
+
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("running the post-processing command failed: %w: %s", err, message)
+		}
+
+		return fmt.Errorf("running the post-processing command failed: %w", err)
+	}
+
+	if err := json.Unmarshal(stdout.Bytes(), out); err != nil {
+		return fmt.Errorf("the post-processing command's output isn't valid JSON: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package postprocess
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		command string
+		wantErr bool
+	}{
+		"command":            {command: "jq ."},
+		"empty":              {command: " ", wantErr: true},
+		"unterminated quote": {command: `jq '.`, wantErr: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if _, err := New(testData.command); (err != nil) != testData.wantErr {
+				t.Errorf("New returned wrong error. Got %#v. Want error: %#v.", err, testData.wantErr)
+			}
+		})
+	}
+}
+
+func TestHook_Process(t *testing.T) {
+	type value struct {
+		Word string
+	}
+
+	for testName, testData := range map[string]struct {
+		command string
+		want    value
+		wantErr bool
+	}{
+		"unchanged":          {command: "cat", want: value{Word: "test"}},
+		"transformed":        {command: `sh -c 'sed s/test/tested/'`, want: value{Word: "tested"}},
+		"replaced":           {command: `sh -c 'cat > /dev/null; echo "{\"Word\": \"other\"}"'`, want: value{Word: "other"}},
+		"invalid output":     {command: "echo not json", wantErr: true},
+		"failing command":    {command: `sh -c 'echo failed >&2; exit 1'`, wantErr: true},
+		"nonexistent binary": {command: "define-nonexistent-hook", wantErr: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			hook, err := New(testData.command)
+			if err != nil {
+				t.Fatalf("New returned an error: %v", err)
+			}
+
+			var got value
+
+			if err := hook.Process(value{Word: "test"}, &got); (err != nil) != testData.wantErr {
+				t.Fatalf("Process returned wrong error. Got %#v. Want error: %#v.", err, testData.wantErr)
+			}
+
+			if !testData.wantErr && !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Process returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestHook_ProcessTimeout(t *testing.T) {
+	hook, err := New(`sh -c 'sleep 10'`)
+	if err != nil {
+		t.Fatalf("New returned an error: %v", err)
+	}
+
+	hook.timeout = 10 * time.Millisecond
+
+	var got any
+
+	if err := hook.Process("test", &got); err == nil || !strings.Contains(err.Error(), "didn't finish") {
+		t.Errorf("Process returned wrong error. Got %#v. Want a timeout error.", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Package profanity provides a bundled, curated list of vulgar English words,
+// and censoring of them in text.
+package profanity
+
+import (
+	_ "embed" // Needed for embedding the profanity data
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/Rican7/define/source"
+)
+
+const (
+	// commentPrefix is the prefix of comment lines in the profanity data
+	commentPrefix = "#"
+
+	// censorMark is the mark that the censored letters of a word are replaced
+	// with
+	censorMark = "*"
+)
+
+//go:embed profanity.txt
+var profanityData string
+
+// wordPattern matches the words of text, including any apostrophes within
+// them (like "don't")
+var wordPattern = regexp.MustCompile(`\p{L}+(?:'\p{L}+)*`)
+
+var (
+	loadWords sync.Once
+	words     map[string]bool
+)
+
+// IsVulgar returns true if the given word (case-insensitively) is in the
+// bundled list of vulgar words.
+func IsVulgar(word string) bool {
+	loadWords.Do(func() {
+		words = parse(profanityData)
+	})
+
+	return words[strings.ToLower(word)]
+}
+
+// Censor returns the given text with each of its vulgar words censored, by
+// replacing all but their first letter with asterisks (like "s***").
+func Censor(text string) string {
+	return wordPattern.ReplaceAllStringFunc(text, func(word string) string {
+		if !IsVulgar(word) {
+			return word
+		}
+
+		first, size := utf8.DecodeRuneInString(word)
+
+		return string(first) + strings.Repeat(censorMark, utf8.RuneCountInString(word[size:]))
+	})
+}
+
+// CensorResults censors the vulgar words of the examples of each sense (and
+// sub-sense) of a list of dictionary results, in place.
+func CensorResults(results source.DictionaryResults) {
+	for i := range results {
+		for j := range results[i].Entries {
+			censorSenses(results[i].Entries[j].Senses)
+		}
+	}
+}
+
+// censorSenses censors the vulgar words of the examples of senses and their
+// sub-senses, in place.
+func censorSenses(senses []source.Sense) {
+	for i := range senses {
+		for j := range senses[i].Examples {
+			senses[i].Examples[j].Text = Censor(senses[i].Examples[j].Text)
+		}
+
+		censorSenses(senses[i].SubSenses)
+	}
+}
+
+// parse parses profanity data into a set of words.
+func parse(data string) map[string]bool {
+	parsed := make(map[string]bool)
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, commentPrefix) {
+			continue
+		}
+
+		parsed[strings.ToLower(line)] = true
+	}
+
+	return parsed
+}
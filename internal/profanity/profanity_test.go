@@ -0,0 +1,62 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestCensor(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text string
+		want string
+	}{
+		"empty":            {text: "", want: ""},
+		"clean":            {text: "Pass the salt, please.", want: "Pass the salt, please."},
+		"vulgar":           {text: "Oh, shit!", want: "Oh, s***!"},
+		"case-insensitive": {text: "BULLSHIT, said the judge.", want: "B*******, said the judge."},
+		"within words":     {text: "Shitake mushrooms", want: "Shitake mushrooms"},
+		"apostrophes":      {text: "Don't piss about.", want: "Don't p*** about."},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := Censor(testData.text); got != testData.want {
+				t.Errorf("Censor returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestCensorResults(t *testing.T) {
+	results := source.DictionaryResults{
+		{
+			Entries: []source.DictionaryEntry{
+				{
+					Senses: []source.Sense{
+						{
+							Examples:  []source.AttributedText{{Text: "What a load of shit."}},
+							SubSenses: []source.Sense{{Examples: []source.AttributedText{{Text: "You bastard!"}}}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	CensorResults(results)
+
+	sense := results[0].Entries[0].Senses[0]
+
+	if got, want := sense.Examples[0].Text, "What a load of s***."; got != want {
+		t.Errorf("CensorResults censored wrong value. Got %#v. Want %#v.", got, want)
+	}
+
+	if got, want := sense.SubSenses[0].Examples[0].Text, "You b******!"; got != want {
+		t.Errorf("CensorResults censored wrong sub-sense value. Got %#v. Want %#v.", got, want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	if words := parse(profanityData); len(words) < 1 {
+		t.Error("parse returned no words from the bundled data")
+	}
+}
@@ -0,0 +1,235 @@
+// Package pronunciation provides types and operations for normalizing the
+// pronunciations of different sources into a consistent notation.
+package pronunciation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rican7/define/source"
+	"golang.org/x/text/unicode/norm"
+)
+
+// List of pronunciation styles.
+const (
+	// StyleSource keeps pronunciations in the notation provided by the source
+	StyleSource Style = ""
+
+	// StyleIPA converts pronunciations to the International Phonetic Alphabet
+	StyleIPA Style = "ipa"
+
+	// StyleRespelling converts pronunciations to the Merriam-Webster
+	// respelling system
+	StyleRespelling Style = "respell"
+)
+
+// Style defines a style for the display of pronunciations.
+type Style string
+
+// transliteration defines a mapping of a sequence of symbols from one
+// notation to another.
+type transliteration struct {
+	from string
+	to   string
+}
+
+// transliterationTable defines a table of transliterations, ordered so that
+// longer sequences are matched before any of their prefixes.
+type transliterationTable []transliteration
+
+var (
+	// respellingToIPA is the table used to convert Merriam-Webster respellings
+	// to IPA.
+	//
+	// See https://www.merriam-webster.com/assets/mw/static/pdf/help/guide-to-pronunciation.pdf
+	respellingToIPA = transliterationTable{
+		{"au̇", "aʊ"},
+		{"ȯi", "ɔɪ"},
+		{"t͟h", "ð"},
+		{"ər", "ɚ"},
+		{"ch", "tʃ"},
+		{"sh", "ʃ"},
+		{"th", "θ"},
+		{"zh", "ʒ"},
+		{"u̇", "ʊ"},
+		{"ā", "eɪ"},
+		{"ä", "ɑ"},
+		{"a", "æ"},
+		{"ē", "i"},
+		{"e", "ɛ"},
+		{"ī", "aɪ"},
+		{"i", "ɪ"},
+		{"ō", "oʊ"},
+		{"ȯ", "ɔ"},
+		{"ü", "u"},
+		{"j", "dʒ"},
+		{"y", "j"},
+		{"ᵊ", "ə"},
+		{"k̟", "x"},
+		{"-", "."},
+	}
+
+	// ipaToRespelling is the table used to convert IPA to Merriam-Webster
+	// respellings.
+	ipaToRespelling = transliterationTable{
+		{"eɪ", "ā"},
+		{"aɪ", "ī"},
+		{"aʊ", "au̇"},
+		{"oʊ", "ō"},
+		{"əʊ", "ō"},
+		{"ɔɪ", "ȯi"},
+		{"tʃ", "ch"},
+		{"dʒ", "j"},
+		{"iː", "ē"},
+		{"uː", "ü"},
+		{"ɔː", "ȯ"},
+		{"ɑː", "ä"},
+		{"ɜː", "ər"},
+		{"æ", "a"},
+		{"ɑ", "ä"},
+		{"ɒ", "ä"},
+		{"ɛ", "e"},
+		{"e", "e"},
+		{"i", "ē"},
+		{"ɪ", "i"},
+		{"ɔ", "ȯ"},
+		{"o", "ō"},
+		{"u", "ü"},
+		{"ʊ", "u̇"},
+		{"ʌ", "ə"},
+		{"ɚ", "ər"},
+		{"ɝ", "ər"},
+		{"ɜ", "ə"},
+		{"ʃ", "sh"},
+		{"ʒ", "zh"},
+		{"θ", "th"},
+		{"ð", "t͟h"},
+		{"j", "y"},
+		{"ɡ", "g"},
+		{"ɹ", "r"},
+		{"ɾ", "t"},
+		{"x", "k̟"},
+		{"ː", ""},
+		{".", "-"},
+	}
+)
+
+// ParseStyle takes a string and returns the matching Style, or an error if no
+// Style matches.
+func ParseStyle(style string) (Style, error) {
+	switch parsed := Style(strings.ToLower(style)); parsed {
+	case StyleSource, StyleIPA, StyleRespelling:
+		return parsed, nil
+	}
+
+	return StyleSource, fmt.Errorf("unknown pronunciation style %q", style)
+}
+
+// Notation returns the source.PronunciationNotation that the style converts
+// to, or an empty notation if the style doesn't convert.
+func (s Style) Notation() source.PronunciationNotation {
+	switch s {
+	case StyleIPA:
+		return source.PronunciationNotationIPA
+	case StyleRespelling:
+		return source.PronunciationNotationRespelling
+	}
+
+	return ""
+}
+
+// Convert takes a pronunciation text and converts it from one notation to
+// another. If there's no known conversion between the notations, the original
+// text is returned unchanged.
+func Convert(text string, from, to source.PronunciationNotation) string {
+	if from == to || from == "" || to == "" {
+		return text
+	}
+
+	switch {
+	case from == source.PronunciationNotationRespelling && to == source.PronunciationNotationIPA:
+		return ToIPA(text)
+	case from == source.PronunciationNotationIPA && to == source.PronunciationNotationRespelling:
+		return ToRespelling(text)
+	}
+
+	return text
+}
+
+// ToIPA converts a Merriam-Webster respelling to IPA.
+func ToIPA(respelling string) string {
+	return respellingToIPA.transliterate(respelling)
+}
+
+// ToRespelling converts an IPA pronunciation to a Merriam-Webster respelling.
+func ToRespelling(ipa string) string {
+	return ipaToRespelling.transliterate(ipa)
+}
+
+// NormalizeResults takes a list of dictionary results and converts the
+// pronunciations of each entry to the notation of the given style, in place.
+func NormalizeResults(results source.DictionaryResults, style Style) {
+	notation := style.Notation()
+
+	if notation == "" {
+		return
+	}
+
+	for i := range results {
+		for j := range results[i].Entries {
+			normalizeEntry(&results[i].Entries[j], notation)
+		}
+	}
+}
+
+// normalizeEntry converts the pronunciations of an entry to a given notation.
+// Pronunciations of an unknown notation are kept as-is.
+func normalizeEntry(entry *source.DictionaryEntry, notation source.PronunciationNotation) {
+	for i, pronunciation := range entry.Pronunciations {
+		from := entry.NotationOf(pronunciation)
+
+		if from == "" || from == notation {
+			continue
+		}
+
+		entry.Pronunciations[i].Spelling = Convert(pronunciation.Spelling, from, notation)
+
+		if pronunciation.Notation != "" {
+			entry.Pronunciations[i].Notation = notation
+		}
+	}
+
+	if entry.PronunciationNotation != "" {
+		entry.PronunciationNotation = notation
+	}
+}
+
+// transliterate converts a text using the table, by greedily matching the
+// table's sequences from left to right. Any unmatched symbols are kept as-is.
+func (t transliterationTable) transliterate(text string) string {
+	text = norm.NFC.String(text)
+
+	var builder strings.Builder
+
+	for len(text) > 0 {
+		matched := false
+
+		for _, mapping := range t {
+			if strings.HasPrefix(text, mapping.from) {
+				builder.WriteString(mapping.to)
+				text = text[len(mapping.from):]
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			// Copy over a single (potentially multi-byte) symbol
+			r := []rune(text)[0]
+			builder.WriteRune(r)
+			text = text[len(string(r)):]
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,207 @@
+package pronunciation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestParseStyle(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		style   string
+		want    Style
+		wantErr bool
+	}{
+		"empty": {
+			style: "",
+			want:  StyleSource,
+		},
+		"ipa": {
+			style: "ipa",
+			want:  StyleIPA,
+		},
+		"ipa capitalized": {
+			style: "IPA",
+			want:  StyleIPA,
+		},
+		"respell": {
+			style: "respell",
+			want:  StyleRespelling,
+		},
+		"unknown": {
+			style:   "klingon",
+			want:    StyleSource,
+			wantErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := ParseStyle(testData.style)
+
+			if (err != nil) != testData.wantErr {
+				t.Errorf("ParseStyle returned an unexpected error. Got %#v.", err)
+			}
+
+			if got != testData.want {
+				t.Errorf("ParseStyle returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestToIPA(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text string
+		want string
+	}{
+		"empty": {
+			text: "",
+			want: "",
+		},
+		"simple": {
+			text: "ˈtrē",
+			want: "ˈtri",
+		},
+		"syllables": {
+			text: "ˈkȯn-shəs",
+			want: "ˈkɔn.ʃəs",
+		},
+		"diphthongs": {
+			text: "ˈau̇t-ˌsīd",
+			want: "ˈaʊt.ˌsaɪd",
+		},
+		"digraphs": {
+			text: "ˈt͟his-ˌthiŋ",
+			want: "ˈðɪs.ˌθɪŋ",
+		},
+		"glides": {
+			text: "ˈyes-ˌjȯi",
+			want: "ˈjɛs.ˌdʒɔɪ",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := ToIPA(testData.text); got != testData.want {
+				t.Errorf("ToIPA returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestToRespelling(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text string
+		want string
+	}{
+		"empty": {
+			text: "",
+			want: "",
+		},
+		"simple": {
+			text: "ˈtri",
+			want: "ˈtrē",
+		},
+		"long vowels": {
+			text: "triː",
+			want: "trē",
+		},
+		"syllables": {
+			text: "ˈkɑn.ʃəs",
+			want: "ˈkän-shəs",
+		},
+		"diphthongs": {
+			text: "ˈaʊtˌsaɪd",
+			want: "ˈau̇tˌsīd",
+		},
+		"glides": {
+			text: "ˈjɛs",
+			want: "ˈyes",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := ToRespelling(testData.text); got != testData.want {
+				t.Errorf("ToRespelling returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeResults(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		results source.DictionaryResults
+		style   Style
+		want    source.DictionaryResults
+	}{
+		"nil": {
+			results: nil,
+			style:   StyleIPA,
+			want:    nil,
+		},
+		"source style": {
+			results: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations:        source.Pronunciations{{Spelling: "ˈtrē"}},
+				PronunciationNotation: source.PronunciationNotationRespelling,
+			}}}},
+			style: StyleSource,
+			want: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations:        source.Pronunciations{{Spelling: "ˈtrē"}},
+				PronunciationNotation: source.PronunciationNotationRespelling,
+			}}}},
+		},
+		"unknown notation": {
+			results: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations: source.Pronunciations{{Spelling: "ˈtrē"}},
+			}}}},
+			style: StyleIPA,
+			want: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations: source.Pronunciations{{Spelling: "ˈtrē"}},
+			}}}},
+		},
+		"respelling to ipa": {
+			results: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations:        source.Pronunciations{{Spelling: "ˈtrē"}},
+				PronunciationNotation: source.PronunciationNotationRespelling,
+			}}}},
+			style: StyleIPA,
+			want: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations:        source.Pronunciations{{Spelling: "ˈtri"}},
+				PronunciationNotation: source.PronunciationNotationIPA,
+			}}}},
+		},
+		"mixed notations": {
+			results: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations: source.Pronunciations{
+					{Spelling: "ˈtrē", Notation: source.PronunciationNotationRespelling},
+					{Spelling: "ˈtri", Notation: source.PronunciationNotationIPA},
+					{Spelling: "ˈtrē"},
+				},
+			}}}},
+			style: StyleIPA,
+			want: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations: source.Pronunciations{
+					{Spelling: "ˈtri", Notation: source.PronunciationNotationIPA},
+					{Spelling: "ˈtri", Notation: source.PronunciationNotationIPA},
+					{Spelling: "ˈtrē"},
+				},
+			}}}},
+		},
+		"ipa to respelling": {
+			results: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations:        source.Pronunciations{{Spelling: "triː"}},
+				PronunciationNotation: source.PronunciationNotationIPA,
+			}}}},
+			style: StyleRespelling,
+			want: source.DictionaryResults{{Entries: []source.DictionaryEntry{{
+				Pronunciations:        source.Pronunciations{{Spelling: "trē"}},
+				PronunciationNotation: source.PronunciationNotationRespelling,
+			}}}},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			NormalizeResults(testData.results, testData.style)
+
+			if !reflect.DeepEqual(testData.results, testData.want) {
+				t.Errorf("NormalizeResults resulted in wrong value. Got %#v. Want %#v.", testData.results, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package pronunciation
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Rican7/define/source"
+)
+
+const (
+	primaryStressMark   = 'ˈ'
+	secondaryStressMark = 'ˌ'
+
+	ipaSyllableSeparator        = '.'
+	respellingSyllableSeparator = '-'
+
+	// ipaSyllabicMark is the combining mark used to denote a syllabic
+	// consonant in IPA (ex: the "n" of "button" /ˈbʌtn̩/)
+	ipaSyllabicMark = '̩'
+
+	// variantSeparators defines the characters used to separate variants of
+	// a pronunciation in a single pronunciation text
+	variantSeparators = ",;"
+)
+
+var (
+	// ipaVowels is the set of IPA symbols that may form a syllable nucleus
+	ipaVowels = "aeiouyæɑɒɔəɚɛɜɝɪʊʌøœɐɨʉɵɯɤɘɞᵻ"
+
+	// ipaDiphthongs is the list of IPA vowel sequences that form a single
+	// syllable nucleus
+	ipaDiphthongs = []string{"eɪ", "aɪ", "aʊ", "oʊ", "əʊ", "ɔɪ", "ɪə", "eə", "ʊə", "ɛə"}
+)
+
+// Syllabify takes a pronunciation text and its notation and derives the
+// syllable information from it. It returns nil if the information can't be
+// derived.
+func Syllabify(text string, notation source.PronunciationNotation) *source.Syllables {
+	// Only use the first variant, if multiple are given
+	if i := strings.IndexAny(text, variantSeparators); i >= 0 {
+		text = text[:i]
+	}
+
+	text = strings.TrimSpace(text)
+
+	if text == "" {
+		return nil
+	}
+
+	var syllables source.Syllables
+
+	switch notation {
+	case source.PronunciationNotationRespelling:
+		syllables = syllabifyRespelling(text)
+	case source.PronunciationNotationIPA:
+		syllables = syllabifyIPA(text)
+	default:
+		return nil
+	}
+
+	if syllables.Count < 1 {
+		return nil
+	}
+
+	return &syllables
+}
+
+// AnnotateResults takes a list of dictionary results and sets the syllable
+// information of each entry, derived from each entry's first pronunciation,
+// in place.
+func AnnotateResults(results source.DictionaryResults) {
+	for i := range results {
+		for j := range results[i].Entries {
+			entry := &results[i].Entries[j]
+
+			if len(entry.Pronunciations) < 1 {
+				continue
+			}
+
+			entry.Syllables = Syllabify(entry.Pronunciations[0].Spelling, entry.NotationOf(entry.Pronunciations[0]))
+		}
+	}
+}
+
+// syllabifyRespelling derives syllable information from a Merriam-Webster
+// respelling, which explicitly separates each syllable with either a hyphen
+// or a stress mark.
+func syllabifyRespelling(text string) source.Syllables {
+	var syllables source.Syllables
+
+	isStressed := false
+	inSyllable := false
+
+	for _, r := range text {
+		switch r {
+		case respellingSyllableSeparator, secondaryStressMark:
+			inSyllable = false
+		case primaryStressMark:
+			inSyllable = false
+			isStressed = true
+		case ' ', '(', ')':
+			continue
+		default:
+			if !inSyllable {
+				inSyllable = true
+				syllables.Count++
+
+				if isStressed {
+					syllables.PrimaryStress = syllables.Count
+					isStressed = false
+				}
+			}
+		}
+	}
+
+	return syllables
+}
+
+// syllabifyIPA derives syllable information from an IPA pronunciation, by
+// counting the vowel (and syllabic consonant) nuclei.
+func syllabifyIPA(text string) source.Syllables {
+	var syllables source.Syllables
+
+	isStressed := false
+	var lastVowel rune
+
+	countNucleus := func() {
+		syllables.Count++
+
+		if isStressed {
+			syllables.PrimaryStress = syllables.Count
+			isStressed = false
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case r == primaryStressMark:
+			isStressed = true
+			lastVowel = 0
+		case r == secondaryStressMark, r == ipaSyllableSeparator, r == ' ':
+			lastVowel = 0
+		case r == ipaSyllabicMark:
+			countNucleus()
+			lastVowel = 0
+		case strings.ContainsRune(ipaVowels, r):
+			if lastVowel == 0 || !isDiphthong(lastVowel, r) {
+				countNucleus()
+				lastVowel = r
+			} else {
+				// The diphthong is complete, so don't extend it further
+				lastVowel = 0
+			}
+		case r == 'ː' || unicode.Is(unicode.Mn, r):
+			// Length and combining marks don't affect the syllable
+		default:
+			lastVowel = 0
+		}
+	}
+
+	return syllables
+}
+
+// isDiphthong returns true if the two given vowels form a single nucleus.
+func isDiphthong(first, second rune) bool {
+	pair := string([]rune{first, second})
+
+	for _, diphthong := range ipaDiphthongs {
+		if pair == diphthong {
+			return true
+		}
+	}
+
+	return false
+}
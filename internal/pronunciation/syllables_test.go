@@ -0,0 +1,88 @@
+package pronunciation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestSyllabify(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text     string
+		notation source.PronunciationNotation
+		want     *source.Syllables
+	}{
+		"empty": {
+			text:     "",
+			notation: source.PronunciationNotationIPA,
+			want:     nil,
+		},
+		"unknown notation": {
+			text:     "ˈtrē",
+			notation: "",
+			want:     nil,
+		},
+		"respelling single": {
+			text:     "ˈtrē",
+			notation: source.PronunciationNotationRespelling,
+			want:     &source.Syllables{Count: 1, PrimaryStress: 1},
+		},
+		"respelling multiple": {
+			text:     "və-ˈlü-mə-nəs",
+			notation: source.PronunciationNotationRespelling,
+			want:     &source.Syllables{Count: 4, PrimaryStress: 2},
+		},
+		"respelling secondary stress": {
+			text:     "ˈau̇t-ˌsīd",
+			notation: source.PronunciationNotationRespelling,
+			want:     &source.Syllables{Count: 2, PrimaryStress: 1},
+		},
+		"respelling variants": {
+			text:     "ˈkän(t)-shəs, -chəs",
+			notation: source.PronunciationNotationRespelling,
+			want:     &source.Syllables{Count: 2, PrimaryStress: 1},
+		},
+		"respelling unstressed": {
+			text:     "ə-ˌbət",
+			notation: source.PronunciationNotationRespelling,
+			want:     &source.Syllables{Count: 2, PrimaryStress: 0},
+		},
+		"ipa single": {
+			text:     "ˈtri",
+			notation: source.PronunciationNotationIPA,
+			want:     &source.Syllables{Count: 1, PrimaryStress: 1},
+		},
+		"ipa multiple": {
+			text:     "vəˈluːmɪnəs",
+			notation: source.PronunciationNotationIPA,
+			want:     &source.Syllables{Count: 4, PrimaryStress: 2},
+		},
+		"ipa diphthongs": {
+			text:     "ˈaʊtˌsaɪd",
+			notation: source.PronunciationNotationIPA,
+			want:     &source.Syllables{Count: 2, PrimaryStress: 1},
+		},
+		"ipa hiatus": {
+			text:     "aɪˈdiə",
+			notation: source.PronunciationNotationIPA,
+			want:     &source.Syllables{Count: 3, PrimaryStress: 2},
+		},
+		"ipa syllabic consonant": {
+			text:     "ˈbʌtn̩",
+			notation: source.PronunciationNotationIPA,
+			want:     &source.Syllables{Count: 2, PrimaryStress: 1},
+		},
+		"ipa separated": {
+			text:     "ˈkɑn.ʃəs",
+			notation: source.PronunciationNotationIPA,
+			want:     &source.Syllables{Count: 2, PrimaryStress: 1},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := Syllabify(testData.text, testData.notation); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Syllabify returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Package quiz provides types and operations for quizzing a user on the
+// definitions of words.
+package quiz
+
+import (
+	"errors"
+	"math/rand/v2"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// maskText is the text that replaces the quizzed word in definitions
+const maskText = "____"
+
+// Question defines the structure of a quiz question.
+type Question struct {
+	Word       string
+	Definition string
+	Choices    []string
+}
+
+// NewQuestion builds a Question for a word from the word's dictionary results.
+//
+// If any distractor words are given, the question will be multiple-choice,
+// with the choices made up of the word and its distractors in a random order.
+func NewQuestion(word string, results source.DictionaryResults, distractors ...string) (Question, error) {
+	definition := FirstDefinition(results)
+	if definition == "" {
+		return Question{}, errors.New("no definitions to quiz on")
+	}
+
+	return NewDefinitionQuestion(word, definition, distractors...), nil
+}
+
+// NewDefinitionQuestion builds a Question for a word from a definition of it,
+// like one that's been saved, with the word masked in the definition.
+//
+// If any distractor words are given, the question will be multiple-choice,
+// with the choices made up of the word and its distractors in a random order.
+func NewDefinitionQuestion(word string, definition string, distractors ...string) Question {
+	question := Question{
+		Word:       word,
+		Definition: mask(definition, word),
+	}
+
+	if len(distractors) > 0 {
+		question.Choices = append([]string{word}, distractors...)
+
+		rand.Shuffle(len(question.Choices), func(i, j int) {
+			question.Choices[i], question.Choices[j] = question.Choices[j], question.Choices[i]
+		})
+	}
+
+	return question
+}
+
+// IsMultipleChoice returns true if the question is multiple-choice.
+func (q Question) IsMultipleChoice() bool {
+	return len(q.Choices) > 0
+}
+
+// Check returns true if the given answer is correct.
+//
+// For multiple-choice questions, the answer may be either the word or the
+// (1-based) number of the choice.
+func (q Question) Check(answer string) bool {
+	answer = strings.TrimSpace(answer)
+
+	if q.IsMultipleChoice() {
+		if num, err := strconv.Atoi(answer); err == nil && num > 0 && num <= len(q.Choices) {
+			answer = q.Choices[num-1]
+		}
+	}
+
+	return strings.EqualFold(answer, q.Word)
+}
+
+// Distractors returns up to a given number of words, randomly picked from a
+// list of words, that aren't the given word.
+func Distractors(word string, words []string, count int) []string {
+	var distractors []string
+
+	for _, i := range rand.Perm(len(words)) {
+		if len(distractors) >= count {
+			break
+		}
+
+		if !strings.EqualFold(words[i], word) {
+			distractors = append(distractors, words[i])
+		}
+	}
+
+	return distractors
+}
+
+// FirstDefinition returns the first non-empty definition found in a list of
+// dictionary results, with its whitespace collapsed.
+func FirstDefinition(results source.DictionaryResults) string {
+	for _, result := range results {
+		for _, entry := range result.Entries {
+			if definition := source.FirstDefinition(entry.Senses); definition != "" {
+				return definition
+			}
+		}
+	}
+
+	return ""
+}
+
+// mask replaces any case-insensitive occurrences of a word within a text, so
+// that a definition doesn't give away the answer.
+func mask(text string, word string) string {
+	if word == "" {
+		return text
+	}
+
+	pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(word) + `\b`)
+
+	return pattern.ReplaceAllString(text, maskText)
+}
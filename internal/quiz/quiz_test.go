@@ -0,0 +1,160 @@
+package quiz
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestNewQuestion(t *testing.T) {
+	results := source.DictionaryResults{
+		{
+			Word: "test",
+			Entries: []source.DictionaryEntry{
+				{Senses: []source.Sense{{}}},
+				{Senses: []source.Sense{
+					{SubSenses: []source.Sense{{Definitions: []string{"a  Test of\nthings, or testing"}}}},
+				}},
+			},
+		},
+	}
+
+	question, err := NewQuestion("test", results)
+	if err != nil {
+		t.Fatalf("NewQuestion returned an error: %s", err)
+	}
+
+	want := Question{Word: "test", Definition: "a ____ of things, or testing"}
+
+	if !reflect.DeepEqual(question, want) {
+		t.Errorf("NewQuestion returned wrong value. Got %#v. Want %#v.", question, want)
+	}
+
+	question, err = NewQuestion("test", results, "foo", "bar")
+	if err != nil {
+		t.Fatalf("NewQuestion returned an error: %s", err)
+	}
+
+	choices := slices.Clone(question.Choices)
+	slices.Sort(choices)
+	wantChoices := []string{"bar", "foo", "test"}
+
+	if !reflect.DeepEqual(choices, wantChoices) {
+		t.Errorf("NewQuestion returned wrong choices. Got %#v. Want %#v.", choices, wantChoices)
+	}
+
+	if _, err := NewQuestion("test", source.DictionaryResults{{Word: "test"}}); err == nil {
+		t.Error("NewQuestion didn't return an error for results without definitions")
+	}
+}
+
+func TestQuestion_Check(t *testing.T) {
+	typed := Question{Word: "test"}
+	multipleChoice := Question{Word: "test", Choices: []string{"foo", "test", "bar"}}
+
+	for testName, testData := range map[string]struct {
+		question Question
+		answer   string
+		want     bool
+	}{
+		"typed correct": {
+			question: typed,
+			answer:   "test",
+			want:     true,
+		},
+		"typed correct with different case and spacing": {
+			question: typed,
+			answer:   "  TeSt\n",
+			want:     true,
+		},
+		"typed incorrect": {
+			question: typed,
+			answer:   "tset",
+			want:     false,
+		},
+		"typed number": {
+			question: typed,
+			answer:   "1",
+			want:     false,
+		},
+		"multiple choice correct number": {
+			question: multipleChoice,
+			answer:   "2",
+			want:     true,
+		},
+		"multiple choice incorrect number": {
+			question: multipleChoice,
+			answer:   "3",
+			want:     false,
+		},
+		"multiple choice out of range number": {
+			question: multipleChoice,
+			answer:   "4",
+			want:     false,
+		},
+		"multiple choice correct word": {
+			question: multipleChoice,
+			answer:   "test",
+			want:     true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.question.Check(testData.answer); got != testData.want {
+				t.Errorf("Question.Check returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestDistractors(t *testing.T) {
+	words := []string{"foo", "Test", "bar", "baz"}
+
+	got := Distractors("test", words, 5)
+	slices.Sort(got)
+	want := []string{"bar", "baz", "foo"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Distractors returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+
+	if got := Distractors("test", words, 2); len(got) != 2 {
+		t.Errorf("Distractors returned wrong number of words. Got %d. Want %d.", len(got), 2)
+	}
+}
+
+func TestScores(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), scoresFileName)
+
+	scores, err := LoadScores(filePath)
+	if err != nil {
+		t.Fatalf("LoadScores returned an error for a missing file: %s", err)
+	}
+
+	scores.Record("test", true)
+	scores.Record("test", false)
+	scores.Record("foo", true)
+
+	if err := scores.Save(filePath); err != nil {
+		t.Fatalf("Scores.Save returned an error: %s", err)
+	}
+
+	got, err := LoadScores(filePath)
+	if err != nil {
+		t.Fatalf("LoadScores returned an error: %s", err)
+	}
+
+	want := Scores{
+		Score: Score{Correct: 2, Incorrect: 1},
+		Words: map[string]Score{
+			"test": {Correct: 1, Incorrect: 1},
+			"foo":  {Correct: 1},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadScores returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package quiz
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+)
+
+const (
+	xdgBaseName    = "define"
+	scoresFileName = "quiz-scores.json"
+)
+
+// Score defines the structure of a tally of answers.
+type Score struct {
+	Correct   uint
+	Incorrect uint
+}
+
+// Scores defines the structure of the tracked quiz scores, both overall and
+// per word.
+type Scores struct {
+	Score
+
+	Words map[string]Score `json:",omitempty"`
+}
+
+// Record records an answer for a word.
+func (s *Score) Record(correct bool) {
+	switch correct {
+	case true:
+		s.Correct++
+	case false:
+		s.Incorrect++
+	}
+}
+
+// Total returns the total number of answers.
+func (s Score) Total() uint {
+	return s.Correct + s.Incorrect
+}
+
+// Record records an answer for a word, in both the overall and word scores.
+func (s *Scores) Record(word string, correct bool) {
+	s.Score.Record(correct)
+
+	if s.Words == nil {
+		s.Words = make(map[string]Score)
+	}
+
+	wordScore := s.Words[word]
+	wordScore.Record(correct)
+	s.Words[word] = wordScore
+}
+
+// ScoresFilePath returns the path of the file that scores are tracked in,
+// within the user's XDG data directory.
+func ScoresFilePath() (string, error) {
+	return xdg.DataFile(filepath.Join(xdgBaseName, scoresFileName))
+}
+
+// LoadScores loads the scores from the file at the given path. If the file
+// doesn't exist, empty scores are returned.
+func LoadScores(filePath string) (Scores, error) {
+	var scores Scores
+
+	fileContents, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return scores, nil
+	}
+
+	if err != nil {
+		return scores, err
+	}
+
+	if len(fileContents) > 0 {
+		err = json.Unmarshal(fileContents, &scores)
+	}
+
+	return scores, err
+}
+
+// Save saves the scores to the file at the given path.
+func (s Scores) Save(filePath string) error {
+	encoded, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, encoded, 0o644)
+}
@@ -0,0 +1,201 @@
+// Package quota provides types and operations for tracking the usage of the
+// APIs that sources make requests to, so that users on limited plans can know
+// where they stand.
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+const (
+	xdgBaseName   = "define"
+	usageFileName = "quota.json"
+
+	dayLayout   = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+// rateLimitHeaderNames is the list of header name pairs (limit and remaining)
+// that APIs commonly use to report their quotas, in order of preference.
+var rateLimitHeaderNames = []struct {
+	limit     string
+	remaining string
+}{
+	{"RateLimit-Limit", "RateLimit-Remaining"},
+	{"X-RateLimit-Limit", "X-RateLimit-Remaining"},
+	{"X-RateLimit-Limit-Month", "X-RateLimit-Remaining-Month"},
+	{"X-RateLimit-Limit-Day", "X-RateLimit-Remaining-Day"},
+}
+
+// Usage defines the structure of the tracked usage of an API.
+type Usage struct {
+	Day       string // The day that the Today count is for
+	Today     uint
+	Month     string // The month that the ThisMonth count is for
+	ThisMonth uint
+
+	RateLimit *RateLimit `json:",omitempty"`
+}
+
+// RateLimit defines the structure of a quota reported by an API.
+type RateLimit struct {
+	Limit     uint
+	Remaining uint
+	UpdatedAt time.Time
+}
+
+// Usages defines the structure of the tracked usages of APIs, keyed by the
+// host of the API.
+type Usages map[string]Usage
+
+// Tracker tracks API usage in a file. It's safe for concurrent use.
+type Tracker struct {
+	filePath string
+
+	// mutex guards the file, so that concurrent records don't lose counts
+	mutex sync.Mutex
+}
+
+// NewTracker returns a new Tracker that tracks usage in the file at the given
+// path.
+func NewTracker(filePath string) *Tracker {
+	return &Tracker{filePath: filePath}
+}
+
+// UsageFilePath returns the path of the file that usage is tracked in, within
+// the user's XDG state directory.
+func UsageFilePath() (string, error) {
+	return xdg.StateFile(filepath.Join(xdgBaseName, usageFileName))
+}
+
+// Usages returns the tracked usages. If no usage has been tracked yet, empty
+// usages are returned.
+func (t *Tracker) Usages() (Usages, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.readUsages()
+}
+
+// Record records a request made to an API host at the given time, along with
+// any quota reported in the response headers.
+func (t *Tracker) Record(host string, at time.Time, header http.Header) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	usages, err := t.readUsages()
+	if err != nil {
+		return err
+	}
+
+	usages[host] = usages[host].record(at, header)
+
+	return t.writeUsages(usages)
+}
+
+// readUsages reads the tracked usages from the file.
+func (t *Tracker) readUsages() (Usages, error) {
+	usages := make(Usages)
+
+	fileContents, err := os.ReadFile(t.filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return usages, nil
+	}
+
+	if err != nil {
+		return usages, err
+	}
+
+	if len(fileContents) > 0 {
+		err = json.Unmarshal(fileContents, &usages)
+	}
+
+	return usages, err
+}
+
+// writeUsages writes the usages to the file, by replacing it with a complete
+// temporary file, so that it's never left partially written.
+func (t *Tracker) writeUsages(usages Usages) error {
+	encoded, err := json.MarshalIndent(usages, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(t.filePath), "."+filepath.Base(t.filePath)+".new-*")
+	if err != nil {
+		return err
+	}
+
+	tempPath := tempFile.Name()
+
+	// Clean up, if we don't make it to the end
+	defer os.Remove(tempPath)
+
+	if _, err := tempFile.Write(encoded); err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tempPath, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, t.filePath)
+}
+
+// Current returns the usage as of the given time, resetting the counts if
+// they're for a past day or month.
+func (u Usage) Current(at time.Time) Usage {
+	if day := at.Format(dayLayout); u.Day != day {
+		u.Day, u.Today = day, 0
+	}
+
+	if month := at.Format(monthLayout); u.Month != month {
+		u.Month, u.ThisMonth = month, 0
+	}
+
+	return u
+}
+
+// record returns the usage with a request made at the given time recorded.
+func (u Usage) record(at time.Time, header http.Header) Usage {
+	u = u.Current(at)
+
+	u.Today++
+	u.ThisMonth++
+
+	if rateLimit := parseRateLimit(header, at); rateLimit != nil {
+		u.RateLimit = rateLimit
+	}
+
+	return u
+}
+
+// parseRateLimit parses a quota from response headers, returning nil if the
+// headers don't report one.
+func parseRateLimit(header http.Header, at time.Time) *RateLimit {
+	for _, names := range rateLimitHeaderNames {
+		limit, limitErr := strconv.ParseUint(header.Get(names.limit), 10, 0)
+		remaining, remainingErr := strconv.ParseUint(header.Get(names.remaining), 10, 0)
+
+		if limitErr == nil && remainingErr == nil {
+			return &RateLimit{Limit: uint(limit), Remaining: uint(remaining), UpdatedAt: at}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package quota
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUsage_Current(t *testing.T) {
+	at := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	for testName, testData := range map[string]struct {
+		usage Usage
+		want  Usage
+	}{
+		"empty": {
+			usage: Usage{},
+			want:  Usage{Day: "2024-03-15", Month: "2024-03"},
+		},
+		"same day": {
+			usage: Usage{Day: "2024-03-15", Today: 3, Month: "2024-03", ThisMonth: 10},
+			want:  Usage{Day: "2024-03-15", Today: 3, Month: "2024-03", ThisMonth: 10},
+		},
+		"new day": {
+			usage: Usage{Day: "2024-03-14", Today: 3, Month: "2024-03", ThisMonth: 10},
+			want:  Usage{Day: "2024-03-15", Today: 0, Month: "2024-03", ThisMonth: 10},
+		},
+		"new month": {
+			usage: Usage{Day: "2024-02-29", Today: 3, Month: "2024-02", ThisMonth: 10},
+			want:  Usage{Day: "2024-03-15", Today: 0, Month: "2024-03", ThisMonth: 0},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.usage.Current(at); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Usage.Current returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	at := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	for testName, testData := range map[string]struct {
+		header http.Header
+		want   *RateLimit
+	}{
+		"none": {
+			header: http.Header{},
+			want:   nil,
+		},
+		"standard": {
+			header: http.Header{"Ratelimit-Limit": {"1000"}, "Ratelimit-Remaining": {"990"}},
+			want:   &RateLimit{Limit: 1000, Remaining: 990, UpdatedAt: at},
+		},
+		"x-prefixed": {
+			header: http.Header{"X-Ratelimit-Limit": {"60"}, "X-Ratelimit-Remaining": {"59"}},
+			want:   &RateLimit{Limit: 60, Remaining: 59, UpdatedAt: at},
+		},
+		"incomplete": {
+			header: http.Header{"X-Ratelimit-Limit": {"60"}},
+			want:   nil,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := parseRateLimit(testData.header, at); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("parseRateLimit returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", "999")
+	}))
+	defer server.Close()
+
+	tracker := NewTracker(filepath.Join(t.TempDir(), usageFileName))
+	client := http.Client{Transport: NewTransport(http.DefaultTransport, tracker)}
+
+	for i := 0; i < 2; i++ {
+		response, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("request returned an error: %s", err)
+		}
+
+		response.Body.Close()
+	}
+
+	usages, err := tracker.Usages()
+	if err != nil {
+		t.Fatalf("Tracker.Usages returned an error: %s", err)
+	}
+
+	usage := usages["127.0.0.1"]
+
+	if usage.Today != 2 || usage.ThisMonth != 2 {
+		t.Errorf("Tracker.Usages returned wrong counts. Got %d, %d. Want %d, %d.", usage.Today, usage.ThisMonth, 2, 2)
+	}
+
+	if usage.RateLimit == nil || usage.RateLimit.Remaining != 999 {
+		t.Errorf("Tracker.Usages returned wrong rate limit. Got %#v.", usage.RateLimit)
+	}
+}
+
+func TestTracker_RecordConcurrently(t *testing.T) {
+	const records = 50
+
+	tracker := NewTracker(filepath.Join(t.TempDir(), usageFileName))
+	at := time.Now()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < records; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := tracker.Record("example.com", at, nil); err != nil {
+				t.Errorf("Tracker.Record returned an error: %s", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	usages, err := tracker.Usages()
+	if err != nil {
+		t.Fatalf("Tracker.Usages returned an error: %s", err)
+	}
+
+	if usage := usages["example.com"]; usage.Today != records || usage.ThisMonth != records {
+		t.Errorf("Tracker.Usages returned wrong counts. Got %d, %d. Want %d, %d.", usage.Today, usage.ThisMonth, records, records)
+	}
+}
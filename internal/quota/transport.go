@@ -0,0 +1,31 @@
+package quota
+
+import (
+	"net/http"
+	"time"
+)
+
+// Transport is an http.RoundTripper that records the usage of the APIs that
+// requests are made to with a Tracker.
+type Transport struct {
+	inner   http.RoundTripper
+	tracker *Tracker
+}
+
+// NewTransport returns a new Transport that wraps an inner http.RoundTripper.
+func NewTransport(inner http.RoundTripper, tracker *Tracker) *Transport {
+	return &Transport{inner: inner, tracker: tracker}
+}
+
+// RoundTrip executes a single HTTP transaction, recording the usage of the
+// requested API if a response was received.
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.inner.RoundTrip(request)
+
+	if err == nil {
+		// Tracking usage is a best-effort, so it shouldn't fail the request
+		_ = t.tracker.Record(request.URL.Hostname(), time.Now(), response.Header)
+	}
+
+	return response, err
+}
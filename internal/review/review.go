@@ -0,0 +1,256 @@
+// Package review provides types and operations for reviewing saved words with
+// spaced repetition, scheduled by the SM-2 algorithm.
+//
+// See https://super-memory.com/english/ol/sm2.htm
+package review
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+const (
+	xdgBaseName  = "define"
+	deckFileName = "review-deck.json"
+)
+
+const (
+	// initialEaseFactor is the ease factor of newly saved words
+	initialEaseFactor = 2.5
+
+	// minEaseFactor is the lowest that an ease factor can go, so that words
+	// that are hard to remember aren't reviewed too often
+	minEaseFactor = 1.3
+)
+
+// List of qualities of recall, as graded by the SM-2 algorithm.
+const (
+	Blackout Quality = iota
+	Incorrect
+	IncorrectButFamiliar
+	CorrectWithDifficulty
+	Correct
+	Perfect
+)
+
+// Quality defines the quality of the recall of a word in a review, from 0 (a
+// complete blackout) to 5 (a perfect response).
+type Quality uint
+
+// Card defines the structure of a saved word and its review schedule.
+type Card struct {
+	Word string
+
+	// Definition is the definition to quiz on, saved so that reviews don't
+	// need to look the word up again
+	Definition string
+
+	// Tags are the lower-cased tags that the word is organized with, like
+	// "gre" or "favorite", in order
+	Tags []string `json:",omitempty"`
+
+	Repetitions uint    // The number of consecutive successful reviews
+	Interval    uint    // The number of days between the last review and the next
+	EaseFactor  float64 // How much the interval grows with each successful review
+
+	SavedAt time.Time
+	Due     time.Time
+}
+
+// Deck defines the structure of the saved words to review.
+type Deck struct {
+	Cards []Card
+}
+
+// IsPassing returns true if the quality is a successful recall.
+func (q Quality) IsPassing() bool {
+	return q >= CorrectWithDifficulty
+}
+
+// Review schedules the next review of the card, given the quality of its
+// recall at the given time.
+func (c *Card) Review(quality Quality, now time.Time) {
+	quality = min(quality, Perfect)
+
+	switch {
+	case !quality.IsPassing():
+		c.Repetitions = 0
+		c.Interval = 1
+	case c.Repetitions == 0:
+		c.Repetitions, c.Interval = 1, 1
+	case c.Repetitions == 1:
+		c.Repetitions, c.Interval = 2, 6
+	default:
+		c.Repetitions++
+		c.Interval = uint(math.Round(float64(c.Interval) * c.EaseFactor))
+	}
+
+	distance := float64(Perfect - quality)
+
+	c.EaseFactor = max(minEaseFactor, c.EaseFactor+0.1-distance*(0.08+distance*0.02))
+	c.Due = now.AddDate(0, 0, int(c.Interval))
+}
+
+// AddTags tags the card with the given tags, ignoring case and any tags that
+// it already has.
+func (c *Card) AddTags(tags ...string) {
+	for _, tag := range tags {
+		if tag = normalizeTag(tag); tag != "" && !slices.Contains(c.Tags, tag) {
+			c.Tags = append(c.Tags, tag)
+		}
+	}
+
+	slices.Sort(c.Tags)
+}
+
+// HasAnyTag returns true if the card is tagged with any of the given tags
+// (ignoring case), or if no tags are given.
+func (c Card) HasAnyTag(tags ...string) bool {
+	if len(tags) < 1 {
+		return true
+	}
+
+	return slices.ContainsFunc(tags, func(tag string) bool {
+		return slices.Contains(c.Tags, normalizeTag(tag))
+	})
+}
+
+// IsDue returns true if the card is due for review at the given time.
+func (c Card) IsDue(now time.Time) bool {
+	return !c.Due.After(now)
+}
+
+// Add saves a word to the deck with any given tags, due for review
+// immediately, and returns true. If the word is already saved, only its
+// definition and tags are updated, and false is returned.
+func (d *Deck) Add(word string, definition string, tags []string, now time.Time) bool {
+	if card := d.Find(word); card != nil {
+		card.Definition = definition
+		card.AddTags(tags...)
+		return false
+	}
+
+	card := Card{
+		Word:       word,
+		Definition: definition,
+		EaseFactor: initialEaseFactor,
+		SavedAt:    now,
+		Due:        now,
+	}
+
+	card.AddTags(tags...)
+	d.Cards = append(d.Cards, card)
+
+	return true
+}
+
+// Find returns the card of a word (ignoring case), or nil if the word isn't
+// saved.
+func (d *Deck) Find(word string) *Card {
+	for i := range d.Cards {
+		if strings.EqualFold(d.Cards[i].Word, word) {
+			return &d.Cards[i]
+		}
+	}
+
+	return nil
+}
+
+// Saved returns the saved cards that are tagged with any of the given tags, or
+// all of them if no tags are given, in the order they were saved.
+func (d *Deck) Saved(tags ...string) []*Card {
+	var saved []*Card
+
+	for i := range d.Cards {
+		if d.Cards[i].HasAnyTag(tags...) {
+			saved = append(saved, &d.Cards[i])
+		}
+	}
+
+	return saved
+}
+
+// Due returns the cards that are due for review at the given time, and that
+// are tagged with any of the given tags (if any are given), the most overdue
+// first.
+func (d *Deck) Due(now time.Time, tags ...string) []*Card {
+	var due []*Card
+
+	for _, card := range d.Saved(tags...) {
+		if card.IsDue(now) {
+			due = append(due, card)
+		}
+	}
+
+	slices.SortStableFunc(due, func(a, b *Card) int {
+		return a.Due.Compare(b.Due)
+	})
+
+	return due
+}
+
+// NextDue returns the time that the next card that's tagged with any of the
+// given tags (if any are given) is due for review, or the zero time if there
+// are no such cards.
+func (d *Deck) NextDue(tags ...string) time.Time {
+	var next time.Time
+
+	for _, card := range d.Saved(tags...) {
+		if next.IsZero() || card.Due.Before(next) {
+			next = card.Due
+		}
+	}
+
+	return next
+}
+
+// normalizeTag normalizes a tag for matching, by trimming and lower-casing it.
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// DeckFilePath returns the path of the file that the deck is saved in, within
+// the user's XDG data directory.
+func DeckFilePath() (string, error) {
+	return xdg.DataFile(filepath.Join(xdgBaseName, deckFileName))
+}
+
+// LoadDeck loads the deck from the file at the given path. If the file doesn't
+// exist, an empty deck is returned.
+func LoadDeck(filePath string) (Deck, error) {
+	var deck Deck
+
+	fileContents, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return deck, nil
+	}
+
+	if err != nil {
+		return deck, err
+	}
+
+	if len(fileContents) > 0 {
+		err = json.Unmarshal(fileContents, &deck)
+	}
+
+	return deck, err
+}
+
+// Save saves the deck to the file at the given path.
+func (d Deck) Save(filePath string) error {
+	encoded, err := json.MarshalIndent(d, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, encoded, 0o644)
+}
@@ -0,0 +1,115 @@
+package review
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCard_Review(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	for testName, testData := range map[string]struct {
+		card      Card
+		qualities []Quality
+		want      Card
+	}{
+		"first success": {
+			card:      Card{EaseFactor: initialEaseFactor},
+			qualities: []Quality{Correct},
+			want:      Card{Repetitions: 1, Interval: 1, EaseFactor: 2.5, Due: now.AddDate(0, 0, 1)},
+		},
+		"second success": {
+			card:      Card{EaseFactor: initialEaseFactor},
+			qualities: []Quality{Correct, Perfect},
+			want:      Card{Repetitions: 2, Interval: 6, EaseFactor: 2.6, Due: now.AddDate(0, 0, 6)},
+		},
+		"later success": {
+			card:      Card{Repetitions: 2, Interval: 6, EaseFactor: 2.5},
+			qualities: []Quality{Correct},
+			want:      Card{Repetitions: 3, Interval: 15, EaseFactor: 2.5, Due: now.AddDate(0, 0, 15)},
+		},
+		"failure": {
+			card:      Card{Repetitions: 3, Interval: 15, EaseFactor: 2.5},
+			qualities: []Quality{Incorrect},
+			want:      Card{Repetitions: 0, Interval: 1, EaseFactor: 1.96, Due: now.AddDate(0, 0, 1)},
+		},
+		"minimum ease factor": {
+			card:      Card{EaseFactor: 1.4},
+			qualities: []Quality{Blackout},
+			want:      Card{Repetitions: 0, Interval: 1, EaseFactor: minEaseFactor, Due: now.AddDate(0, 0, 1)},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			card := testData.card
+
+			for _, quality := range testData.qualities {
+				card.Review(quality, now)
+			}
+
+			// Round away any floating-point error
+			card.EaseFactor = float64(int(card.EaseFactor*100+0.5)) / 100
+
+			if !reflect.DeepEqual(card, testData.want) {
+				t.Errorf("Review resulted in wrong card. Got %#v. Want %#v.", card, testData.want)
+			}
+		})
+	}
+}
+
+func TestDeck(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	var deck Deck
+
+	if !deck.Add("test", "a test", []string{"GRE"}, now) || !deck.Add("word", "a word of text", nil, now.Add(-time.Hour)) {
+		t.Fatal("Add returned false for a new word")
+	}
+
+	if deck.Add("Test", "a new test", []string{"favorite", "gre"}, now) {
+		t.Error("Add returned true for a saved word")
+	}
+
+	if got, want := *deck.Find("test"), (Card{Word: "test", Definition: "a new test", Tags: []string{"favorite", "gre"}, EaseFactor: initialEaseFactor, SavedAt: now, Due: now}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Add didn't update the saved word. Got %#v. Want %#v.", got, want)
+	}
+
+	if got := deck.Saved("Gre", "toefl"); len(got) != 1 || got[0].Word != "test" {
+		t.Errorf("Saved returned wrong cards for a tag. Got %#v.", got)
+	}
+
+	if got := deck.Due(now, "toefl"); len(got) != 0 {
+		t.Errorf("Due returned wrong cards for a tag. Got %#v.", got)
+	}
+
+	due := deck.Due(now)
+	if len(due) != 2 || due[0].Word != "word" || due[1].Word != "test" {
+		t.Fatalf("Due returned wrong cards. Got %#v.", due)
+	}
+
+	due[0].Review(Correct, now)
+
+	if got := deck.Due(now); len(got) != 1 || got[0].Word != "test" {
+		t.Errorf("Due returned wrong cards after a review. Got %#v.", got)
+	}
+
+	if got, want := deck.NextDue(), now; !got.Equal(want) {
+		t.Errorf("NextDue returned wrong value. Got %s. Want %s.", got, want)
+	}
+
+	filePath := filepath.Join(t.TempDir(), deckFileName)
+
+	if err := deck.Save(filePath); err != nil {
+		t.Fatalf("Save returned an error: %s", err)
+	}
+
+	loaded, err := LoadDeck(filePath)
+	if err != nil {
+		t.Fatalf("LoadDeck returned an error: %s", err)
+	}
+
+	if !reflect.DeepEqual(loaded, deck) {
+		t.Errorf("LoadDeck returned wrong value. Got %#v. Want %#v.", loaded, deck)
+	}
+}
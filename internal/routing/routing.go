@@ -0,0 +1,115 @@
+// Package routing provides types and operations for routing words to the
+// sources that should define them, based on user configured rules.
+package routing
+
+import (
+	"fmt"
+	"regexp"
+	"unicode"
+)
+
+// Rule defines the structure of a rule that routes matching words to a source.
+//
+// A word matches a rule if it matches all of the rule's set conditions.
+type Rule struct {
+	Pattern string // A regular expression that the word must match
+	Script  string // A Unicode script (like "Cyrillic") that the word's letters must be in
+	Source  string // The source to route matching words to
+}
+
+// Router routes words to sources by a list of rules, in order.
+type Router struct {
+	rules []compiledRule
+}
+
+// compiledRule is a Rule with its conditions compiled for matching.
+type compiledRule struct {
+	pattern *regexp.Regexp
+	script  *unicode.RangeTable
+	source  string
+}
+
+// NewRouter returns a new Router for the given rules, or an error if any of
+// the rules are invalid.
+func NewRouter(rules []Rule) (*Router, error) {
+	router := &Router{rules: make([]compiledRule, 0, len(rules))}
+
+	for i, rule := range rules {
+		compiled := compiledRule{source: rule.Source}
+
+		if rule.Source == "" {
+			return nil, fmt.Errorf("source route %d has no source", i+1)
+		}
+
+		if rule.Pattern == "" && rule.Script == "" {
+			return nil, fmt.Errorf("source route %d has no pattern or script to match", i+1)
+		}
+
+		if rule.Pattern != "" {
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("source route %d has an invalid pattern: %w", i+1, err)
+			}
+
+			compiled.pattern = pattern
+		}
+
+		if rule.Script != "" {
+			script, exists := unicode.Scripts[rule.Script]
+			if !exists {
+				return nil, fmt.Errorf("source route %d has an unknown script %q", i+1, rule.Script)
+			}
+
+			compiled.script = script
+		}
+
+		router.rules = append(router.rules, compiled)
+	}
+
+	return router, nil
+}
+
+// Route returns the source of the first rule that the word matches, and true,
+// or false if the word matches none of the rules.
+func (r *Router) Route(word string) (string, bool) {
+	for _, rule := range r.rules {
+		if rule.matches(word) {
+			return rule.source, true
+		}
+	}
+
+	return "", false
+}
+
+// matches returns true if the word matches all of the rule's conditions.
+func (r compiledRule) matches(word string) bool {
+	if r.pattern != nil && !r.pattern.MatchString(word) {
+		return false
+	}
+
+	if r.script != nil && !inScript(word, r.script) {
+		return false
+	}
+
+	return true
+}
+
+// inScript returns true if all of the letters of the word are in the script,
+// and the word has at least one letter.
+func inScript(word string, script *unicode.RangeTable) bool {
+	var hasLetters bool
+
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		if !unicode.Is(script, r) {
+			return false
+		}
+
+		hasLetters = true
+	}
+
+	return hasLetters
+}
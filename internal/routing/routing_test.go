@@ -0,0 +1,56 @@
+package routing
+
+import (
+	"testing"
+)
+
+func TestNewRouterInvalid(t *testing.T) {
+	for testName, rule := range map[string]Rule{
+		"no source":       {Pattern: "^a"},
+		"no conditions":   {Source: "OxfordDictionary"},
+		"invalid pattern": {Pattern: "(", Source: "OxfordDictionary"},
+		"unknown script":  {Script: "Klingon", Source: "OxfordDictionary"},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if _, err := NewRouter([]Rule{rule}); err == nil {
+				t.Errorf("NewRouter returned no error for rule %#v", rule)
+			}
+		})
+	}
+}
+
+func TestRoute(t *testing.T) {
+	router, err := NewRouter([]Rule{
+		{Pattern: `in'$`, Source: "Slang"},
+		{Script: "Cyrillic", Source: "Russian"},
+		{Pattern: `^[A-Z]`, Script: "Latin", Source: "Names"},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter returned an error: %v", err)
+	}
+
+	for testName, testData := range map[string]struct {
+		word       string
+		wantSource string
+		wantOK     bool
+	}{
+		"pattern":             {word: "chillin'", wantSource: "Slang", wantOK: true},
+		"script":              {word: "слово", wantSource: "Russian", wantOK: true},
+		"script with symbols": {word: "что-то", wantSource: "Russian", wantOK: true},
+		"all conditions":      {word: "Paris", wantSource: "Names", wantOK: true},
+		"partial conditions":  {word: "Москва", wantSource: "Russian", wantOK: true},
+		"no match":            {word: "word", wantOK: false},
+		"no letters":          {word: "123", wantOK: false},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			source, ok := router.Route(testData.word)
+
+			if source != testData.wantSource || ok != testData.wantOK {
+				t.Errorf(
+					"Route returned wrong value. Got %#v, %#v. Want %#v, %#v.",
+					source, ok, testData.wantSource, testData.wantOK,
+				)
+			}
+		})
+	}
+}
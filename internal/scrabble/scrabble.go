@@ -0,0 +1,96 @@
+// Package scrabble provides letter scoring and word validity checking for
+// word games, like Scrabble and Words With Friends.
+package scrabble
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Rican7/define/internal/wordlist"
+)
+
+// Scoring defines a mapping of letters to their point values in a word game.
+type Scoring struct {
+	Name   string
+	Values map[rune]uint
+}
+
+// WordList defines a list of words that are valid in a word game.
+type WordList map[string]struct{}
+
+var (
+	// Scrabble is the letter scoring of (English) Scrabble.
+	Scrabble = Scoring{
+		Name: "Scrabble",
+		Values: map[rune]uint{
+			'A': 1, 'B': 3, 'C': 3, 'D': 2, 'E': 1, 'F': 4, 'G': 2, 'H': 4, 'I': 1,
+			'J': 8, 'K': 5, 'L': 1, 'M': 3, 'N': 1, 'O': 1, 'P': 3, 'Q': 10, 'R': 1,
+			'S': 1, 'T': 1, 'U': 1, 'V': 4, 'W': 4, 'X': 8, 'Y': 4, 'Z': 10,
+		},
+	}
+
+	// WordsWithFriends is the letter scoring of Words With Friends.
+	WordsWithFriends = Scoring{
+		Name: "Words With Friends",
+		Values: map[rune]uint{
+			'A': 1, 'B': 4, 'C': 4, 'D': 2, 'E': 1, 'F': 4, 'G': 3, 'H': 3, 'I': 1,
+			'J': 10, 'K': 5, 'L': 2, 'M': 4, 'N': 2, 'O': 1, 'P': 4, 'Q': 10, 'R': 1,
+			'S': 1, 'T': 1, 'U': 2, 'V': 5, 'W': 4, 'X': 8, 'Y': 3, 'Z': 10,
+		},
+	}
+
+	// Scorings is the list of supported letter scorings.
+	Scorings = []Scoring{Scrabble, WordsWithFriends}
+)
+
+// Score returns the point value of a word, without any board bonuses. It
+// returns an error if the word contains any letters that can't be played.
+func (s Scoring) Score(word string) (uint, error) {
+	var score uint
+
+	for _, letter := range word {
+		value, exists := s.Values[unicode.ToUpper(letter)]
+		if !exists {
+			return 0, fmt.Errorf("%q can't be played in %s", letter, s.Name)
+		}
+
+		score += value
+	}
+
+	return score, nil
+}
+
+// LoadWordList loads a list of valid words from the file at the given path,
+// with one word per line.
+func LoadWordList(filePath string) (WordList, error) {
+	words, err := wordlist.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWordList(words...), nil
+}
+
+// NewWordList creates a new WordList from a list of words.
+func NewWordList(words ...string) WordList {
+	list := make(WordList, len(words))
+
+	for _, word := range words {
+		list[normalize(word)] = struct{}{}
+	}
+
+	return list
+}
+
+// Contains returns true if the list contains the given word.
+func (l WordList) Contains(word string) bool {
+	_, exists := l[normalize(word)]
+
+	return exists
+}
+
+// normalize normalizes a word for case-insensitive comparison.
+func normalize(word string) string {
+	return strings.ToUpper(strings.TrimSpace(word))
+}
@@ -0,0 +1,68 @@
+package scrabble
+
+import (
+	"testing"
+)
+
+func TestScoring_Score(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		scoring Scoring
+		word    string
+		want    uint
+		wantErr bool
+	}{
+		"scrabble empty": {
+			scoring: Scrabble,
+			word:    "",
+			want:    0,
+		},
+		"scrabble word": {
+			scoring: Scrabble,
+			word:    "quiz",
+			want:    22,
+		},
+		"scrabble mixed case": {
+			scoring: Scrabble,
+			word:    "JukeBox",
+			want:    27,
+		},
+		"scrabble invalid letters": {
+			scoring: Scrabble,
+			word:    "jack-in-the-box",
+			wantErr: true,
+		},
+		"words with friends word": {
+			scoring: WordsWithFriends,
+			word:    "quiz",
+			want:    23,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := testData.scoring.Score(testData.word)
+
+			if (err != nil) != testData.wantErr {
+				t.Errorf("Scoring.Score returned an unexpected error. Got %#v.", err)
+			}
+
+			if got != testData.want {
+				t.Errorf("Scoring.Score returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestWordList_Contains(t *testing.T) {
+	list := NewWordList("quiz", " JUKEBOX ")
+
+	for word, want := range map[string]bool{
+		"quiz":    true,
+		"QUIZ":    true,
+		"jukebox": true,
+		"quizzes": false,
+		"":        false,
+	} {
+		if got := list.Contains(word); got != want {
+			t.Errorf("WordList.Contains(%q) returned wrong value. Got %#v. Want %#v.", word, got, want)
+		}
+	}
+}
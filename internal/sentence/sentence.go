@@ -0,0 +1,171 @@
+// Package sentence provides operations for splitting sentences into the words
+// that are worth defining.
+package sentence
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// stopWordsNone is a special value to specify no stop words
+	stopWordsNone = "none"
+
+	// stopWordsSeparator is the separator of a list of stop words
+	stopWordsSeparator = ","
+)
+
+// StopWords defines a set of words to skip when defining each word of a
+// sentence.
+type StopWords map[string]struct{}
+
+// DefaultStopWords is the default set of (English) stop words: common
+// function words that are rarely worth defining.
+var DefaultStopWords = NewStopWords(
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "from",
+	"had", "has", "have", "he", "her", "his", "i", "if", "in", "into", "is",
+	"it", "its", "my", "no", "not", "of", "on", "or", "our", "she", "so",
+	"than", "that", "the", "their", "them", "then", "there", "these", "they",
+	"this", "to", "was", "we", "were", "what", "which", "who", "will", "with",
+	"you", "your",
+)
+
+// NewStopWords creates a new set of StopWords from a list of words.
+func NewStopWords(words ...string) StopWords {
+	stopWords := make(StopWords, len(words))
+
+	for _, word := range words {
+		stopWords[strings.ToLower(word)] = struct{}{}
+	}
+
+	return stopWords
+}
+
+// ParseStopWords takes a comma separated list of stop words and returns the
+// matching StopWords. An empty list returns the DefaultStopWords, and the
+// special value "none" returns no stop words.
+func ParseStopWords(list string) StopWords {
+	switch strings.ToLower(strings.TrimSpace(list)) {
+	case "":
+		return DefaultStopWords
+	case stopWordsNone:
+		return StopWords{}
+	}
+
+	var words []string
+
+	for _, word := range strings.Split(list, stopWordsSeparator) {
+		if word = strings.TrimSpace(word); word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return NewStopWords(words...)
+}
+
+// Contains returns true if the stop words contain the given word, ignoring
+// case.
+func (s StopWords) Contains(word string) bool {
+	_, contains := s[strings.ToLower(word)]
+
+	return contains
+}
+
+// Token defines the structure of a word in a text, along with its position.
+type Token struct {
+	Text  string
+	Start int // The byte offset of the start of the word in the text
+	End   int // The byte offset just after the end of the word in the text
+}
+
+// Tokenize splits a text into its words, keeping any inner apostrophes and
+// hyphens (like in "don't" or "well-known") but stripping all other
+// punctuation.
+func Tokenize(text string) []string {
+	tokens := Tokens(text)
+
+	words := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		words = append(words, token.Text)
+	}
+
+	return words
+}
+
+// Tokens splits a text into its words, like Tokenize, but also returns the
+// positions of the words in the text.
+func Tokens(text string) []Token {
+	var tokens []Token
+
+	start := -1
+
+	addToken := func(end int) {
+		field := text[start:end]
+		trimmedStart := len(field) - len(strings.TrimLeftFunc(field, isInnerWordRune))
+		trimmed := strings.TrimFunc(field, isInnerWordRune)
+
+		if trimmed != "" {
+			tokens = append(tokens, Token{
+				Text:  trimmed,
+				Start: start + trimmedStart,
+				End:   start + trimmedStart + len(trimmed),
+			})
+		}
+	}
+
+	for i, r := range text {
+		isPartOfWord := isWordRune(r) || isInnerWordRune(r)
+
+		switch {
+		case isPartOfWord && start < 0:
+			start = i
+		case !isPartOfWord && start >= 0:
+			addToken(i)
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		addToken(len(text))
+	}
+
+	return tokens
+}
+
+// Words splits a text into its unique words, skipping any of the given stop
+// words, in the order that they first appear.
+func Words(text string, stopWords StopWords) []string {
+	var words []string
+
+	seen := make(map[string]struct{})
+
+	for _, word := range Tokenize(text) {
+		key := strings.ToLower(word)
+
+		if _, isSeen := seen[key]; isSeen || stopWords.Contains(word) {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		words = append(words, word)
+	}
+
+	return words
+}
+
+// isWordRune returns true if the rune is part of a word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// isInnerWordRune returns true if the rune may be part of a word, as long as
+// it's not at the start or end of it.
+func isInnerWordRune(r rune) bool {
+	switch r {
+	case '\'', '’', '-':
+		return true
+	}
+
+	return false
+}
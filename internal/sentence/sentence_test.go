@@ -0,0 +1,117 @@
+package sentence
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStopWords(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		list string
+		want StopWords
+	}{
+		"empty": {
+			list: "",
+			want: DefaultStopWords,
+		},
+		"none": {
+			list: "None",
+			want: StopWords{},
+		},
+		"list": {
+			list: "The, of,,AND",
+			want: NewStopWords("the", "of", "and"),
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := ParseStopWords(testData.list); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("ParseStopWords returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text string
+		want []string
+	}{
+		"empty": {
+			text: "",
+			want: []string{},
+		},
+		"simple": {
+			text: "The quick brown fox",
+			want: []string{"The", "quick", "brown", "fox"},
+		},
+		"punctuation": {
+			text: "Well, it's a well-known fact -- isn't it? 'Yes.'",
+			want: []string{"Well", "it's", "a", "well-known", "fact", "isn't", "it", "Yes"},
+		},
+		"unicode": {
+			text: "Un café, s'il vous plaît.",
+			want: []string{"Un", "café", "s'il", "vous", "plaît"},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := Tokenize(testData.text); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Tokenize returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestTokens(t *testing.T) {
+	text := "'Hi,' said the well-known café owner"
+
+	want := []Token{
+		{Text: "Hi", Start: 1, End: 3},
+		{Text: "said", Start: 6, End: 10},
+		{Text: "the", Start: 11, End: 14},
+		{Text: "well-known", Start: 15, End: 25},
+		{Text: "café", Start: 26, End: 31},
+		{Text: "owner", Start: 32, End: 37},
+	}
+
+	got := Tokens(text)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokens returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+
+	for _, token := range got {
+		if text[token.Start:token.End] != token.Text {
+			t.Errorf("Tokens returned wrong position for %q. Got %q.", token.Text, text[token.Start:token.End])
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text      string
+		stopWords StopWords
+		want      []string
+	}{
+		"default stop words": {
+			text:      "The quick brown fox jumps over the lazy dog",
+			stopWords: DefaultStopWords,
+			want:      []string{"quick", "brown", "fox", "jumps", "over", "lazy", "dog"},
+		},
+		"no stop words": {
+			text:      "The cat and the hat",
+			stopWords: StopWords{},
+			want:      []string{"The", "cat", "and", "hat"},
+		},
+		"only stop words": {
+			text:      "to be or not to be",
+			stopWords: DefaultStopWords,
+			want:      nil,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := Words(testData.text, testData.stopWords); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Words returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
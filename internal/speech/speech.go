@@ -0,0 +1,99 @@
+// Package speech provides a way to speak words aloud, by running an external
+// text-to-speech command.
+package speech
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/Rican7/define/internal/command"
+)
+
+// List of placeholders that are replaced in the arguments of a command.
+const (
+	// PlaceholderWord is replaced by the word to speak
+	PlaceholderWord = "{word}"
+
+	// PlaceholderIPA is replaced by the IPA pronunciation of the word, or the
+	// word itself, if there's no known pronunciation
+	PlaceholderIPA = "{ipa}"
+)
+
+// Text defines the structure of the text to speak.
+type Text struct {
+	Word string
+	IPA  string // The IPA pronunciation of the word, if known
+}
+
+// Speaker speaks text by running a command.
+type Speaker struct {
+	args []string
+}
+
+// DefaultCommand returns the default text-to-speech command for the given
+// operating system (as named by runtime.GOOS).
+func DefaultCommand(goos string) string {
+	switch goos {
+	case "darwin":
+		return "say " + PlaceholderWord
+	case "windows":
+		// SAPI, via PowerShell, reading the word from stdin
+		return `powershell -NoProfile -Command "Add-Type -AssemblyName System.Speech; (New-Object System.Speech.Synthesis.SpeechSynthesizer).Speak([Console]::In.ReadToEnd())"`
+	}
+
+	return "espeak-ng " + PlaceholderWord
+}
+
+// NewSpeaker returns a new Speaker that runs the given command.
+//
+// The command is split into arguments by whitespace, with single or double
+// quotes grouping arguments containing whitespace. If none of the arguments
+// contain a placeholder, the word is written to the command's stdin instead.
+func NewSpeaker(commandLine string) (*Speaker, error) {
+	args, err := command.Split(commandLine)
+	if err != nil {
+		return nil, fmt.Errorf("invalid speech command: %w", err)
+	}
+
+	if len(args) < 1 {
+		return nil, errors.New("the speech command is empty")
+	}
+
+	return &Speaker{args: args}, nil
+}
+
+// Speak speaks the given text, waiting for the command to finish.
+func (s *Speaker) Speak(text Text) error {
+	ipa := text.IPA
+	if ipa == "" {
+		ipa = text.Word
+	}
+
+	replacer := strings.NewReplacer(PlaceholderWord, text.Word, PlaceholderIPA, ipa)
+
+	args := make([]string, len(s.args))
+	hasPlaceholder := false
+
+	for i, arg := range s.args {
+		args[i] = replacer.Replace(arg)
+		hasPlaceholder = hasPlaceholder || args[i] != arg
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+
+	if !hasPlaceholder {
+		cmd.Stdin = strings.NewReader(text.Word)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("running the speech command failed: %w: %s", err, message)
+		}
+
+		return fmt.Errorf("running the speech command failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package speech
+
+import (
+	"testing"
+)
+
+func TestSpeak(t *testing.T) {
+	testData := map[string]struct {
+		command string
+		text    Text
+		wantErr bool
+	}{
+		"word argument":      {command: `sh -c 'test "$0" = test' {word}`, text: Text{Word: "test"}},
+		"ipa argument":       {command: `sh -c 'test "$0" = tɛst' {ipa}`, text: Text{Word: "test", IPA: "tɛst"}},
+		"ipa falls back":     {command: `sh -c 'test "$0" = test' {ipa}`, text: Text{Word: "test"}},
+		"word on stdin":      {command: `sh -c 'test "$(cat)" = test'`, text: Text{Word: "test"}},
+		"failing command":    {command: `sh -c 'exit 1'`, text: Text{Word: "test"}, wantErr: true},
+		"nonexistent binary": {command: "define-nonexistent-tts {word}", text: Text{Word: "test"}, wantErr: true},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			speaker, err := NewSpeaker(testData.command)
+			if err != nil {
+				t.Fatalf("NewSpeaker returned an error: %v", err)
+			}
+
+			if err := speaker.Speak(testData.text); (err != nil) != testData.wantErr {
+				t.Errorf("Speak returned wrong error. Got %#v. Want error: %#v.", err, testData.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package spellcheck provides types and operations for finding the unknown
+// words of a text, by their positions.
+package spellcheck
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Rican7/define/internal/sentence"
+)
+
+// possessiveSuffixes are the suffixes of possessive words (like "Webster's"),
+// which are checked without them.
+var possessiveSuffixes = []string{"'s", "’s", "'", "’"}
+
+// Diagnostic defines the structure of an unknown word in a text.
+type Diagnostic struct {
+	Word        string
+	Line        int      // The line of the word, from 1
+	Column      int      // The column of the word, in characters from 1
+	Suggestions []string `json:",omitempty"` // Known words that may have been meant
+}
+
+// Check takes a text and a function that reports whether a word is known, and
+// returns a diagnostic for each occurrence of an unknown word in the text, in
+// order.
+//
+// Words with digits (like "3rd") are skipped, hyphenated words are known if
+// each of their parts are, and possessive words are checked without their
+// possessive suffix.
+func Check(text string, isKnown func(word string) bool) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	line, lineStart := 1, 0
+	offset := 0
+
+	for _, token := range sentence.Tokens(text) {
+		// Track the line of the token, from where the last one was
+		for i := strings.IndexByte(text[offset:token.Start], '\n'); i >= 0; i = strings.IndexByte(text[offset:token.Start], '\n') {
+			line++
+			offset += i + 1
+			lineStart = offset
+		}
+
+		offset = token.Start
+
+		if strings.IndexFunc(token.Text, unicode.IsDigit) >= 0 || isKnownWord(token.Text, isKnown) {
+			continue
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Word:   token.Text,
+			Line:   line,
+			Column: utf8.RuneCountInString(text[lineStart:token.Start]) + 1,
+		})
+	}
+
+	return diagnostics
+}
+
+// isKnownWord returns whether a word is known, by the given function, after
+// handling its hyphens and possessive suffixes.
+func isKnownWord(word string, isKnown func(word string) bool) bool {
+	if isKnown(word) {
+		return true
+	}
+
+	for _, suffix := range possessiveSuffixes {
+		if stem, found := strings.CutSuffix(word, suffix); found && stem != "" {
+			return isKnownWord(stem, isKnown)
+		}
+	}
+
+	parts := strings.Split(word, "-")
+	if len(parts) < 2 {
+		return false
+	}
+
+	for _, part := range parts {
+		if part == "" || !isKnownWord(part, isKnown) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,70 @@
+package spellcheck
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	knownWords := map[string]bool{
+		"the": true, "cat": true, "sat": true, "on": true, "mat": true,
+		"well": true, "known": true, "webster": true, "café": true,
+	}
+
+	isKnown := func(word string) bool {
+		return knownWords[strings.ToLower(word)]
+	}
+
+	for testName, testData := range map[string]struct {
+		text string
+		want []Diagnostic
+	}{
+		"empty": {
+			text: "",
+			want: nil,
+		},
+		"all known": {
+			text: "The cat sat on the mat.\n",
+			want: nil,
+		},
+		"unknown words": {
+			text: "The catt sat\non teh mat.\n",
+			want: []Diagnostic{
+				{Word: "catt", Line: 1, Column: 5},
+				{Word: "teh", Line: 2, Column: 4},
+			},
+		},
+		"repeated unknown words": {
+			text: "teh cat\n\nteh mat",
+			want: []Diagnostic{
+				{Word: "teh", Line: 1, Column: 1},
+				{Word: "teh", Line: 3, Column: 1},
+			},
+		},
+		"columns in characters": {
+			text: "café catt",
+			want: []Diagnostic{
+				{Word: "catt", Line: 1, Column: 6},
+			},
+		},
+		"digits": {
+			text: "The 3rd cat sat on 42 mats",
+			want: []Diagnostic{
+				{Word: "mats", Line: 1, Column: 23},
+			},
+		},
+		"hyphens and possessives": {
+			text: "The well-known Webster's cat-mat well-knwon",
+			want: []Diagnostic{
+				{Word: "well-knwon", Line: 1, Column: 34},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := Check(testData.text, isKnown); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Check returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,503 @@
+// Package sqlite provides a minimal, read-only reader of SQLite database files,
+// for reading the tables of small databases exported by other apps (like the
+// Kindle's vocabulary builder) without depending on a full SQLite driver.
+//
+// Only UTF-8 databases and table (not index) b-trees are supported, which is
+// enough to read every row of a table.
+package sqlite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+const (
+	headerMagic = "SQLite format 3\x00"
+	headerSize  = 100
+
+	textEncodingUTF8 = 1
+
+	// The page types of table b-trees
+	pageTypeInteriorTable = 0x05
+	pageTypeLeafTable     = 0x0d
+
+	// The root page of the schema table
+	schemaRootPage = 1
+
+	// maxTreeDepth is the maximum depth of a b-tree that will be read, to
+	// guard against cycles in malformed files
+	maxTreeDepth = 64
+)
+
+// ErrNotDatabase is returned when a file isn't an SQLite database.
+var ErrNotDatabase = errors.New("file is not an SQLite database")
+
+// DB is an SQLite database that's been read into memory.
+type DB struct {
+	data       []byte
+	pageSize   int
+	usableSize int
+}
+
+// Row defines the structure of a row of a table, keyed by column name.
+//
+// Values are either nil, an int64, a float64, a string, or a []byte.
+type Row map[string]any
+
+// Open reads the SQLite database file at the given path.
+func Open(path string) (*DB, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(data)
+}
+
+// New returns a DB for the given contents of an SQLite database file.
+func New(data []byte) (*DB, error) {
+	if len(data) < headerSize || !bytes.HasPrefix(data, []byte(headerMagic)) {
+		return nil, ErrNotDatabase
+	}
+
+	pageSize := int(binary.BigEndian.Uint16(data[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, fmt.Errorf("invalid database page size %d", pageSize)
+	}
+
+	if encoding := binary.BigEndian.Uint32(data[56:60]); encoding != textEncodingUTF8 && encoding != 0 {
+		return nil, errors.New("only UTF-8 databases are supported")
+	}
+
+	return &DB{
+		data:       data,
+		pageSize:   pageSize,
+		usableSize: pageSize - int(data[20]),
+	}, nil
+}
+
+// ReadTable returns all of the rows of the table with the given name.
+func (db *DB) ReadTable(name string) ([]Row, error) {
+	schemaRows, err := db.readRows(schemaRootPage, []string{"type", "name", "tbl_name", "rootpage", "sql"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schemaRow := range schemaRows {
+		tableName, _ := schemaRow["name"].(string)
+
+		if schemaRow["type"] != "table" || !strings.EqualFold(tableName, name) {
+			continue
+		}
+
+		rootPage, _ := schemaRow["rootpage"].(int64)
+		sql, _ := schemaRow["sql"].(string)
+
+		schema := parseSchema(sql)
+		if len(schema.columns) < 1 {
+			return nil, fmt.Errorf("unable to parse the columns of table %q", name)
+		}
+
+		rows, err := db.readRows(int(rootPage), schema.columns)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			// An "INTEGER PRIMARY KEY" column is an alias of the row ID,
+			// which is stored in place of the column's value
+			if schema.rowIDColumn != "" {
+				row[schema.rowIDColumn] = row[rowIDKey]
+			}
+
+			// Real values without a fractional part may be stored as integers
+			for _, column := range schema.realColumns {
+				if value, isInt := row[column].(int64); isInt {
+					row[column] = float64(value)
+				}
+			}
+
+			delete(row, rowIDKey)
+		}
+
+		return rows, nil
+	}
+
+	return nil, fmt.Errorf("table %q does not exist", name)
+}
+
+// rowIDKey is the key that the row ID of a row is temporarily stored under.
+const rowIDKey = "\x00rowid"
+
+// readRows reads all of the rows of the table b-tree with the given root page.
+func (db *DB) readRows(rootPage int, columns []string) ([]Row, error) {
+	var rows []Row
+
+	err := db.walkTable(rootPage, 0, func(rowID int64, payload []byte) error {
+		values, err := parseRecord(payload)
+		if err != nil {
+			return err
+		}
+
+		row := Row{rowIDKey: rowID}
+
+		for i, column := range columns {
+			if i < len(values) {
+				row[column] = values[i]
+			} else {
+				// Columns added after the row was written have no values
+				row[column] = nil
+			}
+		}
+
+		rows = append(rows, row)
+
+		return nil
+	})
+
+	return rows, err
+}
+
+// page returns the contents of the page with the given (1-based) number.
+func (db *DB) page(number int) ([]byte, error) {
+	start := (number - 1) * db.pageSize
+
+	if number < 1 || start+db.pageSize > len(db.data) {
+		return nil, fmt.Errorf("page %d is out of range", number)
+	}
+
+	return db.data[start : start+db.pageSize], nil
+}
+
+// walkTable calls the given function with the row ID and payload of each cell
+// of the table b-tree with the given root page, in order.
+func (db *DB) walkTable(pageNumber int, depth int, visit func(int64, []byte) error) error {
+	if depth > maxTreeDepth {
+		return errors.New("table b-tree is too deep")
+	}
+
+	page, err := db.page(pageNumber)
+	if err != nil {
+		return err
+	}
+
+	// The first page's b-tree header follows the database header
+	headerOffset := 0
+	if pageNumber == 1 {
+		headerOffset = headerSize
+	}
+
+	header := page[headerOffset:]
+	cellCount := int(binary.BigEndian.Uint16(header[3:5]))
+
+	var cellPointers []byte
+
+	switch header[0] {
+	case pageTypeLeafTable:
+		cellPointers = header[8:]
+	case pageTypeInteriorTable:
+		cellPointers = header[12:]
+	default:
+		return fmt.Errorf("page %d is not a table b-tree page", pageNumber)
+	}
+
+	if len(cellPointers) < cellCount*2 {
+		return fmt.Errorf("page %d has too many cells", pageNumber)
+	}
+
+	for i := 0; i < cellCount; i++ {
+		cellOffset := int(binary.BigEndian.Uint16(cellPointers[i*2:]))
+		if cellOffset >= len(page) {
+			return fmt.Errorf("page %d has a cell out of range", pageNumber)
+		}
+
+		cell := page[cellOffset:]
+
+		if header[0] == pageTypeInteriorTable {
+			if len(cell) < 4 {
+				return fmt.Errorf("page %d has a truncated cell", pageNumber)
+			}
+
+			if err := db.walkTable(int(binary.BigEndian.Uint32(cell)), depth+1, visit); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		rowID, payload, err := db.parseLeafCell(cell)
+		if err != nil {
+			return err
+		}
+
+		if err := visit(rowID, payload); err != nil {
+			return err
+		}
+	}
+
+	if header[0] == pageTypeInteriorTable {
+		return db.walkTable(int(binary.BigEndian.Uint32(header[8:12])), depth+1, visit)
+	}
+
+	return nil
+}
+
+// parseLeafCell parses the row ID and the full payload of a table leaf cell,
+// following any overflow pages.
+func (db *DB) parseLeafCell(cell []byte) (int64, []byte, error) {
+	payloadSize, n := readVarint(cell)
+	cell = cell[n:]
+
+	rowID, n := readVarint(cell)
+	cell = cell[n:]
+
+	if payloadSize < 0 || payloadSize > int64(len(db.data)) {
+		return 0, nil, errors.New("cell has an invalid payload size")
+	}
+
+	size := int(payloadSize)
+	localSize := db.localPayloadSize(size)
+
+	if len(cell) < localSize {
+		return 0, nil, errors.New("cell is truncated")
+	}
+
+	payload := append([]byte(nil), cell[:localSize]...)
+
+	if localSize == size {
+		return rowID, payload, nil
+	}
+
+	if len(cell) < localSize+4 {
+		return 0, nil, errors.New("cell is truncated")
+	}
+
+	overflowPage := int(binary.BigEndian.Uint32(cell[localSize:]))
+
+	for len(payload) < size {
+		page, err := db.page(overflowPage)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		content := page[4:db.usableSize]
+		remaining := size - len(payload)
+
+		payload = append(payload, content[:min(remaining, len(content))]...)
+		overflowPage = int(binary.BigEndian.Uint32(page))
+	}
+
+	return rowID, payload, nil
+}
+
+// localPayloadSize returns the number of bytes of a table leaf cell's payload
+// that are stored on the page itself, rather than on overflow pages.
+func (db *DB) localPayloadSize(payloadSize int) int {
+	maxLocal := db.usableSize - 35
+
+	if payloadSize <= maxLocal {
+		return payloadSize
+	}
+
+	minLocal := ((db.usableSize-12)*32)/255 - 23
+	localSize := minLocal + (payloadSize-minLocal)%(db.usableSize-4)
+
+	if localSize > maxLocal {
+		return minLocal
+	}
+
+	return localSize
+}
+
+// parseRecord parses the values of a record.
+func parseRecord(payload []byte) ([]any, error) {
+	headerSize, n := readVarint(payload)
+	if headerSize < int64(n) || headerSize > int64(len(payload)) {
+		return nil, errors.New("record has an invalid header")
+	}
+
+	header := payload[n:headerSize]
+	body := payload[headerSize:]
+
+	var values []any
+
+	for len(header) > 0 {
+		serialType, n := readVarint(header)
+		header = header[n:]
+
+		size := serialTypeSize(serialType)
+		if size > len(body) {
+			return nil, errors.New("record is truncated")
+		}
+
+		values = append(values, decodeValue(serialType, body[:size]))
+		body = body[size:]
+	}
+
+	return values, nil
+}
+
+// serialTypeSize returns the size of a value of the given serial type.
+func serialTypeSize(serialType int64) int {
+	switch {
+	case serialType >= 12:
+		return int((serialType - 12) / 2)
+	case serialType == 5:
+		return 6
+	case serialType == 6, serialType == 7:
+		return 8
+	case serialType >= 1 && serialType <= 4:
+		return int(serialType)
+	}
+
+	return 0
+}
+
+// decodeValue decodes a value of the given serial type.
+func decodeValue(serialType int64, data []byte) any {
+	switch {
+	case serialType == 0:
+		return nil
+	case serialType == 7:
+		return math.Float64frombits(binary.BigEndian.Uint64(data))
+	case serialType >= 1 && serialType <= 6:
+		// Sign-extend the big-endian integer
+		value := int64(int8(data[0]))
+		for _, b := range data[1:] {
+			value = value<<8 | int64(b)
+		}
+
+		return value
+	case serialType == 8:
+		return int64(0)
+	case serialType == 9:
+		return int64(1)
+	case serialType >= 13 && serialType%2 == 1:
+		return string(data)
+	case serialType >= 12:
+		return append([]byte(nil), data...)
+	}
+
+	return nil
+}
+
+// readVarint reads a variable-length integer, returning it and the number of
+// bytes read.
+func readVarint(data []byte) (int64, int) {
+	var value uint64
+
+	for i := 0; i < len(data) && i < 9; i++ {
+		if i == 8 {
+			return int64(value<<8 | uint64(data[i])), i + 1
+		}
+
+		value = value<<7 | uint64(data[i]&0x7f)
+
+		if data[i]&0x80 == 0 {
+			return int64(value), i + 1
+		}
+	}
+
+	return int64(value), len(data)
+}
+
+// tableSchema defines the structure of the schema of a table, as needed to
+// read its rows.
+type tableSchema struct {
+	columns     []string
+	rowIDColumn string   // The column that aliases the row ID, if any
+	realColumns []string // The columns with a REAL type affinity
+}
+
+// parseSchema parses the schema of a table from its CREATE TABLE statement.
+func parseSchema(sql string) tableSchema {
+	var schema tableSchema
+
+	start := strings.Index(sql, "(")
+	end := strings.LastIndex(sql, ")")
+
+	if start < 0 || end < start {
+		return schema
+	}
+
+	for _, definition := range splitDefinitions(sql[start+1 : end]) {
+		fields := strings.Fields(definition)
+		if len(fields) < 1 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "CONSTRAINT", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN":
+			// Table constraints, rather than columns
+			continue
+		}
+
+		name := strings.Trim(fields[0], "\"`[]'")
+		schema.columns = append(schema.columns, name)
+
+		upper := strings.ToUpper(strings.Join(fields[1:], " "))
+
+		if strings.HasPrefix(upper, "INTEGER") && strings.Contains(upper, "PRIMARY KEY") && !strings.Contains(upper, "DESC") {
+			schema.rowIDColumn = name
+		}
+
+		if hasRealAffinity(upper) {
+			schema.realColumns = append(schema.realColumns, name)
+		}
+	}
+
+	return schema
+}
+
+// hasRealAffinity returns true if a (upper case) column definition has a REAL
+// type affinity, by SQLite's rules for determining affinity.
+func hasRealAffinity(definition string) bool {
+	typeName, _, _ := strings.Cut(definition, " ")
+
+	for _, otherAffinity := range []string{"INT", "CHAR", "CLOB", "TEXT", "BLOB"} {
+		if strings.Contains(typeName, otherAffinity) {
+			return false
+		}
+	}
+
+	for _, realType := range []string{"REAL", "FLOA", "DOUB"} {
+		if strings.Contains(typeName, realType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitDefinitions splits the definitions of a CREATE TABLE statement on the
+// commas that aren't nested in parentheses.
+func splitDefinitions(definitions string) []string {
+	var parts []string
+
+	depth, start := 0, 0
+
+	for i, r := range definitions {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, definitions[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, definitions[start:])
+}
@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadTable(t *testing.T) {
+	// The test database has a small page size, so that its table has interior
+	// pages, and a long value that overflows onto overflow pages
+	db, err := Open("testdata/test.db")
+	if err != nil {
+		t.Fatalf("Open returned an error: %v", err)
+	}
+
+	rows, err := db.ReadTable("ITEMS")
+	if err != nil {
+		t.Fatalf("ReadTable returned an error: %v", err)
+	}
+
+	if len(rows) != 301 {
+		t.Fatalf("ReadTable returned wrong number of rows. Got %d. Want %d.", len(rows), 301)
+	}
+
+	for i, row := range rows[:300] {
+		id := int64(i + 1)
+
+		count := id * 1000
+		if id%2 == 1 {
+			count = -count
+		}
+
+		want := Row{
+			"id":    id,
+			"name":  fmt.Sprintf("item %d", id),
+			"count": count,
+			"ratio": float64(id) / 4,
+			"data":  []byte{byte(id % 256)},
+		}
+
+		if !reflect.DeepEqual(row, want) {
+			t.Fatalf("ReadTable returned wrong row. Got %#v. Want %#v.", row, want)
+		}
+	}
+
+	want := Row{
+		"id":    int64(301),
+		"name":  "long " + strings.Repeat("x", 3000),
+		"count": int64(1 << 40),
+		"ratio": nil,
+		"data":  nil,
+	}
+
+	if got := rows[300]; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTable returned wrong row. Got %#v. Want %#v.", got, want)
+	}
+
+	if _, err := db.ReadTable("missing"); err == nil {
+		t.Error("ReadTable returned no error for a missing table")
+	}
+}
+
+func TestNewNotDatabase(t *testing.T) {
+	if _, err := New([]byte("not a database")); !errors.Is(err, ErrNotDatabase) {
+		t.Errorf("New returned wrong error. Got %#v. Want %#v.", err, ErrNotDatabase)
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		sql  string
+		want tableSchema
+	}{
+		"simple": {
+			sql:  "CREATE TABLE WORDS (id TEXT PRIMARY KEY NOT NULL, word TEXT, stem TEXT)",
+			want: tableSchema{columns: []string{"id", "word", "stem"}},
+		},
+		"row ID alias, real columns, and constraints": {
+			sql: `CREATE TABLE t ("id" integer primary key, price DOUBLE PRECISION, ratio REAL NOT NULL, UNIQUE (price))`,
+			want: tableSchema{
+				columns:     []string{"id", "price", "ratio"},
+				rowIDColumn: "id",
+				realColumns: []string{"price", "ratio"},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := parseSchema(testData.sql); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("parseSchema returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
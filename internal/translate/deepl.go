@@ -0,0 +1,76 @@
+package translate
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// deepLDefaultEndpoint is the default endpoint of the DeepL backend, which
+	// is that of its free plan
+	deepLDefaultEndpoint = "https://api-free.deepl.com/v2/translate"
+
+	httpRequestAuthorizationHeaderName = "Authorization"
+)
+
+// deepL is a Translator via the DeepL API
+type deepL struct {
+	httpClient *http.Client
+	endpoint   string
+	apiKey     string
+}
+
+// deepLRequest defines the structure of a DeepL API request
+type deepLRequest struct {
+	Texts          []string `json:"text"`
+	SourceLanguage string   `json:"source_lang"`
+	TargetLanguage string   `json:"target_lang"`
+}
+
+// deepLResponse defines the structure of a DeepL API response
+type deepLResponse struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+func newDeepL(httpClient http.Client, endpoint string, apiKey string) *deepL {
+	return &deepL{
+		httpClient: &httpClient,
+		endpoint:   cmp.Or(endpoint, deepLDefaultEndpoint),
+		apiKey:     apiKey,
+	}
+}
+
+// Translate satisfies the Translator interface.
+func (t *deepL) Translate(texts []string, sourceLanguage string, targetLanguage string) ([]string, error) {
+	// The API's language codes are upper case
+	request := deepLRequest{
+		Texts:          texts,
+		SourceLanguage: strings.ToUpper(sourceLanguage),
+		TargetLanguage: strings.ToUpper(targetLanguage),
+	}
+
+	header := http.Header{}
+	header.Set(httpRequestAuthorizationHeaderName, "DeepL-Auth-Key "+t.apiKey)
+
+	var response deepLResponse
+
+	if err := postJSON(t.httpClient, t.endpoint, header, request, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Translations) != len(texts) {
+		return nil, fmt.Errorf("got %d translations of %d texts", len(response.Translations), len(texts))
+	}
+
+	translations := make([]string, len(response.Translations))
+
+	for i, translation := range response.Translations {
+		translations[i] = translation.Text
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,64 @@
+package translate
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+)
+
+// libreTranslateDefaultEndpoint is the default endpoint of the LibreTranslate
+// backend, which can instead be a self-hosted instance
+const libreTranslateDefaultEndpoint = "https://libretranslate.com/translate"
+
+// libreTranslate is a Translator via the LibreTranslate API
+type libreTranslate struct {
+	httpClient *http.Client
+	endpoint   string
+	apiKey     string
+}
+
+// libreTranslateRequest defines the structure of a LibreTranslate API request
+type libreTranslateRequest struct {
+	Texts          []string `json:"q"`
+	SourceLanguage string   `json:"source"`
+	TargetLanguage string   `json:"target"`
+	Format         string   `json:"format"`
+	APIKey         string   `json:"api_key,omitempty"`
+}
+
+// libreTranslateResponse defines the structure of a LibreTranslate API
+// response, to a request of a list of texts
+type libreTranslateResponse struct {
+	TranslatedTexts []string `json:"translatedText"`
+}
+
+func newLibreTranslate(httpClient http.Client, endpoint string, apiKey string) *libreTranslate {
+	return &libreTranslate{
+		httpClient: &httpClient,
+		endpoint:   cmp.Or(endpoint, libreTranslateDefaultEndpoint),
+		apiKey:     apiKey,
+	}
+}
+
+// Translate satisfies the Translator interface.
+func (t *libreTranslate) Translate(texts []string, sourceLanguage string, targetLanguage string) ([]string, error) {
+	request := libreTranslateRequest{
+		Texts:          texts,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: targetLanguage,
+		Format:         "text",
+		APIKey:         t.apiKey,
+	}
+
+	var response libreTranslateResponse
+
+	if err := postJSON(t.httpClient, t.endpoint, nil, request, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.TranslatedTexts) != len(texts) {
+		return nil, fmt.Errorf("got %d translations of %d texts", len(response.TranslatedTexts), len(texts))
+	}
+
+	return response.TranslatedTexts, nil
+}
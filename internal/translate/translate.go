@@ -0,0 +1,103 @@
+// Package translate provides translations of texts into other languages, via
+// a configurable machine translation backend.
+package translate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// List of translation backends.
+const (
+	BackendLibreTranslate Backend = "libretranslate"
+	BackendDeepL          Backend = "deepl"
+)
+
+const (
+	httpRequestContentTypeHeaderName = "Content-Type"
+
+	jsonMIMEType = "application/json"
+
+	// maxErrorSize is the maximum size of an error response that's read
+	maxErrorSize = 1 << 10
+)
+
+// Backend defines a machine translation backend.
+type Backend string
+
+// Translator translates texts from one language into another.
+type Translator interface {
+	// Translate takes a list of texts and the (ISO 639-1) codes of their
+	// language and the language to translate them into, and returns their
+	// translations, in the same order, and an error if any occurred.
+	Translate(texts []string, sourceLanguage string, targetLanguage string) ([]string, error)
+}
+
+// ParseBackend takes a string and returns the matching Backend, or an error
+// if no Backend matches.
+func ParseBackend(backend string) (Backend, error) {
+	switch parsed := Backend(strings.ToLower(backend)); parsed {
+	case BackendLibreTranslate, BackendDeepL:
+		return parsed, nil
+	}
+
+	return BackendLibreTranslate, fmt.Errorf("unknown translation backend %q", backend)
+}
+
+// New returns a new Translator of the given backend, which makes requests with
+// the given client to the given endpoint (or the backend's default, if empty)
+// with the given API key (if any).
+func New(backend Backend, httpClient http.Client, endpoint string, apiKey string) (Translator, error) {
+	switch backend {
+	case BackendLibreTranslate:
+		return newLibreTranslate(httpClient, endpoint, apiKey), nil
+	case BackendDeepL:
+		if apiKey == "" {
+			return nil, errors.New("the DeepL translation backend requires an API key")
+		}
+
+		return newDeepL(httpClient, endpoint, apiKey), nil
+	}
+
+	return nil, fmt.Errorf("unknown translation backend %q", backend)
+}
+
+// postJSON posts a JSON encoded request body to a URL, with the given headers,
+// and decodes the JSON response body into the given value.
+func postJSON(httpClient *http.Client, url string, header http.Header, requestBody any, responseBody any) error {
+	encoded, err := json.Marshal(requestBody)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+
+	for name, values := range header {
+		httpRequest.Header[name] = values
+	}
+
+	httpRequest.Header.Set(httpRequestContentTypeHeaderName, jsonMIMEType)
+
+	httpResponse, err := httpClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(httpResponse.Body, maxErrorSize))
+
+		return fmt.Errorf("translating failed: %s: %s", httpResponse.Status, strings.TrimSpace(string(message)))
+	}
+
+	return json.NewDecoder(httpResponse.Body).Decode(responseBody)
+}
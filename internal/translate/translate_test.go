@@ -0,0 +1,134 @@
+package translate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseBackend(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		backend string
+		want    Backend
+		wantErr bool
+	}{
+		"libretranslate": {backend: "libretranslate", want: BackendLibreTranslate},
+		"deepl":          {backend: "DeepL", want: BackendDeepL},
+		"unknown":        {backend: "babelfish", want: BackendLibreTranslate, wantErr: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := ParseBackend(testData.backend)
+
+			if (err != nil) != testData.wantErr {
+				t.Errorf("ParseBackend returned wrong error. Got %#v. Want an error: %#v.", err, testData.wantErr)
+			}
+
+			if got != testData.want {
+				t.Errorf("ParseBackend returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestNew_DeepLWithoutAPIKey(t *testing.T) {
+	if _, err := New(BackendDeepL, http.Client{}, "", ""); err == nil {
+		t.Error("New returned no error for the DeepL backend without an API key")
+	}
+}
+
+func TestTranslator_Translate(t *testing.T) {
+	texts := []string{"a test", "to try"}
+	translations := []string{"ein Test", "versuchen"}
+
+	for testName, testData := range map[string]struct {
+		backend Backend
+		apiKey  string
+		respond func(t *testing.T, r *http.Request) any
+		wantErr bool
+		want    []string
+	}{
+		"libretranslate": {
+			backend: BackendLibreTranslate,
+			respond: func(t *testing.T, r *http.Request) any {
+				var request libreTranslateRequest
+				decodeRequest(t, r, &request)
+
+				if request.SourceLanguage != "en" || request.TargetLanguage != "de" || !reflect.DeepEqual(request.Texts, texts) {
+					t.Errorf("Translate sent wrong request. Got %#v.", request)
+				}
+
+				return map[string]any{"translatedText": translations}
+			},
+			want: translations,
+		},
+		"deepl": {
+			backend: BackendDeepL,
+			apiKey:  "key",
+			respond: func(t *testing.T, r *http.Request) any {
+				var request deepLRequest
+				decodeRequest(t, r, &request)
+
+				if request.SourceLanguage != "EN" || request.TargetLanguage != "DE" || r.Header.Get(httpRequestAuthorizationHeaderName) != "DeepL-Auth-Key key" {
+					t.Errorf("Translate sent wrong request. Got %#v.", request)
+				}
+
+				return map[string]any{"translations": []map[string]string{{"text": translations[0]}, {"text": translations[1]}}}
+			},
+			want: translations,
+		},
+		"wrong number of translations": {
+			backend: BackendLibreTranslate,
+			respond: func(t *testing.T, r *http.Request) any {
+				return map[string]any{"translatedText": translations[:1]}
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(testData.respond(t, r))
+			}))
+			defer server.Close()
+
+			translator, err := New(testData.backend, http.Client{}, server.URL, testData.apiKey)
+			if err != nil {
+				t.Fatalf("New returned an error: %s", err)
+			}
+
+			got, err := translator.Translate(texts, "en", "de")
+
+			if (err != nil) != testData.wantErr {
+				t.Fatalf("Translate returned wrong error. Got %#v. Want an error: %#v.", err, testData.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Translate returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestTranslator_TranslateError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"error":"Invalid API key"}`)
+	}))
+	defer server.Close()
+
+	translator, _ := New(BackendLibreTranslate, http.Client{}, server.URL, "")
+
+	if _, err := translator.Translate([]string{"a test"}, "en", "de"); err == nil {
+		t.Error("Translate returned no error for an error response")
+	}
+}
+
+func decodeRequest(t *testing.T, r *http.Request, request any) {
+	t.Helper()
+
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		t.Fatalf("decoding the request returned an error: %s", err)
+	}
+}
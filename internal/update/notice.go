@@ -0,0 +1,86 @@
+package update
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+const (
+	xdgBaseName   = "define"
+	stateFileName = "update.json"
+
+	// CheckInterval is the minimum amount of time between checks for the
+	// latest release.
+	CheckInterval = 24 * time.Hour
+)
+
+// CheckState defines the structure of the saved result of the last check for
+// the latest release.
+type CheckState struct {
+	CheckedAt     time.Time
+	LatestVersion string
+}
+
+// StateFilePath returns the path of the file that the last check for the
+// latest release is saved in, within the user's XDG state directory.
+func StateFilePath() (string, error) {
+	return xdg.StateFile(filepath.Join(xdgBaseName, stateFileName))
+}
+
+// LatestVersion returns the version of the latest release, as of the given
+// time.
+//
+// The latest release is only checked for if the last check, saved in the file
+// at the given path, is older than the CheckInterval. Otherwise, the version
+// from the last check is returned.
+func (u *Updater) LatestVersion(stateFilePath string, now time.Time) (string, error) {
+	state, err := readCheckState(stateFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	if state.LatestVersion != "" && now.Sub(state.CheckedAt) < CheckInterval {
+		return state.LatestVersion, nil
+	}
+
+	release, err := u.LatestRelease()
+	if err != nil {
+		return "", err
+	}
+
+	state = CheckState{CheckedAt: now, LatestVersion: release.TagName}
+
+	encoded, err := json.MarshalIndent(state, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return state.LatestVersion, os.WriteFile(stateFilePath, encoded, 0o644)
+}
+
+// readCheckState reads the saved state of the last check. If no check has
+// been saved yet, an empty state is returned.
+func readCheckState(filePath string) (CheckState, error) {
+	var state CheckState
+
+	fileContents, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+
+	if err != nil {
+		return state, err
+	}
+
+	if len(fileContents) > 0 {
+		err = json.Unmarshal(fileContents, &state)
+	}
+
+	return state, err
+}
@@ -0,0 +1,49 @@
+package update
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUpdaterLatestVersion(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		json.NewEncoder(w).Encode(Release{TagName: "v1.2.3"})
+	}))
+	defer server.Close()
+
+	updater := NewUpdater(*server.Client(), server.URL)
+	stateFilePath := filepath.Join(t.TempDir(), stateFileName)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testData := []struct {
+		at           time.Time
+		wantRequests int
+	}{
+		{at: now, wantRequests: 1},
+		{at: now.Add(time.Hour), wantRequests: 1},
+		{at: now.Add(CheckInterval), wantRequests: 2},
+	}
+
+	for _, testData := range testData {
+		got, err := updater.LatestVersion(stateFilePath, testData.at)
+		if err != nil {
+			t.Fatalf("LatestVersion returned an error: %v", err)
+		}
+
+		if got != "v1.2.3" {
+			t.Errorf("LatestVersion returned wrong value. Got %#v. Want %#v.", got, "v1.2.3")
+		}
+
+		if requests != testData.wantRequests {
+			t.Errorf("LatestVersion made wrong number of requests. Got %#v. Want %#v.", requests, testData.wantRequests)
+		}
+	}
+}
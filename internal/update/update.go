@@ -0,0 +1,329 @@
+// Package update provides types and operations for checking for new releases
+// of the app and updating the running executable to them.
+package update
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LatestReleaseURL is the URL of the GitHub API endpoint that describes
+	// the latest release of the app.
+	LatestReleaseURL = "https://api.github.com/repos/Rican7/define/releases/latest"
+
+	// checksumFileExtension is the extension of the checksum files that are
+	// published alongside each release asset.
+	checksumFileExtension = ".sha256"
+
+	// maxAssetSize is the maximum size of a release asset that will be
+	// downloaded.
+	maxAssetSize = 128 << 20
+)
+
+// ErrNoAsset is returned when a release doesn't have an asset for the
+// current platform.
+var ErrNoAsset = errors.New("the release has no asset for this platform")
+
+// Release defines the structure of a published release.
+type Release struct {
+	TagName string  `json:"tag_name"`
+	URL     string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset defines the structure of a file published with a release.
+type Asset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// ChecksumMismatchError is returned when a downloaded asset doesn't match its
+// published checksum.
+type ChecksumMismatchError struct {
+	AssetName string
+	Want      string
+	Got       string
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum of %q doesn't match: got %s, want %s", e.AssetName, e.Got, e.Want)
+}
+
+// Updater checks for and downloads releases.
+type Updater struct {
+	httpClient       http.Client
+	latestReleaseURL string
+}
+
+// NewUpdater returns a new Updater that makes requests with the given client
+// to the given URL of the latest release.
+func NewUpdater(httpClient http.Client, latestReleaseURL string) *Updater {
+	return &Updater{
+		httpClient:       httpClient,
+		latestReleaseURL: latestReleaseURL,
+	}
+}
+
+// LatestRelease returns the latest published release.
+func (u *Updater) LatestRelease() (*Release, error) {
+	request, err := http.NewRequest(http.MethodGet, u.latestReleaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+
+	response, err := u.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("checking for the latest release failed: %s", response.Status)
+	}
+
+	var release Release
+
+	if err := json.NewDecoder(response.Body).Decode(&release); err != nil {
+		return nil, err
+	}
+
+	return &release, nil
+}
+
+// Download downloads the asset of the given release for the given platform,
+// verifies it against its published checksum, and returns its contents.
+func (u *Updater) Download(release *Release, goos string, goarch string) ([]byte, error) {
+	name := AssetName(goos, goarch)
+
+	asset, ok := release.asset(name)
+	if !ok {
+		return nil, ErrNoAsset
+	}
+
+	checksumAsset, ok := release.asset(name + checksumFileExtension)
+	if !ok {
+		return nil, fmt.Errorf("the release has no checksum for %q", name)
+	}
+
+	checksumContents, err := u.fetch(checksumAsset.DownloadURL)
+	if err != nil {
+		return nil, err
+	}
+
+	want, err := parseChecksum(checksumContents)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := u.fetch(asset.DownloadURL)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(contents)
+
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, &ChecksumMismatchError{AssetName: name, Want: want, Got: got}
+	}
+
+	return contents, nil
+}
+
+// fetch returns the contents at the given URL.
+func (u *Updater) fetch(url string) ([]byte, error) {
+	response, err := u.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %q failed: %s", url, response.Status)
+	}
+
+	contents, err := io.ReadAll(io.LimitReader(response.Body, maxAssetSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contents) > maxAssetSize {
+		return nil, fmt.Errorf("downloading %q failed: larger than %d bytes", url, maxAssetSize)
+	}
+
+	return contents, nil
+}
+
+// asset returns the asset of the release with the given name.
+func (r *Release) asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+
+	return Asset{}, false
+}
+
+// AssetName returns the name of the release asset for the given platform, as
+// named by the release build.
+func AssetName(goos string, goarch string) string {
+	name := fmt.Sprintf("define_%s_%s", goos, goarch)
+
+	if goos == "windows" {
+		name += ".exe"
+	}
+
+	return name
+}
+
+// parseChecksum parses the hex-encoded SHA-256 checksum from the contents of
+// a checksum file, in the format written by `sha256sum`.
+func parseChecksum(contents []byte) (string, error) {
+	fields := bytes.Fields(contents)
+
+	if len(fields) < 1 || len(fields[0]) != sha256.Size*2 {
+		return "", errors.New("the release checksum is malformed")
+	}
+
+	checksum := strings.ToLower(string(fields[0]))
+
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return "", errors.New("the release checksum is malformed")
+	}
+
+	return checksum, nil
+}
+
+// IsNewer returns true if the latest version is newer than the current one.
+//
+// Versions are compared as "v"-prefixed, dot-separated numbers. A current
+// version that can't be compared, like a development build, is never
+// considered older than the latest.
+func IsNewer(current string, latest string) bool {
+	currentParts, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+
+	latestParts, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < max(len(currentParts), len(latestParts)); i++ {
+		var currentPart, latestPart int
+
+		if i < len(currentParts) {
+			currentPart = currentParts[i]
+		}
+
+		if i < len(latestParts) {
+			latestPart = latestParts[i]
+		}
+
+		if currentPart != latestPart {
+			return latestPart > currentPart
+		}
+	}
+
+	return false
+}
+
+// parseVersion parses the numeric parts of a version.
+func parseVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(version, "v")
+
+	// Ignore any pre-release or build metadata
+	version, _, _ = strings.Cut(version, "-")
+	version, _, _ = strings.Cut(version, "+")
+
+	var parts []int
+
+	for _, field := range strings.Split(version, ".") {
+		part, err := strconv.Atoi(field)
+		if err != nil || part < 0 {
+			return nil, false
+		}
+
+		parts = append(parts, part)
+	}
+
+	return parts, true
+}
+
+// ReplaceExecutable replaces the executable at the given path with the given
+// contents, keeping its file mode.
+//
+// The new executable is written alongside the existing one before being moved
+// into place, so that a failure doesn't leave a partially written executable.
+func ReplaceExecutable(path string, contents []byte) error {
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	dir, name := filepath.Split(path)
+
+	tempFile, err := os.CreateTemp(dir, "."+name+".new-*")
+	if err != nil {
+		return err
+	}
+
+	tempPath := tempFile.Name()
+
+	// Clean up, if we don't make it to the end
+	defer os.Remove(tempPath)
+
+	if _, err := tempFile.Write(contents); err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tempPath, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	// Move the existing executable out of the way first, as some platforms
+	// (Windows) don't allow a running executable to be replaced, but do allow
+	// it to be renamed
+	oldPath := filepath.Join(dir, "."+name+".old")
+
+	if err := os.Rename(path, oldPath); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		// Put the existing executable back
+		return errors.Join(err, os.Rename(oldPath, path))
+	}
+
+	// This fails on platforms that lock running executables, and that's fine
+	_ = os.Remove(oldPath)
+
+	return nil
+}
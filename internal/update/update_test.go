@@ -0,0 +1,177 @@
+package update
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsNewer(t *testing.T) {
+	testData := map[string]struct {
+		current string
+		latest  string
+		want    bool
+	}{
+		"newer patch":        {current: "v1.2.3", latest: "v1.2.4", want: true},
+		"newer minor":        {current: "v1.2.3", latest: "v1.3.0", want: true},
+		"newer major":        {current: "v1.9.9", latest: "v2.0.0", want: true},
+		"same":               {current: "v1.2.3", latest: "v1.2.3", want: false},
+		"older":              {current: "v1.2.3", latest: "v1.2.2", want: false},
+		"different lengths":  {current: "v1.2", latest: "v1.2.1", want: true},
+		"without prefix":     {current: "1.2.3", latest: "v1.10.0", want: true},
+		"pre-release latest": {current: "v1.2.3", latest: "v1.2.4-rc.1", want: true},
+		"development build":  {current: "dev#abc123", latest: "v1.2.3", want: false},
+		"malformed latest":   {current: "v1.2.3", latest: "latest", want: false},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			if got := IsNewer(testData.current, testData.latest); got != testData.want {
+				t.Errorf("IsNewer returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	testData := map[string]struct {
+		goos   string
+		goarch string
+		want   string
+	}{
+		"linux":   {goos: "linux", goarch: "amd64", want: "define_linux_amd64"},
+		"darwin":  {goos: "darwin", goarch: "arm64", want: "define_darwin_arm64"},
+		"windows": {goos: "windows", goarch: "386", want: "define_windows_386.exe"},
+	}
+
+	for testName, testData := range testData {
+		t.Run(testName, func(t *testing.T) {
+			if got := AssetName(testData.goos, testData.goarch); got != testData.want {
+				t.Errorf("AssetName returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func newReleaseServer(t *testing.T, binary []byte, checksum string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	name := AssetName("linux", "amd64")
+
+	mux.HandleFunc("/latest", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Release{
+			TagName: "v1.0.0",
+			Assets: []Asset{
+				{Name: name, DownloadURL: server.URL + "/binary"},
+				{Name: name + checksumFileExtension, DownloadURL: server.URL + "/checksum"},
+			},
+		})
+	})
+	mux.HandleFunc("/binary", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(binary)
+	})
+	mux.HandleFunc("/checksum", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  .tmpbuild/%s\n", checksum, name)
+	})
+
+	return server
+}
+
+func TestUpdaterDownload(t *testing.T) {
+	binary := []byte("new binary")
+	sum := sha256.Sum256(binary)
+
+	server := newReleaseServer(t, binary, hex.EncodeToString(sum[:]))
+	updater := NewUpdater(*server.Client(), server.URL+"/latest")
+
+	release, err := updater.LatestRelease()
+	if err != nil {
+		t.Fatalf("LatestRelease returned an error: %v", err)
+	}
+
+	if release.TagName != "v1.0.0" {
+		t.Errorf("LatestRelease returned wrong tag. Got %#v. Want %#v.", release.TagName, "v1.0.0")
+	}
+
+	got, err := updater.Download(release, "linux", "amd64")
+	if err != nil {
+		t.Fatalf("Download returned an error: %v", err)
+	}
+
+	if string(got) != string(binary) {
+		t.Errorf("Download returned wrong value. Got %#v. Want %#v.", string(got), string(binary))
+	}
+
+	if _, err := updater.Download(release, "plan9", "386"); !errors.Is(err, ErrNoAsset) {
+		t.Errorf("Download returned wrong error. Got %#v. Want %#v.", err, ErrNoAsset)
+	}
+}
+
+func TestUpdaterDownloadChecksumMismatch(t *testing.T) {
+	sum := sha256.Sum256([]byte("some other binary"))
+
+	server := newReleaseServer(t, []byte("new binary"), hex.EncodeToString(sum[:]))
+	updater := NewUpdater(*server.Client(), server.URL+"/latest")
+
+	release, err := updater.LatestRelease()
+	if err != nil {
+		t.Fatalf("LatestRelease returned an error: %v", err)
+	}
+
+	_, err = updater.Download(release, "linux", "amd64")
+
+	var mismatchErr *ChecksumMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Errorf("Download returned wrong error. Got %#v. Want a %T.", err, mismatchErr)
+	}
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "define")
+
+	if err := os.WriteFile(path, []byte("old binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReplaceExecutable(path, []byte("new binary")); err != nil {
+		t.Fatalf("ReplaceExecutable returned an error: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != "new binary" {
+		t.Errorf("ReplaceExecutable wrote wrong contents. Got %#v. Want %#v.", string(got), "new binary")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mode := info.Mode().Perm(); mode != 0o755 {
+		t.Errorf("ReplaceExecutable wrote wrong mode. Got %#v. Want %#v.", mode, 0o755)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("ReplaceExecutable left files behind. Got %d entries. Want 1.", len(entries))
+	}
+}
@@ -6,6 +6,8 @@ package version
 import (
 	"fmt"
 	"runtime"
+	"runtime/debug"
+	"strconv"
 )
 
 // AppName is the name of the application.
@@ -21,8 +23,19 @@ var (
 
 	// commitHash is the VCS commit hash.
 	commitHash string
+
+	// buildDate is the date that the app was built.
+	buildDate string
 )
 
+// BuildInfo defines the structure of information about how the app was built.
+type BuildInfo struct {
+	Date      string // The build date, or the commit date if unknown
+	GoVersion string
+	Revision  string // The VCS commit hash
+	Modified  *bool  // Whether the VCS tree had local changes, or nil if unknown
+}
+
 // Name returns the name of the version.
 func Name() string {
 	if devID == identifier && commitHash != "" {
@@ -36,3 +49,39 @@ func Name() string {
 func Printable() string {
 	return fmt.Sprintf("%s %s (%s/%s)", AppName, Name(), runtime.GOOS, runtime.GOARCH)
 }
+
+// Build returns information about how the app was built.
+//
+// Values filled by the compiler are preferred, falling back to the information
+// that the Go toolchain embeds in the binary.
+func Build() BuildInfo {
+	info := BuildInfo{
+		Date:      buildDate,
+		GoVersion: runtime.Version(),
+		Revision:  commitHash,
+	}
+
+	debugInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	for _, setting := range debugInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Revision == "" {
+				info.Revision = setting.Value
+			}
+		case "vcs.time":
+			if info.Date == "" {
+				info.Date = setting.Value
+			}
+		case "vcs.modified":
+			if modified, err := strconv.ParseBool(setting.Value); err == nil {
+				info.Modified = &modified
+			}
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,185 @@
+// Package vocab provides types and operations for importing the vocabulary
+// that readers collect on e-readers, and exporting it along with definitions.
+package vocab
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Rican7/define/internal/sqlite"
+)
+
+// List of export formats.
+const (
+	FormatMarkdown Format = "markdown"
+	FormatAnki     Format = "anki"
+)
+
+// Format defines a format to export vocabulary in.
+type Format string
+
+// Word defines the structure of a word collected on an e-reader.
+type Word struct {
+	Word      string // The word, as it appeared in the text
+	Stem      string // The word's base form, if known
+	Language  string
+	Usage     string // The sentence that the word was first looked up in
+	Timestamp int64  // When the word was collected, in milliseconds since the epoch
+}
+
+// Entry defines the structure of an exported word and its definition.
+type Entry struct {
+	Word
+	Definition string
+}
+
+// ParseFormat takes a string and returns the matching Format, or an error if
+// no Format matches.
+func ParseFormat(format string) (Format, error) {
+	switch parsed := Format(strings.ToLower(format)); parsed {
+	case FormatMarkdown, FormatAnki:
+		return parsed, nil
+	}
+
+	return FormatMarkdown, fmt.Errorf("unknown export format %q", format)
+}
+
+// LookupWord returns the form of the word that should be looked up: its stem,
+// if known, or the word itself.
+func (w Word) LookupWord() string {
+	return cmp.Or(w.Stem, w.Word)
+}
+
+// ReadKindle reads the words collected by a Kindle's vocabulary builder, from
+// its "vocab.db" database file at the given path, in the order they were
+// collected.
+func ReadKindle(path string) ([]Word, error) {
+	db, err := sqlite.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	wordRows, err := db.ReadTable("WORDS")
+	if err != nil {
+		return nil, err
+	}
+
+	lookupRows, err := db.ReadTable("LOOKUPS")
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the usage of the first lookup of each word
+	usages := make(map[string]string)
+	usageTimestamps := make(map[string]int64)
+
+	for _, row := range lookupRows {
+		wordKey, usage, timestamp := stringValue(row["word_key"]), stringValue(row["usage"]), intValue(row["timestamp"])
+
+		if existing, exists := usageTimestamps[wordKey]; usage == "" || (exists && existing <= timestamp) {
+			continue
+		}
+
+		usages[wordKey], usageTimestamps[wordKey] = usage, timestamp
+	}
+
+	words := make([]Word, 0, len(wordRows))
+
+	for _, row := range wordRows {
+		word := Word{
+			Word:      stringValue(row["word"]),
+			Stem:      stringValue(row["stem"]),
+			Language:  stringValue(row["lang"]),
+			Usage:     strings.TrimSpace(usages[stringValue(row["id"])]),
+			Timestamp: intValue(row["timestamp"]),
+		}
+
+		if word.LookupWord() != "" {
+			words = append(words, word)
+		}
+	}
+
+	slices.SortStableFunc(words, func(a, b Word) int {
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+
+	return words, nil
+}
+
+// Write writes the entries to the writer in the given format.
+func Write(writer io.Writer, format Format, entries []Entry) error {
+	switch format {
+	case FormatAnki:
+		return writeAnki(writer, entries)
+	default:
+		return writeMarkdown(writer, entries)
+	}
+}
+
+// writeMarkdown writes the entries as a Markdown list.
+func writeMarkdown(writer io.Writer, entries []Entry) error {
+	var builder strings.Builder
+
+	builder.WriteString("# Vocabulary\n\n")
+
+	for _, entry := range entries {
+		builder.WriteString(fmt.Sprintf("- **%s**: %s\n", entry.LookupWord(), collapseLines(entry.Definition)))
+
+		if entry.Usage != "" {
+			builder.WriteString(fmt.Sprintf("  > %s\n", collapseLines(entry.Usage)))
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// writeAnki writes the entries as a tab-separated file of notes (with the word
+// on the front, and its definition and usage on the back), in the format that
+// Anki imports.
+func writeAnki(writer io.Writer, entries []Entry) error {
+	var builder strings.Builder
+
+	builder.WriteString("#separator:tab\n#html:true\n")
+
+	for _, entry := range entries {
+		back := html.EscapeString(collapseLines(entry.Definition))
+
+		if entry.Usage != "" {
+			back += "<br><br><i>" + html.EscapeString(collapseLines(entry.Usage)) + "</i>"
+		}
+
+		builder.WriteString(fmt.Sprintf("%s\t%s\n", html.EscapeString(collapseLines(entry.LookupWord())), back))
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// collapseLines collapses the whitespace (including tabs and newlines) of a
+// text, so that it fits on a single line.
+func collapseLines(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// stringValue returns a database value as a string, or an empty string if
+// it's not a string.
+func stringValue(value any) string {
+	str, _ := value.(string)
+
+	return str
+}
+
+// intValue returns a database value as an integer, or 0 if it's not an
+// integer.
+func intValue(value any) int64 {
+	integer, _ := value.(int64)
+
+	return integer
+}
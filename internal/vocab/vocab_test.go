@@ -0,0 +1,73 @@
+package vocab
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadKindle(t *testing.T) {
+	got, err := ReadKindle("testdata/vocab.db")
+	if err != nil {
+		t.Fatalf("ReadKindle returned an error: %v", err)
+	}
+
+	want := []Word{
+		{
+			Word:      "perambulating",
+			Stem:      "perambulate",
+			Language:  "en",
+			Usage:     "He was perambulating the garden.",
+			Timestamp: 1700000001000,
+		},
+		{
+			Word:      "ubiquitous",
+			Stem:      "ubiquitous",
+			Language:  "en",
+			Usage:     "Phones are ubiquitous.",
+			Timestamp: 1700000002000,
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadKindle returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	entries := []Entry{
+		{Word: Word{Word: "perambulating", Stem: "perambulate", Usage: "He was\tperambulating."}, Definition: "perambulate (verb): walk or travel through"},
+		{Word: Word{Word: "<tag>"}, Definition: "a & b"},
+	}
+
+	for testName, testData := range map[string]struct {
+		format Format
+		want   string
+	}{
+		"markdown": {
+			format: FormatMarkdown,
+			want: "# Vocabulary\n\n" +
+				"- **perambulate**: perambulate (verb): walk or travel through\n" +
+				"  > He was perambulating.\n" +
+				"- **<tag>**: a & b\n",
+		},
+		"anki": {
+			format: FormatAnki,
+			want: "#separator:tab\n#html:true\n" +
+				"perambulate\tperambulate (verb): walk or travel through<br><br><i>He was perambulating.</i>\n" +
+				"&lt;tag&gt;\ta &amp; b\n",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var builder strings.Builder
+
+			if err := Write(&builder, testData.format, entries); err != nil {
+				t.Fatalf("Write returned an error: %v", err)
+			}
+
+			if got := builder.String(); got != testData.want {
+				t.Errorf("Write returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package wordlist
+
+import (
+	"os"
+	"strings"
+)
+
+// ReadFile reads a list of words from the file at the given path, with one
+// word (or phrase) per line. Blank lines and lines starting with "#" are
+// ignored.
+func ReadFile(filePath string) ([]string, error) {
+	fileContents, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseList(string(fileContents)), nil
+}
+
+// parseList parses a list of words, with one word (or phrase) per line.
+func parseList(data string) []string {
+	var words []string
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, commentPrefix) {
+			continue
+		}
+
+		words = append(words, line)
+	}
+
+	return words
+}
@@ -0,0 +1,210 @@
+// Package wordlist provides a bundled list of English words and their
+// frequencies of use.
+package wordlist
+
+import (
+	_ "embed" // Needed for embedding the word list data
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Rican7/define/source"
+)
+
+// List of word difficulties.
+const (
+	DifficultyAny    Difficulty = ""
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+const (
+	// commentPrefix is the prefix of comment lines in the word list data
+	commentPrefix = "#"
+
+	// easyMinZipf is the minimum frequency of an "easy" word
+	easyMinZipf = 5.0
+
+	// mediumMinZipf is the minimum frequency of a "medium" word
+	mediumMinZipf = 4.0
+)
+
+// frequencyBands is the list of frequency bands, ordered from most to least
+// frequent, with the minimum frequency of each band.
+var frequencyBands = []struct {
+	minZipf float64
+	name    string
+}{
+	{6.0, "very common"},
+	{5.0, "common"},
+	{4.0, "uncommon"},
+	{3.0, "rare"},
+	{0.0, "very rare"},
+}
+
+// Difficulty defines a difficulty of a word, based on its frequency of use.
+type Difficulty string
+
+// Word defines the structure of a word in the word list.
+type Word struct {
+	Text string
+	Zipf float64 // The frequency of the word, on the Zipf scale
+}
+
+//go:embed frequency.txt
+var frequencyData string
+
+var (
+	loadWords sync.Once
+	words     []Word
+	wordsErr  error
+
+	loadWordIndex sync.Once
+	wordIndex     map[string]Word
+)
+
+// ParseDifficulty takes a string and returns the matching Difficulty, or an
+// error if no Difficulty matches.
+func ParseDifficulty(difficulty string) (Difficulty, error) {
+	switch parsed := Difficulty(strings.ToLower(difficulty)); parsed {
+	case DifficultyAny, DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return parsed, nil
+	}
+
+	return DifficultyAny, fmt.Errorf("unknown difficulty %q", difficulty)
+}
+
+// Includes returns true if a word with the given frequency is of the
+// difficulty.
+func (d Difficulty) Includes(zipf float64) bool {
+	switch d {
+	case DifficultyEasy:
+		return zipf >= easyMinZipf
+	case DifficultyMedium:
+		return zipf >= mediumMinZipf && zipf < easyMinZipf
+	case DifficultyHard:
+		return zipf < mediumMinZipf
+	}
+
+	return true
+}
+
+// IncludesOrHarder returns true if a word with the given frequency is of the
+// difficulty, or of a harder difficulty.
+func (d Difficulty) IncludesOrHarder(zipf float64) bool {
+	switch d {
+	case DifficultyMedium:
+		return zipf < easyMinZipf
+	case DifficultyHard:
+		return zipf < mediumMinZipf
+	}
+
+	return true
+}
+
+// Words returns the bundled list of words, ordered from most to least
+// frequent.
+func Words() ([]Word, error) {
+	loadWords.Do(func() {
+		words, wordsErr = parse(frequencyData)
+	})
+
+	return words, wordsErr
+}
+
+// Lookup returns the word in the bundled list of words that matches the given
+// word (case-insensitively), and whether it was found.
+func Lookup(word string) (Word, bool) {
+	loadWordIndex.Do(func() {
+		allWords, _ := Words()
+
+		wordIndex = make(map[string]Word, len(allWords))
+
+		for _, w := range allWords {
+			wordIndex[strings.ToLower(w.Text)] = w
+		}
+	})
+
+	found, exists := wordIndex[strings.ToLower(word)]
+
+	return found, exists
+}
+
+// Band returns the name of the frequency band that a frequency falls in, like
+// "common" or "rare".
+func Band(zipf float64) string {
+	for _, band := range frequencyBands {
+		if zipf >= band.minZipf {
+			return band.name
+		}
+	}
+
+	return frequencyBands[len(frequencyBands)-1].name
+}
+
+// AnnotateResults takes a list of dictionary results and sets the frequency of
+// each result whose word is in the bundled list of words, in place.
+func AnnotateResults(results source.DictionaryResults) {
+	for i := range results {
+		word, exists := Lookup(results[i].Word)
+		if !exists {
+			continue
+		}
+
+		results[i].Frequency = &source.Frequency{Zipf: word.Zipf, Band: Band(word.Zipf)}
+	}
+}
+
+// Random returns a random word from the bundled list of words, of the given
+// difficulty.
+func Random(difficulty Difficulty) (Word, error) {
+	allWords, err := Words()
+	if err != nil {
+		return Word{}, err
+	}
+
+	var candidates []Word
+
+	for _, word := range allWords {
+		if difficulty.Includes(word.Zipf) {
+			candidates = append(candidates, word)
+		}
+	}
+
+	if len(candidates) < 1 {
+		return Word{}, errors.New("no words available of the given difficulty")
+	}
+
+	return candidates[rand.IntN(len(candidates))], nil
+}
+
+// parse parses word list data into a list of words.
+func parse(data string) ([]Word, error) {
+	var parsed []Word
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, commentPrefix) {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid word list data on line %d", i+1)
+		}
+
+		zipf, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid word frequency on line %d: %s", i+1, err)
+		}
+
+		parsed = append(parsed, Word{Text: fields[0], Zipf: zipf})
+	}
+
+	return parsed, nil
+}
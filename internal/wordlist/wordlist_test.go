@@ -0,0 +1,156 @@
+package wordlist
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestWords(t *testing.T) {
+	words, err := Words()
+	if err != nil {
+		t.Fatalf("Words returned an error: %s", err)
+	}
+
+	if len(words) < 1 {
+		t.Fatal("Words returned no words")
+	}
+
+	for i := 1; i < len(words); i++ {
+		if words[i].Zipf > words[i-1].Zipf {
+			t.Errorf("Words returned words out of frequency order at %q", words[i].Text)
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for _, difficulty := range []Difficulty{DifficultyAny, DifficultyEasy, DifficultyMedium, DifficultyHard} {
+		t.Run(string(difficulty), func(t *testing.T) {
+			word, err := Random(difficulty)
+			if err != nil {
+				t.Fatalf("Random returned an error: %s", err)
+			}
+
+			if word.Text == "" || !difficulty.Includes(word.Zipf) {
+				t.Errorf("Random returned an invalid word for the difficulty. Got %#v.", word)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	word, exists := Lookup("The")
+	if !exists || word.Text != "the" {
+		t.Errorf("Lookup returned wrong value. Got %#v, %#v.", word, exists)
+	}
+
+	if word, exists := Lookup("notawordatall"); exists {
+		t.Errorf("Lookup returned a word that shouldn't exist. Got %#v.", word)
+	}
+}
+
+func TestBand(t *testing.T) {
+	for zipf, want := range map[float64]string{
+		7.7: "very common",
+		6.0: "very common",
+		5.5: "common",
+		4.2: "uncommon",
+		3.0: "rare",
+		1.5: "very rare",
+		0:   "very rare",
+		-1:  "very rare",
+	} {
+		if got := Band(zipf); got != want {
+			t.Errorf("Band(%v) returned wrong value. Got %#v. Want %#v.", zipf, got, want)
+		}
+	}
+}
+
+func TestDifficultyIncludesOrHarder(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		difficulty Difficulty
+		zipf       float64
+		want       bool
+	}{
+		"any":              {difficulty: DifficultyAny, zipf: 7.0, want: true},
+		"easy":             {difficulty: DifficultyEasy, zipf: 7.0, want: true},
+		"medium of medium": {difficulty: DifficultyMedium, zipf: 4.5, want: true},
+		"medium of hard":   {difficulty: DifficultyMedium, zipf: 2.0, want: true},
+		"medium of easy":   {difficulty: DifficultyMedium, zipf: 5.0, want: false},
+		"hard of hard":     {difficulty: DifficultyHard, zipf: 3.9, want: true},
+		"hard of medium":   {difficulty: DifficultyHard, zipf: 4.0, want: false},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.difficulty.IncludesOrHarder(testData.zipf); got != testData.want {
+				t.Errorf("IncludesOrHarder returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestAnnotateResults(t *testing.T) {
+	results := source.DictionaryResults{{Word: "the"}, {Word: "notawordatall"}}
+
+	AnnotateResults(results)
+
+	want := &source.Frequency{Zipf: 7.7, Band: "very common"}
+
+	if !reflect.DeepEqual(results[0].Frequency, want) {
+		t.Errorf("AnnotateResults set wrong frequency. Got %#v. Want %#v.", results[0].Frequency, want)
+	}
+
+	if results[1].Frequency != nil {
+		t.Errorf("AnnotateResults set a frequency for an unknown word. Got %#v.", results[1].Frequency)
+	}
+}
+
+func TestParse(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		data    string
+		want    []Word
+		wantErr bool
+	}{
+		"empty": {
+			data: "",
+			want: nil,
+		},
+		"comments": {
+			data: "# test\n\n# test test\n",
+			want: nil,
+		},
+		"words": {
+			data: "# test\nthe 7.5\ntest 4.25\n",
+			want: []Word{{Text: "the", Zipf: 7.5}, {Text: "test", Zipf: 4.25}},
+		},
+		"missing frequency": {
+			data:    "test\n",
+			wantErr: true,
+		},
+		"invalid frequency": {
+			data:    "test high\n",
+			wantErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := parse(testData.data)
+
+			if (err != nil) != testData.wantErr {
+				t.Errorf("parse returned an unexpected error. Got %#v.", err)
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("parse returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestParseList(t *testing.T) {
+	got := parseList("# words\nfoo\n\n  bar baz \n")
+	want := []string{"foo", "bar baz"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseList returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+package registry
+
+import (
+	"net/http"
+	"sync"
+)
+
+var (
+	httpClientMutex sync.RWMutex
+	httpClient      http.Client
+)
+
+// SetHTTPClient sets the HTTP client that's shared with providers, so that
+// sources can reuse connections and share their transport configuration.
+//
+// This is intended to be called ONLY by the registry owner, before any sources
+// are provided.
+func SetHTTPClient(client http.Client) {
+	httpClientMutex.Lock()
+	defer httpClientMutex.Unlock()
+
+	httpClient = client
+}
+
+// HTTPClient returns the HTTP client that providers should provide their
+// sources with. If no client has been set, a zero-value (default) client is
+// returned.
+func HTTPClient() http.Client {
+	httpClientMutex.RLock()
+	defer httpClientMutex.RUnlock()
+
+	return httpClient
+}
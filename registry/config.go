@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	envNamesMutex sync.Mutex
+	envNames      []string
+)
+
+// RequiredConfigError represents an error when a required configuration key of
+// a provider is missing or invalid.
+type RequiredConfigError struct {
+	Key     string // The configuration key of the value
+	Flag    string // The name of the flag that sets the value, if any
+	EnvName string // The environment variable that sets the value, if any
+	JSONKey string // The provider's JSON key in the config file, if any
+}
+
+func (e *RequiredConfigError) Error() string {
+	return fmt.Sprintf("required configuration key %q is missing", e.Key)
+}
+
+// Remedy returns the ways that the missing value can be set.
+func (e *RequiredConfigError) Remedy() string {
+	var ways []string
+
+	if e.Flag != "" {
+		ways = append(ways, fmt.Sprintf("pass --%s", e.Flag))
+	}
+
+	if e.EnvName != "" {
+		ways = append(ways, fmt.Sprintf("set %s", e.EnvName))
+	}
+
+	if e.JSONKey != "" {
+		ways = append(ways, fmt.Sprintf("set %q under %q in the config file", e.Key, e.JSONKey))
+	}
+
+	switch len(ways) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("To fix this, %s.", ways[0])
+	default:
+		return fmt.Sprintf("To fix this, %s, or %s.", strings.Join(ways[:len(ways)-1], ", "), ways[len(ways)-1])
+	}
+}
+
+// FillEmpty sets a configuration value to a fallback value, but only if the
+// configuration value is currently empty (the zero-value).
+//
+// This is intended to help providers consistently layer their configuration
+// values, with each later source of values only filling in what's missing, so
+// that the precedence is always: flags > environment variables > config file.
+func FillEmpty[T comparable](value *T, fallback T) {
+	var zero T
+
+	if *value == zero {
+		*value = fallback
+	}
+}
+
+// FillEmptyFromEnv sets a configuration value to the value of an environment
+// variable, but only if the configuration value is currently empty.
+//
+// This is intended to be called in a DynamicConfiguration's LoadEnv method,
+// so that environment variables take precedence over the config file.
+func FillEmptyFromEnv(value *string, envName string) {
+	FillEmpty(value, getenv(envName))
+}
+
+// FillEmptyUintFromEnv sets an unsigned integer configuration value to the
+// parsed value of an environment variable, but only if the configuration value
+// is currently empty and the environment variable is a valid unsigned integer.
+//
+// This is intended to be called in a DynamicConfiguration's LoadEnv method,
+// so that environment variables take precedence over the config file.
+func FillEmptyUintFromEnv(value *uint, envName string) {
+	if val, err := strconv.ParseUint(getenv(envName), 10, 0); err == nil {
+		FillEmpty(value, uint(val))
+	}
+}
+
+// EnvNames returns the names of the environment variables that providers have
+// read their configuration from, in the order that they were first read.
+//
+// This is intended to be called after the provider configurations have been
+// loaded, as that's when the environment variables are read.
+func EnvNames() []string {
+	envNamesMutex.Lock()
+	defer envNamesMutex.Unlock()
+
+	return slices.Clone(envNames)
+}
+
+// getenv returns the value of an environment variable, recording its name so
+// that the environment variables that providers read can be listed.
+func getenv(envName string) string {
+	envNamesMutex.Lock()
+	defer envNamesMutex.Unlock()
+
+	if !slices.Contains(envNames, envName) {
+		envNames = append(envNames, envName)
+	}
+
+	return os.Getenv(envName)
+}
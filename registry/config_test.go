@@ -0,0 +1,116 @@
+package registry
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFillEmpty(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		value    string
+		fallback string
+		want     string
+	}{
+		"both empty": {
+			value:    "",
+			fallback: "",
+			want:     "",
+		},
+		"empty value": {
+			value:    "",
+			fallback: "fallback",
+			want:     "fallback",
+		},
+		"non-empty value": {
+			value:    "value",
+			fallback: "fallback",
+			want:     "value",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			value := testData.value
+
+			FillEmpty(&value, testData.fallback)
+
+			if value != testData.want {
+				t.Errorf("FillEmpty set wrong value. Got %#v. Want %#v.", value, testData.want)
+			}
+		})
+	}
+}
+
+func TestFillEmptyFromEnv(t *testing.T) {
+	t.Setenv("DEFINE_TEST_FILL_EMPTY_FROM_ENV", "env")
+
+	empty, nonEmpty := "", "value"
+
+	FillEmptyFromEnv(&empty, "DEFINE_TEST_FILL_EMPTY_FROM_ENV")
+	FillEmptyFromEnv(&nonEmpty, "DEFINE_TEST_FILL_EMPTY_FROM_ENV")
+
+	if empty != "env" {
+		t.Errorf("FillEmptyFromEnv set wrong value. Got %#v. Want %#v.", empty, "env")
+	}
+
+	if nonEmpty != "value" {
+		t.Errorf("FillEmptyFromEnv set wrong value. Got %#v. Want %#v.", nonEmpty, "value")
+	}
+}
+
+func TestFillEmptyUintFromEnv(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		value uint
+		env   string
+		want  uint
+	}{
+		"empty value": {
+			value: 0,
+			env:   "5",
+			want:  5,
+		},
+		"non-empty value": {
+			value: 3,
+			env:   "5",
+			want:  3,
+		},
+		"invalid env": {
+			value: 0,
+			env:   "five",
+			want:  0,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			t.Setenv("DEFINE_TEST_FILL_EMPTY_UINT_FROM_ENV", testData.env)
+
+			value := testData.value
+
+			FillEmptyUintFromEnv(&value, "DEFINE_TEST_FILL_EMPTY_UINT_FROM_ENV")
+
+			if value != testData.want {
+				t.Errorf("FillEmptyUintFromEnv set wrong value. Got %#v. Want %#v.", value, testData.want)
+			}
+		})
+	}
+}
+
+func TestEnvNames(t *testing.T) {
+	var value string
+	var uintValue uint
+
+	FillEmptyFromEnv(&value, "DEFINE_TEST_ENV_NAMES_STRING")
+	FillEmptyUintFromEnv(&uintValue, "DEFINE_TEST_ENV_NAMES_UINT")
+	FillEmptyFromEnv(&value, "DEFINE_TEST_ENV_NAMES_STRING")
+
+	var got []string
+	for _, envName := range EnvNames() {
+		if strings.HasPrefix(envName, "DEFINE_TEST_ENV_NAMES_") {
+			got = append(got, envName)
+		}
+	}
+
+	want := []string{"DEFINE_TEST_ENV_NAMES_STRING", "DEFINE_TEST_ENV_NAMES_UINT"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EnvNames returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+}
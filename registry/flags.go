@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/Rican7/define/internal/flag"
+)
+
+// FlagCollisionError represents an error caused by a provider defining a flag
+// that's already defined.
+type FlagCollisionError struct {
+	Provider string
+	Flag     string
+}
+
+// StringFlag defines the structure of a declaratively defined string flag for
+// a provider's configuration.
+type StringFlag struct {
+	Name  string
+	Usage string
+	Value *string // The configuration value that the flag sets
+}
+
+func (e *FlagCollisionError) Error() string {
+	return fmt.Sprintf("provider %q defines the flag %q, which is already defined", e.Provider, e.Flag)
+}
+
+// DefineStringFlags defines a list of declaratively defined string flags on a
+// flag set, with empty default values.
+func DefineStringFlags(flags *flag.FlagSet, definitions ...StringFlag) {
+	for _, definition := range definitions {
+		flags.StringVar(definition.Value, definition.Name, "", definition.Usage)
+	}
+}
+
+// addFlags adds all of the flags of a provider's flag set to another flag set,
+// grouped under the provider's name, returning an error if any of the flags
+// collide with flags that are already defined, rather than panicking.
+func addFlags(flags *flag.FlagSet, providerFlags *flag.FlagSet, providerName string) error {
+	shorthands := make(map[string]bool)
+
+	flags.VisitAll(func(f *flag.Flag) {
+		if f.Shorthand != "" {
+			shorthands[f.Shorthand] = true
+		}
+	})
+
+	var err error
+
+	providerFlags.VisitAll(func(f *flag.Flag) {
+		switch {
+		case err != nil:
+			return
+		case flags.Lookup(f.Name) != nil:
+			err = &FlagCollisionError{providerName, "--" + f.Name}
+		case f.Shorthand != "" && shorthands[f.Shorthand]:
+			err = &FlagCollisionError{providerName, "-" + f.Shorthand}
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	providerFlags.VisitAll(func(f *flag.Flag) {
+		f.Group = providerName
+		flags.AddFlag(f)
+	})
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Rican7/define/internal/flag"
+)
+
+func TestAddFlags(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		existing      func(*flag.FlagSet)
+		provider      func(*flag.FlagSet)
+		wantCollision string
+	}{
+		"no collisions": {
+			existing: func(flags *flag.FlagSet) { flags.StringP("source", "s", "", "") },
+			provider: func(flags *flag.FlagSet) { flags.String("test-app-key", "", "") },
+		},
+		"name collision": {
+			existing:      func(flags *flag.FlagSet) { flags.StringP("source", "s", "", "") },
+			provider:      func(flags *flag.FlagSet) { flags.String("source", "", "") },
+			wantCollision: "--source",
+		},
+		"shorthand collision": {
+			existing:      func(flags *flag.FlagSet) { flags.StringP("source", "s", "", "") },
+			provider:      func(flags *flag.FlagSet) { flags.StringP("secret", "s", "", "") },
+			wantCollision: "-s",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			providerFlags := flag.NewFlagSet("provider", flag.ContinueOnError)
+
+			testData.existing(flags)
+			testData.provider(providerFlags)
+
+			err := addFlags(flags, providerFlags, "Test")
+
+			var collisionErr *FlagCollisionError
+			isCollision := errors.As(err, &collisionErr)
+
+			if testData.wantCollision == "" {
+				if err != nil {
+					t.Fatalf("addFlags returned an unexpected error: %s", err)
+				}
+
+				providerFlags.VisitAll(func(f *flag.Flag) {
+					if flags.Lookup(f.Name) == nil {
+						t.Errorf("addFlags didn't add flag %q", f.Name)
+					}
+				})
+
+				return
+			}
+
+			if !isCollision || collisionErr.Flag != testData.wantCollision {
+				t.Errorf("addFlags returned wrong error. Got %#v. Want collision of %q.", err, testData.wantCollision)
+			}
+		})
+	}
+}
+
+func TestDefineStringFlags(t *testing.T) {
+	var value string
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	DefineStringFlags(flags, StringFlag{Name: "test-value", Usage: "A test value", Value: &value})
+
+	if err := flags.Parse([]string{"--test-value=set"}); err != nil {
+		t.Fatalf("Parse returned an error: %s", err)
+	}
+
+	if value != "set" {
+		t.Errorf("DefineStringFlags defined a flag that set wrong value. Got %#v. Want %#v.", value, "set")
+	}
+}
@@ -8,10 +8,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
-	flag "github.com/ogier/pflag"
-
+	"github.com/Rican7/define/internal/flag"
 	"github.com/Rican7/define/source"
 )
 
@@ -24,6 +25,29 @@ type SourceProvider interface {
 	Provide(Configuration) (source.Source, error)
 }
 
+// AliasedProvider defines the interface for providers of sources that can also
+// be referred to by short, human-friendly aliases (like "oxford").
+type AliasedProvider interface {
+	SourceProvider
+
+	// Aliases returns the aliases that the source can be referred to by, which
+	// should be unique among the providers' aliases and JSON keys.
+	Aliases() []string
+}
+
+// SpecialtyProvider defines the interface for providers of specialty sources,
+// which cover specific domains (like "medical") rather than general language.
+//
+// Specialty sources are only used when they're chosen, either explicitly or by
+// their domain, and never as a fallback for other sources.
+type SpecialtyProvider interface {
+	SourceProvider
+
+	// Domains returns the lower-case names of the domains that the source
+	// specializes in.
+	Domains() []string
+}
+
 // Configuration defines a generic SourceProvider's configuration structure.
 //
 // Implementations may wish to implement the json.Marshaler and
@@ -43,12 +67,56 @@ type DynamicConfiguration interface {
 	Configuration
 	json.Unmarshaler
 
-	// Finalize is a method called on a configuration when loading is completed.
+	// LoadEnv is a method called on a configuration once its flags have been
+	// parsed, before its config file values are unmarshalled.
 	//
-	// The intent is to be able signal to the configuration that its been loaded
-	// so that it can perform any necessary post-load processes, such as
-	// validation, normalization, or having values fall-back to defaults.
-	Finalize()
+	// The intent is to be able to fill in any values that weren't passed as
+	// flags from environment variables, so that a provider's values take the
+	// same precedence as the app's: flags > environment variables > config
+	// file.
+	LoadEnv()
+}
+
+// ProviderError is returned when a provider fails to provide a source.
+type ProviderError struct {
+	Provider string // The name of the provider
+	Err      error  // The reason that the source couldn't be provided
+}
+
+// NoSourceError is returned when no source can be provided, as every provider
+// that was tried failed to provide one.
+type NoSourceError struct {
+	Errs []*ProviderError // The errors of the providers, ordered by name
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("source %q failed to initialize with error: %s", e.Provider, e.Err)
+}
+
+// Unwrap returns the reason that the source couldn't be provided.
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *NoSourceError) Error() string {
+	reasons := make([]string, 0, len(e.Errs))
+	for _, providerErr := range e.Errs {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", providerErr.Provider, providerErr.Err))
+	}
+
+	return fmt.Sprintf("no source could be provided (%s)", strings.Join(reasons, "; "))
+}
+
+// Unwrap returns the errors of the providers.
+func (e *NoSourceError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errs))
+	for _, providerErr := range e.Errs {
+		errs = append(errs, providerErr)
+	}
+
+	return errs
 }
 
 // RegisterFunc is the function that allows SourceProviders to define and
@@ -57,11 +125,10 @@ type DynamicConfiguration interface {
 type RegisterFunc func(*flag.FlagSet) (SourceProvider, Configuration)
 
 var (
-	configured    sync.Once
-	finalized     sync.Once
 	registrations = make([]RegisterFunc, 0)
 
-	providers = make(map[Configuration]SourceProvider)
+	providersMutex sync.RWMutex
+	providers      = make(map[Configuration]SourceProvider)
 )
 
 // Register makes a source provider available by the provided name.
@@ -72,82 +139,153 @@ func Register(registerFunc RegisterFunc) {
 // ConfigureProviders configures the providers and returns a map of their names
 // as keys and their configurations as values.
 //
+// Each provider defines its flags on its own flag set, which are then added to
+// the given flag set, so that any flag collisions are returned as an error
+// instead of panicking.
+//
+// Each call configures the providers anew, replacing the configurations of any
+// previous call, so that the app can be run more than once in a process.
+//
 // This is intended to be called ONLY by the registry owner.
 // TODO: Prevent external calls somehow?
-func ConfigureProviders(flags *flag.FlagSet) map[string]Configuration {
+func ConfigureProviders(flags *flag.FlagSet) (map[string]Configuration, error) {
 	confs := make(map[string]Configuration)
+	configuredProviders := make(map[Configuration]SourceProvider)
+	var err error
 
-	configured.Do(func() {
-		for _, registerFunc := range registrations {
-			provider, conf := registerFunc(flags)
+	for _, registerFunc := range registrations {
+		providerFlags := flag.NewFlagSet("provider", flag.ContinueOnError)
 
-			if provider == nil || conf == nil {
-				panic("register func returned nil values")
-			}
+		provider, conf := registerFunc(providerFlags)
 
-			providers[conf], confs[conf.JSONKey()] = provider, conf
+		if provider == nil || conf == nil {
+			panic("register func returned nil values")
 		}
-	})
 
-	return confs
+		if flagErr := addFlags(flags, providerFlags, provider.Name()); flagErr != nil {
+			err = errors.Join(err, flagErr)
+			continue
+		}
+
+		configuredProviders[conf], confs[conf.JSONKey()] = provider, conf
+	}
+
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+
+	providers = configuredProviders
+
+	return confs, err
 }
 
-// Finalize takes a number of configurations and marks them as loaded, if they
-// support a DynamicConfiguration signaling.
+// LoadEnv takes a number of configurations and loads their values from
+// environment variables, if they support a DynamicConfiguration loading.
 //
 // This is intended to be called ONLY by the registry owner.
 // TODO: Prevent external calls somehow?
-func Finalize(confs ...Configuration) {
-	finalized.Do(func() {
-		for _, conf := range confs {
-			if dynamicConf, ok := conf.(DynamicConfiguration); ok {
-				dynamicConf.Finalize()
-			}
+func LoadEnv(confs ...Configuration) {
+	for _, conf := range confs {
+		if dynamicConf, ok := conf.(DynamicConfiguration); ok {
+			dynamicConf.LoadEnv()
 		}
-	})
+	}
 }
 
 // Provide takes a configuration and calls the associated source providers
 // Provide function to provide a source.
 func Provide(conf Configuration) (source.Source, error) {
+	providersMutex.RLock()
 	provider := providers[conf]
+	providersMutex.RUnlock()
 
 	src, err := provider.Provide(conf)
 	if err != nil {
-		err = fmt.Errorf("source %q failed to initialize with error: %s", provider.Name(), err)
+		return src, &ProviderError{Provider: provider.Name(), Err: err}
 	}
 
-	return src, err
+	return src, nil
 }
 
 // ProvidePreferred takes a preferred provider key (that aligns with the value
 // returned by the Configuration.JSONKey method) and a list of configurations,
 // and provides the matching source if possible, but will fall back to another
 // source if the preferred source returns an error when trying to provide it.
+//
+// Specialty sources are never fallen back to, so they're only provided when
+// they're the preferred source.
+//
+// If no source can be provided, a *NoSourceError is returned, with the errors
+// of all of the providers that were tried.
 func ProvidePreferred(preferredProvider string, confs []Configuration) (source.Source, error) {
 	var src source.Source
 	var err error
+	var providerErrs []*ProviderError
 
 	if len(confs) < 1 {
 		return nil, errors.New("no configurations available to provide a source")
 	}
 
+	providersMutex.RLock()
+	confProviders := providers
+	providersMutex.RUnlock()
+
 	for _, providerConf := range confs {
-		if src == nil || err != nil || preferredProvider == providerConf.JSONKey() {
+		isPreferred := preferredProvider == providerConf.JSONKey()
+
+		if _, isSpecialty := confProviders[providerConf].(SpecialtyProvider); isSpecialty && !isPreferred {
+			continue
+		}
+
+		if src == nil || err != nil || isPreferred {
 			iSrc, iErr := Provide(providerConf)
 
 			if iSrc != nil && iErr == nil {
 				src, err = iSrc, iErr
 			}
+
+			var providerErr *ProviderError
+			if errors.As(iErr, &providerErr) {
+				providerErrs = append(providerErrs, providerErr)
+			}
 		}
 	}
 
+	if src == nil && len(providerErrs) > 0 {
+		slices.SortFunc(providerErrs, func(a, b *ProviderError) int {
+			return strings.Compare(a.Provider, b.Provider)
+		})
+
+		return nil, &NoSourceError{Errs: providerErrs}
+	}
+
 	return src, err
 }
 
+// Aliases returns the aliases of a provider, if it has any.
+func Aliases(provider SourceProvider) []string {
+	if aliasedProvider, ok := provider.(AliasedProvider); ok {
+		return aliasedProvider.Aliases()
+	}
+
+	return nil
+}
+
+// Domains returns the domains that a provider specializes in, if it's a
+// specialty provider.
+func Domains(provider SourceProvider) []string {
+	if specialtyProvider, ok := provider.(SpecialtyProvider); ok {
+		return specialtyProvider.Domains()
+	}
+
+	return nil
+}
+
 // Providers returns a map of the source configurations as keys and their
 // corresponding providers as values.
 func Providers() map[Configuration]SourceProvider {
+	providersMutex.RLock()
+	defer providersMutex.RUnlock()
+
 	provs := make(map[Configuration]SourceProvider)
 
 	for conf, provider := range providers {
@@ -0,0 +1,41 @@
+package registry_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source/oxford"
+	"github.com/Rican7/define/source/webster"
+)
+
+func TestProvidePreferredNoSource(t *testing.T) {
+	confs, err := registry.ConfigureProviders(flag.NewFlagSet("test", flag.ContinueOnError))
+	if err != nil {
+		t.Fatalf("ConfigureProviders returned an error: %s", err)
+	}
+
+	// Neither source can be provided without its keys
+	_, err = registry.ProvidePreferred(oxford.JSONKey, []registry.Configuration{confs[webster.JSONKey], confs[oxford.JSONKey]})
+
+	var noSourceErr *registry.NoSourceError
+	if !errors.As(err, &noSourceErr) {
+		t.Fatalf("ProvidePreferred returned wrong error. Got %#v. Want a %T.", err, noSourceErr)
+	}
+
+	var gotProviders []string
+	for _, providerErr := range noSourceErr.Errs {
+		gotProviders = append(gotProviders, providerErr.Provider)
+	}
+
+	if want := []string{webster.Name, oxford.Name}; !reflect.DeepEqual(gotProviders, want) {
+		t.Errorf("ProvidePreferred returned errors of wrong providers. Got %#v. Want %#v.", gotProviders, want)
+	}
+
+	var requiredConfigErr *registry.RequiredConfigError
+	if !errors.As(err, &requiredConfigErr) {
+		t.Errorf("ProvidePreferred returned an error that doesn't wrap the providers' errors. Got %#v.", err)
+	}
+}
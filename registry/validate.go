@@ -0,0 +1,116 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Rican7/define/internal/flag"
+)
+
+// ContractError represents an error caused by a provider breaking the provider
+// contract that the registry expects.
+type ContractError struct {
+	Provider string
+	Problem  string
+}
+
+func (e *ContractError) Error() string {
+	return fmt.Sprintf("provider %q breaks the provider contract: %s", e.Provider, e.Problem)
+}
+
+// Validate takes a number of register funcs and validates that the providers
+// and configurations that they register satisfy the provider contract, and
+// that they can be registered together, returning an error for each problem
+// that's found.
+//
+// A provider must have a name, its configuration must have a JSON key that's
+// unique among the providers, any aliases that it has must be unique among the
+// providers' aliases and JSON keys (ignoring case), any domains that it
+// specializes in must be lower-case and unique among the providers, and none of
+// the flags that it defines may collide with the flags of another provider.
+//
+// This is intended to be used in tests, as a conformance check.
+func Validate(registerFuncs ...RegisterFunc) error {
+	var errs []error
+
+	// The JSON keys and aliases that the sources can be referred to by, in
+	// lower case, mapped to the names of their providers
+	sourceNames := make(map[string]string)
+	domainNames := make(map[string]string)
+	flagNames := make(map[string]string)
+
+	for i, registerFunc := range registerFuncs {
+		flags := flag.NewFlagSet(fmt.Sprintf("provider-%d", i), flag.ContinueOnError)
+
+		provider, conf := registerFunc(flags)
+
+		if provider == nil || conf == nil {
+			errs = append(errs, &ContractError{fmt.Sprintf("#%d", i), "register func returned nil values"})
+			continue
+		}
+
+		name := provider.Name()
+
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+			errs = append(errs, &ContractError{name, "the name is empty"})
+		}
+
+		switch jsonKey := conf.JSONKey(); {
+		case jsonKey == "":
+			errs = append(errs, &ContractError{name, "the configuration's JSON key is empty"})
+		case sourceNames[strings.ToLower(jsonKey)] != "":
+			errs = append(errs, &ContractError{name, fmt.Sprintf("the configuration's JSON key %q is already used by %q", jsonKey, sourceNames[strings.ToLower(jsonKey)])})
+		default:
+			sourceNames[strings.ToLower(jsonKey)] = name
+		}
+
+		for _, alias := range Aliases(provider) {
+			switch owner := sourceNames[strings.ToLower(alias)]; {
+			case alias == "":
+				errs = append(errs, &ContractError{name, "an alias is empty"})
+			case owner != "":
+				errs = append(errs, &ContractError{name, fmt.Sprintf("the alias %q is already used by %q", alias, owner)})
+			default:
+				sourceNames[strings.ToLower(alias)] = name
+			}
+		}
+
+		for _, domain := range Domains(provider) {
+			switch owner := domainNames[domain]; {
+			case domain == "":
+				errs = append(errs, &ContractError{name, "a domain is empty"})
+			case domain != strings.ToLower(domain):
+				errs = append(errs, &ContractError{name, fmt.Sprintf("the domain %q isn't lower-case", domain)})
+			case owner != "":
+				errs = append(errs, &ContractError{name, fmt.Sprintf("the domain %q is already specialized in by %q", domain, owner)})
+			default:
+				domainNames[domain] = name
+			}
+		}
+
+		flags.VisitAll(func(f *flag.Flag) {
+			for _, flagName := range []string{"--" + f.Name, "-" + f.Shorthand} {
+				if flagName == "-" {
+					continue
+				}
+
+				if owner, exists := flagNames[flagName]; exists {
+					errs = append(errs, &ContractError{name, fmt.Sprintf("the flag %q is already defined by %q", flagName, owner)})
+					continue
+				}
+
+				flagNames[flagName] = name
+			}
+		})
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateRegistered validates the register funcs that have been registered,
+// in the same way as Validate.
+func ValidateRegistered() error {
+	return Validate(registrations...)
+}
@@ -0,0 +1,200 @@
+package registry_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+
+	_ "github.com/Rican7/define/source/customhttp"
+	_ "github.com/Rican7/define/source/execsource"
+	_ "github.com/Rican7/define/source/freedictionaryapi"
+	_ "github.com/Rican7/define/source/gcide"
+	_ "github.com/Rican7/define/source/mock"
+	_ "github.com/Rican7/define/source/oxford"
+	_ "github.com/Rican7/define/source/webster"
+)
+
+type testProvider struct {
+	name string
+}
+
+type testAliasedProvider struct {
+	testProvider
+
+	aliases []string
+}
+
+type testSpecialtyProvider struct {
+	testProvider
+
+	domains []string
+}
+
+type testConfig struct {
+	jsonKey string
+}
+
+func (p *testProvider) Name() string {
+	return p.name
+}
+
+func (p *testProvider) Provide(registry.Configuration) (source.Source, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *testAliasedProvider) Aliases() []string {
+	return p.aliases
+}
+
+func (p *testSpecialtyProvider) Domains() []string {
+	return p.domains
+}
+
+func (c *testConfig) JSONKey() string {
+	return c.jsonKey
+}
+
+func testRegisterFunc(name string, jsonKey string, flagNames ...string) registry.RegisterFunc {
+	return func(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+		for _, flagName := range flagNames {
+			flags.String(flagName, "", "")
+		}
+
+		return &testProvider{name}, &testConfig{jsonKey}
+	}
+}
+
+func testAliasedRegisterFunc(name string, jsonKey string, aliases ...string) registry.RegisterFunc {
+	return func(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+		return &testAliasedProvider{testProvider{name}, aliases}, &testConfig{jsonKey}
+	}
+}
+
+func testSpecialtyRegisterFunc(name string, jsonKey string, domains ...string) registry.RegisterFunc {
+	return func(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+		return &testSpecialtyProvider{testProvider{name}, domains}, &testConfig{jsonKey}
+	}
+}
+
+func TestValidateRegistered(t *testing.T) {
+	if err := registry.ValidateRegistered(); err != nil {
+		t.Errorf("ValidateRegistered returned an error: %s", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		registerFuncs []registry.RegisterFunc
+		wantErr       bool
+	}{
+		"none": {
+			registerFuncs: nil,
+			wantErr:       false,
+		},
+		"valid": {
+			registerFuncs: []registry.RegisterFunc{
+				testRegisterFunc("A", "a", "a-key"),
+				testRegisterFunc("B", "b", "b-key"),
+			},
+			wantErr: false,
+		},
+		"nil values": {
+			registerFuncs: []registry.RegisterFunc{
+				func(*flag.FlagSet) (registry.SourceProvider, registry.Configuration) { return nil, nil },
+			},
+			wantErr: true,
+		},
+		"empty name": {
+			registerFuncs: []registry.RegisterFunc{testRegisterFunc("", "a")},
+			wantErr:       true,
+		},
+		"empty JSON key": {
+			registerFuncs: []registry.RegisterFunc{testRegisterFunc("A", "")},
+			wantErr:       true,
+		},
+		"duplicate JSON key": {
+			registerFuncs: []registry.RegisterFunc{
+				testRegisterFunc("A", "key"),
+				testRegisterFunc("B", "key"),
+			},
+			wantErr: true,
+		},
+		"valid aliases": {
+			registerFuncs: []registry.RegisterFunc{
+				testAliasedRegisterFunc("A", "a", "alpha", "first"),
+				testAliasedRegisterFunc("B", "b", "beta"),
+			},
+			wantErr: false,
+		},
+		"empty alias": {
+			registerFuncs: []registry.RegisterFunc{testAliasedRegisterFunc("A", "a", "")},
+			wantErr:       true,
+		},
+		"duplicate alias": {
+			registerFuncs: []registry.RegisterFunc{
+				testAliasedRegisterFunc("A", "a", "alias"),
+				testAliasedRegisterFunc("B", "b", "Alias"),
+			},
+			wantErr: true,
+		},
+		"alias of another JSON key": {
+			registerFuncs: []registry.RegisterFunc{
+				testRegisterFunc("A", "Key"),
+				testAliasedRegisterFunc("B", "b", "key"),
+			},
+			wantErr: true,
+		},
+		"JSON key of another alias": {
+			registerFuncs: []registry.RegisterFunc{
+				testAliasedRegisterFunc("A", "a", "key"),
+				testRegisterFunc("B", "Key"),
+			},
+			wantErr: true,
+		},
+		"valid domains": {
+			registerFuncs: []registry.RegisterFunc{
+				testSpecialtyRegisterFunc("A", "a", "medical", "dental"),
+				testSpecialtyRegisterFunc("B", "b", "legal"),
+			},
+			wantErr: false,
+		},
+		"empty domain": {
+			registerFuncs: []registry.RegisterFunc{testSpecialtyRegisterFunc("A", "a", "")},
+			wantErr:       true,
+		},
+		"non-lower-case domain": {
+			registerFuncs: []registry.RegisterFunc{testSpecialtyRegisterFunc("A", "a", "Medical")},
+			wantErr:       true,
+		},
+		"duplicate domain": {
+			registerFuncs: []registry.RegisterFunc{
+				testSpecialtyRegisterFunc("A", "a", "medical"),
+				testSpecialtyRegisterFunc("B", "b", "medical"),
+			},
+			wantErr: true,
+		},
+		"flag collision": {
+			registerFuncs: []registry.RegisterFunc{
+				testRegisterFunc("A", "a", "app-key"),
+				testRegisterFunc("B", "b", "app-key"),
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			err := registry.Validate(testData.registerFuncs...)
+
+			if (err != nil) != testData.wantErr {
+				t.Errorf("Validate returned an unexpected error. Got %#v.", err)
+			}
+
+			var contractErr *registry.ContractError
+			if testData.wantErr && !errors.As(err, &contractErr) {
+				t.Errorf("Validate returned wrong error type. Got %#v.", err)
+			}
+		})
+	}
+}
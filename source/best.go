@@ -0,0 +1,199 @@
+package source
+
+import (
+	"cmp"
+	"strings"
+	"unicode"
+)
+
+// List of weights used to score the relevance of senses.
+const (
+	// bestSenseCategoryWeight is the score of each context word that matches
+	// one of a sense's categories (like a domain, such as "Law")
+	bestSenseCategoryWeight = 3.0
+
+	// bestSenseTextWeight is the score of each context word that's used in a
+	// sense's definitions or examples
+	bestSenseTextWeight = 1.0
+
+	// bestSenseExactWordWeight is the score of a sense of a result for the
+	// exact word, rather than a related one (like a different capitalization)
+	bestSenseExactWordWeight = 2.0
+
+	// bestSenseFrequencyWeight is the score of each point of the Zipf
+	// frequency of a sense's result
+	bestSenseFrequencyWeight = 0.1
+
+	// bestSenseSpecializedWeight is the score of a sense that's in categories
+	// that no context word matches, as specialized senses are less likely to
+	// be meant without context for them
+	bestSenseSpecializedWeight = -0.5
+
+	// minContextWordLength is the minimum length of a context word to match,
+	// to avoid matching short function words (like "a" or "of")
+	minContextWordLength = 3
+)
+
+// BestSense defines the structure of the single most relevant sense of a word
+type BestSense struct {
+	Word            string
+	LexicalCategory string
+	PartOfSpeech    PartOfSpeech
+
+	Sense
+}
+
+// BestSense takes a word and optional context words (like the rest of the
+// sentence the word was used in), and returns the single most relevant sense
+// of the word in the results (without its sub-senses), and whether one was
+// found.
+//
+// Senses are scored by how many of the context words match their categories
+// or are used in their definitions and examples, preferring results for the
+// exact word and more frequently used words. As sources list senses from most
+// to least common, the earliest sense is chosen among equally scored senses.
+func (r DictionaryResults) BestSense(word string, context []string) (BestSense, bool) {
+	contextWords := normalizeContextWords(context)
+
+	var best BestSense
+	var bestScore float64
+	found := false
+
+	for _, result := range r {
+		resultScore := 0.0
+
+		if result.Word == word {
+			resultScore += bestSenseExactWordWeight
+		}
+
+		if result.Frequency != nil {
+			resultScore += result.Frequency.Zipf * bestSenseFrequencyWeight
+		}
+
+		for _, entry := range result.Entries {
+			for _, sense := range flattenSenses(entry.Senses) {
+				if len(sense.Definitions) < 1 {
+					continue
+				}
+
+				score := resultScore + scoreSense(sense, contextWords)
+
+				if !found || score > bestScore {
+					// Only the single sense is wanted, not its sub-senses
+					sense.SubSenses = nil
+
+					best = BestSense{
+						Word:            cmp.Or(entry.Word, result.Word),
+						LexicalCategory: entry.LexicalCategory,
+						PartOfSpeech:    entry.PartOfSpeech,
+						Sense:           sense,
+					}
+					bestScore = score
+					found = true
+				}
+			}
+		}
+	}
+
+	return best, found
+}
+
+// scoreSense returns the score of a sense's relevance to the context words.
+func scoreSense(sense Sense, contextWords map[string]bool) float64 {
+	score := 0.0
+	matchedCategory := false
+
+	for _, category := range sense.Categories {
+		for _, categoryWord := range textWords(category) {
+			if contextWords[categoryWord] {
+				score += bestSenseCategoryWeight
+				matchedCategory = true
+			}
+		}
+	}
+
+	if len(sense.Categories) > 0 && !matchedCategory {
+		score += bestSenseSpecializedWeight
+	}
+
+	texts := append([]string{}, sense.Definitions...)
+	for _, example := range sense.Examples {
+		texts = append(texts, example.Text)
+	}
+
+	matchedWords := make(map[string]bool)
+
+	for _, text := range texts {
+		for _, textWord := range textWords(text) {
+			if contextWords[textWord] && !matchedWords[textWord] {
+				score += bestSenseTextWeight
+				matchedWords[textWord] = true
+			}
+		}
+	}
+
+	return score
+}
+
+// flattenSenses returns the senses and all of their sub-senses, in order.
+func flattenSenses(senses []Sense) []Sense {
+	var flattened []Sense
+
+	for _, sense := range senses {
+		flattened = append(flattened, sense)
+		flattened = append(flattened, flattenSenses(sense.SubSenses)...)
+	}
+
+	return flattened
+}
+
+// FirstDefinition returns the first non-empty definition found in a list of
+// senses (or their sub-senses), with its whitespace collapsed to fit on a
+// single line.
+func FirstDefinition(senses []Sense) string {
+	for _, sense := range flattenSenses(senses) {
+		for _, definition := range sense.Definitions {
+			if collapsed := strings.Join(strings.Fields(definition), " "); collapsed != "" {
+				return collapsed
+			}
+		}
+	}
+
+	return ""
+}
+
+// normalizeContextWords returns the set of the normalized words of the given
+// context.
+func normalizeContextWords(context []string) map[string]bool {
+	contextWords := make(map[string]bool)
+
+	for _, text := range context {
+		for _, word := range textWords(text) {
+			contextWords[word] = true
+		}
+	}
+
+	return contextWords
+}
+
+// textWords splits a text into its normalized words, for matching, ignoring
+// any that are too short.
+func textWords(text string) []string {
+	var words []string
+
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if len([]rune(word)) < minContextWordLength {
+			continue
+		}
+
+		// Normalize simple plurals, so that "courts" matches "court"
+		word = strings.ToLower(RemoveDiacritics(word))
+		if len(word) > minContextWordLength && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			word = strings.TrimSuffix(word, "s")
+		}
+
+		words = append(words, word)
+	}
+
+	return words
+}
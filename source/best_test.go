@@ -0,0 +1,107 @@
+package source
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDictionaryResults_BestSense(t *testing.T) {
+	general := Sense{Definitions: []string{"a short written summary"}}
+	law := Sense{Definitions: []string{"a document setting out the facts of a case"}, Categories: []string{"Law"}}
+	clothing := Sense{Definitions: []string{"short underpants"}, Examples: []AttributedText{{Text: "a pair of cotton briefs"}}}
+	instruct := Sense{Definitions: []string{"to give information to someone"}}
+
+	results := DictionaryResults{
+		{
+			Word:      "brief",
+			Frequency: &Frequency{Zipf: 4.5},
+			Entries: []DictionaryEntry{
+				{Entry: Entry{Word: "brief", LexicalCategory: "noun"}, Senses: []Sense{general, law, {SubSenses: []Sense{clothing}}}},
+				{Entry: Entry{Word: "brief", LexicalCategory: "verb"}, Senses: []Sense{instruct}},
+			},
+		},
+	}
+
+	for testName, testData := range map[string]struct {
+		results DictionaryResults
+		word    string
+		context []string
+		want    BestSense
+		wantOK  bool
+	}{
+		"no results": {
+			results: nil,
+			word:    "brief",
+			want:    BestSense{},
+			wantOK:  false,
+		},
+		"no context": {
+			results: results,
+			word:    "brief",
+			want:    BestSense{Word: "brief", LexicalCategory: "noun", Sense: general},
+			wantOK:  true,
+		},
+		"category context": {
+			results: results,
+			word:    "brief",
+			context: []string{"the", "LAW", "firm"},
+			want:    BestSense{Word: "brief", LexicalCategory: "noun", Sense: law},
+			wantOK:  true,
+		},
+		"definition context": {
+			results: results,
+			word:    "brief",
+			context: []string{"she gave the team information"},
+			want:    BestSense{Word: "brief", LexicalCategory: "verb", Sense: instruct},
+			wantOK:  true,
+		},
+		"example context in sub-sense": {
+			results: results,
+			word:    "brief",
+			context: []string{"cotton", "pairs"},
+			want:    BestSense{Word: "brief", LexicalCategory: "noun", Sense: clothing},
+			wantOK:  true,
+		},
+		"exact word preferred": {
+			results: DictionaryResults{
+				{Word: "Polish", Entries: []DictionaryEntry{{Entry: Entry{Word: "Polish", LexicalCategory: "adjective"}, Senses: []Sense{general}}}},
+				{Word: "polish", Entries: []DictionaryEntry{{Entry: Entry{Word: "polish", LexicalCategory: "verb"}, Senses: []Sense{instruct}}}},
+			},
+			word:   "polish",
+			want:   BestSense{Word: "polish", LexicalCategory: "verb", Sense: instruct},
+			wantOK: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, ok := testData.results.BestSense(testData.word, testData.context)
+
+			if ok != testData.wantOK {
+				t.Errorf("BestSense returned wrong found value. Got %#v. Want %#v.", ok, testData.wantOK)
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("BestSense returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestFirstDefinition(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		senses []Sense
+		want   string
+	}{
+		"none":              {senses: nil, want: ""},
+		"first":             {senses: []Sense{{Definitions: []string{"a trial", "an exam"}}}, want: "a trial"},
+		"collapsed":         {senses: []Sense{{Definitions: []string{"  a\n\ttrial "}}}, want: "a trial"},
+		"skipping empty":    {senses: []Sense{{Definitions: []string{" "}}, {Definitions: []string{"a trial"}}}, want: "a trial"},
+		"sub-sense":         {senses: []Sense{{SubSenses: []Sense{{Definitions: []string{"an exam"}}}}, {Definitions: []string{"a trial"}}}, want: "an exam"},
+		"sense before subs": {senses: []Sense{{Definitions: []string{"a trial"}, SubSenses: []Sense{{Definitions: []string{"an exam"}}}}}, want: "a trial"},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := FirstDefinition(testData.senses); got != testData.want {
+				t.Errorf("FirstDefinition returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
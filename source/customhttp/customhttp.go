@@ -0,0 +1,144 @@
+// Package customhttp provides a dictionary source via any HTTP API that
+// responds with JSON, configured with a URL template and a mapping of the
+// API's responses to results, so that unsupported APIs can be used without
+// writing a source for them.
+package customhttp
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"text/template"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source, unless it's configured with another
+const Name = "Custom HTTP Dictionary"
+
+const (
+	httpRequestAcceptHeaderName = "Accept"
+
+	jsonMIMEType = "application/json"
+)
+
+// urlTemplateFuncs are the functions available to URL templates
+var urlTemplateFuncs = template.FuncMap{
+	"pathEscape":  url.PathEscape,
+	"queryEscape": url.QueryEscape,
+}
+
+// Options defines the structure of the options of a custom HTTP source.
+type Options struct {
+	// Name is the printable, human-readable name of the source
+	Name string
+
+	// URL is a template of the URL to request a word's definitions from, like
+	// "https://example.com/define?word={{.Word | queryEscape}}"
+	URL string
+
+	// Headers are the headers to send with requests, with any environment
+	// variables in their values (like "Bearer $API_KEY") expanded
+	Headers map[string]string
+
+	Mapping Mapping
+}
+
+// urlTemplateData defines the structure of the data that URL templates are
+// executed with.
+type urlTemplateData struct {
+	Word string
+}
+
+// api contains a configured HTTP client and options for custom API operations
+type api struct {
+	httpClient  *http.Client
+	urlTemplate *template.Template
+	options     Options
+}
+
+// New returns a new custom HTTP dictionary source, or an error if its URL
+// template can't be parsed.
+func New(httpClient http.Client, options Options) (source.Source, error) {
+	if options.Name == "" {
+		options.Name = Name
+	}
+
+	urlTemplate, err := template.New("URL").Funcs(urlTemplateFuncs).Option("missingkey=error").Parse(options.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL template: %w", err)
+	}
+
+	return &api{httpClient: &httpClient, urlTemplate: urlTemplate, options: options}, nil
+}
+
+// Name returns the printable, human-readable name of the source.
+func (a *api) Name() string {
+	return a.options.Name
+}
+
+// Define takes a word string and returns a list of dictionary results, and
+// an error if any occurred.
+func (a *api) Define(word string) (source.DictionaryResults, error) {
+	var requestURL bytes.Buffer
+
+	if err := a.urlTemplate.Execute(&requestURL, urlTemplateData{Word: word}); err != nil {
+		return nil, fmt.Errorf("invalid URL template: %w", err)
+	}
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
+
+	for name, value := range a.options.Headers {
+		httpRequest.Header.Set(name, os.ExpandEnv(value))
+	}
+
+	httpResponse, err := a.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if http.StatusNotFound == httpResponse.StatusCode {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	if http.StatusUnauthorized == httpResponse.StatusCode || http.StatusForbidden == httpResponse.StatusCode {
+		return nil, &source.AuthenticationError{}
+	}
+
+	// Don't validate the content type, as APIs (and static files) don't always
+	// set it for JSON, which is instead validated by decoding it
+	if err = source.ValidateHTTPResponse(httpResponse, nil, nil); err != nil {
+		return nil, err
+	}
+
+	var response any
+
+	if err = source.DecodeJSONResponse(httpResponse, &response); err != nil {
+		return nil, err
+	}
+
+	results := a.options.Mapping.toResults(word, response)
+
+	// Attribute the results to the requested URL without its query, as the
+	// query may hold credentials, like an API key
+	sourceURL := *httpRequest.URL
+	sourceURL.User, sourceURL.RawQuery, sourceURL.Fragment = nil, "", ""
+
+	for i := range results {
+		results[i].Attribution = source.ResultAttribution{
+			Provider:   a.options.Name,
+			SourceURLs: []string{sourceURL.String()},
+		}
+	}
+
+	return source.ValidateAndReturnDictionaryResults(word, results)
+}
@@ -0,0 +1,179 @@
+package customhttp
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func newTestResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{jsonMIMEType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestDefine(t *testing.T) {
+	t.Setenv("CUSTOM_HTTP_TEST_KEY", "secret")
+
+	var request *http.Request
+
+	httpClient := http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		request = r
+
+		return newTestResponse(http.StatusOK, `{
+			"data": [
+				{
+					"headword": "test",
+					"lang": "en",
+					"meanings": [
+						{
+							"pos": "noun",
+							"ipa": ["/tɛst/"],
+							"senses": [
+								{"gloss": "a procedure to establish quality", "examples": [{"text": "a nuclear test"}], "synonyms": ["trial"]},
+								{"gloss": null}
+							]
+						},
+						{"pos": "verb", "senses": [{"gloss": ["to put to the test", "to try"]}]}
+					]
+				}
+			]
+		}`), nil
+	})}
+
+	src, err := New(httpClient, Options{
+		URL:     "https://example.com/entries/{{.Word | pathEscape}}?lang=en&api_key=secret",
+		Headers: map[string]string{"Authorization": "Bearer $CUSTOM_HTTP_TEST_KEY"},
+		Mapping: Mapping{
+			Results:         "$.data",
+			Word:            "headword",
+			Language:        "lang",
+			Entries:         "meanings",
+			LexicalCategory: "pos",
+			Pronunciations:  "ipa",
+			Senses:          "senses",
+			Definitions:     "gloss",
+			Examples:        "examples.text",
+			Synonyms:        "synonyms",
+		},
+	})
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	results, err := src.Define("a test")
+	if err != nil {
+		t.Fatalf("Define returned an error: %s", err)
+	}
+
+	if got, want := request.URL.String(), "https://example.com/entries/a%20test?lang=en&api_key=secret"; got != want {
+		t.Errorf("Define requested wrong URL. Got %#v. Want %#v.", got, want)
+	}
+
+	if got, want := request.Header.Get("Authorization"), "Bearer secret"; got != want {
+		t.Errorf("Define sent wrong header. Got %#v. Want %#v.", got, want)
+	}
+
+	want := source.DictionaryResults{
+		{
+			Language: "en",
+			Word:     "test",
+			Entries: []source.DictionaryEntry{
+				{
+					Entry:          source.Entry{Word: "test", LexicalCategory: "noun", PartOfSpeech: source.PartOfSpeechNoun},
+					Pronunciations: source.Pronunciations{{Spelling: "tɛst"}},
+					Senses: []source.Sense{
+						{
+							Definitions:     []string{"a procedure to establish quality"},
+							Examples:        []source.AttributedText{{Text: "a nuclear test"}},
+							ThesaurusValues: source.ThesaurusValues{Synonyms: []string{"trial"}},
+						},
+					},
+				},
+				{
+					Entry:  source.Entry{Word: "test", LexicalCategory: "verb", PartOfSpeech: source.PartOfSpeechVerb},
+					Senses: []source.Sense{{Definitions: []string{"to put to the test", "to try"}}},
+				},
+			},
+			Attribution: source.ResultAttribution{
+				Provider:   Name,
+				SourceURLs: []string{"https://example.com/entries/a%20test"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Define returned wrong value. Got %#v. Want %#v.", results, want)
+	}
+}
+
+func TestMapping_toResults(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		mapping  Mapping
+		response any
+		want     source.DictionaryResults
+	}{
+		"list of definitions": {
+			mapping:  Mapping{Senses: "$"},
+			response: []any{"a first definition", "a second definition"},
+			want: source.DictionaryResults{
+				{
+					Word: "test",
+					Entries: []source.DictionaryEntry{
+						{
+							Entry: source.Entry{Word: "test"},
+							Senses: []source.Sense{
+								{Definitions: []string{"a first definition"}},
+								{Definitions: []string{"a second definition"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		"indexed path": {
+			mapping:  Mapping{Senses: "senses.1"},
+			response: map[string]any{"senses": []any{"a first definition", "a second definition"}},
+			want: source.DictionaryResults{
+				{
+					Word: "test",
+					Entries: []source.DictionaryEntry{
+						{Entry: source.Entry{Word: "test"}, Senses: []source.Sense{{Definitions: []string{"a second definition"}}}},
+					},
+				},
+			},
+		},
+		"empty mapping of a single definition": {
+			response: "a definition",
+			want: source.DictionaryResults{
+				{
+					Word:    "test",
+					Entries: []source.DictionaryEntry{{Entry: source.Entry{Word: "test"}, Senses: []source.Sense{{Definitions: []string{"a definition"}}}}},
+				},
+			},
+		},
+		"no definitions": {
+			mapping:  Mapping{Results: "results"},
+			response: map[string]any{"results": []any{}},
+			want:     nil,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.mapping.toResults("test", testData.response); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("toResults returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package customhttp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// Mapping defines the structure of the mapping of a JSON response to results,
+// as paths to the values of each part of the results.
+//
+// Paths are JSON object keys separated by dots (like "meta.id"), optionally
+// starting with "$.", with any arrays along the way traversed, so that the
+// values of all of their elements are found, unless the key is an index (like
+// "senses.0"). Each path is relative to the value of its parent: results are
+// found in the response, entries in a result, and senses in an entry.
+//
+// An empty path to results, entries, senses, or definitions refers to the
+// parent value itself, as a single value, while a path of "$" refers to the
+// elements of the parent value, if it's an array. For example, a response
+// that's only a list of definitions can be mapped with only the path of senses
+// set, as "$". Other empty paths aren't mapped.
+type Mapping struct {
+	Results  string // The path of the results in the response
+	Word     string // The path of the word of a result (the looked up word, if empty)
+	Language string // The path of the language of a result
+
+	Entries         string // The path of the entries of a result
+	LexicalCategory string // The path of the lexical category of an entry
+	Pronunciations  string // The path of the pronunciations of an entry
+	Etymologies     string // The path of the etymologies of an entry
+
+	Senses      string // The path of the senses of an entry
+	Definitions string // The path of the definitions of a sense
+	Examples    string // The path of the examples of a sense
+	Synonyms    string // The path of the synonyms of a sense
+	Antonyms    string // The path of the antonyms of a sense
+}
+
+// toResults maps a decoded JSON response to results for a word.
+func (m Mapping) toResults(word string, response any) source.DictionaryResults {
+	var results source.DictionaryResults
+
+	for _, resultValue := range lookup(response, m.Results) {
+		result := source.DictionaryResult{
+			Language: firstString(m.optionalStrings(resultValue, m.Language)),
+			Word:     firstString(m.optionalStrings(resultValue, m.Word)),
+		}
+
+		if result.Word == "" {
+			result.Word = word
+		}
+
+		for _, entryValue := range lookup(resultValue, m.Entries) {
+			entry := m.toEntry(entryValue)
+			entry.Word = result.Word
+
+			if len(entry.Senses) > 0 {
+				result.Entries = append(result.Entries, entry)
+			}
+		}
+
+		if len(result.Entries) > 0 {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// toEntry maps a decoded JSON value to an entry.
+func (m Mapping) toEntry(entryValue any) source.DictionaryEntry {
+	var entry source.DictionaryEntry
+
+	entry.LexicalCategory = firstString(m.optionalStrings(entryValue, m.LexicalCategory))
+	entry.PartOfSpeech = source.ParsePartOfSpeech(entry.LexicalCategory)
+	entry.Etymologies = m.optionalStrings(entryValue, m.Etymologies)
+
+	for _, pronunciation := range m.optionalStrings(entryValue, m.Pronunciations) {
+		entry.Pronunciations = append(entry.Pronunciations, source.Pronunciation{Spelling: strings.Trim(pronunciation, "/")})
+	}
+
+	for _, senseValue := range lookup(entryValue, m.Senses) {
+		sense := source.Sense{
+			Definitions: lookupStrings(senseValue, m.Definitions),
+			ThesaurusValues: source.ThesaurusValues{
+				Synonyms: m.optionalStrings(senseValue, m.Synonyms),
+				Antonyms: m.optionalStrings(senseValue, m.Antonyms),
+			},
+		}
+
+		for _, example := range m.optionalStrings(senseValue, m.Examples) {
+			sense.Examples = append(sense.Examples, source.AttributedText{Text: example})
+		}
+
+		if len(sense.Definitions) > 0 {
+			entry.Senses = append(entry.Senses, sense)
+		}
+	}
+
+	return entry
+}
+
+// optionalStrings returns the strings at a path in a value, or nil if the path
+// is empty, as optional values aren't mapped without a path.
+func (m Mapping) optionalStrings(value any, path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	return lookupStrings(value, path)
+}
+
+// lookup returns the values at a path in a decoded JSON value, with any arrays
+// flattened into their elements. An empty path returns the value itself, as a
+// single value.
+func lookup(value any, path string) []any {
+	if value == nil {
+		return nil
+	}
+
+	values := []any{value}
+
+	if path == "" {
+		return values
+	}
+
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+
+	if path != "" {
+		for _, key := range strings.Split(path, ".") {
+			var children []any
+
+			for _, value := range values {
+				children = append(children, lookupKey(value, key)...)
+			}
+
+			values = children
+		}
+	}
+
+	return flatten(values)
+}
+
+// lookupKey returns the values of a key of a decoded JSON value, traversing
+// any arrays, unless the key is an index of the array.
+func lookupKey(value any, key string) []any {
+	switch value := value.(type) {
+	case map[string]any:
+		if child, exists := value[key]; exists {
+			return []any{child}
+		}
+	case []any:
+		if index, err := strconv.Atoi(key); err == nil {
+			if index >= 0 && index < len(value) {
+				return []any{value[index]}
+			}
+
+			return nil
+		}
+
+		var children []any
+
+		for _, element := range value {
+			children = append(children, lookupKey(element, key)...)
+		}
+
+		return children
+	}
+
+	return nil
+}
+
+// flatten returns the values with any arrays replaced by their elements, and
+// without any nulls.
+func flatten(values []any) []any {
+	var flattened []any
+
+	for _, value := range values {
+		switch value := value.(type) {
+		case nil:
+			continue
+		case []any:
+			flattened = append(flattened, flatten(value)...)
+		default:
+			flattened = append(flattened, value)
+		}
+	}
+
+	return flattened
+}
+
+// lookupStrings returns the non-empty scalar values at a path in a decoded
+// JSON value (or in any arrays at the path), as strings.
+func lookupStrings(value any, path string) []string {
+	var strs []string
+
+	for _, value := range flatten(lookup(value, path)) {
+		var str string
+
+		switch value := value.(type) {
+		case string:
+			str = strings.TrimSpace(value)
+		case float64, bool:
+			str = fmt.Sprint(value)
+		}
+
+		if str != "" {
+			strs = append(strs, str)
+		}
+	}
+
+	return strs
+}
+
+// firstString returns the first of the strings, or an empty string if there
+// are none.
+func firstString(strs []string) string {
+	if len(strs) < 1 {
+		return ""
+	}
+
+	return strs[0]
+}
@@ -0,0 +1,58 @@
+package customhttp
+
+import (
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+// RequiredConfigError represents an error when a required configuration key is
+// missing or invalid.
+type RequiredConfigError = registry.RequiredConfigError
+
+// config is only set in the config file, as its mapping is too structured to
+// pass as flags or environment variables.
+type config struct {
+	Options
+}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "CustomHTTP"
+
+// Ensure our types satisfy the registry's provider contract at compile-time
+var (
+	_ registry.AliasedProvider = (*provider)(nil)
+	_ registry.Configuration   = (*config)(nil)
+)
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(*flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, &config{}
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Aliases() []string {
+	return []string{"custom"}
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	config := conf.(*config)
+
+	if config.URL == "" {
+		return nil, &RequiredConfigError{Key: "URL", JSONKey: JSONKey}
+	}
+
+	return New(registry.HTTPClient(), config.Options)
+}
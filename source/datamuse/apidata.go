@@ -0,0 +1,66 @@
+package datamuse
+
+import (
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+const (
+	// apiDefinitionSeparator defines the character that separates the part of
+	// speech from the text of a definition in the Datamuse API
+	apiDefinitionSeparator = "\t"
+)
+
+// apiPartsOfSpeech is a map of Datamuse API part of speech codes to their
+// lexical category names
+var apiPartsOfSpeech = map[string]string{
+	"n":   "noun",
+	"v":   "verb",
+	"adj": "adjective",
+	"adv": "adverb",
+}
+
+// apiResponse defines the structure of a Datamuse API "words" response
+type apiResponse []apiWordResult
+
+// apiWordResult defines the structure of a Datamuse API word result
+type apiWordResult struct {
+	Word         string   `json:"word"`
+	Score        int      `json:"score"`
+	NumSyllables uint     `json:"numSyllables"`
+	Tags         []string `json:"tags"`
+	Defs         []string `json:"defs"`
+}
+
+// toRelatedWords converts the API response to a list of related words.
+func (r apiResponse) toRelatedWords() source.RelatedWords {
+	words := make(source.RelatedWords, 0, len(r))
+
+	for _, apiResult := range r {
+		if apiResult.Word == "" {
+			continue
+		}
+
+		relatedWord := source.RelatedWord{Word: apiResult.Word}
+
+		if len(apiResult.Defs) > 0 {
+			relatedWord.LexicalCategory, relatedWord.Gloss = parseDefinition(apiResult.Defs[0])
+		}
+
+		words = append(words, relatedWord)
+	}
+
+	return words
+}
+
+// parseDefinition parses an API definition into its lexical category and its
+// text.
+func parseDefinition(definition string) (string, string) {
+	partOfSpeech, text, found := strings.Cut(definition, apiDefinitionSeparator)
+	if !found {
+		return "", strings.TrimSpace(definition)
+	}
+
+	return apiPartsOfSpeech[partOfSpeech], strings.TrimSpace(text)
+}
@@ -0,0 +1,79 @@
+package datamuse
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestAPIResponse_ToRelatedWords(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		json string
+		want source.RelatedWords
+	}{
+		"empty": {
+			json: `[]`,
+			want: source.RelatedWords{},
+		},
+		"words": {
+			json: `[{"word":"night","score":100,"numSyllables":1},{"word":"knight","score":99,"numSyllables":1}]`,
+			want: source.RelatedWords{{Word: "night"}, {Word: "knight"}},
+		},
+		"blank words": {
+			json: `[{"word":""},{"word":"nite"}]`,
+			want: source.RelatedWords{{Word: "nite"}},
+		},
+		"definitions": {
+			json: `[{"word":"arachnophobia","defs":["n\tan abnormal fear of spiders","n\tsecond"]},{"word":"fast","defs":["adv\tquickly"]}]`,
+			want: source.RelatedWords{
+				{Word: "arachnophobia", LexicalCategory: "noun", Gloss: "an abnormal fear of spiders"},
+				{Word: "fast", LexicalCategory: "adverb", Gloss: "quickly"},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var response apiResponse
+
+			if err := json.Unmarshal([]byte(testData.json), &response); err != nil {
+				t.Fatalf("json.Unmarshal returned an error: %s", err)
+			}
+
+			if got := response.toRelatedWords(); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("apiResponse.toRelatedWords returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestParseDefinition(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		definition   string
+		wantCategory string
+		wantText     string
+	}{
+		"empty": {},
+		"no part of speech": {
+			definition: "a definition",
+			wantText:   "a definition",
+		},
+		"known part of speech": {
+			definition:   "adj\tvery good ",
+			wantCategory: "adjective",
+			wantText:     "very good",
+		},
+		"unknown part of speech": {
+			definition: "u\tsomething",
+			wantText:   "something",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			category, text := parseDefinition(testData.definition)
+
+			if category != testData.wantCategory || text != testData.wantText {
+				t.Errorf("parseDefinition returned wrong value. Got %#v, %#v. Want %#v, %#v.", category, text, testData.wantCategory, testData.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Package datamuse provides word-finding operations via the Datamuse API
+package datamuse
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "Datamuse API"
+
+const (
+	// baseURLString is the base URL for all Datamuse API interactions
+	baseURLString = "https://api.datamuse.com/"
+
+	wordsURLString = baseURLString + "words"
+
+	httpRequestAcceptHeaderName = "Accept"
+
+	queryParamSoundsLike = "sl"
+	queryParamMeansLike  = "ml"
+	queryParamMetadata   = "md"
+	queryParamMax        = "max"
+
+	queryParamAdjectivesForNoun = "rel_jjb"
+	queryParamNounsForAdjective = "rel_jja"
+	queryParamFollowers         = "rel_bga"
+	queryParamPredecessors      = "rel_bgb"
+
+	metadataDefinitions = "d"
+
+	jsonMIMEType = "application/json"
+)
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{jsonMIMEType}
+
+// collocationQueries is the list of queries made to find collocations, in the
+// order that their groups are returned
+var collocationQueries = []struct {
+	groupName  string
+	queryParam string
+}{
+	{"Adjectives used with it", queryParamAdjectivesForNoun},
+	{"Nouns it's used with", queryParamNounsForAdjective},
+	{"Words that often follow it", queryParamFollowers},
+	{"Words that often precede it", queryParamPredecessors},
+}
+
+// API contains a configured HTTP client for Datamuse API operations
+type API struct {
+	httpClient *http.Client
+}
+
+// New returns a new Datamuse API client
+func New(httpClient http.Client) *API {
+	return &API{&httpClient}
+}
+
+// Name returns the printable, human-readable name of the source.
+func (a *API) Name() string {
+	return Name
+}
+
+// SoundsLike takes a word string and returns a list of words that sound like
+// it (homophones and near-homophones), and an error if any occurred.
+func (a *API) SoundsLike(word string, limit uint) (source.RelatedWords, error) {
+	return a.findRelatedWords(word, url.Values{queryParamSoundsLike: {word}}, limit)
+}
+
+// MeansLike takes a description string (such as "fear of spiders") and returns
+// a list of words, with short glosses, whose meanings match the description,
+// and an error if any occurred.
+func (a *API) MeansLike(description string, limit uint) (source.RelatedWords, error) {
+	return a.findRelatedWords(description, url.Values{
+		queryParamMeansLike: {description},
+		queryParamMetadata:  {metadataDefinitions},
+	}, limit)
+}
+
+// Collocations takes a word string and returns groups of words that are
+// commonly used with it (such as the adjectives commonly used with a noun),
+// and an error if any occurred. Groups without any words are omitted.
+func (a *API) Collocations(word string, limit uint) ([]source.RelatedWordGroup, error) {
+	var groups []source.RelatedWordGroup
+
+	for _, query := range collocationQueries {
+		response, err := a.findWords(url.Values{query.queryParam: {word}}, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		if words := response.toRelatedWords(); len(words) > 0 {
+			groups = append(groups, source.RelatedWordGroup{Name: query.groupName, Words: words})
+		}
+	}
+
+	if len(groups) < 1 {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	return groups, nil
+}
+
+// findRelatedWords finds words related to a query with the given query
+// parameters, and returns an error if none were found.
+func (a *API) findRelatedWords(query string, queryParams url.Values, limit uint) (source.RelatedWords, error) {
+	response, err := a.findWords(queryParams, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	words := response.toRelatedWords()
+	if len(words) < 1 {
+		return nil, &source.EmptyResultError{Word: query}
+	}
+
+	return words, nil
+}
+
+// findWords makes a request to the API's "words" endpoint with the given query
+// parameters, and returns the API response.
+func (a *API) findWords(queryParams url.Values, limit uint) (apiResponse, error) {
+	requestURL, err := url.Parse(wordsURLString)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit > 0 {
+		queryParams.Set(queryParamMax, strconv.FormatUint(uint64(limit), 10))
+	}
+
+	requestURL.RawQuery = queryParams.Encode()
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
+
+	httpResponse, err := a.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); err != nil {
+		return nil, err
+	}
+
+	var response apiResponse
+
+	if err = source.DecodeJSONResponse(httpResponse, &response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -11,7 +11,10 @@ import (
 const (
 	emptyResultErrorMessage         = "the source returned an empty result"
 	authenticationErrorMessage      = "the source returned an authentication error"
+	entitlementErrorMessage         = "the source refused access, as it isn't included in the account's plan"
+	emptyResponseErrorMessage       = "the source returned an empty response"
 	invalidResponseErrorMessage     = "the source returned an invalid response"
+	parseWarningsErrorMessage       = "the source returned a result that couldn't be fully parsed"
 	errorMessageForWordSuffixFormat = " for word: %q"
 
 	contentTypeHeaderName = "Content-Type"
@@ -25,7 +28,31 @@ type EmptyResultError struct {
 }
 
 // AuthenticationError represents an error caused by an authentication problem
-type AuthenticationError struct{}
+type AuthenticationError struct {
+	Message string // The source's explanation of the problem, if any
+	Hint    string // A suggestion of how to fix the problem, if any
+}
+
+// EntitlementError represents an error caused by a source refusing access to
+// something that the (otherwise authenticated) account's plan doesn't include
+type EntitlementError struct {
+	Message string // The source's explanation of the problem, if any
+	Hint    string // A suggestion of how to fix the problem, if any
+}
+
+// EmptyResponseError represents an error caused by a successful response
+// without a body, which (unlike an EmptyResultError) doesn't say whether the
+// word exists or not
+type EmptyResponseError struct {
+	Word string
+}
+
+// ParseWarningsError represents an error caused by a result that couldn't be
+// fully parsed, when any parse warnings are treated as errors
+type ParseWarningsError struct {
+	Word     string
+	Warnings []ParseWarning
+}
 
 // InvalidResponseError represents an error caused by an invalid response
 type InvalidResponseError struct {
@@ -126,9 +153,53 @@ func (e *EmptyResultError) Error() string {
 }
 
 func (e *AuthenticationError) Error() string {
-	return authenticationErrorMessage
+	return withSourceMessage(authenticationErrorMessage, e.Message)
+}
+
+// Remedy returns a suggestion of how to fix the problem, if any.
+func (e *AuthenticationError) Remedy() string {
+	return e.Hint
+}
+
+func (e *EntitlementError) Error() string {
+	return withSourceMessage(entitlementErrorMessage, e.Message)
+}
+
+// Remedy returns a suggestion of how to fix the problem, if any.
+func (e *EntitlementError) Remedy() string {
+	return e.Hint
+}
+
+func (e *EmptyResponseError) Error() string {
+	msg := emptyResponseErrorMessage
+
+	if e.Word != "" {
+		msg = msg + fmt.Sprintf(errorMessageForWordSuffixFormat, e.Word)
+	}
+
+	return msg
+}
+
+func (e *ParseWarningsError) Error() string {
+	msg := parseWarningsErrorMessage
+
+	if e.Word != "" {
+		msg = msg + fmt.Sprintf(errorMessageForWordSuffixFormat, e.Word)
+	}
+
+	return msg
 }
 
 func (e *InvalidResponseError) Error() string {
 	return invalidResponseErrorMessage
 }
+
+// withSourceMessage returns an error message with a source's own explanation
+// appended, if there is one.
+func withSourceMessage(msg string, sourceMessage string) string {
+	if sourceMessage == "" {
+		return msg
+	}
+
+	return fmt.Sprintf("%s (%q)", msg, sourceMessage)
+}
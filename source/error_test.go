@@ -12,6 +12,10 @@ import (
 // Enforce interface contracts
 var (
 	_ error = (*EmptyResultError)(nil)
+	_ error = (*AuthenticationError)(nil)
+	_ error = (*EntitlementError)(nil)
+	_ error = (*EmptyResponseError)(nil)
+	_ error = (*ParseWarningsError)(nil)
 	_ error = (*InvalidResponseError)(nil)
 )
 
@@ -177,6 +181,33 @@ func TestAuthenticationError_Error(t *testing.T) {
 	}
 }
 
+func TestAuthenticationError_ErrorWithMessage(t *testing.T) {
+	message := "Authentication failed"
+	msg := (&AuthenticationError{Message: message}).Error()
+
+	if !strings.Contains(msg, message) {
+		t.Errorf("Error message %q didn't contain message %q", msg, message)
+	}
+}
+
+func TestEntitlementError_Error(t *testing.T) {
+	message := "Not available on your plan"
+	msg := (&EntitlementError{Message: message}).Error()
+
+	if !strings.Contains(msg, message) {
+		t.Errorf("Error message %q didn't contain message %q", msg, message)
+	}
+}
+
+func TestEmptyResponseError_Error(t *testing.T) {
+	word := "test"
+	msg := (&EmptyResponseError{Word: word}).Error()
+
+	if !strings.Contains(msg, word) {
+		t.Errorf("Error message %q didn't contain word %q", msg, word)
+	}
+}
+
 func TestInvalidResponseError_Error(t *testing.T) {
 	msg := (&InvalidResponseError{}).Error()
 
@@ -0,0 +1,116 @@
+// Package execsource provides a dictionary source via an external command,
+// which is run with the word as its last argument, after an argument of "--",
+// and writes the word's results to its stdout as JSON, so that sources can be
+// written in any language.
+package execsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Rican7/define/internal/command"
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "External Command Dictionary"
+
+// commandTimeout is how long the command may run for a word before it's killed
+const commandTimeout = 30 * time.Second
+
+// execSource contains the arguments of the command to run for words
+type execSource struct {
+	args []string
+}
+
+// New returns a new external command dictionary source, which runs the given
+// command.
+//
+// The command is split into arguments by whitespace, with single or double
+// quotes grouping arguments containing whitespace.
+func New(commandLine string) (source.Source, error) {
+	args, err := command.Split(commandLine)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+
+	if len(args) < 1 {
+		return nil, errors.New("the command is empty")
+	}
+
+	return &execSource{args: args}, nil
+}
+
+// Name returns the printable, human-readable name of the source.
+func (s *execSource) Name() string {
+	return Name
+}
+
+// Define takes a word string and returns a list of dictionary results, and
+// an error if any occurred.
+//
+// The command's output is decoded as either the results printed with
+// "--output=json" (their "DictionaryResults"), or only a list of those results,
+// and empty output (or an empty list) means that the word has no results.
+func (s *execSource) Define(word string) (source.DictionaryResults, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	// End the command's options before the word, so that a word starting with
+	// a "-" isn't taken as one
+	args := append(slices.Clone(s.args[1:]), "--", word)
+
+	cmd := exec.CommandContext(ctx, s.args[0], args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("running the command failed: %w: %s", err, message)
+		}
+
+		return nil, fmt.Errorf("running the command failed: %w", err)
+	}
+
+	output := bytes.TrimSpace(stdout.Bytes())
+	if len(output) < 1 {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	results, err := decodeResults(output)
+	if err != nil {
+		return nil, fmt.Errorf("the command's output isn't valid JSON results: %w", err)
+	}
+
+	results.NormalizePartsOfSpeech()
+
+	return source.ValidateAndReturnDictionaryResults(word, results)
+}
+
+// decodeResults decodes the given JSON output of a command into dictionary
+// results, from either an object in the structure printed with "--output=json"
+// or a list of results.
+func decodeResults(output []byte) (source.DictionaryResults, error) {
+	if output[0] != '{' {
+		var results source.DictionaryResults
+		err := json.Unmarshal(output, &results)
+
+		return results, err
+	}
+
+	var printed struct {
+		DictionaryResults source.DictionaryResults
+	}
+	err := json.Unmarshal(output, &printed)
+
+	return printed.DictionaryResults, err
+}
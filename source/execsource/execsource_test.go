@@ -0,0 +1,138 @@
+package execsource
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/internal/io/printer"
+	"github.com/Rican7/define/source"
+)
+
+func TestNew(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		command string
+		wantErr bool
+	}{
+		"command":            {command: "./define-word --json"},
+		"empty":              {command: " ", wantErr: true},
+		"unterminated quote": {command: `sh -c 'echo`, wantErr: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if _, err := New(testData.command); (err != nil) != testData.wantErr {
+				t.Errorf("New returned wrong error. Got %#v. Want error: %#v.", err, testData.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecSource_Define(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		command   string
+		want      source.DictionaryResults
+		wantEmpty bool
+		wantErr   bool
+	}{
+		"results": {
+			command: `sh -c 'echo "[{\"Word\": \"$1\", \"Entries\": [{\"LexicalCategory\": \"noun\", \"Senses\": [{\"Definitions\": [\"a trial\"]}]}]}]"'`,
+			want: source.DictionaryResults{
+				{
+					Word: "test",
+					Entries: []source.DictionaryEntry{
+						{
+							Entry:  source.Entry{LexicalCategory: "noun", PartOfSpeech: source.PartOfSpeechNoun},
+							Senses: []source.Sense{{Definitions: []string{"a trial"}}},
+						},
+					},
+				},
+			},
+		},
+		"no output":          {command: "true", wantEmpty: true},
+		"empty list":         {command: `sh -c 'echo []'`, wantEmpty: true},
+		"invalid output":     {command: "echo not json", wantErr: true},
+		"failing command":    {command: `sh -c 'echo failed >&2; exit 1'`, wantErr: true},
+		"nonexistent binary": {command: "define-nonexistent-source", wantErr: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			src, err := New(testData.command)
+			if err != nil {
+				t.Fatalf("New returned an error: %v", err)
+			}
+
+			got, err := src.Define("test")
+
+			if _, isEmptyResult := err.(*source.EmptyResultError); isEmptyResult != testData.wantEmpty {
+				t.Errorf("Define returned wrong empty result error. Got %#v. Want empty: %#v.", err, testData.wantEmpty)
+			}
+
+			if isErr := err != nil && !testData.wantEmpty; isErr != testData.wantErr {
+				t.Errorf("Define returned wrong error. Got %#v. Want error: %#v.", err, testData.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Define returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestExecSource_DefineOptionLikeWord(t *testing.T) {
+	// Only define the word if it's passed after an argument of "--"
+	src, err := New(`sh -c 'test "$0" = "--" && echo "[{\"Word\": \"$1\", \"Entries\": [{\"Senses\": [{\"Definitions\": [\"a prefix\"]}]}]}]"'`)
+	if err != nil {
+		t.Fatalf("New returned an error: %v", err)
+	}
+
+	got, err := src.Define("-ish")
+	if err != nil {
+		t.Fatalf("Define returned an error: %v", err)
+	}
+
+	if len(got) != 1 || got[0].Word != "-ish" {
+		t.Errorf("Define returned wrong value. Got %#v. Want a result of %#v.", got, "-ish")
+	}
+}
+
+func TestExecSource_DefineJSONPrinterOutput(t *testing.T) {
+	want := source.DictionaryResults{
+		{
+			Language: "en",
+			Word:     "test",
+			Entries: []source.DictionaryEntry{
+				{
+					Entry:  source.Entry{LexicalCategory: "noun", PartOfSpeech: source.PartOfSpeechNoun},
+					Senses: []source.Sense{{Definitions: []string{"a trial"}}},
+				},
+			},
+		},
+	}
+
+	var output bytes.Buffer
+
+	jsonPrinter := printer.NewJSONPrinter(defineio.NewPanicWriter(&output, 4))
+	if err := jsonPrinter.PrintResult(printer.Result{Word: "test", Source: "Source", DictionaryResults: want}); err != nil {
+		t.Fatalf("PrintResult returned an error: %v", err)
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "output.json")
+	if err := os.WriteFile(outputPath, output.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := New(`sh -c 'cat "` + outputPath + `"'`)
+	if err != nil {
+		t.Fatalf("New returned an error: %v", err)
+	}
+
+	got, err := src.Define("test")
+	if err != nil {
+		t.Fatalf("Define returned an error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Define returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+}
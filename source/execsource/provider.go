@@ -0,0 +1,100 @@
+package execsource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+// RequiredConfigError represents an error when a required configuration key is
+// missing or invalid.
+type RequiredConfigError = registry.RequiredConfigError
+
+type config struct {
+	Command string
+}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "ExecSource"
+
+// The names of the flag and environment variable that set the command
+const (
+	commandFlag    = "exec-source-command"
+	commandEnvName = "EXEC_SOURCE_COMMAND"
+)
+
+// Ensure our types satisfy the registry's provider contract at compile-time
+var (
+	_ registry.AliasedProvider      = (*provider)(nil)
+	_ registry.DynamicConfiguration = (*config)(nil)
+)
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, initConfig(flags)
+}
+
+func initConfig(flags *flag.FlagSet) *config {
+	conf := &config{}
+
+	// Define our flags
+	registry.DefineStringFlags(
+		flags,
+		registry.StringFlag{Name: commandFlag, Usage: fmt.Sprintf("The command to run for the %s, with the word as its last argument", Name), Value: &conf.Command},
+	)
+
+	return conf
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+// UnmarshalJSON defines how the configuration should be JSON unmarshalled.
+func (c *config) UnmarshalJSON(data []byte) error {
+	// Alias our type so that we can unmarshal as usual
+	type alias config
+	copy := &alias{}
+
+	// Unmarshal into our copy
+	err := json.Unmarshal(data, copy)
+	if err != nil {
+		return err
+	}
+
+	registry.FillEmpty(&c.Command, copy.Command)
+
+	return nil
+}
+
+// LoadEnv fills in any values that weren't passed as flags from environment
+// variables.
+func (c *config) LoadEnv() {
+	registry.FillEmptyFromEnv(&c.Command, commandEnvName)
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Aliases() []string {
+	return []string{"exec"}
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	config := conf.(*config)
+
+	if config.Command == "" {
+		return nil, &RequiredConfigError{Key: "Command", Flag: commandFlag, EnvName: commandEnvName, JSONKey: JSONKey}
+	}
+
+	return New(config.Command)
+}
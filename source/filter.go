@@ -0,0 +1,143 @@
+package source
+
+import (
+	"slices"
+	"strings"
+)
+
+// offensiveCategoryTerms is the list of terms that the labels of offensive
+// senses contain, like "often offensive" or "usually vulgar".
+var offensiveCategoryTerms = []string{"offensive", "vulgar", "obscene", "disparaging"}
+
+// FilterByLanguage takes a language (as a tag, like "en" or "en-GB") and
+// returns a copy of the results with only the results in that language.
+//
+// A language without a region (like "en") matches the results in any of its
+// regional variants (like "en-gb"), compared case-insensitively. Results
+// without a language are kept, as their language isn't known.
+func (r DictionaryResults) FilterByLanguage(language string) DictionaryResults {
+	var filteredResults DictionaryResults
+
+	wanted := normalizeLanguageTag(language)
+
+	for _, result := range r {
+		resultLanguage := normalizeLanguageTag(result.Language)
+
+		if resultLanguage == "" || resultLanguage == wanted || strings.HasPrefix(resultLanguage, wanted+"-") {
+			filteredResults = append(filteredResults, result)
+		}
+	}
+
+	return filteredResults
+}
+
+// normalizeLanguageTag normalizes a language tag for matching, by lower-casing
+// it and separating its parts with hyphens (rather than underscores).
+func normalizeLanguageTag(tag string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tag)), "_", "-")
+}
+
+// FilterByCategory takes a category (like a domain, such as "Law") and returns
+// a copy of the results with only the senses in that category, compared
+// case-insensitively and ignoring diacritics.
+//
+// A sense is kept with all of its sub-senses if it's in the category itself,
+// or otherwise with only the sub-senses that are in the category. Any entries
+// and results that are left without senses are removed.
+func (r DictionaryResults) FilterByCategory(category string) DictionaryResults {
+	var filteredResults DictionaryResults
+
+	for _, result := range r {
+		var filteredEntries []DictionaryEntry
+
+		for _, entry := range result.Entries {
+			if entry.Senses = filterSensesByCategory(entry.Senses, category); len(entry.Senses) > 0 {
+				filteredEntries = append(filteredEntries, entry)
+			}
+		}
+
+		if len(filteredEntries) > 0 {
+			result.Entries = filteredEntries
+			filteredResults = append(filteredResults, result)
+		}
+	}
+
+	return filteredResults
+}
+
+// filterSensesByCategory returns the senses that are in a category, or that
+// have sub-senses that are in the category.
+func filterSensesByCategory(senses []Sense, category string) []Sense {
+	var filtered []Sense
+
+	for _, sense := range senses {
+		isInCategory := slices.ContainsFunc(sense.Categories, func(senseCategory string) bool {
+			return EqualFoldPlain(senseCategory, category)
+		})
+
+		if isInCategory {
+			filtered = append(filtered, sense)
+			continue
+		}
+
+		if sense.SubSenses = filterSensesByCategory(sense.SubSenses, category); len(sense.SubSenses) > 0 {
+			filtered = append(filtered, sense)
+		}
+	}
+
+	return filtered
+}
+
+// FilterOffensive returns a copy of the results without the entries that the
+// source flags as offensive, or the senses that are labelled as offensive (or
+// vulgar, obscene, or disparaging), along with their sub-senses.
+//
+// Any entries that are left without senses, and results without entries, are
+// removed.
+func (r DictionaryResults) FilterOffensive() DictionaryResults {
+	var filteredResults DictionaryResults
+
+	for _, result := range r {
+		var filteredEntries []DictionaryEntry
+
+		for _, entry := range result.Entries {
+			if entry.Offensive {
+				continue
+			}
+
+			hadSenses := len(entry.Senses) > 0
+
+			if entry.Senses = filterOffensiveSenses(entry.Senses); len(entry.Senses) > 0 || !hadSenses {
+				filteredEntries = append(filteredEntries, entry)
+			}
+		}
+
+		if len(filteredEntries) > 0 {
+			result.Entries = filteredEntries
+			filteredResults = append(filteredResults, result)
+		}
+	}
+
+	return filteredResults
+}
+
+// filterOffensiveSenses returns the senses that aren't labelled as offensive,
+// with only their sub-senses that aren't either.
+func filterOffensiveSenses(senses []Sense) []Sense {
+	var filtered []Sense
+
+	for _, sense := range senses {
+		isOffensive := slices.ContainsFunc(sense.Categories, func(category string) bool {
+			return slices.ContainsFunc(offensiveCategoryTerms, func(term string) bool {
+				return strings.Contains(strings.ToLower(category), term)
+			})
+		})
+
+		if !isOffensive {
+			sense.SubSenses = filterOffensiveSenses(sense.SubSenses)
+			filtered = append(filtered, sense)
+		}
+	}
+
+	return filtered
+}
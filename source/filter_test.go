@@ -0,0 +1,163 @@
+package source
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDictionaryResults_FilterByLanguage(t *testing.T) {
+	english := DictionaryResult{Word: "pain", Language: "en"}
+	britishEnglish := DictionaryResult{Word: "pain", Language: "en-gb"}
+	french := DictionaryResult{Word: "pain", Language: "fr"}
+	unknown := DictionaryResult{Word: "pain"}
+
+	results := DictionaryResults{english, britishEnglish, french, unknown}
+
+	for testName, testData := range map[string]struct {
+		language string
+		want     DictionaryResults
+	}{
+		"language": {
+			language: "en",
+			want:     DictionaryResults{english, britishEnglish, unknown},
+		},
+		"regional variant": {
+			language: "en_GB",
+			want:     DictionaryResults{britishEnglish, unknown},
+		},
+		"other language": {
+			language: "FR",
+			want:     DictionaryResults{french, unknown},
+		},
+		"no matches": {
+			language: "de",
+			want:     DictionaryResults{unknown},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := results.FilterByLanguage(testData.language); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("FilterByLanguage returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestDictionaryResults_FilterByCategory(t *testing.T) {
+	law := Sense{Definitions: []string{"a legal case"}, Categories: []string{"Law"}}
+	music := Sense{Definitions: []string{"a musical piece"}, Categories: []string{"Music"}}
+	plain := Sense{Definitions: []string{"a general meaning"}}
+	withLawSubSense := Sense{Definitions: []string{"a parent meaning"}, SubSenses: []Sense{music, law}}
+
+	results := DictionaryResults{
+		{
+			Word: "brief",
+			Entries: []DictionaryEntry{
+				{Entry: Entry{Word: "brief", LexicalCategory: "noun"}, Senses: []Sense{plain, law, withLawSubSense}},
+				{Entry: Entry{Word: "brief", LexicalCategory: "verb"}, Senses: []Sense{music}},
+			},
+		},
+	}
+
+	for testName, testData := range map[string]struct {
+		category string
+		want     DictionaryResults
+	}{
+		"no matches": {
+			category: "Computing",
+			want:     nil,
+		},
+		"case-insensitive match": {
+			category: "law",
+			want: DictionaryResults{
+				{
+					Word: "brief",
+					Entries: []DictionaryEntry{
+						{
+							Entry: Entry{Word: "brief", LexicalCategory: "noun"},
+							Senses: []Sense{
+								law,
+								{Definitions: []string{"a parent meaning"}, SubSenses: []Sense{law}},
+							},
+						},
+					},
+				},
+			},
+		},
+		"match in another entry": {
+			category: "Music",
+			want: DictionaryResults{
+				{
+					Word: "brief",
+					Entries: []DictionaryEntry{
+						{
+							Entry:  Entry{Word: "brief", LexicalCategory: "noun"},
+							Senses: []Sense{{Definitions: []string{"a parent meaning"}, SubSenses: []Sense{music}}},
+						},
+						{Entry: Entry{Word: "brief", LexicalCategory: "verb"}, Senses: []Sense{music}},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := results.FilterByCategory(testData.category); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("DictionaryResults.FilterByCategory returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+
+	// Make sure the original results weren't modified
+	if got := len(results[0].Entries[0].Senses[2].SubSenses); got != 2 {
+		t.Errorf("DictionaryResults.FilterByCategory modified the original results. Got %d sub-senses. Want %d.", got, 2)
+	}
+}
+
+func TestDictionaryResults_FilterOffensive(t *testing.T) {
+	plain := Sense{Definitions: []string{"a general meaning"}}
+	vulgar := Sense{Definitions: []string{"a vulgar meaning"}, Categories: []string{"usually vulgar"}}
+	disparaging := Sense{Definitions: []string{"a disparaging meaning"}, Categories: []string{"Disparaging"}}
+	withOffensiveSubSense := Sense{Definitions: []string{"a parent meaning"}, SubSenses: []Sense{plain, disparaging}}
+
+	for testName, testData := range map[string]struct {
+		results DictionaryResults
+		want    DictionaryResults
+	}{
+		"none offensive": {
+			results: DictionaryResults{{Word: "word", Entries: []DictionaryEntry{{Senses: []Sense{plain}}}}},
+			want:    DictionaryResults{{Word: "word", Entries: []DictionaryEntry{{Senses: []Sense{plain}}}}},
+		},
+		"offensive entry": {
+			results: DictionaryResults{
+				{Word: "word", Entries: []DictionaryEntry{{Senses: []Sense{plain}, Offensive: true}, {Senses: []Sense{plain}}}},
+			},
+			want: DictionaryResults{{Word: "word", Entries: []DictionaryEntry{{Senses: []Sense{plain}}}}},
+		},
+		"offensive senses": {
+			results: DictionaryResults{
+				{Word: "word", Entries: []DictionaryEntry{{Senses: []Sense{vulgar, withOffensiveSubSense}}}},
+			},
+			want: DictionaryResults{
+				{
+					Word: "word",
+					Entries: []DictionaryEntry{
+						{Senses: []Sense{{Definitions: []string{"a parent meaning"}, SubSenses: []Sense{plain}}}},
+					},
+				},
+			},
+		},
+		"only offensive": {
+			results: DictionaryResults{{Word: "word", Entries: []DictionaryEntry{{Senses: []Sense{vulgar}}}}},
+			want:    nil,
+		},
+		"entry without senses": {
+			results: DictionaryResults{{Word: "word", Entries: []DictionaryEntry{{EntryNotes: []string{"a note"}}}}},
+			want:    DictionaryResults{{Word: "word", Entries: []DictionaryEntry{{EntryNotes: []string{"a note"}}}}},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.results.FilterOffensive(); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("DictionaryResults.FilterOffensive returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
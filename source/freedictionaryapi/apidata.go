@@ -76,7 +76,7 @@ func (r apiResponse) toResults() source.DictionaryResults {
 		if apiResult.Phonetic != "" {
 			pronunciation := cleanPhoneticText(apiResult.Phonetic)
 
-			pronunciations = append(pronunciations, source.Pronunciation(pronunciation))
+			pronunciations = append(pronunciations, source.Pronunciation{Spelling: pronunciation, Notation: source.PronunciationNotationIPA})
 		}
 
 		for _, phonetic := range apiResult.Phonetics {
@@ -86,8 +86,8 @@ func (r apiResponse) toResults() source.DictionaryResults {
 
 			pronunciation := cleanPhoneticText(phonetic.Text)
 
-			if len(pronunciations) < 1 || string(pronunciations[0]) != pronunciation {
-				pronunciations = append(pronunciations, source.Pronunciation(pronunciation))
+			if len(pronunciations) < 1 || pronunciations[0].Spelling != pronunciation {
+				pronunciations = append(pronunciations, source.Pronunciation{Spelling: pronunciation, Notation: source.PronunciationNotationIPA})
 			}
 		}
 
@@ -96,6 +96,7 @@ func (r apiResponse) toResults() source.DictionaryResults {
 
 			sourceEntry.Word = apiResult.Word
 			sourceEntry.Pronunciations = pronunciations
+			sourceEntry.PronunciationNotation = source.PronunciationNotationIPA
 
 			sourceEntries = append(sourceEntries, sourceEntry)
 		}
@@ -106,6 +107,12 @@ func (r apiResponse) toResults() source.DictionaryResults {
 				Language: "en", // TODO
 				Word:     apiResult.Word,
 				Entries:  sourceEntries,
+
+				Attribution: source.ResultAttribution{
+					License:    apiResult.License.Name,
+					LicenseURL: apiResult.License.URL,
+					SourceURLs: apiResult.SourceUrls,
+				},
 			},
 		)
 	}
@@ -118,6 +125,7 @@ func (m *apiMeaning) toEntry() source.DictionaryEntry {
 	sourceEntry := source.DictionaryEntry{}
 
 	sourceEntry.LexicalCategory = m.PartOfSpeech
+	sourceEntry.PartOfSpeech = source.ParsePartOfSpeech(m.PartOfSpeech)
 
 	for _, apiDefinition := range m.Definitions {
 		sourceSense := apiDefinition.toSense()
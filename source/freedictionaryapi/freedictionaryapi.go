@@ -5,8 +5,6 @@
 package freedictionaryapi
 
 import (
-	"encoding/json"
-	"io"
 	"net/http"
 	"net/url"
 
@@ -62,12 +60,12 @@ func (a *api) Name() string {
 // an error if any occurred.
 func (a *api) Define(word string) (source.DictionaryResults, error) {
 	// Prepare our URL
-	requestURL, err := url.Parse(entriesURLString + "en/" + word)
+	requestURL, err := source.NewPathURL(entriesURLString, "en", word)
 	if err != nil {
 		return nil, err
 	}
 
-	httpRequest, err := http.NewRequest(http.MethodGet, apiURL.ResolveReference(requestURL).String(), nil)
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -93,14 +91,9 @@ func (a *api) Define(word string) (source.DictionaryResults, error) {
 		return nil, err
 	}
 
-	body, err := io.ReadAll(httpResponse.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var response apiResponse
 
-	if err = json.Unmarshal(body, &response); err != nil {
+	if err = source.DecodeJSONResponse(httpResponse, &response); err != nil {
 		return nil, err
 	}
 
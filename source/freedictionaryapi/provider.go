@@ -3,19 +3,14 @@
 package freedictionaryapi
 
 import (
-	"net/http"
-
-	flag "github.com/ogier/pflag"
-
+	"github.com/Rican7/define/internal/flag"
 	"github.com/Rican7/define/registry"
 	"github.com/Rican7/define/source"
 )
 
 // RequiredConfigError represents an error when a required configuration key is
 // missing or invalid.
-type RequiredConfigError struct {
-	Key string
-}
+type RequiredConfigError = registry.RequiredConfigError
 
 type config struct{}
 
@@ -24,6 +19,12 @@ type provider struct{}
 // JSONKey defines the JSON key used for the provider
 const JSONKey = "FreeDictionaryAPI"
 
+// Ensure our types satisfy the registry's provider contract at compile-time
+var (
+	_ registry.AliasedProvider = (*provider)(nil)
+	_ registry.Configuration   = (*config)(nil)
+)
+
 func init() {
 	registry.Register(registry.RegisterFunc(register))
 }
@@ -40,6 +41,10 @@ func (p *provider) Name() string {
 	return Name
 }
 
+func (p *provider) Aliases() []string {
+	return []string{"freedict"}
+}
+
 func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
-	return New(http.Client{}), nil
+	return New(registry.HTTPClient()), nil
 }
@@ -0,0 +1,156 @@
+package gcide
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// regexpEntities is a regular expression for matching the character
+	// entities of the data, which are written both as self-closing tags (like
+	// "<ae/>") and as SGML entities (like "&aelig;"), capturing their names.
+	regexpEntities = regexp.MustCompile(`<([A-Za-z][A-Za-z0-9]*)/>|&([A-Za-z][A-Za-z0-9]*);`)
+
+	// regexpLineBreaks is a regular expression for matching the line breaks of
+	// the data, which aren't closed (like "<br/" followed by a new line).
+	regexpLineBreaks = regexp.MustCompile(`<br/>?\s*`)
+
+	// regexpTags is a regular expression for matching the markup tags of the
+	// data.
+	regexpTags = regexp.MustCompile(`<[^>]*>`)
+)
+
+// entities maps the names of the character entities of the data to their
+// text.
+var entities = map[string]string{
+	"ae":     "æ",
+	"AE":     "Æ",
+	"aelig":  "æ",
+	"AElig":  "Æ",
+	"oe":     "œ",
+	"OE":     "Œ",
+	"oelig":  "œ",
+	"OElig":  "Œ",
+	"szlig":  "ß",
+	"eth":    "ð",
+	"thorn":  "þ",
+	"amp":    "&",
+	"lt":     "<",
+	"gt":     ">",
+	"quot":   `"`,
+	"sect":   "§",
+	"para":   "¶",
+	"deg":    "°",
+	"pound":  "£",
+	"cent":   "¢",
+	"middot": "·",
+	"times":  "×",
+	"divide": "÷",
+	"frac12": "½",
+	"frac14": "¼",
+	"frac34": "¾",
+	"prime":  "′",
+	"dprime": "″",
+	"mdash":  "—",
+	"ndash":  "–",
+	"lsquo":  "‘",
+	"rsquo":  "’",
+	"ldquo":  "“",
+	"rdquo":  "”",
+	"dagger": "†",
+	"hand":   "☞",
+	"br":     " ",
+
+	// Greek letters, used in etymologies
+	"alpha":   "α",
+	"beta":    "β",
+	"gamma":   "γ",
+	"delta":   "δ",
+	"epsilon": "ε",
+	"zeta":    "ζ",
+	"eta":     "η",
+	"theta":   "θ",
+	"iota":    "ι",
+	"kappa":   "κ",
+	"lambda":  "λ",
+	"mu":      "μ",
+	"nu":      "ν",
+	"xi":      "ξ",
+	"omicron": "ο",
+	"pi":      "π",
+	"rho":     "ρ",
+	"sigma":   "σ",
+	"sigmat":  "ς",
+	"tau":     "τ",
+	"upsilon": "υ",
+	"phi":     "φ",
+	"chi":     "χ",
+	"psi":     "ψ",
+	"omega":   "ω",
+}
+
+// diacriticSuffixes maps the suffixes of the names of the entities of letters
+// with diacritics (like "eacute") to the combining characters of the
+// diacritics.
+var diacriticSuffixes = []struct {
+	suffix    string
+	combining string
+}{
+	{"acute", "\u0301"},
+	{"grave", "\u0300"},
+	{"uml", "\u0308"},
+	{"circ", "\u0302"},
+	{"tilde", "\u0303"},
+	{"cedil", "\u0327"},
+	{"macr", "\u0304"},
+	{"mac", "\u0304"},
+	{"breve", "\u0306"},
+	{"cr", "\u0306"},
+	{"ring", "\u030A"},
+	{"caron", "\u030C"},
+	{"dot", "\u0307"},
+	{"it", ""}, // An italic letter, in pronunciations
+}
+
+// entityText returns the text of the character entity of the given name, or
+// an empty string if it's not known.
+func entityText(name string) string {
+	if text, ok := entities[name]; ok {
+		return text
+	}
+
+	for _, diacritic := range diacriticSuffixes {
+		base, found := strings.CutSuffix(name, diacritic.suffix)
+
+		if found && len(base) == 1 {
+			return norm.NFC.String(base + diacritic.combining)
+		}
+	}
+
+	return ""
+}
+
+// cleanText takes a text of the data and returns it as plain text, without
+// any markup.
+func cleanText(text string) string {
+	text = regexpEntities.ReplaceAllStringFunc(text, func(match string) string {
+		if strings.HasPrefix(match, "&") {
+			// Leave SGML entities until the tags are removed, as they may be
+			// tag delimiters themselves
+			return match
+		}
+
+		return entityText(strings.TrimSuffix(strings.TrimPrefix(match, "<"), "/>"))
+	})
+
+	text = regexpLineBreaks.ReplaceAllString(text, " ")
+	text = regexpTags.ReplaceAllString(text, "")
+
+	text = regexpEntities.ReplaceAllStringFunc(text, func(match string) string {
+		return entityText(strings.TrimSuffix(strings.TrimPrefix(match, "&"), ";"))
+	})
+
+	return strings.Join(strings.Fields(text), " ")
+}
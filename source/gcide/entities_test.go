@@ -0,0 +1,45 @@
+package gcide
+
+import "testing"
+
+func TestCleanText(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text string
+		want string
+	}{
+		"plain": {
+			text: "To give up wholly.",
+			want: "To give up wholly.",
+		},
+		"tags": {
+			text: "as, to <ex>abandon</ex> a claim",
+			want: "as, to abandon a claim",
+		},
+		"line breaks": {
+			text: "freedom<br/\n[<source>1913 Webster</source>]",
+			want: "freedom [1913 Webster]",
+		},
+		"tag entities": {
+			text: "<ae/>sthetics; na<iuml/>ve",
+			want: "æsthetics; naïve",
+		},
+		"SGML entities": {
+			text: "&AElig;s*thet\"ic &amp; caf&eacute;",
+			want: "Æs*thet\"ic & café",
+		},
+		"escaped tags": {
+			text: "&lt;not a tag&gt;",
+			want: "<not a tag>",
+		},
+		"unknown entities": {
+			text: "a<unknown/>b",
+			want: "ab",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := cleanText(testData.text); got != testData.want {
+				t.Errorf("cleanText returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
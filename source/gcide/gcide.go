@@ -0,0 +1,121 @@
+// Package gcide provides an offline dictionary source via the data of the GNU
+// Collaborative International Dictionary of English (GCIDE), once installed
+package gcide
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Rican7/define/internal/datapack"
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "GNU Collaborative International Dictionary of English"
+
+const (
+	// packName is the name of the data pack of the data
+	packName = "gcide"
+
+	// dataFilePrefix is the prefix of the names of the data files, which are
+	// suffixed by the (upper case) letter that their words start with
+	dataFilePrefix = "CIDE."
+
+	// installCommand is the command that installs the data
+	installCommand = "define data install " + packName
+)
+
+// attribution is the attribution of the results of the source
+var attribution = source.ResultAttribution{
+	Provider:   Name,
+	License:    "GPL-3.0-or-later",
+	LicenseURL: "https://www.gnu.org/licenses/gpl-3.0.html",
+	SourceURLs: []string{"https://www.gnu.org/software/gcide/"},
+}
+
+// NotInstalledError is returned when the source's data isn't installed.
+type NotInstalledError struct {
+	DirPath string
+}
+
+// gcide contains the path of the directory of the installed data
+type gcide struct {
+	dirPath string
+}
+
+// DirPath returns the path of the directory that the data is installed to.
+func DirPath() string {
+	return filepath.Join(datapack.DirPath(), packName)
+}
+
+// New returns a new GCIDE dictionary source, reading the data installed in
+// the directory at the given path
+func New(dirPath string) (source.Source, error) {
+	matches, err := filepath.Glob(filepath.Join(dirPath, dataFilePrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) < 1 {
+		return nil, &NotInstalledError{DirPath: dirPath}
+	}
+
+	return &gcide{dirPath}, nil
+}
+
+// Name returns the printable, human-readable name of the source.
+func (g *gcide) Name() string {
+	return Name
+}
+
+// Define takes a word string and returns a list of dictionary results, and
+// an error if any occurred.
+func (g *gcide) Define(word string) (source.DictionaryResults, error) {
+	first, _ := utf8.DecodeRuneInString(word)
+	if first < 'A' || (first > 'Z' && first < 'a') || first > 'z' {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	// The words are split into files by their first letter
+	fileName := dataFilePrefix + strings.ToUpper(string(first))
+
+	fileContents, err := os.ReadFile(filepath.Join(g.dirPath, fileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries := findEntries(decodeText(fileContents), word)
+	if len(entries) < 1 {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	results := source.DictionaryResults{
+		{
+			Language:    "en",
+			Word:        entries[0].Word,
+			Entries:     entries,
+			Attribution: attribution,
+		},
+	}
+
+	return source.ValidateAndReturnDictionaryResults(word, results)
+}
+
+// Error satisfies the error interface by returning a string message.
+func (e *NotInstalledError) Error() string {
+	return fmt.Sprintf("the GCIDE data isn't installed (in %q)", e.DirPath)
+}
+
+// Remedy returns a suggestion of how to fix the problem.
+func (e *NotInstalledError) Remedy() string {
+	return fmt.Sprintf("Install the data with %q.", installCommand)
+}
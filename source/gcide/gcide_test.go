@@ -0,0 +1,98 @@
+package gcide
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestNewNotInstalled(t *testing.T) {
+	_, err := New(t.TempDir())
+
+	var notInstalledErr *NotInstalledError
+	if !errors.As(err, &notInstalledErr) {
+		t.Errorf("New returned wrong error. Got %#v. Want a %T.", err, notInstalledErr)
+	}
+}
+
+func TestDefine(t *testing.T) {
+	src, err := New("testdata")
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	results, err := src.Define("abandon")
+	if err != nil {
+		t.Fatalf("Define returned an error: %s", err)
+	}
+
+	if len(results) != 1 || len(results[0].Entries) != 2 {
+		t.Fatalf("Define returned wrong results. Got %#v.", results)
+	}
+
+	if !reflect.DeepEqual(results[0].Attribution, attribution) {
+		t.Errorf("Define returned wrong attribution. Got %#v. Want %#v.", results[0].Attribution, attribution)
+	}
+
+	verb, noun := results[0].Entries[0], results[0].Entries[1]
+
+	if verb.Word != "Abandon" || verb.LexicalCategory != "transitive verb" || verb.PartOfSpeech != source.PartOfSpeechVerb {
+		t.Errorf("Define returned wrong entry. Got %#v.", verb.Entry)
+	}
+
+	if want := []string{"A", "ban", "don"}; !reflect.DeepEqual(verb.Hyphenation, want) {
+		t.Errorf("Define returned wrong hyphenation. Got %#v. Want %#v.", verb.Hyphenation, want)
+	}
+
+	if want := []string{"[OF. abandoner, F. abandonner.]"}; !reflect.DeepEqual(verb.Etymologies, want) {
+		t.Errorf("Define returned wrong etymologies. Got %#v. Want %#v.", verb.Etymologies, want)
+	}
+
+	wantSenses := []source.Sense{
+		{
+			Label:       "1",
+			Definitions: []string{"To cast or drive out; to banish; to expel; to reject."},
+			Notes:       []string{"Obs."},
+			Examples: []source.AttributedText{
+				{Text: "That he might . . . abandon them from him.", Attribution: source.Attribution{Author: "Udall"}},
+			},
+		},
+		{
+			Label:       "2",
+			Definitions: []string{"To give up wholly; to relinquish; as, to abandon a claim."},
+		},
+	}
+
+	if !reflect.DeepEqual(verb.Senses, wantSenses) {
+		t.Errorf("Define returned wrong senses. Got %#v. Want %#v.", verb.Senses, wantSenses)
+	}
+
+	if noun.LexicalCategory != "noun" || len(noun.Senses) != 1 {
+		t.Errorf("Define returned wrong entry. Got %#v.", noun)
+	}
+}
+
+func TestDefineEmptyResult(t *testing.T) {
+	src, err := New("testdata")
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	for testName, word := range map[string]string{
+		"unknown word":            "abacus",
+		"without senses":          "apple of the eye",
+		"without a data file":     "zebra",
+		"not starting w/a letter": "1st",
+	} {
+		t.Run(testName, func(t *testing.T) {
+			_, err := src.Define(word)
+
+			var emptyErr *source.EmptyResultError
+			if !errors.As(err, &emptyErr) {
+				t.Errorf("Define returned wrong error. Got %#v. Want a %T.", err, emptyErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package gcide
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Rican7/define/source"
+)
+
+const (
+	paragraphStartTag = "<p>"
+	paragraphEndTag   = "</p>"
+)
+
+// syllableMarkers are the characters that headwords are split into syllables
+// by (like "Ab*an\"don"), which also mark their stressed syllables
+const syllableMarkers = "*\"`"
+
+// lexicalCategories maps the abbreviated lexical categories of the data to
+// their full names.
+var lexicalCategories = map[string]string{
+	"n.":       "noun",
+	"n. pl.":   "plural noun",
+	"n. sing.": "singular noun",
+	"v.":       "verb",
+	"v. t.":    "transitive verb",
+	"v. i.":    "intransitive verb",
+	"a.":       "adjective",
+	"adj.":     "adjective",
+	"p. a.":    "participial adjective",
+	"adv.":     "adverb",
+	"prep.":    "preposition",
+	"conj.":    "conjunction",
+	"interj.":  "interjection",
+	"pron.":    "pronoun",
+	"prefix.":  "prefix",
+	"suffix.":  "suffix",
+}
+
+// decodeText decodes the contents of a data file as text. Older releases of
+// the data are encoded in Latin-1, rather than UTF-8.
+func decodeText(contents []byte) string {
+	if utf8.Valid(contents) {
+		return string(contents)
+	}
+
+	runes := make([]rune, len(contents))
+
+	for i, b := range contents {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
+
+// findEntries takes the text of a data file and returns the entries of the
+// given word, matching their headwords case-insensitively.
+//
+// An entry starts at a paragraph with an "<ent>" (entry) tag, and continues
+// through the following paragraphs without one, which hold its further senses.
+func findEntries(text string, word string) []source.DictionaryEntry {
+	var entries []source.DictionaryEntry
+	var matchedWord string
+	var paragraphs []string
+
+	flush := func() {
+		if len(paragraphs) < 1 {
+			return
+		}
+
+		// Skip any entries without senses, like those of only collocations
+		if entry := parseEntry(matchedWord, paragraphs); len(entry.Senses) > 0 {
+			entries = append(entries, entry)
+		}
+
+		paragraphs = nil
+	}
+
+	for _, paragraph := range splitParagraphs(text) {
+		headwords := tagContents(paragraph, "ent")
+
+		if len(headwords) > 0 {
+			flush()
+
+			matchedWord = ""
+
+			for _, headword := range headwords {
+				if headword = cleanText(headword); strings.EqualFold(headword, word) {
+					matchedWord = headword
+					break
+				}
+			}
+		}
+
+		if matchedWord != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+
+	flush()
+
+	return entries
+}
+
+// parseEntry takes a word and the paragraphs of its entry and returns the
+// entry.
+func parseEntry(word string, paragraphs []string) source.DictionaryEntry {
+	entry := source.DictionaryEntry{Entry: source.Entry{Word: word}}
+
+	for _, paragraph := range paragraphs {
+		if entry.LexicalCategory == "" {
+			if partsOfSpeech := tagContents(paragraph, "pos"); len(partsOfSpeech) > 0 {
+				entry.LexicalCategory = lexicalCategory(cleanText(partsOfSpeech[0]))
+				entry.PartOfSpeech = source.ParsePartOfSpeech(entry.LexicalCategory)
+			}
+		}
+
+		if entry.Hyphenation == nil {
+			if headwords := tagContents(paragraph, "hw"); len(headwords) > 0 {
+				entry.Hyphenation = hyphenation(word, headwords[0])
+			}
+		}
+
+		for _, etymology := range tagContents(paragraph, "ety") {
+			if etymology = cleanText(etymology); etymology != "" && !slices.Contains(entry.Etymologies, etymology) {
+				entry.Etymologies = append(entry.Etymologies, etymology)
+			}
+		}
+
+		if sense, ok := parseSense(paragraph); ok {
+			entry.Senses = append(entry.Senses, sense)
+		} else if len(entry.Senses) > 0 {
+			// Quotations are often given in their own paragraph, after the
+			// sense that they're an example of
+			lastSense := &entry.Senses[len(entry.Senses)-1]
+			lastSense.Examples = append(lastSense.Examples, parseExamples(paragraph)...)
+		}
+	}
+
+	return entry
+}
+
+// parseSense takes a paragraph of an entry and returns the sense that it
+// defines, and whether it defines one.
+func parseSense(paragraph string) (source.Sense, bool) {
+	var sense source.Sense
+
+	for _, definition := range tagContents(paragraph, "def") {
+		if definition = cleanText(definition); definition != "" {
+			sense.Definitions = append(sense.Definitions, definition)
+		}
+	}
+
+	if len(sense.Definitions) < 1 {
+		return sense, false
+	}
+
+	if labels := tagContents(paragraph, "sn"); len(labels) > 0 {
+		sense.Label = strings.TrimSuffix(cleanText(labels[0]), ".")
+	}
+
+	// Fields (like "(Bot.)") are the domains that the sense is used in
+	for _, field := range tagContents(paragraph, "fld") {
+		if field = strings.Trim(cleanText(field), "()"); field != "" {
+			sense.Categories = append(sense.Categories, field)
+		}
+	}
+
+	// Marks (like "[Obs.]") are notes on the sense's usage
+	for _, mark := range tagContents(paragraph, "mark") {
+		if mark = strings.Trim(cleanText(mark), "[]"); mark != "" {
+			sense.Notes = append(sense.Notes, mark)
+		}
+	}
+
+	sense.Examples = parseExamples(paragraph)
+
+	return sense, true
+}
+
+// parseExamples takes a paragraph of an entry and returns the quotations in
+// it, attributed to their authors.
+func parseExamples(paragraph string) []source.AttributedText {
+	var examples []source.AttributedText
+
+	authors := tagContents(paragraph, "qau")
+
+	for i, quote := range tagContents(paragraph, "q") {
+		example := source.AttributedText{Text: cleanText(quote)}
+
+		if i < len(authors) {
+			example.Author = strings.TrimSuffix(cleanText(authors[i]), ".")
+		}
+
+		if example.Text != "" {
+			examples = append(examples, example)
+		}
+	}
+
+	return examples
+}
+
+// lexicalCategory returns the full name of an abbreviated lexical category
+// (like "v. t."), or the category as-is if it's not known.
+func lexicalCategory(abbreviation string) string {
+	if category, ok := lexicalCategories[abbreviation]; ok {
+		return category
+	}
+
+	return abbreviation
+}
+
+// hyphenation returns the parts of a word split at its syllables, as marked
+// in its headword (like "Ab*an\"don"), or nil if the headword isn't marked.
+func hyphenation(word string, headword string) []string {
+	parts := strings.FieldsFunc(cleanText(headword), func(r rune) bool {
+		return strings.ContainsRune(syllableMarkers, r)
+	})
+
+	if len(parts) < 2 || strings.Join(parts, "") != word {
+		return nil
+	}
+
+	return parts
+}
+
+// splitParagraphs splits a text into the contents of its paragraphs.
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+
+	for {
+		start := strings.Index(text, paragraphStartTag)
+		if start < 0 {
+			break
+		}
+
+		text = text[start+len(paragraphStartTag):]
+
+		end := strings.Index(text, paragraphEndTag)
+		if end < 0 {
+			paragraphs = append(paragraphs, text)
+			break
+		}
+
+		paragraphs = append(paragraphs, text[:end])
+		text = text[end+len(paragraphEndTag):]
+	}
+
+	return paragraphs
+}
+
+// tagContents returns the contents of each of the elements of the given tag
+// name in a text, in order.
+func tagContents(text string, name string) []string {
+	var contents []string
+
+	startTag, endTag := "<"+name+">", "</"+name+">"
+
+	for {
+		start := strings.Index(text, startTag)
+		if start < 0 {
+			break
+		}
+
+		text = text[start+len(startTag):]
+
+		end := strings.Index(text, endTag)
+		if end < 0 {
+			break
+		}
+
+		contents = append(contents, text[:end])
+		text = text[end+len(endTag):]
+	}
+
+	return contents
+}
@@ -0,0 +1,40 @@
+package gcide
+
+import (
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+type config struct{}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "GCIDE"
+
+// Ensure our types satisfy the registry's provider contract at compile-time
+var (
+	_ registry.SourceProvider = (*provider)(nil)
+	_ registry.Configuration  = (*config)(nil)
+)
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(*flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, &config{}
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	return New(DirPath())
+}
@@ -0,0 +1,98 @@
+// Package mock provides a dictionary source that serves canned results from a
+// JSON file, so that the app can be exercised without network access or keys
+package mock
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "Mock Dictionary"
+
+// Data defines the structure of the canned results of the source, keyed by
+// the word that they're the results for
+type Data map[string]source.DictionaryResults
+
+// mock contains the canned results of the source
+type mock struct {
+	data Data
+}
+
+// New returns a new mock dictionary source, serving the given results
+func New(data Data) source.Source {
+	normalized := make(Data, len(data))
+
+	for word, results := range data {
+		normalized[normalizeWord(word)] = results
+	}
+
+	return &mock{normalized}
+}
+
+// NewFromFile returns a new mock dictionary source, serving the results
+// decoded from the JSON file at the given path
+func NewFromFile(filePath string) (source.Source, error) {
+	fileContents, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var data Data
+
+	if err = json.Unmarshal(fileContents, &data); err != nil {
+		return nil, err
+	}
+
+	// Normalize the parts of speech of any entries that don't have them, as
+	// the other sources do
+	for _, results := range data {
+		results.NormalizePartsOfSpeech()
+	}
+
+	return New(data), nil
+}
+
+// Name returns the printable, human-readable name of the source.
+func (m *mock) Name() string {
+	return Name
+}
+
+// Define takes a word string and returns a list of dictionary results, and
+// an error if any occurred.
+func (m *mock) Define(word string) (source.DictionaryResults, error) {
+	return source.ValidateAndReturnDictionaryResults(word, m.data[normalizeWord(word)])
+}
+
+// Search takes a word string and returns a list of found words, and an
+// error if any occurred.
+func (m *mock) Search(word string, limit uint) (source.SearchResults, error) {
+	var results source.SearchResults
+
+	word = normalizeWord(word)
+
+	for candidate := range m.data {
+		if candidate != word && (strings.Contains(candidate, word) || strings.Contains(word, candidate)) {
+			results = append(results, source.SearchResult(candidate))
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i] < results[j]
+	})
+
+	if limit > 0 && limit < uint(len(results)) {
+		results = results[:limit]
+	}
+
+	return source.ValidateAndReturnSearchResults(word, results)
+}
+
+// normalizeWord normalizes a word for case-insensitive lookups
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
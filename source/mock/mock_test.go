@@ -0,0 +1,87 @@
+package mock
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestNewFromFile(t *testing.T) {
+	src, err := NewFromFile("testdata/results.json")
+	if err != nil {
+		t.Fatalf("NewFromFile returned an error: %s", err)
+	}
+
+	results, err := src.Define("Test")
+	if err != nil {
+		t.Fatalf("Define returned an error: %s", err)
+	}
+
+	if len(results) != 1 || len(results[0].Entries) != 2 {
+		t.Fatalf("Define returned wrong results. Got %#v.", results)
+	}
+
+	want := source.Sense{
+		Definitions: []string{"A procedure intended to establish the quality, performance, or reliability of something."},
+		Examples:    []source.AttributedText{{Text: "both countries carried out nuclear tests"}},
+	}
+
+	if got := results[0].Entries[0].Senses[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Define returned wrong sense. Got %#v. Want %#v.", got, want)
+	}
+}
+
+func TestMock_Define(t *testing.T) {
+	src := New(Data{"Test": source.DictionaryResults{{Word: "test"}}})
+
+	for testName, testData := range map[string]struct {
+		word    string
+		want    source.DictionaryResults
+		wantErr bool
+	}{
+		"exact": {
+			word: "test",
+			want: source.DictionaryResults{{Word: "test"}},
+		},
+		"different case and spacing": {
+			word: " TEST ",
+			want: source.DictionaryResults{{Word: "test"}},
+		},
+		"missing": {
+			word:    "nope",
+			wantErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := src.Define(testData.word)
+
+			if _, isEmptyResult := err.(*source.EmptyResultError); isEmptyResult != testData.wantErr {
+				t.Errorf("Define returned an unexpected error. Got %#v.", err)
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Define returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestMock_Search(t *testing.T) {
+	src := New(Data{"test": nil, "testing": nil, "tested": nil, "other": nil}).(source.Searcher)
+
+	got, err := src.Search("test", 5)
+	if err != nil {
+		t.Fatalf("Search returned an error: %s", err)
+	}
+
+	want := source.SearchResults{"tested", "testing"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Search returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+
+	if got, _ := src.Search("test", 1); len(got) != 1 {
+		t.Errorf("Search returned wrong number of results. Got %d. Want %d.", len(got), 1)
+	}
+}
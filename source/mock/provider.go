@@ -0,0 +1,100 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+// RequiredConfigError represents an error when a required configuration key is
+// missing or invalid.
+type RequiredConfigError = registry.RequiredConfigError
+
+type config struct {
+	FilePath string
+}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "MockDictionary"
+
+// The names of the flag and environment variable that set the file path
+const (
+	filePathFlag    = "mock-dictionary-file"
+	filePathEnvName = "MOCK_DICTIONARY_FILE"
+)
+
+// Ensure our types satisfy the registry's provider contract at compile-time
+var (
+	_ registry.AliasedProvider      = (*provider)(nil)
+	_ registry.DynamicConfiguration = (*config)(nil)
+)
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, initConfig(flags)
+}
+
+func initConfig(flags *flag.FlagSet) *config {
+	conf := &config{}
+
+	// Define our flags
+	registry.DefineStringFlags(
+		flags,
+		registry.StringFlag{Name: filePathFlag, Usage: fmt.Sprintf("The path of the JSON file of results for the %s", Name), Value: &conf.FilePath},
+	)
+
+	return conf
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+// UnmarshalJSON defines how the configuration should be JSON unmarshalled.
+func (c *config) UnmarshalJSON(data []byte) error {
+	// Alias our type so that we can unmarshal as usual
+	type alias config
+	copy := &alias{}
+
+	// Unmarshal into our copy
+	err := json.Unmarshal(data, copy)
+	if err != nil {
+		return err
+	}
+
+	registry.FillEmpty(&c.FilePath, copy.FilePath)
+
+	return nil
+}
+
+// LoadEnv fills in any values that weren't passed as flags from environment
+// variables.
+func (c *config) LoadEnv() {
+	registry.FillEmptyFromEnv(&c.FilePath, filePathEnvName)
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Aliases() []string {
+	return []string{"mock"}
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	config := conf.(*config)
+
+	if config.FilePath == "" {
+		return nil, &RequiredConfigError{Key: "FilePath", Flag: filePathFlag, EnvName: filePathEnvName, JSONKey: JSONKey}
+	}
+
+	return NewFromFile(config.FilePath)
+}
@@ -0,0 +1,35 @@
+package ngram
+
+import (
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// apiResponse defines the structure of an Ngram Viewer response, which is a
+// list of the matching ngrams
+type apiResponse []apiNgram
+
+// apiNgram defines the structure of an Ngram Viewer ngram
+type apiNgram struct {
+	Ngram      string    `json:"ngram"`
+	Timeseries []float64 `json:"timeseries"`
+}
+
+// toTrend converts the API response to the usage trend of the given word, or
+// nil if the response doesn't have the word's frequencies.
+func (r apiResponse) toTrend(word string) *source.UsageTrend {
+	for _, ngram := range r {
+		if !strings.EqualFold(ngram.Ngram, word) || len(ngram.Timeseries) < 1 {
+			continue
+		}
+
+		return &source.UsageTrend{
+			StartYear:   startYear,
+			EndYear:     startYear + len(ngram.Timeseries) - 1,
+			Frequencies: ngram.Timeseries,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package ngram
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestAPIResponse_ToTrend(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		json string
+		want *source.UsageTrend
+	}{
+		"empty": {
+			json: `[]`,
+			want: nil,
+		},
+		"no frequencies": {
+			json: `[{"ngram":"test","timeseries":[]}]`,
+			want: nil,
+		},
+		"frequencies": {
+			json: `[{"ngram":"other","timeseries":[1]},{"ngram":"Test","parent":"","type":"NGRAM","timeseries":[1e-06,2e-06,3e-06]}]`,
+			want: &source.UsageTrend{StartYear: 1800, EndYear: 1802, Frequencies: []float64{1e-06, 2e-06, 3e-06}},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var response apiResponse
+
+			if err := json.Unmarshal([]byte(testData.json), &response); err != nil {
+				t.Fatalf("json.Unmarshal returned an error: %s", err)
+			}
+
+			if got := response.toTrend("test"); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("apiResponse.toTrend returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Package ngram provides the usage trends of words via the Google Books Ngram
+// Viewer's data
+package ngram
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "Google Books Ngram Viewer"
+
+const (
+	// baseURLString is the base URL for all Ngram Viewer interactions
+	baseURLString = "https://books.google.com/ngrams/"
+
+	jsonURLString = baseURLString + "json"
+
+	httpRequestAcceptHeaderName = "Accept"
+
+	httpRequestContentParamName   = "content"
+	httpRequestCorpusParamName    = "corpus"
+	httpRequestYearStartParamName = "year_start"
+	httpRequestYearEndParamName   = "year_end"
+	httpRequestSmoothingParamName = "smoothing"
+
+	jsonMIMEType = "application/json"
+
+	// The range of years of the corpora, and the number of years that the
+	// frequencies are averaged over (on each side), to smooth out noise
+	startYear = 1800
+	endYear   = 2019
+	smoothing = 3
+)
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{jsonMIMEType}
+
+// corpora maps the ISO 639-1 codes of the languages that are supported to the
+// names of their corpora
+var corpora = map[string]string{
+	"de": "de-2019",
+	"en": "en-2019",
+	"es": "es-2019",
+	"fr": "fr-2019",
+	"he": "iw-2019",
+	"it": "it-2019",
+	"ru": "ru-2019",
+	"zh": "zh-2019",
+}
+
+// API contains a configured HTTP client for Ngram Viewer operations
+type API struct {
+	httpClient *http.Client
+}
+
+// New returns a new Ngram Viewer client
+func New(httpClient http.Client) *API {
+	return &API{&httpClient}
+}
+
+// Name returns the printable, human-readable name of the source.
+func (a *API) Name() string {
+	return Name
+}
+
+// Trend takes a word and the (ISO 639-1) code of its language, and returns the
+// frequency of use of the word over time, and an error if any occurred.
+func (a *API) Trend(word string, language string) (*source.UsageTrend, error) {
+	corpus, isSupported := corpora[strings.ToLower(language)]
+	if !isSupported {
+		return nil, fmt.Errorf("the language %q isn't supported", language)
+	}
+
+	requestURL, err := url.Parse(jsonURLString)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURL.RawQuery = url.Values{
+		httpRequestContentParamName:   {word},
+		httpRequestCorpusParamName:    {corpus},
+		httpRequestYearStartParamName: {strconv.Itoa(startYear)},
+		httpRequestYearEndParamName:   {strconv.Itoa(endYear)},
+		httpRequestSmoothingParamName: {strconv.Itoa(smoothing)},
+	}.Encode()
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
+
+	httpResponse, err := a.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); err != nil {
+		return nil, err
+	}
+
+	var response apiResponse
+
+	if err = source.DecodeJSONResponse(httpResponse, &response); err != nil {
+		return nil, err
+	}
+
+	trend := response.toTrend(word)
+	if trend == nil {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	return trend, nil
+}
@@ -1,6 +1,7 @@
 package source
 
 import (
+	"fmt"
 	"strings"
 	"unicode"
 
@@ -9,6 +10,74 @@ import (
 	"golang.org/x/text/unicode/norm"
 )
 
+// List of word normalizations.
+const (
+	// NormalizeTrim trims any surrounding whitespace, and collapses any inner
+	// whitespace to single spaces
+	NormalizeTrim WordNormalization = "trim"
+
+	// NormalizeLowercase converts the word to lowercase
+	NormalizeLowercase WordNormalization = "lowercase"
+
+	// NormalizePunctuation strips any leading or trailing punctuation, while
+	// keeping any inner punctuation (like in "passers-by" or "don't")
+	NormalizePunctuation WordNormalization = "punctuation"
+
+	// NormalizeDiacritics removes any diacritics
+	NormalizeDiacritics WordNormalization = "diacritics"
+
+	// normalizeNone is a special value to specify no normalizations
+	normalizeNone = "none"
+
+	// wordNormalizationsSeparator is the separator of a list of normalizations
+	wordNormalizationsSeparator = ","
+)
+
+// WordNormalization defines a normalization to apply to a word before it's
+// looked up.
+type WordNormalization string
+
+// ParseWordNormalizations takes a comma separated list of normalizations and
+// returns the matching WordNormalizations, or an error if any don't match. The
+// special value "none" returns no normalizations.
+func ParseWordNormalizations(list string) ([]WordNormalization, error) {
+	var normalizations []WordNormalization
+
+	for _, name := range strings.Split(list, wordNormalizationsSeparator) {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		switch parsed := WordNormalization(name); parsed {
+		case "", normalizeNone:
+			continue
+		case NormalizeTrim, NormalizeLowercase, NormalizePunctuation, NormalizeDiacritics:
+			normalizations = append(normalizations, parsed)
+		default:
+			return nil, fmt.Errorf("unknown word normalization %q", name)
+		}
+	}
+
+	return normalizations, nil
+}
+
+// NormalizeWord takes a word and applies the given normalizations to it, in
+// order, returning the normalized word.
+func NormalizeWord(word string, normalizations ...WordNormalization) string {
+	for _, normalization := range normalizations {
+		switch normalization {
+		case NormalizeTrim:
+			word = strings.Join(strings.Fields(word), " ")
+		case NormalizeLowercase:
+			word = strings.ToLower(word)
+		case NormalizePunctuation:
+			word = strings.TrimFunc(word, unicode.IsPunct)
+		case NormalizeDiacritics:
+			word = RemoveDiacritics(word)
+		}
+	}
+
+	return word
+}
+
 // RemoveDiacritics takes a text and returns the same text with any diacritics
 // removed. If there's an issue with cleaning the string, the original text is
 // returned unchanged.
@@ -1,6 +1,9 @@
 package source
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestRemoveDiacritics(t *testing.T) {
 	for testName, testData := range map[string]struct {
@@ -76,3 +79,95 @@ func TestEqualFoldPlain(t *testing.T) {
 		})
 	}
 }
+
+func TestParseWordNormalizations(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		list    string
+		want    []WordNormalization
+		wantErr bool
+	}{
+		"empty": {
+			list: "",
+			want: nil,
+		},
+		"none": {
+			list: "none",
+			want: nil,
+		},
+		"single": {
+			list: "trim",
+			want: []WordNormalization{NormalizeTrim},
+		},
+		"multiple with spacing and case": {
+			list: "trim, Punctuation ,DIACRITICS",
+			want: []WordNormalization{NormalizeTrim, NormalizePunctuation, NormalizeDiacritics},
+		},
+		"unknown": {
+			list:    "trim,stem",
+			wantErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := ParseWordNormalizations(testData.list)
+
+			if (err != nil) != testData.wantErr {
+				t.Errorf("ParseWordNormalizations returned an unexpected error. Got %#v.", err)
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("ParseWordNormalizations returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeWord(t *testing.T) {
+	all := []WordNormalization{NormalizeTrim, NormalizeLowercase, NormalizePunctuation, NormalizeDiacritics}
+
+	for testName, testData := range map[string]struct {
+		word           string
+		normalizations []WordNormalization
+		want           string
+	}{
+		"no normalizations": {
+			word: " Café! ",
+			want: " Café! ",
+		},
+		"trim": {
+			word:           "  give \t up ",
+			normalizations: []WordNormalization{NormalizeTrim},
+			want:           "give up",
+		},
+		"lowercase": {
+			word:           "Tree",
+			normalizations: []WordNormalization{NormalizeLowercase},
+			want:           "tree",
+		},
+		"surrounding punctuation": {
+			word:           "\"tree,\"",
+			normalizations: []WordNormalization{NormalizePunctuation},
+			want:           "tree",
+		},
+		"inner punctuation": {
+			word:           "passers-by.",
+			normalizations: []WordNormalization{NormalizePunctuation},
+			want:           "passers-by",
+		},
+		"diacritics": {
+			word:           "résumé",
+			normalizations: []WordNormalization{NormalizeDiacritics},
+			want:           "resume",
+		},
+		"all": {
+			word:           "  (Café au Lait!) ",
+			normalizations: all,
+			want:           "cafe au lait",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := NormalizeWord(testData.word, testData.normalizations...); got != testData.want {
+				t.Errorf("NormalizeWord returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,11 @@ type apiDefinitionResponse struct {
 	Results []apiDefinitionResult `json:"results"`
 }
 
+// apiErrorResponse defines the structure of an Oxford API error response
+type apiErrorResponse struct {
+	Error string `json:"error"`
+}
+
 // apiSearchResponse defines the structure of an Oxford API search response
 type apiSearchResponse struct {
 	Metadata struct {
@@ -210,9 +215,14 @@ func (r *apiDefinitionResponse) toResults() source.DictionaryResults {
 		sourceResults = append(
 			sourceResults,
 			source.DictionaryResult{
+				ID:       result.ID,
 				Language: result.Language,
 				Word:     word,
 				Entries:  sourceEntries,
+
+				Attribution: source.ResultAttribution{
+					Provider: r.Metadata.Provider,
+				},
 			},
 		)
 	}
@@ -247,40 +257,65 @@ func (r *apiSearchResponse) toResults() source.SearchResults {
 func (e *apiLexicalEntry) toEntry() source.DictionaryEntry {
 	sourceEntry := source.DictionaryEntry{}
 
+	// Only IPA pronunciations are kept
+	sourceEntry.PronunciationNotation = source.PronunciationNotationIPA
+
 	for _, pronunciation := range e.Pronunciations {
 		if strings.EqualFold(phoneticNotationIPAIdentifier, pronunciation.PhoneticNotation) {
-			sourceEntry.Pronunciations = append(sourceEntry.Pronunciations, source.Pronunciation(pronunciation.PhoneticSpelling))
+			sourceEntry.Pronunciations = append(sourceEntry.Pronunciations, pronunciation.toPronunciation())
 		}
 	}
 
 	sourceEntry.Word = e.Text
 	sourceEntry.LexicalCategory = e.LexicalCategory.Text
+	sourceEntry.PartOfSpeech = source.ParsePartOfSpeech(e.LexicalCategory.Text)
+
+	for _, note := range e.Notes {
+		sourceEntry.EntryNotes = append(sourceEntry.EntryNotes, note.Text)
+	}
 
 	for _, subEntry := range e.Entries {
 		sourceEntry.Etymologies = append(sourceEntry.Etymologies, subEntry.Etymologies...)
 
+		for _, note := range subEntry.Notes {
+			sourceEntry.EntryNotes = append(sourceEntry.EntryNotes, note.Text)
+		}
+
 		for _, pronunciation := range subEntry.Pronunciations {
 			if strings.EqualFold(phoneticNotationIPAIdentifier, pronunciation.PhoneticNotation) {
-				sourceEntry.Pronunciations = append(sourceEntry.Pronunciations, source.Pronunciation(pronunciation.PhoneticSpelling))
+				sourceEntry.Pronunciations = append(sourceEntry.Pronunciations, pronunciation.toPronunciation())
 			}
 		}
 
-		for _, sense := range subEntry.Senses {
-			sourceSense := sense.toSense()
-
-			// Only go one level deep of sub-senses
-			for _, subSense := range sense.Subsenses {
-				sourceSense.SubSenses = append(sourceSense.SubSenses, subSense.toSense())
-			}
+		for _, inflection := range subEntry.Inflections {
+			sourceEntry.Inflections = append(sourceEntry.Inflections, inflection.toInflectedForm())
+		}
 
-			sourceEntry.Senses = append(sourceEntry.Senses, sourceSense)
+		for _, sense := range subEntry.Senses {
+			sourceEntry.Senses = append(sourceEntry.Senses, sense.toSense())
 		}
 	}
 
 	return sourceEntry
 }
 
-// toSense converts the API sense to a source.Sense
+// toInflectedForm converts the API inflection to a source.InflectedForm, with
+// its grammatical features as its label (like "plural" or "past participle")
+func (i *apiInflection) toInflectedForm() source.InflectedForm {
+	features := make([]string, 0, len(i.GrammaticalFeatures))
+
+	for _, feature := range i.GrammaticalFeatures {
+		features = append(features, strings.ToLower(feature.Text))
+	}
+
+	return source.InflectedForm{
+		Form:  i.InflectedForm,
+		Label: strings.Join(features, " "),
+	}
+}
+
+// toSense converts the API sense (and its sub-senses, recursively) to a
+// source.Sense
 func (s *apiSense) toSense() source.Sense {
 	definitions := s.Definitions
 
@@ -312,11 +347,29 @@ func (s *apiSense) toSense() source.Sense {
 		notes = append(notes, note.Text)
 	}
 
+	var subSenses []source.Sense
+
+	for _, subSense := range s.Subsenses {
+		subSenses = append(subSenses, subSense.toSense())
+	}
+
 	return source.Sense{
+		ID:          s.ID,
 		Definitions: definitions,
 		Categories:  categories,
 		Examples:    examples,
 		Notes:       notes,
+		SubSenses:   subSenses,
+	}
+}
+
+// toPronunciation converts the API pronunciation to a source.Pronunciation
+func (p *apiPronunciation) toPronunciation() source.Pronunciation {
+	return source.Pronunciation{
+		Spelling: p.PhoneticSpelling,
+		Notation: source.PronunciationNotationIPA,
+		Dialect:  strings.Join(p.Dialects, ", "),
+		AudioURL: p.AudioFile,
 	}
 }
 
@@ -5,9 +5,12 @@ package oxford
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -17,12 +20,19 @@ import (
 // Name defines the name of the source
 const Name = "Oxford Dictionaries API"
 
+// The base URLs of the Oxford API's endpoints
 const (
-	// baseURLString is the base URL for all Oxford API interactions
-	baseURLString = "https://od-api.oxforddictionaries.com/api/v2/"
+	// ProductionBaseURL is the base URL of the production API
+	ProductionBaseURL = "https://od-api.oxforddictionaries.com/api/v2/"
 
-	entriesURLString = baseURLString + "entries/"
-	searchURLString  = baseURLString + "search/"
+	// SandboxBaseURL is the base URL of the sandbox API, which is what keys of
+	// the free plan are provisioned for
+	SandboxBaseURL = "https://od-api-sandbox.oxforddictionaries.com/api/v2/"
+)
+
+const (
+	entriesPath = "entries"
+	searchPath  = "search"
 
 	httpRequestAcceptHeaderName           = "Accept"
 	httpRequestAppIDHeaderName            = "app_id"
@@ -32,37 +42,61 @@ const (
 
 	jsonMIMEType = "application/json"
 
-	fallbackSearchResultLimit = 10
-
 	phoneticNotationIPAIdentifier = "IPA"
+
+	// wordIDSpaceReplacement is what spaces are replaced with in the word IDs
+	// of multi-word entries (like "give_up")
+	wordIDSpaceReplacement = "_"
+
+	// maxErrorResponseSize is the maximum size, in bytes, of an error
+	// response body that's read for its message
+	maxErrorResponseSize = 4 << 10 // 4 KiB
 )
 
-// apiURL is the URL instance used for Oxford API calls
-var apiURL *url.URL
+// authenticationMessageTerms are the terms that the messages of error
+// responses about authentication contain (as opposed to entitlement), in lower
+// case
+var authenticationMessageTerms = []string{"authentication", "credentials", "app_id", "app_key"}
 
 // validMIMETypes is the list of valid response MIME types
 var validMIMETypes = []string{jsonMIMEType}
 
+// FallbackOptions defines the behavior of the automatic fallback to searching,
+// when a word can't be defined directly.
+type FallbackOptions struct {
+	// SearchResultLimit is the number of search results to consider
+	SearchResultLimit uint
+
+	// MatchTypes is the list of search result match types (like "inflection"
+	// or "headword") to consider as words to define instead, in order of
+	// preference. An empty list disables the fallback.
+	MatchTypes []string
+
+	// MaxDepth is the maximum number of consecutive fallbacks to follow. A
+	// depth of 0 disables the fallback.
+	MaxDepth uint
+}
+
+// DefaultFallbackOptions are the default options for the automatic fallback.
+var DefaultFallbackOptions = FallbackOptions{
+	SearchResultLimit: 10,
+	MatchTypes:        []string{apiSearchResultMatchTypeInflection},
+	MaxDepth:          1,
+}
+
 // api is a struct containing a configured HTTP client for Oxford API operations
 type api struct {
 	httpClient *http.Client
+	baseURL    string
 	appID      string
 	appKey     string
+	fallback   FallbackOptions
 }
 
-// Initialize the package
-func init() {
-	var err error
-
-	apiURL, err = url.Parse(baseURLString)
-	if err != nil {
-		panic(err)
-	}
-}
-
-// New returns a new Oxford API dictionary source
-func New(httpClient http.Client, appID, appKey string) source.Source {
-	return &api{&httpClient, appID, appKey}
+// New returns a new Oxford API dictionary source, that makes requests to the
+// API at the given base URL (like ProductionBaseURL or SandboxBaseURL)
+func New(httpClient http.Client, baseURL string, appID, appKey string, fallback FallbackOptions) source.Source {
+	return &api{&httpClient, baseURL, appID, appKey, fallback}
 }
 
 // Name returns the printable, human-readable name of the source.
@@ -73,13 +107,19 @@ func (a *api) Name() string {
 // Define takes a word string and returns a list of dictionary results, and
 // an error if any occurred.
 func (a *api) Define(word string) (source.DictionaryResults, error) {
+	return a.define(word, 0)
+}
+
+// define takes a word string and the current fallback depth, and returns a
+// list of dictionary results, and an error if any occurred.
+func (a *api) define(word string, depth uint) (source.DictionaryResults, error) {
 	// Prepare our URL
-	requestURL, err := url.Parse(entriesURLString + "en-us/" + word)
+	requestURL, err := source.NewPathURL(a.baseURL, entriesPath, "en-us", toWordID(word))
 	if err != nil {
 		return nil, err
 	}
 
-	httpRequest, err := http.NewRequest(http.MethodGet, apiURL.ResolveReference(requestURL).String(), nil)
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -93,11 +133,11 @@ func (a *api) Define(word string) (source.DictionaryResults, error) {
 
 	defer httpResponse.Body.Close()
 
-	if err = validateResponse(word, httpResponse); err != nil {
+	if err = a.validateResponse(word, httpResponse); err != nil {
 		if _, isEmptyResult := err.(*source.EmptyResultError); isEmptyResult {
 			// Empty (404) result
 			// Try and automatically fallback
-			return a.apiSearchFallback(word)
+			return a.apiSearchFallback(word, depth)
 		}
 
 		return nil, err
@@ -105,14 +145,14 @@ func (a *api) Define(word string) (source.DictionaryResults, error) {
 
 	var response apiDefinitionResponse
 
-	if err = decodeResponseData(httpResponse.Body, &response); err != nil {
+	if err = decodeResponse(word, httpResponse, &response); err != nil {
 		return nil, err
 	}
 
 	if len(response.Results) < 1 {
 		// Valid (200), but empty result
 		// Try and automatically fallback
-		return a.apiSearchFallback(word)
+		return a.apiSearchFallback(word, depth)
 	}
 
 	return source.ValidateAndReturnDictionaryResults(word, response.toResults())
@@ -137,9 +177,12 @@ func (a *api) Search(word string, limit uint) (source.SearchResults, error) {
 
 func (a *api) apiSearch(word string, limit uint) (*apiSearchResponse, error) {
 	// Prepare our URL
-	requestURL, err := url.Parse(searchURLString + "en-us")
+	requestURL, err := source.NewPathURL(a.baseURL, searchPath, "en-us")
+	if err != nil {
+		return nil, err
+	}
 
-	queryParams := apiURL.Query()
+	queryParams := url.Values{}
 	queryParams.Set(httpRequestSearchStringQueryParamName, word)
 
 	if limit > 0 {
@@ -148,11 +191,7 @@ func (a *api) apiSearch(word string, limit uint) (*apiSearchResponse, error) {
 
 	requestURL.RawQuery = queryParams.Encode()
 
-	if err != nil {
-		return nil, err
-	}
-
-	httpRequest, err := http.NewRequest(http.MethodGet, apiURL.ResolveReference(requestURL).String(), nil)
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -166,13 +205,13 @@ func (a *api) apiSearch(word string, limit uint) (*apiSearchResponse, error) {
 
 	defer httpResponse.Body.Close()
 
-	if err = validateResponse(word, httpResponse); err != nil {
+	if err = a.validateResponse(word, httpResponse); err != nil {
 		return nil, err
 	}
 
 	var response apiSearchResponse
 
-	if err = decodeResponseData(httpResponse.Body, &response); err != nil {
+	if err = decodeResponse(word, httpResponse, &response); err != nil {
 		return nil, err
 	}
 
@@ -183,32 +222,53 @@ func (a *api) apiSearch(word string, limit uint) (*apiSearchResponse, error) {
 	return &response, nil
 }
 
-func (a *api) apiSearchFallback(word string) (source.DictionaryResults, error) {
-	response, err := a.apiSearch(word, fallbackSearchResultLimit)
-	if err != nil {
-		return nil, err
+func (a *api) apiSearchFallback(word string, depth uint) (source.DictionaryResults, error) {
+	if depth >= a.fallback.MaxDepth || len(a.fallback.MatchTypes) < 1 {
+		return nil, &source.EmptyResultError{Word: word}
 	}
 
-	if len(response.Results) < 1 {
-		return nil, &source.EmptyResultError{Word: word}
+	response, err := a.apiSearch(word, a.fallback.SearchResultLimit)
+	if err != nil {
+		return nil, err
 	}
 
-	var fallbackWord string
+	// Try each of our fallback words, in order, until one can be defined
+	for _, fallbackWord := range a.fallbackWords(word, response.Results) {
+		results, err := a.define(fallbackWord, depth+1)
 
-	for _, apiSearchResult := range response.Results {
-		// Look for our first inflection result
-		if apiSearchResult.MatchType == apiSearchResultMatchTypeInflection {
-			fallbackWord = apiSearchResult.Label
-			break
+		if _, isEmptyResult := err.(*source.EmptyResultError); !isEmptyResult {
+			return results, err
 		}
 	}
 
-	if fallbackWord == "" || strings.EqualFold(word, fallbackWord) {
-		// Prevent searching for empty or the same word
-		return nil, &source.EmptyResultError{Word: word}
+	return nil, &source.EmptyResultError{Word: word}
+}
+
+// fallbackWords returns the unique words of the search results that match the
+// fallback match types, ordered by the match types' preference.
+func (a *api) fallbackWords(word string, results []apiSearchResult) []string {
+	var words []string
+
+	for _, matchType := range a.fallback.MatchTypes {
+		for _, apiSearchResult := range results {
+			fallbackWord := apiSearchResult.Label
+
+			if !strings.EqualFold(apiSearchResult.MatchType, matchType) || fallbackWord == "" {
+				continue
+			}
+
+			isDuplicate := slices.ContainsFunc(words, func(existing string) bool {
+				return strings.EqualFold(existing, fallbackWord)
+			})
+
+			// Prevent searching for the same word
+			if !isDuplicate && !strings.EqualFold(word, fallbackWord) {
+				words = append(words, fallbackWord)
+			}
+		}
 	}
 
-	return a.Define(fallbackWord)
+	return words
 }
 
 func (a *api) signRequest(request *http.Request) {
@@ -217,12 +277,17 @@ func (a *api) signRequest(request *http.Request) {
 	request.Header.Set(httpRequestAppKeyHeaderName, a.appKey)
 }
 
-func validateResponse(word string, response *http.Response) error {
+// toWordID converts a word or phrase to the word ID format used by the API.
+func toWordID(word string) string {
+	return strings.Join(strings.Fields(word), wordIDSpaceReplacement)
+}
+
+func (a *api) validateResponse(word string, response *http.Response) error {
 	switch response.StatusCode {
 	case http.StatusNotFound:
 		return &source.EmptyResultError{Word: word}
-	case http.StatusForbidden:
-		return &source.AuthenticationError{}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return a.accessError(response)
 	}
 
 	if err := source.ValidateHTTPResponse(response, validMIMETypes, nil); err != nil {
@@ -232,15 +297,63 @@ func validateResponse(word string, response *http.Response) error {
 	return nil
 }
 
-func decodeResponseData(data io.Reader, into any) error {
-	body, err := io.ReadAll(data)
+// accessError returns the error of a response that refused access, which is
+// either an authentication error (like for invalid credentials) or, if the
+// API explains otherwise, an entitlement error (like for an endpoint that the
+// plan doesn't include).
+func (a *api) accessError(response *http.Response) error {
+	message := readErrorMessage(response)
+	lowerMessage := strings.ToLower(message)
+
+	isAuthenticationError := response.StatusCode == http.StatusUnauthorized || message == "" ||
+		slices.ContainsFunc(authenticationMessageTerms, func(term string) bool {
+			return strings.Contains(lowerMessage, term)
+		})
+
+	// Keys of the free plan are only provisioned for the sandbox, and are
+	// refused by production in either way
+	var sandboxHint string
+	if a.baseURL == ProductionBaseURL {
+		sandboxHint = fmt.Sprintf(" Keys of the free plan only work with --%s=%s.", endpointFlag, endpointSandbox)
+	}
+
+	if isAuthenticationError {
+		return &source.AuthenticationError{
+			Message: message,
+			Hint:    fmt.Sprintf("Check the app ID and key (--%s and --%s).%s", appIDFlag, appKeyFlag, sandboxHint),
+		}
+	}
+
+	return &source.EntitlementError{
+		Message: message,
+		Hint:    "The plan of the app key may need upgrading." + sandboxHint,
+	}
+}
+
+// readErrorMessage reads the message of an error response, if it has one.
+func readErrorMessage(response *http.Response) string {
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxErrorResponseSize))
 	if err != nil {
-		return err
+		return ""
 	}
 
-	if err = json.Unmarshal(body, into); err != nil {
-		return err
+	var errorResponse apiErrorResponse
+
+	if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Error != "" {
+		return strings.TrimSpace(errorResponse.Error)
 	}
 
-	return nil
+	return strings.TrimSpace(string(body))
+}
+
+// decodeResponse decodes the JSON body of a successful response, returning an
+// EmptyResponseError if the body is empty.
+func decodeResponse(word string, response *http.Response, into any) error {
+	err := source.DecodeJSONResponse(response, into)
+
+	if errors.Is(err, io.EOF) {
+		return &source.EmptyResponseError{Word: word}
+	}
+
+	return err
 }
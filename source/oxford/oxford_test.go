@@ -0,0 +1,267 @@
+package oxford
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestToWordID(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		word string
+		want string
+	}{
+		"empty": {
+			word: "",
+			want: "",
+		},
+		"single word": {
+			word: "test",
+			want: "test",
+		},
+		"phrasal verb": {
+			word: "give up",
+			want: "give_up",
+		},
+		"idiom with extra spacing": {
+			word: "  ace in  the hole ",
+			want: "ace_in_the_hole",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := toWordID(testData.word); got != testData.want {
+				t.Errorf("toWordID returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestFallbackWords(t *testing.T) {
+	results := []apiSearchResult{
+		{Label: "running", MatchType: "headword"},
+		{Label: "run", MatchType: "inflection"},
+		{Label: "Run", MatchType: "inflection"},
+		{Label: "runner", MatchType: "fuzzy"},
+		{Label: "rerun", MatchType: "headword"},
+	}
+
+	for testName, testData := range map[string]struct {
+		matchTypes []string
+		want       []string
+	}{
+		"no match types": {
+			matchTypes: nil,
+			want:       nil,
+		},
+		"inflections": {
+			matchTypes: []string{"inflection"},
+			want:       []string{"run"},
+		},
+		"inflections then headwords": {
+			matchTypes: []string{"inflection", "headword"},
+			want:       []string{"run", "rerun"},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			a := &api{fallback: FallbackOptions{MatchTypes: testData.matchTypes}}
+
+			if got := a.fallbackWords("running", results); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("fallbackWords returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestAPISense_ToSense(t *testing.T) {
+	sense := apiSense{
+		ID:          "1",
+		Definitions: []string{"first"},
+		Subsenses: []apiSense{
+			{
+				ID:          "1.1",
+				Definitions: []string{"second"},
+				Subsenses: []apiSense{
+					{ID: "1.1.1", Definitions: []string{"third"}},
+				},
+			},
+		},
+	}
+
+	got := sense.toSense()
+
+	var gotIDs []string
+	for current := []source.Sense{got}; len(current) > 0; current = current[0].SubSenses {
+		gotIDs = append(gotIDs, current[0].ID)
+	}
+
+	if want := []string{"1", "1.1", "1.1.1"}; !reflect.DeepEqual(gotIDs, want) {
+		t.Errorf("apiSense.toSense returned wrong nesting. Got %#v. Want %#v.", gotIDs, want)
+	}
+}
+
+func TestAPIInflection_ToInflectedForm(t *testing.T) {
+	inflection := apiInflection{
+		InflectedForm: "ran",
+		GrammaticalFeatures: []apiTypedIDText{
+			{apiIDText: apiIDText{ID: "past", Text: "Past"}, Type: "Tense"},
+		},
+	}
+
+	want := source.InflectedForm{Form: "ran", Label: "past"}
+
+	if got := inflection.toInflectedForm(); !reflect.DeepEqual(got, want) {
+		t.Errorf("apiInflection.toInflectedForm returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+}
+
+func TestAPILexicalEntry_ToEntry_Pronunciations(t *testing.T) {
+	entry := apiLexicalEntry{
+		Pronunciations: []apiPronunciation{
+			{AudioFile: "https://example.com/test_gb_1.mp3", Dialects: []string{"British English"}, PhoneticNotation: "IPA", PhoneticSpelling: "tɛst"},
+			{Dialects: []string{"British English"}, PhoneticNotation: "respell", PhoneticSpelling: "test"},
+		},
+	}
+
+	want := source.Pronunciations{
+		{Spelling: "tɛst", Notation: source.PronunciationNotationIPA, Dialect: "British English", AudioURL: "https://example.com/test_gb_1.mp3"},
+	}
+
+	if got := entry.toEntry().Pronunciations; !reflect.DeepEqual(got, want) {
+		t.Errorf("apiLexicalEntry.toEntry returned wrong pronunciations. Got %#v. Want %#v.", got, want)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func newTestResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{jsonMIMEType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		baseURL     string
+		response    *http.Response
+		want        error
+		wantSandbox bool
+	}{
+		"ok": {
+			response: newTestResponse(http.StatusOK, "{}"),
+			want:     nil,
+		},
+		"not found": {
+			response: newTestResponse(http.StatusNotFound, `{"error": "No entry found"}`),
+			want:     &source.EmptyResultError{Word: "test"},
+		},
+		"unauthorized": {
+			response: newTestResponse(http.StatusUnauthorized, ""),
+			want:     &source.AuthenticationError{},
+		},
+		"forbidden without a message": {
+			response: newTestResponse(http.StatusForbidden, ""),
+			want:     &source.AuthenticationError{},
+		},
+		"forbidden for credentials": {
+			baseURL:     ProductionBaseURL,
+			response:    newTestResponse(http.StatusForbidden, `{"error": "Authentication failed"}`),
+			want:        &source.AuthenticationError{Message: "Authentication failed"},
+			wantSandbox: true,
+		},
+		"forbidden for the plan": {
+			baseURL:     SandboxBaseURL,
+			response:    newTestResponse(http.StatusForbidden, "This endpoint isn't included in your plan"),
+			want:        &source.EntitlementError{Message: "This endpoint isn't included in your plan"},
+			wantSandbox: false,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			a := &api{baseURL: testData.baseURL}
+
+			err := a.validateResponse("test", testData.response)
+
+			if testData.want == nil {
+				if err != nil {
+					t.Errorf("validateResponse returned an unexpected error. Got %#v.", err)
+				}
+
+				return
+			}
+
+			if reflect.TypeOf(err) != reflect.TypeOf(testData.want) || !strings.HasPrefix(err.Error(), testData.want.Error()) {
+				t.Errorf("validateResponse returned wrong error. Got %#v. Want %#v.", err, testData.want)
+			}
+
+			remedier, hasRemedy := err.(interface{ Remedy() string })
+			if !hasRemedy {
+				return
+			}
+
+			if gotSandbox := strings.Contains(remedier.Remedy(), endpointSandbox); gotSandbox != testData.wantSandbox {
+				t.Errorf("validateResponse returned wrong remedy. Got %q.", remedier.Remedy())
+			}
+		})
+	}
+}
+
+func TestDefineEmptyResponse(t *testing.T) {
+	httpClient := http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return newTestResponse(http.StatusOK, ""), nil
+	})}
+
+	_, err := New(httpClient, ProductionBaseURL, "id", "key", DefaultFallbackOptions).Define("test")
+
+	var emptyResponseErr *source.EmptyResponseError
+	if !errors.As(err, &emptyResponseErr) {
+		t.Errorf("Define returned wrong error. Got %#v. Want %#v.", err, &source.EmptyResponseError{Word: "test"})
+	}
+}
+
+func TestAPIDefinitionResponse_ToResults_IDs(t *testing.T) {
+	var response apiDefinitionResponse
+
+	data := `{"results": [{
+		"id": "test",
+		"language": "en-us",
+		"word": "test",
+		"lexicalEntries": [{
+			"text": "test",
+			"lexicalCategory": {"id": "noun", "text": "Noun"},
+			"entries": [{"senses": [
+				{"id": "m_en_gbus1046510.006", "definitions": ["a procedure intended to establish the quality of something"]},
+				{"id": "m_en_gbus1046510.018", "definitions": ["a movable hearth in a reverberating furnace"]}
+			]}]
+		}]
+	}]}`
+
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("json.Unmarshal returned an error: %s", err)
+	}
+
+	got := response.toResults()
+
+	if want := "test"; got[0].ID != want {
+		t.Errorf("apiDefinitionResponse.toResults returned wrong result ID. Got %#v. Want %#v.", got[0].ID, want)
+	}
+
+	var gotIDs []string
+	for _, sense := range got[0].Entries[0].Senses {
+		gotIDs = append(gotIDs, sense.ID)
+	}
+
+	if want := []string{"m_en_gbus1046510.006", "m_en_gbus1046510.018"}; !reflect.DeepEqual(gotIDs, want) {
+		t.Errorf("apiDefinitionResponse.toResults returned wrong sense IDs. Got %#v. Want %#v.", gotIDs, want)
+	}
+}
@@ -3,24 +3,39 @@
 package oxford
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
-
-	flag "github.com/ogier/pflag"
+	"net/url"
+	"strings"
 
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/internal/keypool"
 	"github.com/Rican7/define/registry"
 	"github.com/Rican7/define/source"
 )
 
 // RequiredConfigError represents an error when a required configuration key is
 // missing or invalid.
-type RequiredConfigError struct {
-	Key string
-}
+type RequiredConfigError = registry.RequiredConfigError
 
 type config struct {
+	AppID    string
+	AppKey   string
+	Endpoint string
+
+	// KeyPool is a list of additional credentials, which are rotated to in
+	// order when the quota of the current ones is exhausted
+	KeyPool []credentials
+
+	FallbackSearchLimit uint
+	FallbackMatchTypes  string
+	FallbackMaxDepth    uint
+}
+
+// credentials defines the structure of a pair of an app ID and key
+type credentials struct {
 	AppID  string
 	AppKey string
 }
@@ -30,6 +45,37 @@ type provider struct{}
 // JSONKey defines the JSON key used for the provider
 const JSONKey = "OxfordDictionary"
 
+// The names of the flags and environment variables that set the credentials
+const (
+	appIDFlag     = "oxford-dictionary-app-id"
+	appIDEnvName  = "OXFORD_DICTIONARY_APP_ID"
+	appKeyFlag    = "oxford-dictionary-app-key"
+	appKeyEnvName = "OXFORD_DICTIONARY_APP_KEY"
+)
+
+// The name of the flag that sets the endpoint
+const endpointFlag = "oxford-dictionary-endpoint"
+
+// The names of the endpoints that can be configured, besides a base URL
+const (
+	endpointProduction = "production"
+	endpointSandbox    = "sandbox"
+)
+
+const (
+	// fallbackMatchTypesNone is a special value to disable the fallback
+	fallbackMatchTypesNone = "none"
+
+	// fallbackMatchTypesSeparator is the separator of a list of match types
+	fallbackMatchTypesSeparator = ","
+)
+
+// Ensure our types satisfy the registry's provider contract at compile-time
+var (
+	_ registry.AliasedProvider      = (*provider)(nil)
+	_ registry.DynamicConfiguration = (*config)(nil)
+)
+
 func init() {
 	registry.Register(registry.RegisterFunc(register))
 }
@@ -42,14 +88,18 @@ func initConfig(flags *flag.FlagSet) *config {
 	conf := &config{}
 
 	// Define our flags
-	flags.StringVar(&conf.AppID, "oxford-dictionary-app-id", "", fmt.Sprintf("The app ID for the %s", Name))
-	flags.StringVar(&conf.AppKey, "oxford-dictionary-app-key", "", fmt.Sprintf("The app key for the %s", Name))
+	registry.DefineStringFlags(
+		flags,
+		registry.StringFlag{Name: appIDFlag, Usage: fmt.Sprintf("The app ID for the %s", Name), Value: &conf.AppID},
+		registry.StringFlag{Name: appKeyFlag, Usage: fmt.Sprintf("The app key for the %s", Name), Value: &conf.AppKey},
+		registry.StringFlag{Name: endpointFlag, Usage: fmt.Sprintf("The endpoint of the %s (\"production\", \"sandbox\", or a base URL)", Name), Value: &conf.Endpoint},
+		registry.StringFlag{Name: "oxford-dictionary-fallback-match-types", Usage: fmt.Sprintf("The search match types that the %s falls back to defining, comma separated (like \"inflection,headword\", or \"none\" to disable)", Name), Value: &conf.FallbackMatchTypes},
+	)
 
-	return conf
-}
+	flags.UintVar(&conf.FallbackSearchLimit, "oxford-dictionary-fallback-search-limit", 0, fmt.Sprintf("The number of search results that the %s considers when falling back (0 for the default)", Name))
+	flags.UintVar(&conf.FallbackMaxDepth, "oxford-dictionary-fallback-max-depth", 0, fmt.Sprintf("The maximum number of consecutive fallbacks that the %s follows (0 for the default)", Name))
 
-func (e *RequiredConfigError) Error() string {
-	return fmt.Sprintf("required configuration key %q is missing", e.Key)
+	return conf
 }
 
 func (c *config) JSONKey() string {
@@ -68,41 +118,135 @@ func (c *config) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if c.AppID == "" {
-		c.AppID = copy.AppID
-	}
+	registry.FillEmpty(&c.AppID, copy.AppID)
+	registry.FillEmpty(&c.AppKey, copy.AppKey)
+	registry.FillEmpty(&c.Endpoint, copy.Endpoint)
+	registry.FillEmpty(&c.FallbackSearchLimit, copy.FallbackSearchLimit)
+	registry.FillEmpty(&c.FallbackMatchTypes, copy.FallbackMatchTypes)
+	registry.FillEmpty(&c.FallbackMaxDepth, copy.FallbackMaxDepth)
 
-	if c.AppKey == "" {
-		c.AppKey = copy.AppKey
+	if len(c.KeyPool) < 1 {
+		c.KeyPool = copy.KeyPool
 	}
 
 	return nil
 }
 
-func (c *config) Finalize() {
-	if c.AppID == "" {
-		c.AppID = os.Getenv("OXFORD_DICTIONARY_APP_ID")
+// LoadEnv fills in any values that weren't passed as flags from environment
+// variables.
+func (c *config) LoadEnv() {
+	registry.FillEmptyFromEnv(&c.AppID, appIDEnvName)
+	registry.FillEmptyFromEnv(&c.AppKey, appKeyEnvName)
+	registry.FillEmptyFromEnv(&c.Endpoint, "OXFORD_DICTIONARY_ENDPOINT")
+	registry.FillEmptyUintFromEnv(&c.FallbackSearchLimit, "OXFORD_DICTIONARY_FALLBACK_SEARCH_LIMIT")
+	registry.FillEmptyFromEnv(&c.FallbackMatchTypes, "OXFORD_DICTIONARY_FALLBACK_MATCH_TYPES")
+	registry.FillEmptyUintFromEnv(&c.FallbackMaxDepth, "OXFORD_DICTIONARY_FALLBACK_MAX_DEPTH")
+}
+
+// baseURL returns the base URL of the configured endpoint, which is either the
+// name of one of the API's endpoints, or the base URL of another (like an
+// enterprise endpoint).
+func (c *config) baseURL() (string, error) {
+	switch endpoint := strings.TrimSpace(c.Endpoint); strings.ToLower(endpoint) {
+	case "", endpointProduction:
+		return ProductionBaseURL, nil
+	case endpointSandbox:
+		return SandboxBaseURL, nil
+	default:
+		parsed, err := url.Parse(endpoint)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return "", fmt.Errorf("invalid endpoint %q: must be %q, %q, or an HTTP(S) base URL", endpoint, endpointProduction, endpointSandbox)
+		}
+
+		return endpoint, nil
 	}
+}
+
+// signers returns the signers of requests with each of the configured
+// credentials, starting with the primary ones, or an error if any of the key
+// pool's credentials are incomplete.
+func (c *config) signers() ([]keypool.Signer, error) {
+	signers := []keypool.Signer{signWith(credentials{c.AppID, c.AppKey})}
 
-	if c.AppKey == "" {
-		c.AppKey = os.Getenv("OXFORD_DICTIONARY_APP_KEY")
+	for i, poolCredentials := range c.KeyPool {
+		if poolCredentials.AppID == "" || poolCredentials.AppKey == "" {
+			return nil, fmt.Errorf("the credentials #%d of the key pool are missing an app ID or key", i+1)
+		}
+
+		signers = append(signers, signWith(poolCredentials))
 	}
+
+	return signers, nil
+}
+
+// signWith returns a signer of requests with the given credentials.
+func signWith(signCredentials credentials) keypool.Signer {
+	return func(request *http.Request) {
+		request.Header.Set(httpRequestAppIDHeaderName, signCredentials.AppID)
+		request.Header.Set(httpRequestAppKeyHeaderName, signCredentials.AppKey)
+	}
+}
+
+// fallbackOptions returns the fallback options of the configuration, with any
+// empty values replaced by their defaults.
+func (c *config) fallbackOptions() FallbackOptions {
+	options := DefaultFallbackOptions
+
+	options.SearchResultLimit = cmp.Or(c.FallbackSearchLimit, options.SearchResultLimit)
+	options.MaxDepth = cmp.Or(c.FallbackMaxDepth, options.MaxDepth)
+
+	switch matchTypes := strings.TrimSpace(c.FallbackMatchTypes); strings.ToLower(matchTypes) {
+	case "":
+		// Keep the default match types
+	case fallbackMatchTypesNone:
+		options.MatchTypes = nil
+	default:
+		options.MatchTypes = nil
+
+		for _, matchType := range strings.Split(matchTypes, fallbackMatchTypesSeparator) {
+			if matchType = strings.TrimSpace(matchType); matchType != "" {
+				options.MatchTypes = append(options.MatchTypes, matchType)
+			}
+		}
+	}
+
+	return options
 }
 
 func (p *provider) Name() string {
 	return Name
 }
 
+func (p *provider) Aliases() []string {
+	return []string{"oxford"}
+}
+
 func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
 	config := conf.(*config)
 
 	if config.AppID == "" {
-		return nil, &RequiredConfigError{Key: "AppID"}
+		return nil, &RequiredConfigError{Key: "AppID", Flag: appIDFlag, EnvName: appIDEnvName, JSONKey: JSONKey}
 	}
 
 	if config.AppKey == "" {
-		return nil, &RequiredConfigError{Key: "AppKey"}
+		return nil, &RequiredConfigError{Key: "AppKey", Flag: appKeyFlag, EnvName: appKeyEnvName, JSONKey: JSONKey}
+	}
+
+	baseURL, err := config.baseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := registry.HTTPClient()
+
+	if len(config.KeyPool) > 0 {
+		signers, err := config.signers()
+		if err != nil {
+			return nil, err
+		}
+
+		httpClient.Transport = keypool.NewTransport(httpClient.Transport, signers...)
 	}
 
-	return New(http.Client{}, config.AppID, config.AppKey), nil
+	return New(httpClient, baseURL, config.AppID, config.AppKey, config.fallbackOptions()), nil
 }
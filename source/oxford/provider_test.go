@@ -0,0 +1,116 @@
+package oxford
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestConfigBaseURL(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		endpoint string
+		want     string
+		wantErr  bool
+	}{
+		"default":    {endpoint: "", want: ProductionBaseURL},
+		"production": {endpoint: "production", want: ProductionBaseURL},
+		"sandbox":    {endpoint: " Sandbox ", want: SandboxBaseURL},
+		"base URL":   {endpoint: "https://example.com/api/v2/", want: "https://example.com/api/v2/"},
+		"unknown":    {endpoint: "staging", wantErr: true},
+		"no scheme":  {endpoint: "example.com/api/v2/", wantErr: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			conf := config{Endpoint: testData.endpoint}
+
+			got, err := conf.baseURL()
+
+			if (err != nil) != testData.wantErr {
+				t.Errorf("baseURL returned an unexpected error. Got %#v.", err)
+			}
+
+			if got != testData.want {
+				t.Errorf("baseURL returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestConfigFallbackOptions(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		conf config
+		want FallbackOptions
+	}{
+		"defaults": {
+			conf: config{},
+			want: DefaultFallbackOptions,
+		},
+		"custom": {
+			conf: config{
+				FallbackSearchLimit: 5,
+				FallbackMatchTypes:  "inflection, headword",
+				FallbackMaxDepth:    2,
+			},
+			want: FallbackOptions{
+				SearchResultLimit: 5,
+				MatchTypes:        []string{"inflection", "headword"},
+				MaxDepth:          2,
+			},
+		},
+		"disabled": {
+			conf: config{FallbackMatchTypes: "None"},
+			want: FallbackOptions{
+				SearchResultLimit: DefaultFallbackOptions.SearchResultLimit,
+				MatchTypes:        nil,
+				MaxDepth:          DefaultFallbackOptions.MaxDepth,
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.conf.fallbackOptions(); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("fallbackOptions returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestConfigSigners(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		conf    config
+		want    []credentials
+		wantErr bool
+	}{
+		"primary only": {
+			conf: config{AppID: "id", AppKey: "key"},
+			want: []credentials{{"id", "key"}},
+		},
+		"key pool": {
+			conf: config{AppID: "id", AppKey: "key", KeyPool: []credentials{{"id2", "key2"}, {"id3", "key3"}}},
+			want: []credentials{{"id", "key"}, {"id2", "key2"}, {"id3", "key3"}},
+		},
+		"incomplete pool credentials": {
+			conf:    config{AppID: "id", AppKey: "key", KeyPool: []credentials{{AppID: "id2"}}},
+			wantErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			signers, err := testData.conf.signers()
+
+			if (err != nil) != testData.wantErr {
+				t.Fatalf("signers returned an unexpected error. Got %#v.", err)
+			}
+
+			var got []credentials
+
+			for _, sign := range signers {
+				request, _ := http.NewRequest(http.MethodGet, ProductionBaseURL, nil)
+				sign(request)
+
+				got = append(got, credentials{request.Header.Get(httpRequestAppIDHeaderName), request.Header.Get(httpRequestAppKeyHeaderName)})
+			}
+
+			if !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("signers returned wrong signers. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
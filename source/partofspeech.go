@@ -0,0 +1,98 @@
+package source
+
+import (
+	"strings"
+)
+
+// List of parts of speech.
+const (
+	PartOfSpeechUnknown      PartOfSpeech = ""
+	PartOfSpeechNoun         PartOfSpeech = "noun"
+	PartOfSpeechVerb         PartOfSpeech = "verb"
+	PartOfSpeechAdjective    PartOfSpeech = "adjective"
+	PartOfSpeechAdverb       PartOfSpeech = "adverb"
+	PartOfSpeechPronoun      PartOfSpeech = "pronoun"
+	PartOfSpeechPreposition  PartOfSpeech = "preposition"
+	PartOfSpeechConjunction  PartOfSpeech = "conjunction"
+	PartOfSpeechInterjection PartOfSpeech = "interjection"
+	PartOfSpeechDeterminer   PartOfSpeech = "determiner"
+	PartOfSpeechNumeral      PartOfSpeech = "numeral"
+	PartOfSpeechAbbreviation PartOfSpeech = "abbreviation"
+	PartOfSpeechAffix        PartOfSpeech = "affix" // Prefixes, suffixes, and combining forms
+	PartOfSpeechPhrase       PartOfSpeech = "phrase"
+)
+
+// lexicalCategoryLabelPrefix is a prefix that some lexical category labels
+// are given, after the name of the Webster API field (like "fl: adjective")
+const lexicalCategoryLabelPrefix = "fl:"
+
+// partOfSpeechWords maps the words used in lexical category labels to their
+// part of speech.
+var partOfSpeechWords = map[string]PartOfSpeech{
+	"noun":         PartOfSpeechNoun,
+	"nouns":        PartOfSpeechNoun,
+	"verb":         PartOfSpeechVerb,
+	"verbal":       PartOfSpeechVerb,
+	"auxiliary":    PartOfSpeechVerb,
+	"adjective":    PartOfSpeechAdjective,
+	"adverb":       PartOfSpeechAdverb,
+	"pronoun":      PartOfSpeechPronoun,
+	"preposition":  PartOfSpeechPreposition,
+	"conjunction":  PartOfSpeechConjunction,
+	"interjection": PartOfSpeechInterjection,
+	"exclamation":  PartOfSpeechInterjection,
+	"determiner":   PartOfSpeechDeterminer,
+	"article":      PartOfSpeechDeterminer,
+	"numeral":      PartOfSpeechNumeral,
+	"number":       PartOfSpeechNumeral,
+	"abbreviation": PartOfSpeechAbbreviation,
+	"symbol":       PartOfSpeechAbbreviation,
+	"prefix":       PartOfSpeechAffix,
+	"suffix":       PartOfSpeechAffix,
+	"combining":    PartOfSpeechAffix,
+	"phrase":       PartOfSpeechPhrase,
+	"idiom":        PartOfSpeechPhrase,
+	"idiomatic":    PartOfSpeechPhrase,
+}
+
+// PartOfSpeech defines the canonical part of speech of an entry, normalized
+// from the differing lexical category labels of sources (like "Noun", "noun",
+// or "transitive verb")
+type PartOfSpeech string
+
+// ParsePartOfSpeech takes a lexical category label of a source and returns
+// the part of speech that it's normalized to, or PartOfSpeechUnknown if the
+// label isn't recognized.
+//
+// Labels of more than one word are normalized to the first word that names a
+// part of speech, so that "transitive verb" is a verb, "noun phrase" is a
+// noun, and "adjective or adverb" is an adjective.
+func ParsePartOfSpeech(label string) PartOfSpeech {
+	label = strings.ToLower(strings.TrimSpace(label))
+	label = strings.TrimSpace(strings.TrimPrefix(label, lexicalCategoryLabelPrefix))
+
+	words := strings.FieldsFunc(label, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '_' || r == '-' || r == '/'
+	})
+
+	for _, word := range words {
+		if partOfSpeech, exists := partOfSpeechWords[word]; exists {
+			return partOfSpeech
+		}
+	}
+
+	return PartOfSpeechUnknown
+}
+
+// NormalizePartsOfSpeech sets the part of speech of each of the entries of the
+// results that don't have one, by parsing their lexical category, for results
+// that are decoded rather than built by a source (like canned results).
+func (r DictionaryResults) NormalizePartsOfSpeech() {
+	for _, result := range r {
+		for i, entry := range result.Entries {
+			if entry.PartOfSpeech == PartOfSpeechUnknown {
+				result.Entries[i].PartOfSpeech = ParsePartOfSpeech(entry.LexicalCategory)
+			}
+		}
+	}
+}
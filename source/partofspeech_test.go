@@ -0,0 +1,35 @@
+package source
+
+import (
+	"testing"
+)
+
+func TestParsePartOfSpeech(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		label string
+		want  PartOfSpeech
+	}{
+		"empty":               {label: "", want: PartOfSpeechUnknown},
+		"lowercase":           {label: "noun", want: PartOfSpeechNoun},
+		"capitalized":         {label: "Noun", want: PartOfSpeechNoun},
+		"qualified":           {label: "transitive verb", want: PartOfSpeechVerb},
+		"field prefix":        {label: "fl: adjective", want: PartOfSpeechAdjective},
+		"adverb isn't verb":   {label: "adverb", want: PartOfSpeechAdverb},
+		"noun phrase":         {label: "noun phrase", want: PartOfSpeechNoun},
+		"plural noun":         {label: "noun, plural", want: PartOfSpeechNoun},
+		"first of several":    {label: "adjective or adverb", want: PartOfSpeechAdjective},
+		"article":             {label: "definite article", want: PartOfSpeechDeterminer},
+		"auxiliary":           {label: "verbal auxiliary", want: PartOfSpeechVerb},
+		"combining form":      {label: "combining_form", want: PartOfSpeechAffix},
+		"idiomatic":           {label: "Idiomatic", want: PartOfSpeechPhrase},
+		"exclamation":         {label: "exclamation", want: PartOfSpeechInterjection},
+		"unrecognized":        {label: "residual", want: PartOfSpeechUnknown},
+		"surrounding spacing": {label: "  Verb ", want: PartOfSpeechVerb},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := ParsePartOfSpeech(testData.label); got != testData.want {
+				t.Errorf("ParsePartOfSpeech returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package source
+
+import (
+	"net/url"
+	"strings"
+)
+
+// NewPathURL builds a URL from a base URL and a number of path segments, such
+// as looked-up words, to append to it. Each of the path segments is escaped,
+// so that any special characters in them (like spaces, slashes, question
+// marks, or non-ASCII characters) are kept as part of their segment.
+func NewPathURL(baseURLString string, pathSegments ...string) (*url.URL, error) {
+	escapedSegments := make([]string, 0, len(pathSegments))
+
+	for _, segment := range pathSegments {
+		escapedSegments = append(escapedSegments, url.PathEscape(segment))
+	}
+
+	return url.Parse(strings.TrimSuffix(baseURLString, "/") + "/" + strings.Join(escapedSegments, "/"))
+}
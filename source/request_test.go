@@ -0,0 +1,64 @@
+package source
+
+import (
+	"testing"
+)
+
+func TestNewPathURL(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		base     string
+		segments []string
+		want     string
+	}{
+		"no segments": {
+			base: "https://example.com/entries/",
+			want: "https://example.com/entries/",
+		},
+		"simple word": {
+			base:     "https://example.com/entries/",
+			segments: []string{"en", "test"},
+			want:     "https://example.com/entries/en/test",
+		},
+		"base without trailing slash": {
+			base:     "https://example.com/entries",
+			segments: []string{"test"},
+			want:     "https://example.com/entries/test",
+		},
+		"non-ASCII": {
+			base:     "https://example.com/entries/",
+			segments: []string{"naïve"},
+			want:     "https://example.com/entries/na%C3%AFve",
+		},
+		"hyphenated": {
+			base:     "https://example.com/entries/",
+			segments: []string{"passers-by"},
+			want:     "https://example.com/entries/passers-by",
+		},
+		"spaces": {
+			base:     "https://example.com/entries/",
+			segments: []string{"café au lait"},
+			want:     "https://example.com/entries/caf%C3%A9%20au%20lait",
+		},
+		"slash": {
+			base:     "https://example.com/entries/",
+			segments: []string{"and/or"},
+			want:     "https://example.com/entries/and%2For",
+		},
+		"query and fragment characters": {
+			base:     "https://example.com/entries/",
+			segments: []string{"what?#"},
+			want:     "https://example.com/entries/what%3F%23",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			got, err := NewPathURL(testData.base, testData.segments...)
+			if err != nil {
+				t.Fatalf("NewPathURL returned an error: %s", err)
+			}
+
+			if got.String() != testData.want {
+				t.Errorf("NewPathURL returned wrong value. Got %#v. Want %#v.", got.String(), testData.want)
+			}
+		})
+	}
+}
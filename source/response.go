@@ -0,0 +1,72 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// MaxResponseSize is the maximum size, in bytes, of a response body that will
+// be decoded from a source's API, to protect against pathological responses.
+//
+// The limit is generous, as some entries (like Merriam-Webster's "set") are
+// legitimately very large.
+const MaxResponseSize int64 = 16 << 20 // 16 MiB
+
+// ResponseTooLargeError represents an error caused by a response body that's
+// larger than the maximum allowed size
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+// sizeLimitedReader is an io.Reader that returns a ResponseTooLargeError if
+// more than a limited number of bytes are read from it.
+type sizeLimitedReader struct {
+	reader    io.Reader
+	limit     int64
+	remaining int64
+}
+
+// DecodeJSONResponse decodes the JSON body of an HTTP response into a given
+// value, streaming the body rather than reading it all into memory first. A
+// ResponseTooLargeError is returned if the body is larger than the
+// MaxResponseSize.
+func DecodeJSONResponse(httpResponse *http.Response, into any) error {
+	return decodeJSONResponse(httpResponse, into, MaxResponseSize)
+}
+
+// decodeJSONResponse decodes the JSON body of an HTTP response into a given
+// value, with a given limit on the size of the body.
+func decodeJSONResponse(httpResponse *http.Response, into any, limit int64) error {
+	if httpResponse.ContentLength > limit {
+		return &ResponseTooLargeError{Limit: limit}
+	}
+
+	body := &sizeLimitedReader{reader: httpResponse.Body, limit: limit, remaining: limit}
+
+	return json.NewDecoder(body).Decode(into)
+}
+
+// Read satisfies the io.Reader interface.
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	// Allow reading one byte past the limit, so that we can tell the
+	// difference between a body that's exactly at the limit and one that's
+	// over it
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+
+	if r.remaining < 0 {
+		return 0, &ResponseTooLargeError{Limit: r.limit}
+	}
+
+	return n, err
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("the source returned a response larger than the maximum size of %d bytes", e.Limit)
+}
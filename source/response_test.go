@@ -0,0 +1,65 @@
+package source
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSONResponse(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		body          string
+		contentLength int64
+		limit         int64
+		want          []string
+		wantTooLarge  bool
+	}{
+		"within limit": {
+			body:          `["a","b"]`,
+			contentLength: -1,
+			limit:         64,
+			want:          []string{"a", "b"},
+		},
+		"exactly at limit": {
+			body:          `["a","b"]`,
+			contentLength: -1,
+			limit:         9,
+			want:          []string{"a", "b"},
+		},
+		"body over limit": {
+			body:          `["a","b","c","d"]`,
+			contentLength: -1,
+			limit:         8,
+			wantTooLarge:  true,
+		},
+		"content length over limit": {
+			body:          `["a"]`,
+			contentLength: 1024,
+			limit:         64,
+			wantTooLarge:  true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			httpResponse := &http.Response{
+				Body:          io.NopCloser(strings.NewReader(testData.body)),
+				ContentLength: testData.contentLength,
+			}
+
+			var got []string
+
+			err := decodeJSONResponse(httpResponse, &got, testData.limit)
+
+			var tooLargeErr *ResponseTooLargeError
+			if isTooLarge := errors.As(err, &tooLargeErr); isTooLarge != testData.wantTooLarge {
+				t.Fatalf("decodeJSONResponse returned an unexpected error. Got %#v.", err)
+			}
+
+			if !testData.wantTooLarge && !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("decodeJSONResponse returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@
 package source
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -32,52 +34,214 @@ type DictionaryResults []DictionaryResult
 // SearchResults defines the structure of a list of word search results
 type SearchResults []SearchResult
 
+// RelatedWords defines the structure of a list of words related to a query
+type RelatedWords []RelatedWord
+
 // DictionaryResult defines the structure of a dictionary word result in a
 // specific language
 type DictionaryResult struct {
+	ID       string // The source's identifier of the result, if any
 	Language string
 	Word     string
 	Entries  []DictionaryEntry
+
+	Frequency   *Frequency // Derived from a frequency table, if known
+	Attribution ResultAttribution
+
+	// Trend is the frequency of use of the word over time, derived from a
+	// corpus of books, if requested
+	Trend *UsageTrend `json:",omitempty"`
+
+	// Confusables are the groups of words that the word is commonly confused
+	// with, derived from a curated list
+	Confusables []Confusable `json:",omitempty"`
+
+	// ParseWarnings are any problems encountered when parsing the upstream
+	// data of the result, such as fields that had to be dropped
+	ParseWarnings []ParseWarning `json:",omitempty"`
+}
+
+// ResultAttribution defines the structure of the attribution and license
+// information of a dictionary result
+type ResultAttribution struct {
+	Provider   string   // The name of the original provider of the data
+	License    string   // The name of the license of the data
+	LicenseURL string   // The URL of the license or terms of use
+	SourceURLs []string // The URLs of the original data
 }
 
 // SearchResult defines the structure of a word search result
 type SearchResult string
 
+// RelatedWordGroup defines the structure of a named group of words related to a
+// query in the same way (such as the adjectives commonly used with a noun)
+type RelatedWordGroup struct {
+	Name  string
+	Words RelatedWords
+}
+
+// RelatedWord defines the structure of a word related to a query (such as a
+// word matching a description), along with a short gloss of its meaning
+type RelatedWord struct {
+	Word            string
+	LexicalCategory string `json:",omitempty"`
+	Gloss           string `json:",omitempty"`
+}
+
+// Summary defines the structure of an encyclopedic summary of a subject, for
+// the subjects (like proper nouns) that dictionaries tend not to define
+type Summary struct {
+	Title       string
+	Description string `json:",omitempty"` // A short description, like "city in England"
+	Text        string
+
+	// IsDisambiguation is whether the subject's title is ambiguous, in which
+	// case the text only lists (some of) the subjects that it may refer to
+	IsDisambiguation bool `json:",omitempty"`
+
+	Attribution ResultAttribution
+}
+
 // Entry defines the structure of an entry of a specific word
 type Entry struct {
 	Word            string
-	LexicalCategory string
+	LexicalCategory string       // The source's own label of the category
+	PartOfSpeech    PartOfSpeech `json:",omitempty"` // Normalized from the lexical category
 }
 
 // DictionaryEntry defines the structure of a dictionary entry of a word
 type DictionaryEntry struct {
+	ID   string // The source's identifier of the entry, if any
+	UUID string // The source's universally unique identifier of the entry, if any
+
 	Entry
 
 	Senses      []Sense
 	Etymologies []string // Origins of the word
+	EntryNotes  []string // Notes on the entry as a whole, like usage paragraphs
+	Inflections []InflectedForm
+	Hyphenation []string // The parts of the word split at its hyphenation points, if known
+	Offensive   bool     `json:",omitempty"` // Whether the source flags the entry as offensive
 
 	Pronunciations
+	PronunciationNotation
+	Syllables *Syllables // Derived from the first pronunciation, if known
+
 	ThesaurusValues
 }
 
+// InflectedForm defines the structure of an inflected form of a word, like a
+// plural or a past tense
+type InflectedForm struct {
+	Form      string
+	Label     string // The grammatical label of the form, like "plural"
+	Generated bool   `json:",omitempty"` // Whether the form was generated, rather than provided by the source
+}
+
 // Pronunciations defines the structure of a collection of pronunciations
 type Pronunciations []Pronunciation
 
 // Pronunciation defines the structure of a pronunciation of a word
-type Pronunciation string
+type Pronunciation struct {
+	Spelling string // The written pronunciation
+
+	// Notation is the notation that the pronunciation is written in, if known,
+	// or else it's the notation of its entry
+	Notation PronunciationNotation `json:",omitempty"`
+
+	Dialect  string `json:",omitempty"` // The dialect of the pronunciation, like "British English", if known
+	AudioURL string `json:",omitempty"` // The URL of a recording of the pronunciation, if any
+}
+
+// PronunciationNotation defines the notation system used to write
+// pronunciations
+type PronunciationNotation string
+
+// List of known pronunciation notations.
+const (
+	// PronunciationNotationIPA is the International Phonetic Alphabet
+	PronunciationNotationIPA PronunciationNotation = "IPA"
+
+	// PronunciationNotationRespelling is the Merriam-Webster respelling system
+	PronunciationNotationRespelling PronunciationNotation = "respelling"
+)
+
+// Syllables defines the structure of the syllable information of a word
+type Syllables struct {
+	Count         uint // The number of syllables
+	PrimaryStress uint // The position (from 1) of the stressed syllable, or 0
+}
+
+// Frequency defines the structure of the frequency of use of a word
+type Frequency struct {
+	Zipf float64 // The frequency on the Zipf scale (log10 of uses per billion)
+	Band string  // A human-readable band of the frequency, like "common"
+}
+
+// UsageTrend defines the structure of the frequency of use of a word over time
+type UsageTrend struct {
+	StartYear   int
+	EndYear     int
+	Frequencies []float64 // The relative frequencies of use, one per year
+}
+
+// Etymon defines the structure of a word in the etymology of another word,
+// along with the words that it in turn comes from, as a tree
+type Etymon struct {
+	Word     string // The word, which may be empty if only its language is known
+	Language string // The name of the language of the word
+	Gloss    string `json:",omitempty"`
+
+	// Relation is how the word that comes from this one relates to it, like
+	// "inherited" or "borrowed"
+	Relation string `json:",omitempty"`
+
+	Etymons []Etymon `json:",omitempty"`
+}
+
+// Confusable defines the structure of a group of words that are commonly
+// confused with a word, such as "effect" for "affect"
+type Confusable struct {
+	Words []string // The words that are confused with the word
+	Note  string   // A brief note of how to tell the words apart
+}
 
 // Sense defines the structure of a particular meaning of a word
 type Sense struct {
+	ID          string // The source's identifier of the sense, if any
+	Label       string // The source's own label of the sense (like "1a"), if any
 	Definitions []string
 	Categories  []string
 	Examples    []AttributedText
 	Notes       []string
+	SeeAlso     []CrossReference
+
+	// Translations are the translations of the definitions into another
+	// language, in the same order, if requested
+	Translations []string `json:",omitempty"`
 
 	ThesaurusValues
 
 	SubSenses []Sense
 }
 
+// CrossReference defines the structure of a reference to another entry (or a
+// specific sense of it) in the same source
+type CrossReference struct {
+	Word        string
+	EntryID     string // The source's identifier of the referenced entry, if any
+	SenseNumber string // The number of the referenced sense, if any
+}
+
+// String satisfies the fmt.Stringer interface.
+func (r CrossReference) String() string {
+	if r.SenseNumber == "" {
+		return r.Word
+	}
+
+	return fmt.Sprintf("%s (sense %s)", r.Word, r.SenseNumber)
+}
+
 // AttributedText defines the structure of a general text with attribution
 type AttributedText struct {
 	Text string
@@ -89,6 +253,9 @@ type AttributedText struct {
 type Attribution struct {
 	Author string
 	Source string
+
+	License  string `json:",omitempty"` // The license of the text, if not the result's own
+	Language string `json:",omitempty"` // The language of the text, if from another source
 }
 
 // ThesaurusValues defines the structure of the thesaurus values of a word
@@ -132,8 +299,80 @@ func (r *DictionaryResults) SortForPrimaryResult(word string) {
 	}
 }
 
+// RedirectedWord takes the word that was looked up and returns the word that
+// the results are actually for, if none of the results match the looked up
+// word (such as when a source automatically falls back to an inflection's
+// lemma). An empty string is returned if any result matches the word.
+func (r DictionaryResults) RedirectedWord(word string) string {
+	if len(r) < 1 {
+		return ""
+	}
+
+	for _, result := range r {
+		if EqualFoldPlain(result.Word, word) {
+			return ""
+		}
+	}
+
+	return r[0].Word
+}
+
+// Attributions returns the unique, non-empty attributions of the results, in
+// the order that they're first found.
+func (r DictionaryResults) Attributions() []ResultAttribution {
+	var attributions []ResultAttribution
+
+	for _, result := range r {
+		if result.Attribution.IsZero() {
+			continue
+		}
+
+		isDuplicate := slices.ContainsFunc(attributions, func(attribution ResultAttribution) bool {
+			return attribution.Equal(result.Attribution)
+		})
+
+		if !isDuplicate {
+			attributions = append(attributions, result.Attribution)
+		}
+	}
+
+	return attributions
+}
+
+// IsZero returns true if the attribution is empty.
+func (a ResultAttribution) IsZero() bool {
+	return a.Equal(ResultAttribution{})
+}
+
+// Equal returns true if the attribution is equal to the given attribution.
+func (a ResultAttribution) Equal(other ResultAttribution) bool {
+	return a.Provider == other.Provider &&
+		a.License == other.License &&
+		a.LicenseURL == other.LicenseURL &&
+		slices.Equal(a.SourceURLs, other.SourceURLs)
+}
+
+// NotationOf returns the notation that a pronunciation of the entry is written
+// in, which is its own, if known, or else the entry's.
+func (e DictionaryEntry) NotationOf(pronunciation Pronunciation) PronunciationNotation {
+	if pronunciation.Notation != "" {
+		return pronunciation.Notation
+	}
+
+	return e.PronunciationNotation
+}
+
 // String satisfies fmt.Stringer and dictates the string format of the value
 func (p Pronunciations) String() string {
+	if p.hasDialects() {
+		pronunciationStrings := make([]string, 0, len(p))
+		for _, pronunciation := range p {
+			pronunciationStrings = append(pronunciationStrings, pronunciation.String())
+		}
+
+		return strings.Join(pronunciationStrings, ", ")
+	}
+
 	var pronunciationText string
 
 	if len(p) > 0 {
@@ -152,9 +391,41 @@ func (p Pronunciations) String() string {
 	return pronunciationText
 }
 
+// hasDialects returns true if any of the pronunciations are of a known dialect.
+func (p Pronunciations) hasDialects() bool {
+	for _, pronunciation := range p {
+		if pronunciation.Dialect != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
 // String satisfies fmt.Stringer and dictates the string format of the value
 func (p Pronunciation) String() string {
-	return fmt.Sprintf("/%s/", string(p))
+	if p.Dialect != "" {
+		return fmt.Sprintf("/%s/ (%s)", p.Spelling, p.Dialect)
+	}
+
+	return fmt.Sprintf("/%s/", p.Spelling)
+}
+
+// UnmarshalJSON satisfies the encoding/json.Unmarshaler interface, so that a
+// pronunciation may also be decoded from a plain string of its spelling (as
+// pronunciations were once encoded).
+func (p *Pronunciation) UnmarshalJSON(data []byte) error {
+	var spelling string
+	if err := json.Unmarshal(data, &spelling); err == nil {
+		*p = Pronunciation{Spelling: spelling}
+
+		return nil
+	}
+
+	// Alias our type so that we can unmarshal as usual
+	type alias Pronunciation
+
+	return json.Unmarshal(data, (*alias)(p))
 }
 
 // String satisfies fmt.Stringer and dictates the string format of the value
@@ -165,8 +436,18 @@ func (t AttributedText) String() string {
 		text = fmt.Sprintf("%s - %s", text, t.Author)
 	}
 
+	var sourceParts []string
+
 	if t.Source != "" {
-		text = fmt.Sprintf("%s (%s)", text, t.Source)
+		sourceParts = append(sourceParts, t.Source)
+	}
+
+	if t.License != "" {
+		sourceParts = append(sourceParts, t.License)
+	}
+
+	if len(sourceParts) > 0 {
+		text = fmt.Sprintf("%s (%s)", text, strings.Join(sourceParts, ", "))
 	}
 
 	return text
@@ -3,6 +3,7 @@
 package source
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -176,6 +177,81 @@ func TestDictionaryResults_SortForPrimaryResult(t *testing.T) {
 	}
 }
 
+func TestDictionaryResults_Attributions(t *testing.T) {
+	license := ResultAttribution{License: "CC BY-SA 3.0", SourceURLs: []string{"https://example.com/test"}}
+	provider := ResultAttribution{Provider: "Test Press"}
+
+	for testName, testData := range map[string]struct {
+		results DictionaryResults
+		want    []ResultAttribution
+	}{
+		"nil": {
+			results: nil,
+			want:    nil,
+		},
+		"no attributions": {
+			results: DictionaryResults{{Word: "test"}, {Word: "nope"}},
+			want:    nil,
+		},
+		"single attribution": {
+			results: DictionaryResults{{Word: "test", Attribution: license}},
+			want:    []ResultAttribution{license},
+		},
+		"duplicate attributions": {
+			results: DictionaryResults{
+				{Word: "test", Attribution: license},
+				{Word: "nope"},
+				{Word: "nah", Attribution: license},
+			},
+			want: []ResultAttribution{license},
+		},
+		"multiple attributions": {
+			results: DictionaryResults{
+				{Word: "test", Attribution: provider},
+				{Word: "nah", Attribution: license},
+				{Word: "nope", Attribution: provider},
+			},
+			want: []ResultAttribution{provider, license},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.results.Attributions(); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("Attributions returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestDictionaryEntry_NotationOf(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		entry         DictionaryEntry
+		pronunciation Pronunciation
+		want          PronunciationNotation
+	}{
+		"unknown": {
+			entry:         DictionaryEntry{},
+			pronunciation: Pronunciation{Spelling: "test-1"},
+			want:          "",
+		},
+		"entry's": {
+			entry:         DictionaryEntry{PronunciationNotation: PronunciationNotationIPA},
+			pronunciation: Pronunciation{Spelling: "test-1"},
+			want:          PronunciationNotationIPA,
+		},
+		"own": {
+			entry:         DictionaryEntry{PronunciationNotation: PronunciationNotationIPA},
+			pronunciation: Pronunciation{Spelling: "test-1", Notation: PronunciationNotationRespelling},
+			want:          PronunciationNotationRespelling,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.entry.NotationOf(testData.pronunciation); got != testData.want {
+				t.Errorf("NotationOf returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
 func TestPronunciations_String(t *testing.T) {
 	for testName, testData := range map[string]struct {
 		pronunciations Pronunciations
@@ -190,17 +266,25 @@ func TestPronunciations_String(t *testing.T) {
 			want:           "",
 		},
 		"one": {
-			pronunciations: Pronunciations{"test-1"},
+			pronunciations: Pronunciations{{Spelling: "test-1"}},
 			want:           "/test-1/",
 		},
 		"two": {
-			pronunciations: Pronunciations{"test-1", "test-2"},
+			pronunciations: Pronunciations{{Spelling: "test-1"}, {Spelling: "test-2"}},
 			want:           "/test-1/ (/test-2/)",
 		},
 		"three": {
-			pronunciations: Pronunciations{"test-1", "test-2", "test-3"},
+			pronunciations: Pronunciations{{Spelling: "test-1"}, {Spelling: "test-2"}, {Spelling: "test-3"}},
 			want:           "/test-1/ (/test-2/ /test-3/)",
 		},
+		"dialects": {
+			pronunciations: Pronunciations{{Spelling: "test-1", Dialect: "British English"}, {Spelling: "test-2", Dialect: "American English"}},
+			want:           "/test-1/ (British English), /test-2/ (American English)",
+		},
+		"some dialects": {
+			pronunciations: Pronunciations{{Spelling: "test-1", Dialect: "British English"}, {Spelling: "test-2"}},
+			want:           "/test-1/ (British English), /test-2/",
+		},
 	} {
 		t.Run(testName, func(t *testing.T) {
 			if got := testData.pronunciations.String(); got != testData.want {
@@ -216,11 +300,19 @@ func TestPronunciation_String(t *testing.T) {
 		want          string
 	}{
 		"empty": {
-			pronunciation: Pronunciation(""),
+			pronunciation: Pronunciation{Spelling: ""},
 			want:          "//",
 		},
 		"word": {
-			pronunciation: Pronunciation("test-1"),
+			pronunciation: Pronunciation{Spelling: "test-1"},
+			want:          "/test-1/",
+		},
+		"dialect": {
+			pronunciation: Pronunciation{Spelling: "test-1", Dialect: "British English"},
+			want:          "/test-1/ (British English)",
+		},
+		"notation": {
+			pronunciation: Pronunciation{Spelling: "test-1", Notation: PronunciationNotationRespelling, AudioURL: "https://example.com/test.mp3"},
 			want:          "/test-1/",
 		},
 	} {
@@ -232,6 +324,34 @@ func TestPronunciation_String(t *testing.T) {
 	}
 }
 
+func TestPronunciation_UnmarshalJSON(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		data string
+		want Pronunciation
+	}{
+		"string": {
+			data: `"test-1"`,
+			want: Pronunciation{Spelling: "test-1"},
+		},
+		"object": {
+			data: `{"Spelling": "test-1", "Dialect": "British English", "AudioURL": "https://example.com/test.mp3"}`,
+			want: Pronunciation{Spelling: "test-1", Dialect: "British English", AudioURL: "https://example.com/test.mp3"},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var got Pronunciation
+
+			if err := json.Unmarshal([]byte(testData.data), &got); err != nil {
+				t.Fatalf("Unmarshal returned an error: %v", err)
+			}
+
+			if got != testData.want {
+				t.Errorf("Unmarshal returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
 func TestAttributedText_String(t *testing.T) {
 	for testName, testData := range map[string]struct {
 		attributedText AttributedText
@@ -278,6 +398,17 @@ func TestAttributedText_String(t *testing.T) {
 			},
 			want: "\"test\" - Mr. Testy (WikiTest)",
 		},
+		"text, source, and license": {
+			attributedText: AttributedText{
+				Text: "test",
+
+				Attribution: Attribution{
+					Source:  "WikiTest",
+					License: "CC BY 2.0",
+				},
+			},
+			want: "\"test\" (WikiTest, CC BY 2.0)",
+		},
 	} {
 		t.Run(testName, func(t *testing.T) {
 			if got := testData.attributedText.String(); got != testData.want {
@@ -286,3 +417,43 @@ func TestAttributedText_String(t *testing.T) {
 		})
 	}
 }
+
+func TestDictionaryResults_RedirectedWord(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		results DictionaryResults
+		word    string
+		want    string
+	}{
+		"nil": {
+			results: nil,
+			word:    "running",
+			want:    "",
+		},
+		"match": {
+			results: DictionaryResults{{Word: "running"}},
+			word:    "running",
+			want:    "",
+		},
+		"match ignoring case and diacritics": {
+			results: DictionaryResults{{Word: "Café"}},
+			word:    "cafe",
+			want:    "",
+		},
+		"secondary match": {
+			results: DictionaryResults{{Word: "run"}, {Word: "running"}},
+			word:    "running",
+			want:    "",
+		},
+		"redirected": {
+			results: DictionaryResults{{Word: "run"}},
+			word:    "running",
+			want:    "run",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := testData.results.RedirectedWord(testData.word); got != testData.want {
+				t.Errorf("DictionaryResults.RedirectedWord returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package source
+
+// List of text styles.
+const (
+	// TextStylePlain renders text without any styling
+	TextStylePlain TextStyle = ""
+
+	// TextStyleANSI renders text with ANSI terminal escape sequences
+	TextStyleANSI TextStyle = "ansi"
+
+	// TextStyleMarkdown renders text with Markdown emphasis
+	TextStyleMarkdown TextStyle = "markdown"
+)
+
+// TextStyle defines a style for rendering any formatting (like emphasis)
+// within a source's result text.
+type TextStyle string
+
+// TextStyler defines an interface for a source that supports rendering the
+// formatting of its result text in different styles
+type TextStyler interface {
+	// SetTextStyle sets the style to render the formatting of result text in.
+	SetTextStyle(TextStyle)
+}
@@ -0,0 +1,48 @@
+package tatoeba
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// apiSearchResponse defines the structure of a Tatoeba API search response
+type apiSearchResponse struct {
+	Results []apiSentence `json:"results"`
+}
+
+// apiSentence defines the structure of a Tatoeba API sentence
+type apiSentence struct {
+	ID      int    `json:"id"`
+	Text    string `json:"text"`
+	License string `json:"license"`
+	User    struct {
+		Username string `json:"username"`
+	} `json:"user"`
+}
+
+// toExamples converts the API response to a list of example sentences, in the
+// given language.
+func (r apiSearchResponse) toExamples(language string) []source.AttributedText {
+	examples := make([]source.AttributedText, 0, len(r.Results))
+
+	for _, sentence := range r.Results {
+		text := strings.TrimSpace(sentence.Text)
+		if text == "" {
+			continue
+		}
+
+		examples = append(examples, source.AttributedText{
+			Text: text,
+			Attribution: source.Attribution{
+				Author:   sentence.User.Username,
+				Source:   fmt.Sprintf("%s #%d", Name, sentence.ID),
+				License:  sentence.License,
+				Language: language,
+			},
+		})
+	}
+
+	return examples
+}
@@ -0,0 +1,63 @@
+package tatoeba
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestAPISearchResponse_ToExamples(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		json string
+		want []source.AttributedText
+	}{
+		"empty": {
+			json: `{}`,
+			want: []source.AttributedText{},
+		},
+		"sentences": {
+			json: `{"results":[{"id":1276,"text":" Let's try something. ","lang":"eng","license":"CC BY 2.0 FR","user":{"username":"CK"}},{"id":2,"text":""}]}`,
+			want: []source.AttributedText{
+				{
+					Text: "Let's try something.",
+					Attribution: source.Attribution{
+						Author:   "CK",
+						Source:   "Tatoeba #1276",
+						License:  "CC BY 2.0 FR",
+						Language: "en",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var response apiSearchResponse
+
+			if err := json.Unmarshal([]byte(testData.json), &response); err != nil {
+				t.Fatalf("json.Unmarshal returned an error: %s", err)
+			}
+
+			if got := response.toExamples("en"); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("apiSearchResponse.toExamples returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestExactQuery(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		word string
+		want string
+	}{
+		"word":   {word: "try", want: `"=try"`},
+		"phrase": {word: " ice  cream ", want: `"=ice =cream"`},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := exactQuery(testData.word); got != testData.want {
+				t.Errorf("exactQuery returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Package tatoeba provides example sentences via the Tatoeba API
+package tatoeba
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "Tatoeba"
+
+const (
+	// baseURLString is the base URL for all Tatoeba API interactions
+	baseURLString = "https://tatoeba.org/en/api_v0/"
+
+	searchURLString = baseURLString + "search"
+
+	httpRequestAcceptHeaderName    = "Accept"
+	httpRequestUserAgentHeaderName = "User-Agent"
+
+	httpRequestFromParamName       = "from"
+	httpRequestQueryParamName      = "query"
+	httpRequestOrphansParamName    = "orphans"
+	httpRequestUnapprovedParamName = "unapproved"
+	httpRequestSortParamName       = "sort"
+
+	// userAgent identifies the app to the API
+	userAgent = "define (https://github.com/Rican7/define)"
+
+	jsonMIMEType = "application/json"
+)
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{jsonMIMEType}
+
+// languageCodes maps the ISO 639-1 codes of the languages that are supported
+// to the ISO 639-3 codes that the API uses
+var languageCodes = map[string]string{
+	"de": "deu",
+	"en": "eng",
+	"es": "spa",
+	"fr": "fra",
+	"it": "ita",
+	"ja": "jpn",
+	"nl": "nld",
+	"pt": "por",
+	"ru": "rus",
+	"zh": "cmn",
+}
+
+// API contains a configured HTTP client for Tatoeba API operations
+type API struct {
+	httpClient *http.Client
+}
+
+// New returns a new Tatoeba API client
+func New(httpClient http.Client) *API {
+	return &API{&httpClient}
+}
+
+// Name returns the printable, human-readable name of the source.
+func (a *API) Name() string {
+	return Name
+}
+
+// Examples takes a word and the (ISO 639-1) code of its language, and returns
+// up to a limited number of example sentences that use the exact word, and an
+// error if any occurred.
+func (a *API) Examples(word string, language string, limit uint) ([]source.AttributedText, error) {
+	languageCode, isSupported := languageCodes[strings.ToLower(language)]
+	if !isSupported {
+		return nil, fmt.Errorf("the language %q isn't supported", language)
+	}
+
+	requestURL, err := url.Parse(searchURLString)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURL.RawQuery = url.Values{
+		httpRequestFromParamName:       {languageCode},
+		httpRequestQueryParamName:      {exactQuery(word)},
+		httpRequestOrphansParamName:    {"no"},
+		httpRequestUnapprovedParamName: {"no"},
+		httpRequestSortParamName:       {"random"},
+	}.Encode()
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
+	httpRequest.Header.Set(httpRequestUserAgentHeaderName, userAgent)
+
+	httpResponse, err := a.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); err != nil {
+		return nil, err
+	}
+
+	var response apiSearchResponse
+
+	if err = source.DecodeJSONResponse(httpResponse, &response); err != nil {
+		return nil, err
+	}
+
+	examples := response.toExamples(strings.ToLower(language))
+	if len(examples) < 1 {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	if uint(len(examples)) > limit {
+		examples = examples[:limit]
+	}
+
+	return examples, nil
+}
+
+// exactQuery returns a search query for the exact forms of the words of a
+// word or phrase (like `"=ice =cream"`), rather than any of their stems.
+func exactQuery(word string) string {
+	return strconv.Quote("=" + strings.Join(strings.Fields(word), " ="))
+}
@@ -0,0 +1,33 @@
+package source
+
+import (
+	"fmt"
+)
+
+// ParseWarning defines the structure of a problem encountered when parsing a
+// source's upstream data, such as an unknown or unsupported field that had to
+// be dropped.
+type ParseWarning struct {
+	Path    string // The location of the problem within the upstream data
+	Message string
+}
+
+// String satisfies the fmt.Stringer interface.
+func (w ParseWarning) String() string {
+	if w.Path == "" {
+		return w.Message
+	}
+
+	return fmt.Sprintf("%s: %s", w.Path, w.Message)
+}
+
+// ParseWarnings returns the parse warnings of all of the results, in order.
+func (r DictionaryResults) ParseWarnings() []ParseWarning {
+	var warnings []ParseWarning
+
+	for _, result := range r {
+		warnings = append(warnings, result.ParseWarnings...)
+	}
+
+	return warnings
+}
@@ -2,6 +2,7 @@ package webster
 
 import (
 	"encoding/json"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -32,8 +33,21 @@ const (
 
 	// idSeparator defines the character used to separate data in IDs
 	idSeparator = ':'
+
+	// providerName is the name of the provider of all Webster API data
+	providerName = "Merriam-Webster"
+
+	// wordURLString is the base URL for the public web pages of words
+	wordURLString = "https://www.merriam-webster.com/dictionary/"
 )
 
+// apiSourceNames maps the Webster API source data set identifiers (meta.src)
+// to their published names
+var apiSourceNames = map[string]string{
+	"collegiate": "Merriam-Webster's Collegiate® Dictionary",
+	"learners":   "Merriam-Webster's Learner's Dictionary",
+}
+
 var (
 	// regexpWebsterTokens is a regular exprssion for matching Webster API
 	// text tokens.
@@ -81,6 +95,7 @@ type apiDefinitionResult struct {
 	Fl   string                    `json:"fl"`
 	Ins  []struct {
 		If string `json:"if"`
+		Il string `json:"il"`
 	} `json:"ins"`
 	Def  []apiDefinitionSectionEntry `json:"def"`
 	Uros []struct {
@@ -91,6 +106,10 @@ type apiDefinitionResult struct {
 		Pl string  `json:"pl"`
 		Pt [][]any `json:"pt"`
 	} `json:"syns"`
+	Usages []struct {
+		Pl string  `json:"pl"`
+		Pt [][]any `json:"pt"`
+	} `json:"usages"`
 	Et     [][]string `json:"et"`
 	Date   string     `json:"date"`
 	LdLink struct {
@@ -216,7 +235,7 @@ func apiResponseFromRaw[T apiResponseItem](raw apiRawResponse) apiResponse[T] {
 
 // toResult converts the API response to the results that a source expects to
 // return.
-func (r apiDefinitionResults) toResults() source.DictionaryResults {
+func (r apiDefinitionResults) toResults(style source.TextStyle) source.DictionaryResults {
 	primaryResult := r[0]
 	primaryID := getBaseOfID(primaryResult.Meta.ID)
 	primaryWord := cleanHeadword(primaryResult.Hwi.Hw)
@@ -257,17 +276,26 @@ func (r apiDefinitionResults) toResults() source.DictionaryResults {
 		}
 
 		if sourceResult.Word == "" {
+			sourceResult.ID = id
 			sourceResult.Word = headword
+			sourceResult.Attribution = apiResult.Meta.toAttribution(headword)
 		}
 
 		sourceEntry := source.DictionaryEntry{}
 
+		sourceEntry.ID = apiResult.Meta.ID
+		sourceEntry.UUID = apiResult.Meta.UUID
+		sourceEntry.Offensive = apiResult.Meta.Offensive
+
 		sourceEntry.Word = headword
 		sourceEntry.LexicalCategory = apiResult.Fl
+		sourceEntry.PartOfSpeech = source.ParsePartOfSpeech(apiResult.Fl)
+		sourceEntry.Hyphenation = hyphenateHeadword(apiResult.Hwi.Hw)
 
+		sourceEntry.PronunciationNotation = source.PronunciationNotationRespelling
 		sourceEntry.Pronunciations = make([]source.Pronunciation, 0, len(apiResult.Hwi.Prs))
 		for _, pronunciation := range apiResult.Hwi.Prs {
-			sourceEntry.Pronunciations = append(sourceEntry.Pronunciations, source.Pronunciation(pronunciation.Mw))
+			sourceEntry.Pronunciations = append(sourceEntry.Pronunciations, source.Pronunciation{Spelling: pronunciation.Mw, Notation: source.PronunciationNotationRespelling})
 		}
 
 		for _, etymology := range apiResult.Et {
@@ -277,16 +305,45 @@ func (r apiDefinitionResults) toResults() source.DictionaryResults {
 				continue
 			}
 
-			etymologyText := cleanTextOfTokens(etymology[1])
+			etymologyText := renderText(etymology[1], style)
 
 			sourceEntry.Etymologies = append(sourceEntry.Etymologies, etymologyText)
 		}
 
+		for _, inflection := range apiResult.Ins {
+			// Webster API inflections mark their syllables with asterisks
+			// See https://www.dictionaryapi.com/products/json#sec-2.ins
+			form := strings.ReplaceAll(inflection.If, "*", "")
+			if form == "" {
+				continue
+			}
+
+			sourceEntry.Inflections = append(sourceEntry.Inflections, source.InflectedForm{
+				Form:  form,
+				Label: inflection.Il,
+			})
+		}
+
 		for _, def := range apiResult.Def {
-			sourceEntry.Senses = append(sourceEntry.Senses, def.Sseq.toSenses()...)
+			sourceEntry.Senses = append(sourceEntry.Senses, def.Sseq.toSenses(style)...)
+		}
+
+		for _, usage := range apiResult.Usages {
+			// Webster API usage paragraphs are returned in prefixed arrays.
+			// See https://www.dictionaryapi.com/products/json#sec-2.usages
+			for _, paragraph := range usage.Pt {
+				if len(paragraph) < 2 || paragraph[0] != arrayDataTagText {
+					continue
+				}
+
+				if text, ok := paragraph[1].(string); ok {
+					sourceEntry.EntryNotes = append(sourceEntry.EntryNotes, renderText(text, style))
+				}
+			}
 		}
 
 		sourceResult.Entries = append(sourceResult.Entries, sourceEntry)
+		sourceResult.ParseWarnings = append(sourceResult.ParseWarnings, apiResult.parseWarnings()...)
 	}
 
 	// Add the last result
@@ -295,6 +352,20 @@ func (r apiDefinitionResults) toResults() source.DictionaryResults {
 	return sourceResults
 }
 
+// toAttribution converts the API definition meta to a source.ResultAttribution
+func (m apiDefinitionMeta) toAttribution(headword string) source.ResultAttribution {
+	provider := providerName
+
+	if sourceName, ok := apiSourceNames[m.Src]; ok {
+		provider = sourceName
+	}
+
+	return source.ResultAttribution{
+		Provider:   provider,
+		SourceURLs: []string{wordURLString + url.PathEscape(headword)},
+	}
+}
+
 // toResult converts the API response to the results that a source expects to
 // return.
 func (r apiSearchResults) toResults() source.SearchResults {
@@ -309,8 +380,9 @@ func (r apiSearchResults) toResults() source.SearchResults {
 	return sourceResults
 }
 
-// toSenses converts the API sense sequence to a list of source.Sense
-func (s apiSenseSequence) toSenses() []source.Sense {
+// toSenses converts the API sense sequence to a list of source.Sense, rendering
+// the text in a given style
+func (s apiSenseSequence) toSenses(style source.TextStyle) []source.Sense {
 	senses := make([]source.Sense, 0)
 
 	for _, apiSense := range s {
@@ -338,7 +410,7 @@ func (s apiSenseSequence) toSenses() []source.Sense {
 
 			senseNumber := parseSenseNumber(senseData[objectDataTagSenseNumber])
 
-			sourceSense := senseData.toSense()
+			sourceSense := senseData.toSense(style)
 
 			if lastSenseNumber == nil || (senseNumber != nil && lastSenseNumber.number < senseNumber.number) {
 				// The sense is a new sense
@@ -356,10 +428,12 @@ func (s apiSenseSequence) toSenses() []source.Sense {
 	return senses
 }
 
-// toSense converts the API sense data to a source.Sense
-func (d apiSenseData) toSense() source.Sense {
+// toSense converts the API sense data to a source.Sense, rendering the text in
+// a given style
+func (d apiSenseData) toSense(style source.TextStyle) source.Sense {
 	definitions := make([]string, 0)
 	examples := make([]source.AttributedText, 0)
+	var seeAlso []source.CrossReference
 
 	senseDefinitions := d[objectDataTagDefiningText].([]any)
 
@@ -374,16 +448,19 @@ func (d apiSenseData) toSense() source.Sense {
 
 		switch definition[0] {
 		case arrayDataTagText:
-			definitionText := cleanTextOfTokens(definition[1].(string))
+			text, references := extractCrossReferences(definition[1].(string))
+			seeAlso = append(seeAlso, references...)
 
-			definitions = append(definitions, definitionText)
+			if definitionText := renderText(text, style); definitionText != "" {
+				definitions = append(definitions, definitionText)
+			}
 		case arrayDataTagVerbalIllustrations:
 			exampleTextObjects := definition[1].([]any)
 
 			for _, exampleTextObject := range exampleTextObjects {
 				example := apiExample(exampleTextObject.(map[string]any))
 
-				examples = append(examples, example.toAttributedText())
+				examples = append(examples, example.toAttributedText(style))
 			}
 		}
 	}
@@ -396,15 +473,18 @@ func (d apiSenseData) toSense() source.Sense {
 	}
 
 	return source.Sense{
+		Label:       parseSenseLabel(d[objectDataTagSenseNumber]),
 		Definitions: definitions,
 		Categories:  categories,
 		Examples:    examples,
+		SeeAlso:     seeAlso,
 	}
 }
 
-// toAttributedText converts the API example to a source.AttributedText
-func (e apiExample) toAttributedText() source.AttributedText {
-	exampleText := cleanTextOfTokens(e[objectDataTagText].(string))
+// toAttributedText converts the API example to a source.AttributedText,
+// rendering the text in a given style
+func (e apiExample) toAttributedText(style source.TextStyle) source.AttributedText {
+	exampleText := renderText(e[objectDataTagText].(string), style)
 
 	var author, src string
 
@@ -436,6 +516,16 @@ func cleanHeadword(headword string) string {
 	return strings.ReplaceAll(headword, string(headwordSyllableSeparator), "")
 }
 
+// hyphenateHeadword splits a headword at its syllable separators, which mark
+// the points at which it may be hyphenated, or returns nil if it has none.
+func hyphenateHeadword(headword string) []string {
+	if !strings.ContainsRune(headword, headwordSyllableSeparator) || strings.Contains(headword, " ") {
+		return nil
+	}
+
+	return strings.Split(headword, string(headwordSyllableSeparator))
+}
+
 func getBaseOfID(id string) string {
 	return strings.Split(id, string(idSeparator))[0]
 }
@@ -444,6 +534,14 @@ func cleanTextOfTokens(text string) string {
 	return regexpWebsterTokens.ReplaceAllString(text, "$1")
 }
 
+// parseSenseLabel parses a raw sense number into a compact label for the sense
+// (like "1a" for "1 a"), or an empty string if there's no sense number.
+func parseSenseLabel(rawSenseNumber any) string {
+	senseNumber, _ := rawSenseNumber.(string)
+
+	return strings.Join(strings.Fields(senseNumber), "")
+}
+
 func parseSenseNumber(rawSenseNumber any) *apiSenseNumber {
 	if rawSenseNumber == nil {
 		return nil
@@ -1,8 +1,11 @@
 package webster
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+
+	"github.com/Rican7/define/source"
 )
 
 func TestCleanHeadword(t *testing.T) {
@@ -35,6 +38,36 @@ func TestCleanHeadword(t *testing.T) {
 	}
 }
 
+func TestHyphenateHeadword(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text string
+		want []string
+	}{
+		"no marks": {
+			text: "tree",
+			want: nil,
+		},
+		"single mark": {
+			text: "re*fuse",
+			want: []string{"re", "fuse"},
+		},
+		"multiple marks": {
+			text: "vo*lu*mi*nous",
+			want: []string{"vo", "lu", "mi", "nous"},
+		},
+		"phrase": {
+			text: "tree ear*ly",
+			want: nil,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := hyphenateHeadword(testData.text); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("hyphenateHeadword returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
 func TestCleanTextOfTokens(t *testing.T) {
 	for testName, testData := range map[string]struct {
 		text string
@@ -126,3 +159,156 @@ func TestParseSenseNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractCrossReferences(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text           string
+		wantText       string
+		wantReferences []source.CrossReference
+	}{
+		"no references": {
+			text:     "{bc}a test",
+			wantText: "{bc}a test",
+		},
+		"synonymous": {
+			text:     "{bc}{sx|trial||} {bc}{sx|ex*am|exam:1|2}",
+			wantText: "{bc}{sx|trial||} {bc}{sx|ex*am|exam:1|2}",
+			wantReferences: []source.CrossReference{
+				{Word: "trial"},
+				{Word: "exam", EntryID: "exam:1", SenseNumber: "2"},
+			},
+		},
+		"directional": {
+			text:     "{bc}a test {dx}see also {dxt|quiz||} and {dxt|exam|exam:2|1a}{/dx}",
+			wantText: "{bc}a test",
+			wantReferences: []source.CrossReference{
+				{Word: "quiz"},
+				{Word: "exam", EntryID: "exam:2", SenseNumber: "1a"},
+			},
+		},
+		"directional definition": {
+			text:     "{bc}a unit {dx_def}see {dxt|metric system||}{/dx_def} of length",
+			wantText: "{bc}a unit of length",
+			wantReferences: []source.CrossReference{
+				{Word: "metric system"},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			gotText, gotReferences := extractCrossReferences(testData.text)
+
+			if gotText != testData.wantText {
+				t.Errorf("extractCrossReferences returned wrong text. Got %#v. Want %#v.", gotText, testData.wantText)
+			}
+
+			if !reflect.DeepEqual(gotReferences, testData.wantReferences) {
+				t.Errorf("extractCrossReferences returned wrong references. Got %#v. Want %#v.", gotReferences, testData.wantReferences)
+			}
+		})
+	}
+}
+
+func TestParseSenseLabel(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		senseNumber any
+		want        string
+	}{
+		"nil": {
+			senseNumber: nil,
+			want:        "",
+		},
+		"number": {
+			senseNumber: "1",
+			want:        "1",
+		},
+		"number and letter": {
+			senseNumber: "1 a",
+			want:        "1a",
+		},
+		"letter and sub-number": {
+			senseNumber: "b (2)",
+			want:        "b(2)",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := parseSenseLabel(testData.senseNumber); got != testData.want {
+				t.Errorf("parseSenseLabel returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestAPIDefinitionResults_ToResults_EntryNotes(t *testing.T) {
+	var results apiDefinitionResults
+
+	data := `[{
+		"meta": {"id": "test:1"},
+		"hwi": {"hw": "test"},
+		"fl": "noun",
+		"usages": [{"pl": "usage", "pt": [["text", "{it}Test{/it} is often used loosely."], ["vis", [{"t": "an example"}]]]}]
+	}]`
+
+	if err := json.Unmarshal([]byte(data), &results); err != nil {
+		t.Fatalf("json.Unmarshal returned an error: %s", err)
+	}
+
+	got := results.toResults(source.TextStyleMarkdown)[0].Entries[0].EntryNotes
+
+	if want := []string{"_Test_ is often used loosely."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("apiDefinitionResults.toResults returned wrong entry notes. Got %#v. Want %#v.", got, want)
+	}
+}
+
+func TestAPIDefinitionResults_ToResults_Inflections(t *testing.T) {
+	var results apiDefinitionResults
+
+	data := `[{
+		"meta": {"id": "testimony"},
+		"hwi": {"hw": "tes*ti*mo*ny"},
+		"fl": "noun",
+		"ins": [{"il": "plural", "if": "tes*ti*mo*nies", "ifc": "-nies"}]
+	}]`
+
+	if err := json.Unmarshal([]byte(data), &results); err != nil {
+		t.Fatalf("json.Unmarshal returned an error: %s", err)
+	}
+
+	got := results.toResults(source.TextStylePlain)[0].Entries[0].Inflections
+
+	if want := []source.InflectedForm{{Form: "testimonies", Label: "plural"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("apiDefinitionResults.toResults returned wrong inflections. Got %#v. Want %#v.", got, want)
+	}
+}
+
+func TestAPIDefinitionResults_ToResults_IDs(t *testing.T) {
+	var results apiDefinitionResults
+
+	data := `[
+		{"meta": {"id": "test:1", "uuid": "3d2a5a3e-8a0f-4a8f-9d4e-0c3b7c1e1f01"}, "hwi": {"hw": "test"}, "fl": "noun"},
+		{"meta": {"id": "test:2", "uuid": "3d2a5a3e-8a0f-4a8f-9d4e-0c3b7c1e1f02"}, "hwi": {"hw": "test"}, "fl": "verb"}
+	]`
+
+	if err := json.Unmarshal([]byte(data), &results); err != nil {
+		t.Fatalf("json.Unmarshal returned an error: %s", err)
+	}
+
+	got := results.toResults(source.TextStylePlain)
+
+	if want := "test"; got[0].ID != want {
+		t.Errorf("apiDefinitionResults.toResults returned wrong result ID. Got %#v. Want %#v.", got[0].ID, want)
+	}
+
+	var gotIDs, gotUUIDs []string
+	for _, entry := range got[0].Entries {
+		gotIDs = append(gotIDs, entry.ID)
+		gotUUIDs = append(gotUUIDs, entry.UUID)
+	}
+
+	if want := []string{"test:1", "test:2"}; !reflect.DeepEqual(gotIDs, want) {
+		t.Errorf("apiDefinitionResults.toResults returned wrong entry IDs. Got %#v. Want %#v.", gotIDs, want)
+	}
+
+	if want := []string{"3d2a5a3e-8a0f-4a8f-9d4e-0c3b7c1e1f01", "3d2a5a3e-8a0f-4a8f-9d4e-0c3b7c1e1f02"}; !reflect.DeepEqual(gotUUIDs, want) {
+		t.Errorf("apiDefinitionResults.toResults returned wrong entry UUIDs. Got %#v. Want %#v.", gotUUIDs, want)
+	}
+}
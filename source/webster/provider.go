@@ -6,52 +6,126 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
-
-	flag "github.com/ogier/pflag"
 
+	"github.com/Rican7/define/internal/flag"
+	"github.com/Rican7/define/internal/keypool"
 	"github.com/Rican7/define/registry"
 	"github.com/Rican7/define/source"
 )
 
 // RequiredConfigError represents an error when a required configuration key is
 // missing or invalid.
-type RequiredConfigError struct {
-	Key string
+type RequiredConfigError = registry.RequiredConfigError
+
+// reference defines how a source of one of the Webster API's dictionary
+// references is registered and provided.
+type reference struct {
+	name          string
+	jsonKey       string
+	appKeyFlag    string
+	appKeyEnvName string
+	aliases       []string
+	newSource     func(httpClient http.Client, appKey string) source.Source
 }
 
 type config struct {
 	AppKey string
+
+	// KeyPool is a list of additional app keys, which are rotated to in order
+	// when the quota of the current one is exhausted
+	KeyPool []string
+
+	reference *reference
+}
+
+type provider struct {
+	reference *reference
 }
 
-type provider struct{}
+// specialtyProvider is a provider of a source of a specialty reference, like
+// the medical dictionary.
+type specialtyProvider struct {
+	provider
+
+	domains []string
+}
 
 // JSONKey defines the JSON key used for the provider
 const JSONKey = "MerriamWebsterDictionary"
 
+// MedicalJSONKey defines the JSON key used for the medical dictionary provider
+const MedicalJSONKey = "MerriamWebsterMedicalDictionary"
+
+// LearnersJSONKey defines the JSON key used for the learner's dictionary
+// provider
+const LearnersJSONKey = "MerriamWebsterLearnersDictionary"
+
+var collegiateReference = reference{
+	name:          Name,
+	jsonKey:       JSONKey,
+	appKeyFlag:    "merriam-webster-dictionary-app-key",
+	appKeyEnvName: "MERRIAM_WEBSTER_DICTIONARY_APP_KEY",
+	aliases:       []string{"webster", "mw"},
+	newSource:     New,
+}
+
+var medicalReference = reference{
+	name:          MedicalName,
+	jsonKey:       MedicalJSONKey,
+	appKeyFlag:    "merriam-webster-medical-dictionary-app-key",
+	appKeyEnvName: "MERRIAM_WEBSTER_MEDICAL_DICTIONARY_APP_KEY",
+	aliases:       []string{"webster-medical", "mw-medical"},
+	newSource:     NewMedical,
+}
+
+var learnersReference = reference{
+	name:          LearnersName,
+	jsonKey:       LearnersJSONKey,
+	appKeyFlag:    "merriam-webster-learners-dictionary-app-key",
+	appKeyEnvName: "MERRIAM_WEBSTER_LEARNERS_DICTIONARY_APP_KEY",
+	aliases:       []string{"webster-learners", "mw-learners"},
+	newSource:     NewLearners,
+}
+
+// Ensure our types satisfy the registry's provider contract at compile-time
+var (
+	_ registry.AliasedProvider      = (*provider)(nil)
+	_ registry.SpecialtyProvider    = (*specialtyProvider)(nil)
+	_ registry.DynamicConfiguration = (*config)(nil)
+)
+
 func init() {
-	registry.Register(registry.RegisterFunc(register))
+	registry.Register(registry.RegisterFunc(registerCollegiate))
+	registry.Register(registry.RegisterFunc(registerMedical))
+	registry.Register(registry.RegisterFunc(registerLearners))
+}
+
+func registerCollegiate(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{&collegiateReference}, initConfig(flags, &collegiateReference)
+}
+
+func registerMedical(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &specialtyProvider{provider{&medicalReference}, []string{"medical"}}, initConfig(flags, &medicalReference)
 }
 
-func register(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
-	return &provider{}, initConfig(flags)
+func registerLearners(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{&learnersReference}, initConfig(flags, &learnersReference)
 }
 
-func initConfig(flags *flag.FlagSet) *config {
-	conf := &config{}
+func initConfig(flags *flag.FlagSet, ref *reference) *config {
+	conf := &config{reference: ref}
 
 	// Define our flags
-	flags.StringVar(&conf.AppKey, "merriam-webster-dictionary-app-key", "", fmt.Sprintf("The app key for the %s", Name))
+	registry.DefineStringFlags(
+		flags,
+		registry.StringFlag{Name: ref.appKeyFlag, Usage: fmt.Sprintf("The app key for the %s", ref.name), Value: &conf.AppKey},
+	)
 
 	return conf
 }
 
-func (e *RequiredConfigError) Error() string {
-	return fmt.Sprintf("required configuration key %q is missing", e.Key)
-}
-
 func (c *config) JSONKey() string {
-	return JSONKey
+	return c.reference.jsonKey
 }
 
 // UnmarshalJSON defines how the configuration should be JSON unmarshalled.
@@ -66,29 +140,76 @@ func (c *config) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if c.AppKey == "" {
-		c.AppKey = copy.AppKey
+	registry.FillEmpty(&c.AppKey, copy.AppKey)
+
+	if len(c.KeyPool) < 1 {
+		c.KeyPool = copy.KeyPool
 	}
 
 	return nil
 }
 
-func (c *config) Finalize() {
-	if c.AppKey == "" {
-		c.AppKey = os.Getenv("MERRIAM_WEBSTER_DICTIONARY_APP_KEY")
+// LoadEnv fills in any values that weren't passed as flags from environment
+// variables.
+func (c *config) LoadEnv() {
+	registry.FillEmptyFromEnv(&c.AppKey, c.reference.appKeyEnvName)
+}
+
+// signers returns the signers of requests with each of the configured app
+// keys, starting with the primary one, or an error if any of the key pool's
+// keys are empty.
+func (c *config) signers() ([]keypool.Signer, error) {
+	signers := []keypool.Signer{signWith(c.AppKey)}
+
+	for i, appKey := range c.KeyPool {
+		if appKey == "" {
+			return nil, fmt.Errorf("the app key #%d of the key pool is empty", i+1)
+		}
+
+		signers = append(signers, signWith(appKey))
+	}
+
+	return signers, nil
+}
+
+// signWith returns a signer of requests with the given app key.
+func signWith(appKey string) keypool.Signer {
+	return func(request *http.Request) {
+		queryParams := request.URL.Query()
+		queryParams.Set(httpRequestKeyQueryParamName, appKey)
+		request.URL.RawQuery = queryParams.Encode()
 	}
 }
 
 func (p *provider) Name() string {
-	return Name
+	return p.reference.name
+}
+
+func (p *provider) Aliases() []string {
+	return p.reference.aliases
+}
+
+func (p *specialtyProvider) Domains() []string {
+	return p.domains
 }
 
 func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
 	config := conf.(*config)
 
 	if config.AppKey == "" {
-		return nil, &RequiredConfigError{Key: "AppKey"}
+		return nil, &RequiredConfigError{Key: "AppKey", Flag: p.reference.appKeyFlag, EnvName: p.reference.appKeyEnvName, JSONKey: p.reference.jsonKey}
+	}
+
+	httpClient := registry.HTTPClient()
+
+	if len(config.KeyPool) > 0 {
+		signers, err := config.signers()
+		if err != nil {
+			return nil, err
+		}
+
+		httpClient.Transport = keypool.NewTransport(httpClient.Transport, signers...)
 	}
 
-	return New(http.Client{}, config.AppKey), nil
+	return p.reference.newSource(httpClient, config.AppKey), nil
 }
@@ -0,0 +1,71 @@
+package webster
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Rican7/define/internal/flag"
+)
+
+func TestConfigPrecedence(t *testing.T) {
+	for _, ref := range []*reference{&collegiateReference, &medicalReference, &learnersReference} {
+		t.Run(ref.jsonKey, func(t *testing.T) {
+			testConfigPrecedence(t, ref)
+		})
+	}
+}
+
+func testConfigPrecedence(t *testing.T, ref *reference) {
+	for testName, testData := range map[string]struct {
+		args     []string
+		fileJSON string
+		env      string
+		want     string
+	}{
+		"none": {
+			fileJSON: `{}`,
+			want:     "",
+		},
+		"env only": {
+			fileJSON: `{}`,
+			env:      "env",
+			want:     "env",
+		},
+		"file only": {
+			fileJSON: `{"AppKey":"file"}`,
+			want:     "file",
+		},
+		"env over file": {
+			fileJSON: `{"AppKey":"file"}`,
+			env:      "env",
+			want:     "env",
+		},
+		"flag over env and file": {
+			args:     []string{fmt.Sprintf("--%s=flag", ref.appKeyFlag)},
+			fileJSON: `{"AppKey":"file"}`,
+			env:      "env",
+			want:     "flag",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			t.Setenv(ref.appKeyEnvName, testData.env)
+
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf := initConfig(flags, ref)
+
+			if err := flags.Parse(testData.args); err != nil {
+				t.Fatalf("flags.Parse returned an error: %s", err)
+			}
+
+			conf.LoadEnv()
+
+			if err := conf.UnmarshalJSON([]byte(testData.fileJSON)); err != nil {
+				t.Fatalf("UnmarshalJSON returned an error: %s", err)
+			}
+
+			if conf.AppKey != testData.want {
+				t.Errorf("config resolved wrong value. Got %#v. Want %#v.", conf.AppKey, testData.want)
+			}
+		})
+	}
+}
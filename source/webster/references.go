@@ -0,0 +1,50 @@
+package webster
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+var (
+	// regexpDirectionalCrossReferences is a regular expression for matching
+	// Webster API directional cross-reference groups (like "see also"), which
+	// are removed from the text after their references are collected.
+	//
+	// See https://www.dictionaryapi.com/products/json#sec-2.xrefregtokens
+	regexpDirectionalCrossReferences = regexp.MustCompile(`\s*{(dx|dx_def)}.*?{/(?:dx|dx_def)}`)
+
+	// regexpCrossReferenceTokens is a regular expression for matching Webster
+	// API cross-reference tokens, capturing the referenced word, entry ID, and
+	// sense number.
+	regexpCrossReferenceTokens = regexp.MustCompile(`{(?:sx|dxt)\|([^|}]*)\|([^|}]*)\|([^|}]*)}`)
+)
+
+// extractCrossReferences takes a Webster API text and returns the text with
+// any directional cross-reference groups removed, along with the references
+// of all of the cross-reference tokens in the text.
+//
+// Synonymous cross-references (like "{sx|test||}") are kept in the text, as
+// they're part of the definition itself.
+func extractCrossReferences(text string) (string, []source.CrossReference) {
+	var references []source.CrossReference
+
+	for _, match := range regexpCrossReferenceTokens.FindAllStringSubmatch(text, -1) {
+		word := cleanHeadword(match[1])
+
+		if word == "" {
+			continue
+		}
+
+		references = append(references, source.CrossReference{
+			Word:        word,
+			EntryID:     match[2],
+			SenseNumber: match[3],
+		})
+	}
+
+	text = regexpDirectionalCrossReferences.ReplaceAllString(text, "")
+
+	return strings.TrimSpace(text), references
+}
@@ -0,0 +1,133 @@
+package webster
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// List of Webster API formatting token names.
+//
+// See https://www.dictionaryapi.com/products/json#sec-2.fmttokens
+const (
+	tokenBold         = "b"
+	tokenItalic       = "it"
+	tokenSubscript    = "inf"
+	tokenSuperscript  = "sup"
+	tokenSmallCaps    = "sc"
+	tokenQuotedWord   = "qword"
+	tokenWordInIllus  = "wi"
+	tokenPhrase       = "phrase"
+	tokenParaHeadword = "parahw"
+)
+
+// emphasis defines a kind of text emphasis.
+type emphasis uint
+
+// List of kinds of emphasis.
+const (
+	emphasisBold emphasis = iota
+	emphasisItalic
+)
+
+var (
+	// emphasisTokens maps the formatting tokens that emphasize text to their
+	// kind of emphasis.
+	emphasisTokens = map[string]emphasis{
+		tokenBold:         emphasisBold,
+		tokenPhrase:       emphasisBold,
+		tokenParaHeadword: emphasisBold,
+		tokenItalic:       emphasisItalic,
+		tokenQuotedWord:   emphasisItalic,
+		tokenWordInIllus:  emphasisItalic,
+	}
+
+	// regexpFormattingTokens is a regular expression for matching a pair of
+	// opening and closing formatting tokens, and the text between them.
+	regexpFormattingTokens = regexp.MustCompile(`{(b|it|inf|sup|sc|qword|wi|phrase|parahw)}(.*?){/(b|it|inf|sup|sc|qword|wi|phrase|parahw)}`)
+
+	// emphasisStyles maps text styles to the opening and closing markers of
+	// each kind of emphasis.
+	emphasisStyles = map[source.TextStyle]map[emphasis][2]string{
+		source.TextStyleANSI: {
+			emphasisBold:   {"\x1b[1m", "\x1b[22m"},
+			emphasisItalic: {"\x1b[3m", "\x1b[23m"},
+		},
+		source.TextStyleMarkdown: {
+			emphasisBold:   {"**", "**"},
+			emphasisItalic: {"_", "_"},
+		},
+	}
+
+	// subscriptRunes maps runes to their Unicode subscript forms.
+	subscriptRunes = map[rune]rune{
+		'0': '₀', '1': '₁', '2': '₂', '3': '₃', '4': '₄', '5': '₅', '6': '₆',
+		'7': '₇', '8': '₈', '9': '₉', '+': '₊', '-': '₋', '=': '₌', '(': '₍',
+		')': '₎', 'a': 'ₐ', 'e': 'ₑ', 'h': 'ₕ', 'i': 'ᵢ', 'j': 'ⱼ', 'k': 'ₖ',
+		'l': 'ₗ', 'm': 'ₘ', 'n': 'ₙ', 'o': 'ₒ', 'p': 'ₚ', 'r': 'ᵣ', 's': 'ₛ',
+		't': 'ₜ', 'u': 'ᵤ', 'v': 'ᵥ', 'x': 'ₓ',
+	}
+
+	// superscriptRunes maps runes to their Unicode superscript forms.
+	superscriptRunes = map[rune]rune{
+		'0': '⁰', '1': '¹', '2': '²', '3': '³', '4': '⁴', '5': '⁵', '6': '⁶',
+		'7': '⁷', '8': '⁸', '9': '⁹', '+': '⁺', '-': '⁻', '=': '⁼', '(': '⁽',
+		')': '⁾', 'a': 'ᵃ', 'b': 'ᵇ', 'c': 'ᶜ', 'd': 'ᵈ', 'e': 'ᵉ', 'f': 'ᶠ',
+		'g': 'ᵍ', 'h': 'ʰ', 'i': 'ⁱ', 'j': 'ʲ', 'k': 'ᵏ', 'l': 'ˡ', 'm': 'ᵐ',
+		'n': 'ⁿ', 'o': 'ᵒ', 'p': 'ᵖ', 'r': 'ʳ', 's': 'ˢ', 't': 'ᵗ', 'u': 'ᵘ',
+		'v': 'ᵛ', 'w': 'ʷ', 'x': 'ˣ', 'y': 'ʸ', 'z': 'ᶻ',
+	}
+)
+
+// renderText renders the tokens of a Webster API text in a given style.
+//
+// Formatting tokens are converted to the style's emphasis (if any), with
+// subscripts and superscripts converted to their Unicode forms where possible.
+// All other tokens are cleaned from the text, like with cleanTextOfTokens.
+func renderText(text string, style source.TextStyle) string {
+	text = regexpFormattingTokens.ReplaceAllStringFunc(text, func(match string) string {
+		parts := regexpFormattingTokens.FindStringSubmatch(match)
+		name, contents, closingName := parts[1], parts[2], parts[3]
+
+		if name != closingName {
+			return match
+		}
+
+		switch name {
+		case tokenSubscript:
+			return toScript(contents, subscriptRunes)
+		case tokenSuperscript:
+			return toScript(contents, superscriptRunes)
+		case tokenSmallCaps:
+			return contents
+		}
+
+		markers, hasMarkers := emphasisStyles[style][emphasisTokens[name]]
+		if !hasMarkers || contents == "" {
+			return contents
+		}
+
+		return markers[0] + contents + markers[1]
+	})
+
+	return cleanTextOfTokens(text)
+}
+
+// toScript converts a text to its Unicode subscript or superscript form, using
+// a given mapping. If any rune of the text can't be converted, the text is
+// returned unchanged.
+func toScript(text string, mapping map[rune]rune) string {
+	var builder strings.Builder
+
+	for _, r := range text {
+		scripted, ok := mapping[r]
+		if !ok {
+			return text
+		}
+
+		builder.WriteRune(scripted)
+	}
+
+	return builder.String()
+}
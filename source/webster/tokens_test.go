@@ -0,0 +1,62 @@
+package webster
+
+import (
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestRenderText(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		text  string
+		style source.TextStyle
+		want  string
+	}{
+		"plain": {
+			text:  "{bc}a {it}test{/it} of {b}skill{/b}",
+			style: source.TextStylePlain,
+			want:  "a test of skill",
+		},
+		"ansi": {
+			text:  "{bc}a {it}test{/it} of {b}skill{/b}",
+			style: source.TextStyleANSI,
+			want:  "a \x1b[3mtest\x1b[23m of \x1b[1mskill\x1b[22m",
+		},
+		"markdown": {
+			text:  "{bc}a {qword}test{/qword} of {phrase}skill{/phrase}",
+			style: source.TextStyleMarkdown,
+			want:  "a _test_ of **skill**",
+		},
+		"subscripts and superscripts": {
+			text:  "H{inf}2{/inf}O and x{sup}2{/sup}",
+			style: source.TextStylePlain,
+			want:  "H₂O and x²",
+		},
+		"unconvertible superscript": {
+			text:  "the 1{sup}st{/sup}",
+			style: source.TextStylePlain,
+			want:  "the 1ˢᵗ",
+		},
+		"unconvertible subscript": {
+			text:  "C{inf}q{/inf}",
+			style: source.TextStylePlain,
+			want:  "Cq",
+		},
+		"small caps": {
+			text:  "see {sc}test{/sc}",
+			style: source.TextStyleANSI,
+			want:  "see test",
+		},
+		"other tokens": {
+			text:  "{bc}see {sx|test||} or {a_link|trial}",
+			style: source.TextStyleANSI,
+			want:  "see test or trial",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := renderText(testData.text, testData.style); got != testData.want {
+				t.Errorf("renderText returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
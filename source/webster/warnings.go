@@ -0,0 +1,168 @@
+package webster
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/Rican7/define/source"
+)
+
+var (
+	// regexpWebsterTokenNames is a regular expression for matching the names
+	// of Webster API text tokens (ignoring any closing slash).
+	regexpWebsterTokenNames = regexp.MustCompile(`{/?([a-z_]+)[|}]`)
+
+	// knownTokenNames is the set of documented Webster API text token names.
+	//
+	// See https://www.dictionaryapi.com/products/json#sec-2.tokens
+	knownTokenNames = map[string]bool{
+		"b": true, "bc": true, "inf": true, "it": true, "ldquo": true,
+		"p_br": true, "rdquo": true, "sc": true, "sup": true, "gloss": true,
+		"parahw": true, "phrase": true, "qword": true, "wi": true, "dx": true,
+		"dx_def": true, "dx_ety": true, "ma": true, "a_link": true,
+		"d_link": true, "i_link": true, "et_link": true, "mat": true,
+		"sx": true, "dxt": true, "ds": true,
+	}
+
+	// parsedSenseTags is the set of sense sequence element tags that are parsed.
+	parsedSenseTags = map[string]bool{
+		arrayDataTagSense:             true,
+		arrayDataTagBindingSubstitute: true,
+	}
+
+	// parsedDefiningTextTags is the set of defining text element tags that are
+	// parsed.
+	parsedDefiningTextTags = map[string]bool{
+		arrayDataTagText:                true,
+		arrayDataTagVerbalIllustrations: true,
+	}
+)
+
+// parseWarningCollector collects the parse warnings of an API definition
+// result.
+type parseWarningCollector struct {
+	id       string
+	warnings []source.ParseWarning
+}
+
+// parseWarnings returns the problems encountered when parsing the API
+// definition result, such as missing sections, and data that isn't parsed
+// (like unknown tokens and unsupported sense elements) and is dropped.
+func (r apiDefinitionResult) parseWarnings() []source.ParseWarning {
+	collector := &parseWarningCollector{id: r.Meta.ID}
+
+	if r.Hwi.Hw == "" {
+		collector.warn("hwi.hw", "missing headword")
+	}
+
+	if len(r.Def) < 1 {
+		collector.warn("def", "missing definition section")
+	}
+
+	for i, def := range r.Def {
+		for j, apiSense := range def.Sseq {
+			for k, apiSenseContainer := range apiSense {
+				path := fmt.Sprintf("def[%d].sseq[%d][%d]", i, j, k)
+
+				if len(apiSenseContainer) < 2 {
+					collector.warn(path, "malformed sense element")
+					continue
+				}
+
+				tag, _ := apiSenseContainer[0].(string)
+
+				if !parsedSenseTags[tag] {
+					collector.warn(path, "dropped unsupported sense element %q", tag)
+					continue
+				}
+
+				collector.checkSenseData(path, apiSenseContainer[1])
+			}
+		}
+	}
+
+	for i, etymology := range r.Et {
+		path := fmt.Sprintf("et[%d]", i)
+
+		if len(etymology) < 2 || etymology[0] != arrayDataTagText {
+			collector.warn(path, "dropped unsupported etymology element")
+			continue
+		}
+
+		collector.checkTokens(path, etymology[1])
+	}
+
+	return collector.warnings
+}
+
+// warn adds a parse warning at a given path.
+func (c *parseWarningCollector) warn(path string, format string, args ...any) {
+	c.warnings = append(c.warnings, source.ParseWarning{
+		Path:    fmt.Sprintf("%q %s", c.id, path),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkSenseData checks the API sense data at a given path.
+func (c *parseWarningCollector) checkSenseData(path string, rawSenseData any) {
+	senseData, _ := rawSenseData.(map[string]any)
+
+	// Binding substitutes wrap their sense data
+	if nested, ok := senseData[objectDataTagSense].(map[string]any); ok {
+		senseData = nested
+	}
+
+	definingTexts, ok := senseData[objectDataTagDefiningText].([]any)
+	if !ok {
+		c.warn(path, "missing defining text")
+		return
+	}
+
+	for i, rawDefiningText := range definingTexts {
+		definingTextPath := fmt.Sprintf("%s.dt[%d]", path, i)
+
+		definingText, _ := rawDefiningText.([]any)
+		if len(definingText) < 2 {
+			c.warn(definingTextPath, "malformed defining text element")
+			continue
+		}
+
+		tag, _ := definingText[0].(string)
+
+		if !parsedDefiningTextTags[tag] {
+			c.warn(definingTextPath, "dropped unsupported defining text element %q", tag)
+			continue
+		}
+
+		c.checkTokens(definingTextPath, definingText[1])
+	}
+}
+
+// checkTokens checks the strings of a given value (recursively) for unknown
+// tokens.
+func (c *parseWarningCollector) checkTokens(path string, value any) {
+	switch value := value.(type) {
+	case string:
+		for _, match := range regexpWebsterTokenNames.FindAllStringSubmatch(value, -1) {
+			if name := match[1]; !knownTokenNames[name] {
+				c.warn(path, "unknown token %q", name)
+			}
+		}
+	case []any:
+		for _, item := range value {
+			c.checkTokens(path, item)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			c.checkTokens(path, value[key])
+		}
+	}
+}
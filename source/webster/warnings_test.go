@@ -0,0 +1,60 @@
+package webster
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestAPIDefinitionResult_ParseWarnings(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		json string
+		want []source.ParseWarning
+	}{
+		"clean": {
+			json: `{
+				"meta": {"id": "test:1"},
+				"hwi": {"hw": "test"},
+				"def": [{"sseq": [[["sense", {"sn": "1", "dt": [["text", "{bc}a {it}procedure{/it}"]]}]]]}],
+				"et": [["text", "from {et_link|testum|testum}"]]
+			}`,
+			want: nil,
+		},
+		"missing sections": {
+			json: `{"meta": {"id": "test:1"}}`,
+			want: []source.ParseWarning{
+				{Path: `"test:1" hwi.hw`, Message: "missing headword"},
+				{Path: `"test:1" def`, Message: "missing definition section"},
+			},
+		},
+		"dropped elements and unknown tokens": {
+			json: `{
+				"meta": {"id": "test:1"},
+				"hwi": {"hw": "test"},
+				"def": [{"sseq": [[
+					["pseq", [["sense", {"dt": [["text", "a test"]]}]]],
+					["sense", {"dt": [["snote", [["t", "a note"]]], ["text", "{bc}a {xyz}test"]]}]
+				]]}]
+			}`,
+			want: []source.ParseWarning{
+				{Path: `"test:1" def[0].sseq[0][0]`, Message: `dropped unsupported sense element "pseq"`},
+				{Path: `"test:1" def[0].sseq[0][1].dt[0]`, Message: `dropped unsupported defining text element "snote"`},
+				{Path: `"test:1" def[0].sseq[0][1].dt[1]`, Message: `unknown token "xyz"`},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var result apiDefinitionResult
+
+			if err := json.Unmarshal([]byte(testData.json), &result); err != nil {
+				t.Fatalf("json.Unmarshal returned an error: %s", err)
+			}
+
+			if got := result.parseWarnings(); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("apiDefinitionResult.parseWarnings returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
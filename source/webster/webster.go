@@ -4,8 +4,6 @@
 package webster
 
 import (
-	"encoding/json"
-	"io"
 	"net/http"
 	"net/url"
 
@@ -15,11 +13,23 @@ import (
 // Name defines the name of the source
 const Name = "Merriam-Webster's Dictionary API"
 
+// MedicalName defines the name of the medical dictionary source
+const MedicalName = "Merriam-Webster's Medical Dictionary API"
+
+// LearnersName defines the name of the learner's dictionary source
+const LearnersName = "Merriam-Webster's Learner's Dictionary API"
+
 const (
 	// baseURLString is the base URL for all Webster API interactions
 	baseURLString = "https://www.dictionaryapi.com/api/v3/"
 
-	entriesURLString = baseURLString + "references/collegiate/json/"
+	referencesURLString = baseURLString + "references/"
+
+	// The names of the API's dictionary references that the sources use, which
+	// each require their own app key
+	collegiateReferenceName = "collegiate"
+	medicalReferenceName    = "medical"
+	learnersReferenceName   = "learners"
 
 	httpRequestAcceptHeaderName  = "Accept"
 	httpRequestKeyQueryParamName = "key"
@@ -35,8 +45,11 @@ var validMIMETypes = []string{jsonMIMEType}
 
 // api contains a configured HTTP client for Webster API operations
 type api struct {
-	httpClient *http.Client
-	appKey     string
+	httpClient    *http.Client
+	name          string
+	referenceName string
+	appKey        string
+	textStyle     source.TextStyle
 }
 
 // Initialize the package
@@ -51,12 +64,34 @@ func init() {
 
 // New returns a new Webster API dictionary source
 func New(httpClient http.Client, appKey string) source.Source {
-	return &api{&httpClient, appKey}
+	return &api{httpClient: &httpClient, name: Name, referenceName: collegiateReferenceName, appKey: appKey}
+}
+
+// NewMedical returns a new Webster API medical dictionary source
+//
+// The medical dictionary's entries share the format of the collegiate
+// dictionary's, so they're parsed the same way.
+func NewMedical(httpClient http.Client, appKey string) source.Source {
+	return &api{httpClient: &httpClient, name: MedicalName, referenceName: medicalReferenceName, appKey: appKey}
+}
+
+// NewLearners returns a new Webster API learner's dictionary source
+//
+// The learner's dictionary is written for learners of English, with simpler
+// definitions and more examples, but its entries share the format of the
+// collegiate dictionary's, so they're parsed the same way.
+func NewLearners(httpClient http.Client, appKey string) source.Source {
+	return &api{httpClient: &httpClient, name: LearnersName, referenceName: learnersReferenceName, appKey: appKey}
 }
 
 // Name returns the printable, human-readable name of the source.
 func (a *api) Name() string {
-	return Name
+	return a.name
+}
+
+// SetTextStyle sets the style to render the formatting of result text in.
+func (a *api) SetTextStyle(style source.TextStyle) {
+	a.textStyle = style
 }
 
 // Define takes a word string and returns a list of dictionary results, and
@@ -74,7 +109,7 @@ func (a *api) Define(word string) (source.DictionaryResults, error) {
 		return nil, &source.EmptyResultError{Word: word}
 	case apiDefinitionResult:
 		response := apiResponseFromRaw[apiDefinitionResult](rawResponse)
-		results := apiDefinitionResults(response).toResults()
+		results := apiDefinitionResults(response).toResults(a.textStyle)
 
 		return source.ValidateAndReturnDictionaryResults(word, results)
 	}
@@ -111,16 +146,16 @@ func (a *api) Search(word string, limit uint) (source.SearchResults, error) {
 
 func (a *api) makeAPIRequest(word string) (apiRawResponse, error) {
 	// Prepare our URL
-	requestURL, err := url.Parse(entriesURLString + word)
-	queryParams := apiURL.Query()
-	queryParams.Set(httpRequestKeyQueryParamName, a.appKey)
-	requestURL.RawQuery = queryParams.Encode()
-
+	requestURL, err := source.NewPathURL(referencesURLString, a.referenceName, "json", word)
 	if err != nil {
 		return nil, err
 	}
 
-	httpRequest, err := http.NewRequest(http.MethodGet, apiURL.ResolveReference(requestURL).String(), nil)
+	queryParams := apiURL.Query()
+	queryParams.Set(httpRequestKeyQueryParamName, a.appKey)
+	requestURL.RawQuery = queryParams.Encode()
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -138,14 +173,9 @@ func (a *api) makeAPIRequest(word string) (apiRawResponse, error) {
 		return nil, err
 	}
 
-	body, err := io.ReadAll(httpResponse.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var rawResponse apiRawResponse
 
-	if err = json.Unmarshal(body, &rawResponse); err != nil {
+	if err = source.DecodeJSONResponse(httpResponse, &rawResponse); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,51 @@
+package wikipedia
+
+import (
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+const (
+	// apiSummaryTypeDisambiguation is the type of summaries of disambiguation
+	// pages, which list the subjects that an ambiguous title may refer to
+	apiSummaryTypeDisambiguation = "disambiguation"
+
+	license    = "CC BY-SA 4.0"
+	licenseURL = "https://creativecommons.org/licenses/by-sa/4.0/"
+)
+
+// apiSummaryResponse defines the structure of a Wikipedia API page summary
+// response
+type apiSummaryResponse struct {
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Extract     string `json:"extract"`
+	ContentURLs struct {
+		Desktop struct {
+			Page string `json:"page"`
+		} `json:"desktop"`
+	} `json:"content_urls"`
+}
+
+// toSummary converts the API response to a summary.
+func (r apiSummaryResponse) toSummary() *source.Summary {
+	summary := &source.Summary{
+		Title:            r.Title,
+		Description:      strings.TrimSpace(r.Description),
+		Text:             strings.TrimSpace(r.Extract),
+		IsDisambiguation: r.Type == apiSummaryTypeDisambiguation,
+		Attribution: source.ResultAttribution{
+			Provider:   Name,
+			License:    license,
+			LicenseURL: licenseURL,
+		},
+	}
+
+	if pageURL := r.ContentURLs.Desktop.Page; pageURL != "" {
+		summary.Attribution.SourceURLs = []string{pageURL}
+	}
+
+	return summary
+}
@@ -0,0 +1,76 @@
+package wikipedia
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestAPISummaryResponse_ToSummary(t *testing.T) {
+	attribution := source.ResultAttribution{Provider: Name, License: license, LicenseURL: licenseURL}
+
+	for testName, testData := range map[string]struct {
+		json string
+		want *source.Summary
+	}{
+		"empty": {
+			json: `{}`,
+			want: &source.Summary{Attribution: attribution},
+		},
+		"standard": {
+			json: `{"type":"standard","title":"Oxford","description":"City in England ","extract":" Oxford is a city in England.","content_urls":{"desktop":{"page":"https://en.wikipedia.org/wiki/Oxford"}}}`,
+			want: &source.Summary{
+				Title:       "Oxford",
+				Description: "City in England",
+				Text:        "Oxford is a city in England.",
+				Attribution: source.ResultAttribution{
+					Provider:   Name,
+					License:    license,
+					LicenseURL: licenseURL,
+					SourceURLs: []string{"https://en.wikipedia.org/wiki/Oxford"},
+				},
+			},
+		},
+		"disambiguation": {
+			json: `{"type":"disambiguation","title":"Mercury","extract":"Mercury may refer to:"}`,
+			want: &source.Summary{
+				Title:            "Mercury",
+				Text:             "Mercury may refer to:",
+				IsDisambiguation: true,
+				Attribution:      attribution,
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var response apiSummaryResponse
+
+			if err := json.Unmarshal([]byte(testData.json), &response); err != nil {
+				t.Fatalf("json.Unmarshal returned an error: %s", err)
+			}
+
+			if got := response.toSummary(); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("apiSummaryResponse.toSummary returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
+
+func TestToTitle(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		term string
+		want string
+	}{
+		"single word":       {term: "Oxford", want: "Oxford"},
+		"multiple words":    {term: "New York", want: "New_York"},
+		"extra whitespace":  {term: "  New   York ", want: "New_York"},
+		"inner punctuation": {term: "Rock 'n' roll", want: "Rock_'n'_roll"},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := toTitle(testData.term); got != testData.want {
+				t.Errorf("toTitle returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
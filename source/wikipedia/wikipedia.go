@@ -0,0 +1,104 @@
+// Package wikipedia provides encyclopedic summaries via the Wikipedia REST API
+package wikipedia
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "Wikipedia"
+
+const (
+	// baseURLString is the base URL for all Wikipedia API interactions
+	baseURLString = "https://en.wikipedia.org/api/rest_v1/"
+
+	summaryURLString = baseURLString + "page/summary/"
+
+	httpRequestAcceptHeaderName    = "Accept"
+	httpRequestUserAgentHeaderName = "User-Agent"
+	httpRequestRedirectParamName   = "redirect"
+
+	// userAgent identifies the app to the API, as the API's policy requires
+	userAgent = "define (https://github.com/Rican7/define)"
+
+	jsonMIMEType = "application/json"
+
+	// titleSpaceReplacement is what spaces are replaced with in the titles of
+	// pages (like "New_York")
+	titleSpaceReplacement = "_"
+)
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{jsonMIMEType}
+
+// API contains a configured HTTP client for Wikipedia API operations
+type API struct {
+	httpClient *http.Client
+}
+
+// New returns a new Wikipedia API client
+func New(httpClient http.Client) *API {
+	return &API{&httpClient}
+}
+
+// Name returns the printable, human-readable name of the source.
+func (a *API) Name() string {
+	return Name
+}
+
+// Summary takes a term (such as a proper noun, like "Oxford") and returns a
+// summary of the Wikipedia article about it, following any redirects, and an
+// error if any occurred.
+func (a *API) Summary(term string) (*source.Summary, error) {
+	requestURL, err := source.NewPathURL(summaryURLString, toTitle(term))
+	if err != nil {
+		return nil, err
+	}
+
+	requestURL.RawQuery = url.Values{httpRequestRedirectParamName: {"true"}}.Encode()
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
+	httpRequest.Header.Set(httpRequestUserAgentHeaderName, userAgent)
+
+	httpResponse, err := a.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode == http.StatusNotFound {
+		return nil, &source.EmptyResultError{Word: term}
+	}
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); err != nil {
+		return nil, err
+	}
+
+	var response apiSummaryResponse
+
+	if err = source.DecodeJSONResponse(httpResponse, &response); err != nil {
+		return nil, err
+	}
+
+	summary := response.toSummary()
+	if summary.Text == "" {
+		return nil, &source.EmptyResultError{Word: term}
+	}
+
+	return summary, nil
+}
+
+// toTitle converts a term to the title format used by the API.
+func toTitle(term string) string {
+	return strings.Join(strings.Fields(term), titleSpaceReplacement)
+}
@@ -0,0 +1,62 @@
+package wiktionary
+
+// languageNames maps Wiktionary's codes of common languages (which are their
+// ISO 639 codes, or its own for reconstructed and historical ones) to their
+// names, as used in the headings of its entries
+var languageNames = map[string]string{
+	"ang":     "Old English",
+	"ar":      "Arabic",
+	"cy":      "Welsh",
+	"da":      "Danish",
+	"de":      "German",
+	"dum":     "Middle Dutch",
+	"el":      "Greek",
+	"en":      "English",
+	"enm":     "Middle English",
+	"es":      "Spanish",
+	"fa":      "Persian",
+	"fr":      "French",
+	"frk":     "Frankish",
+	"frm":     "Middle French",
+	"fro":     "Old French",
+	"ga":      "Irish",
+	"gem-pro": "Proto-Germanic",
+	"gmh":     "Middle High German",
+	"gml":     "Middle Low German",
+	"goh":     "Old High German",
+	"grc":     "Ancient Greek",
+	"he":      "Hebrew",
+	"hi":      "Hindi",
+	"ine-pro": "Proto-Indo-European",
+	"it":      "Italian",
+	"itc-pro": "Proto-Italic",
+	"ja":      "Japanese",
+	"la":      "Latin",
+	"la-lat":  "Late Latin",
+	"la-med":  "Medieval Latin",
+	"la-new":  "New Latin",
+	"la-vul":  "Vulgar Latin",
+	"nl":      "Dutch",
+	"no":      "Norwegian",
+	"non":     "Old Norse",
+	"odt":     "Old Dutch",
+	"ofs":     "Old Frisian",
+	"osx":     "Old Saxon",
+	"pt":      "Portuguese",
+	"ru":      "Russian",
+	"sa":      "Sanskrit",
+	"sco":     "Scots",
+	"sv":      "Swedish",
+	"xno":     "Anglo-Norman",
+	"zh":      "Chinese",
+}
+
+// languageName returns the name of a language by its Wiktionary code, or the
+// code itself if its name isn't known.
+func languageName(code string) string {
+	if name, isKnown := languageNames[code]; isKnown {
+		return name
+	}
+
+	return code
+}
@@ -0,0 +1,292 @@
+package wiktionary
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// Relations of a word to the words that it comes from, by the names of the
+// templates that link them
+var ancestorRelations = map[string]string{
+	"inh":       "inherited",
+	"inh+":      "inherited",
+	"inherited": "inherited",
+	"der":       "derived",
+	"der+":      "derived",
+	"derived":   "derived",
+	"uder":      "derived",
+	"bor":       "borrowed",
+	"bor+":      "borrowed",
+	"borrowed":  "borrowed",
+	"lbor":      "learned borrowing",
+	"slbor":     "semi-learned borrowing",
+	"calque":    "calque",
+	"cal":       "calque",
+}
+
+// componentTemplates is the set of the names of the templates that list the
+// parts that a word was formed from (like compounds and affixed words)
+var componentTemplates = map[string]bool{
+	"affix":    true,
+	"af":       true,
+	"compound": true,
+	"com":      true,
+	"prefix":   true,
+	"pre":      true,
+	"suffix":   true,
+	"suf":      true,
+	"confix":   true,
+	"con":      true,
+	"blend":    true,
+}
+
+var (
+	// regexpLanguageHeading matches the heading of a language's section (like
+	// "==English=="), capturing the name of the language
+	regexpLanguageHeading = regexp.MustCompile(`^==\s*([^=]+?)\s*==\s*$`)
+
+	// regexpHeading matches any heading, capturing its name
+	regexpHeading = regexp.MustCompile(`^=+\s*([^=]+?)\s*=+\s*$`)
+
+	// regexpEtymologyHeading matches the name of the heading of an etymology
+	// section, which is numbered if a word has several
+	regexpEtymologyHeading = regexp.MustCompile(`^Etymology(\s+\d+)?$`)
+
+	// regexpLink matches a wiki link (like "[[word]]" or "[[word|text]]"),
+	// capturing its text
+	regexpLink = regexp.MustCompile(`\[\[(?:[^|\]]*\|)?([^\]]*)\]\]`)
+)
+
+// template defines the structure of a parsed wikitext template, like
+// "{{inh|en|enm|word}}"
+type template struct {
+	name       string
+	positional []string
+	named      map[string]string
+}
+
+// parseEtymologies parses the etymology sections of a word's entry for the
+// given language (by name) in the wikitext of a page, into a tree of the
+// word's ancestors for each section that has any.
+//
+// Each ancestor that a section links the word to (with a template like
+// "{{inh}}") is taken to be an ancestor of the one before it, in order, until
+// the parts that a word was formed from are listed (with a template like
+// "{{compound}}"), as what follows them is usually about the parts.
+func parseEtymologies(wikitext string, word string, languageName string) []source.Etymon {
+	var etymologies []source.Etymon
+
+	for _, section := range etymologySections(wikitext, languageName) {
+		root := source.Etymon{Word: word, Language: languageName}
+		current := &root
+
+		for _, tmpl := range parseTemplates(section) {
+			if relation, isAncestor := ancestorRelations[tmpl.name]; isAncestor {
+				etymon, ok := ancestorEtymon(tmpl, relation)
+				if !ok {
+					continue
+				}
+
+				current.Etymons = append(current.Etymons, etymon)
+				current = &current.Etymons[len(current.Etymons)-1]
+
+				continue
+			}
+
+			if componentTemplates[tmpl.name] {
+				current.Etymons = append(current.Etymons, componentEtymons(tmpl)...)
+
+				break
+			}
+		}
+
+		if len(root.Etymons) > 0 {
+			etymologies = append(etymologies, root)
+		}
+	}
+
+	return etymologies
+}
+
+// etymologySections returns the texts of the etymology sections of the given
+// language's section of a page's wikitext.
+func etymologySections(wikitext string, languageName string) []string {
+	var sections []string
+	var section *strings.Builder
+
+	inLanguage := false
+
+	for _, line := range strings.Split(wikitext, "\n") {
+		if match := regexpLanguageHeading.FindStringSubmatch(line); match != nil {
+			inLanguage = strings.EqualFold(match[1], languageName)
+		}
+
+		if match := regexpHeading.FindStringSubmatch(line); match != nil {
+			if section != nil {
+				sections = append(sections, section.String())
+				section = nil
+			}
+
+			if inLanguage && regexpEtymologyHeading.MatchString(match[1]) {
+				section = new(strings.Builder)
+			}
+
+			continue
+		}
+
+		if section != nil {
+			section.WriteString(line)
+			section.WriteString("\n")
+		}
+	}
+
+	if section != nil {
+		sections = append(sections, section.String())
+	}
+
+	return sections
+}
+
+// parseTemplates parses the top-level templates of a text, in order.
+func parseTemplates(text string) []template {
+	var templates []template
+
+	depth, start := 0, 0
+
+	for i := 0; i+1 < len(text); i++ {
+		switch text[i : i+2] {
+		case "{{":
+			if depth == 0 {
+				start = i + 2
+			}
+
+			depth++
+			i++
+		case "}}":
+			if depth == 0 {
+				continue
+			}
+
+			depth--
+			if depth == 0 {
+				templates = append(templates, parseTemplate(text[start:i]))
+			}
+
+			i++
+		}
+	}
+
+	return templates
+}
+
+// parseTemplate parses the contents of a template (between its braces).
+func parseTemplate(contents string) template {
+	parts := splitTemplateParts(contents)
+
+	tmpl := template{
+		name:  strings.ToLower(strings.TrimSpace(parts[0])),
+		named: make(map[string]string),
+	}
+
+	for _, part := range parts[1:] {
+		if key, value, found := strings.Cut(part, "="); found && !strings.ContainsAny(key, "[{") {
+			tmpl.named[strings.TrimSpace(key)] = cleanText(value)
+			continue
+		}
+
+		tmpl.positional = append(tmpl.positional, cleanText(part))
+	}
+
+	return tmpl
+}
+
+// splitTemplateParts splits the contents of a template by its separators,
+// ignoring those of any nested templates or links.
+func splitTemplateParts(contents string) []string {
+	var parts []string
+
+	depth, start := 0, 0
+
+	for i := 0; i < len(contents); i++ {
+		switch contents[i] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth = max(depth-1, 0)
+		case '|':
+			if depth == 0 {
+				parts = append(parts, contents[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, contents[start:])
+}
+
+// ancestorEtymon returns the ancestor of a template that links a word to one
+// (like "{{inh|en|enm|word||gloss}}"), and whether the template has one.
+func ancestorEtymon(tmpl template, relation string) (source.Etymon, bool) {
+	// The positional parameters are the language of the word, and then the
+	// language of the ancestor, its term, an alternative form, and a gloss
+	if len(tmpl.positional) < 2 {
+		return source.Etymon{}, false
+	}
+
+	etymon := source.Etymon{
+		Language: languageName(tmpl.positional[1]),
+		Relation: relation,
+		Gloss:    templateGloss(tmpl, 4),
+	}
+
+	if len(tmpl.positional) > 2 && tmpl.positional[2] != "-" {
+		etymon.Word = tmpl.positional[2]
+	}
+
+	return etymon, true
+}
+
+// componentEtymons returns the parts that a word was formed from, by a
+// template that lists them (like "{{compound|ang|hand|bōc}}").
+func componentEtymons(tmpl template) []source.Etymon {
+	if len(tmpl.positional) < 2 {
+		return nil
+	}
+
+	language := languageName(tmpl.positional[0])
+
+	var etymons []source.Etymon
+
+	for _, part := range tmpl.positional[1:] {
+		if part == "" {
+			continue
+		}
+
+		etymons = append(etymons, source.Etymon{Word: part, Language: language, Relation: "component"})
+	}
+
+	return etymons
+}
+
+// templateGloss returns the gloss of a template, by either its named parameter
+// or its positional parameter at the given index.
+func templateGloss(tmpl template, index int) string {
+	for _, key := range []string{"t", "gloss"} {
+		if gloss := tmpl.named[key]; gloss != "" {
+			return gloss
+		}
+	}
+
+	if index < len(tmpl.positional) {
+		return tmpl.positional[index]
+	}
+
+	return ""
+}
+
+// cleanText removes the markup of a template parameter's text.
+func cleanText(text string) string {
+	return strings.TrimSpace(regexpLink.ReplaceAllString(text, "$1"))
+}
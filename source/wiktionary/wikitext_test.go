@@ -0,0 +1,108 @@
+package wiktionary
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestParseEtymologies(t *testing.T) {
+	for testName, testData := range map[string]struct {
+		wikitext string
+		word     string
+		want     []source.Etymon
+	}{
+		"empty": {
+			wikitext: "",
+			word:     "test",
+			want:     nil,
+		},
+		"chain": {
+			wikitext: "==English==\n" +
+				"===Etymology===\n" +
+				"From {{inh|en|enm|word}}, from {{inh|en|ang|word}}, from {{inh|en|gem-pro|*wurdą||word}}. " +
+				"Cognate with {{cog|de|Wort}}.\n" +
+				"===Noun===\n" +
+				"{{en-noun}}\n" +
+				"==German==\n" +
+				"===Etymology===\n" +
+				"From {{inh|de|gmh|wort}}.\n",
+			word: "word",
+			want: []source.Etymon{
+				{
+					Word:     "word",
+					Language: "English",
+					Etymons: []source.Etymon{
+						{
+							Word:     "word",
+							Language: "Middle English",
+							Relation: "inherited",
+							Etymons: []source.Etymon{
+								{
+									Word:     "word",
+									Language: "Old English",
+									Relation: "inherited",
+									Etymons: []source.Etymon{
+										{Word: "*wurdą", Language: "Proto-Germanic", Relation: "inherited", Gloss: "word"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"numbered etymologies and components": {
+			wikitext: "==English==\n" +
+				"===Etymology 1===\n" +
+				"{{bor|en|fr|[[bureau|bureau]]|t=desk}}\n" +
+				"===Etymology 2===\n" +
+				"From {{inh|en|enm|handbok}}, from {{compound|ang|hand|bōc}}, from {{der|en|la|ignored}}.\n" +
+				"===Etymology 3===\n" +
+				"Unknown.\n",
+			word: "test",
+			want: []source.Etymon{
+				{
+					Word:     "test",
+					Language: "English",
+					Etymons: []source.Etymon{
+						{Word: "bureau", Language: "French", Relation: "borrowed", Gloss: "desk"},
+					},
+				},
+				{
+					Word:     "test",
+					Language: "English",
+					Etymons: []source.Etymon{
+						{
+							Word:     "handbok",
+							Language: "Middle English",
+							Relation: "inherited",
+							Etymons: []source.Etymon{
+								{Word: "hand", Language: "Old English", Relation: "component"},
+								{Word: "bōc", Language: "Old English", Relation: "component"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"unknown language code and no term": {
+			wikitext: "==English==\n===Etymology===\n{{der|en|xx-pro|-}}\n",
+			word:     "test",
+			want: []source.Etymon{
+				{
+					Word:     "test",
+					Language: "English",
+					Etymons:  []source.Etymon{{Language: "xx-pro", Relation: "derived"}},
+				},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			if got := parseEtymologies(testData.wikitext, testData.word, "English"); !reflect.DeepEqual(got, testData.want) {
+				t.Errorf("parseEtymologies returned wrong value. Got %#v. Want %#v.", got, testData.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package wiktionary provides structured etymologies via the Wiktionary API,
+// by parsing the etymology sections of its entries
+package wiktionary
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "Wiktionary"
+
+const (
+	// baseURLString is the base URL for all Wiktionary API interactions
+	baseURLString = "https://en.wiktionary.org/"
+
+	apiURLString  = baseURLString + "w/api.php"
+	pageURLString = baseURLString + "wiki/"
+
+	httpRequestAcceptHeaderName    = "Accept"
+	httpRequestUserAgentHeaderName = "User-Agent"
+
+	httpRequestActionParamName        = "action"
+	httpRequestPageParamName          = "page"
+	httpRequestPropParamName          = "prop"
+	httpRequestFormatParamName        = "format"
+	httpRequestFormatVersionParamName = "formatversion"
+	httpRequestRedirectsParamName     = "redirects"
+
+	// userAgent identifies the app to the API, as the API's policy requires
+	userAgent = "define (https://github.com/Rican7/define)"
+
+	jsonMIMEType = "application/json"
+
+	// apiErrorCodeMissingTitle is the code of the API's error for pages that
+	// don't exist
+	apiErrorCodeMissingTitle = "missingtitle"
+
+	license    = "CC BY-SA 4.0"
+	licenseURL = "https://creativecommons.org/licenses/by-sa/4.0/"
+)
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{jsonMIMEType}
+
+// API contains a configured HTTP client for Wiktionary API operations
+type API struct {
+	httpClient *http.Client
+}
+
+// apiParseResponse defines the structure of a Wiktionary API parse response
+type apiParseResponse struct {
+	Parse struct {
+		Title    string `json:"title"`
+		Wikitext string `json:"wikitext"`
+	} `json:"parse"`
+	Error *struct {
+		Code string `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+// New returns a new Wiktionary API client
+func New(httpClient http.Client) *API {
+	return &API{&httpClient}
+}
+
+// Name returns the printable, human-readable name of the source.
+func (a *API) Name() string {
+	return Name
+}
+
+// Attribution returns the attribution of the etymologies of a word.
+func Attribution(word string) source.ResultAttribution {
+	return source.ResultAttribution{
+		Provider:   Name,
+		License:    license,
+		LicenseURL: licenseURL,
+		SourceURLs: []string{pageURLString + url.PathEscape(strings.ReplaceAll(word, " ", "_"))},
+	}
+}
+
+// Etymologies takes a word and the (ISO 639-1) code of its language, and
+// returns a tree of the word's ancestors for each of its etymologies, and an
+// error if any occurred.
+func (a *API) Etymologies(word string, language string) ([]source.Etymon, error) {
+	languageName, isKnown := languageNames[strings.ToLower(language)]
+	if !isKnown {
+		return nil, fmt.Errorf("the language %q isn't supported", language)
+	}
+
+	requestURL, err := url.Parse(apiURLString)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURL.RawQuery = url.Values{
+		httpRequestActionParamName:        {"parse"},
+		httpRequestPageParamName:          {word},
+		httpRequestPropParamName:          {"wikitext"},
+		httpRequestFormatParamName:        {"json"},
+		httpRequestFormatVersionParamName: {"2"},
+		httpRequestRedirectsParamName:     {"1"},
+	}.Encode()
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
+	httpRequest.Header.Set(httpRequestUserAgentHeaderName, userAgent)
+
+	httpResponse, err := a.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); err != nil {
+		return nil, err
+	}
+
+	var response apiParseResponse
+
+	if err = source.DecodeJSONResponse(httpResponse, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		if response.Error.Code == apiErrorCodeMissingTitle {
+			return nil, &source.EmptyResultError{Word: word}
+		}
+
+		return nil, fmt.Errorf("the API returned an error: %s", response.Error.Info)
+	}
+
+	etymologies := parseEtymologies(response.Parse.Wikitext, word, languageName)
+	if len(etymologies) < 1 {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	return etymologies, nil
+}